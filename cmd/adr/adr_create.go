@@ -125,4 +125,6 @@ func init() {
 	adrCreateCmd.Flags().String("links", "", "Comma-separated links (URLs, task IDs, wiki slugs, etc.)")
 	adrCreateCmd.Flags().String("template", "", "Template name (from configured ADR templates)")
 	adrCreateCmd.Flags().String("body", "", "ADR body markdown (use '-' to read from stdin)")
+	cli.CompleteFlag(adrCreateCmd, "status", cli.CompleteADRStatuses)
+	cli.CompleteFlag(adrCreateCmd, "template", cli.CompleteTemplates(cli.TemplateADR))
 }
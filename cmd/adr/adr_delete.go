@@ -50,4 +50,5 @@ var adrDeleteCmd = &cobra.Command{
 func init() {
 	adrDeleteCmd.Flags().Bool("force", false, "Skip confirmation prompt")
 	adrCmd.AddCommand(adrDeleteCmd)
+	adrDeleteCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteADRIDs)
 }
@@ -68,5 +68,6 @@ var adrEditCmd = &cobra.Command{
 
 func init() {
 	adrCmd.AddCommand(adrEditCmd)
+	adrEditCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteADRIDs)
 	adrEditCmd.Flags().String("editor", "", "Override the editor command")
 }
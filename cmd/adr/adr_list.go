@@ -8,6 +8,7 @@ import (
 	"mochi-sticky/internal/adr"
 	"mochi-sticky/internal/board"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -16,6 +17,10 @@ var adrListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List ADRs",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -72,6 +77,9 @@ var adrListCmd = &cobra.Command{
 			CaseInsensitive: true,
 		})
 		adr.SortADRs(adrs)
+		if format != output.FormatTable {
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindADRs, output.FromADRs(adrs, workingDir))
+		}
 		if len(adrs) == 0 {
 			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No ADRs found.")
 			return err
@@ -103,9 +111,12 @@ var adrListCmd = &cobra.Command{
 
 func init() {
 	adrCmd.AddCommand(adrListCmd)
+	cli.SupportOutput(adrListCmd)
+	cli.SupportRevision(adrListCmd)
 	adrListCmd.Flags().String("status", "", "Filter by status key")
 	adrListCmd.Flags().String("tags", "", "Filter by tags (comma-separated)")
 	adrListCmd.Flags().String("query", "", "Filter by keyword query (title/body)")
 	adrListCmd.Flags().String("since", "", "Filter by date >= YYYY-MM-DD")
 	adrListCmd.Flags().String("until", "", "Filter by date <= YYYY-MM-DD")
+	cli.CompleteFlag(adrListCmd, "status", cli.CompleteADRStatuses)
 }
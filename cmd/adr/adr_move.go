@@ -48,4 +48,5 @@ var adrMoveCmd = &cobra.Command{
 
 func init() {
 	adrCmd.AddCommand(adrMoveCmd)
+	adrMoveCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteADRIDs, cli.CompleteADRStatuses)
 }
@@ -9,6 +9,7 @@ import (
 
 	"mochi-sticky/internal/adr"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,10 @@ var adrStatusesCmd = &cobra.Command{
 	Use:   "statuses",
 	Short: "List available ADR status keys",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -39,6 +44,15 @@ var adrStatusesCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if format != output.FormatTable {
+			records := make([]output.Status, 0, len(config.Columns))
+			for _, column := range config.Columns {
+				if key := strings.TrimSpace(column.Key); key != "" {
+					records = append(records, output.Status{Key: key, Title: strings.TrimSpace(column.Title)})
+				}
+			}
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindStatuses, records)
+		}
 		if len(config.Columns) == 0 {
 			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No statuses found.")
 			return err
@@ -64,4 +78,6 @@ var adrStatusesCmd = &cobra.Command{
 
 func init() {
 	adrCmd.AddCommand(adrStatusesCmd)
+	cli.SupportOutput(adrStatusesCmd)
+	cli.SupportRevision(adrStatusesCmd)
 }
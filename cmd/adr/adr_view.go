@@ -6,6 +6,8 @@ import (
 
 	"mochi-sticky/internal/adr"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+	"mochi-sticky/internal/shared"
 
 	"github.com/spf13/cobra"
 )
@@ -19,6 +21,10 @@ var adrViewCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 
 		workingDir, err := os.Getwd()
 		if err != nil {
@@ -37,7 +43,10 @@ var adrViewCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		data, err := os.ReadFile(record.FilePath)
+		if format != output.FormatTable {
+			return output.WriteRecord(cmd.OutOrStdout(), format, output.KindADR, output.FromADR(record, workingDir))
+		}
+		data, err := shared.Files().ReadFile(record.FilePath)
 		if err != nil {
 			return fmt.Errorf("failed to read adr %s: %w", record.FilePath, err)
 		}
@@ -48,4 +57,7 @@ var adrViewCmd = &cobra.Command{
 
 func init() {
 	adrCmd.AddCommand(adrViewCmd)
+	adrViewCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteADRIDs)
+	cli.SupportOutput(adrViewCmd)
+	cli.SupportRevision(adrViewCmd)
 }
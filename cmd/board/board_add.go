@@ -36,6 +36,15 @@ var boardAddCmd = &cobra.Command{
 			return err
 		}
 		name := strings.Join(args, " ")
+		idStrategy, err := cmd.Flags().GetString("id-strategy")
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("id-strategy") {
+			if idStrategy, err = board.NormalizeIDStrategy(idStrategy); err != nil {
+				return err
+			}
+		}
 		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 		defer stop()
 		createdBoard, err := repo.CreateBoardContext(ctx, name)
@@ -69,6 +78,20 @@ var boardAddCmd = &cobra.Command{
 				return err
 			}
 		}
+		if cmd.Flags().Changed("id-strategy") {
+			boardRepo, err := board.NewRepositoryForBoardWithStorage(workingDir, createdBoard.ID, storageRoot)
+			if err != nil {
+				return err
+			}
+			cfg, err := boardRepo.LoadConfigContext(ctx)
+			if err != nil {
+				return err
+			}
+			cfg.IDStrategy = idStrategy
+			if err := boardRepo.SaveConfigContext(ctx, cfg); err != nil {
+				return err
+			}
+		}
 		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Created board %s\n", createdBoard.ID)
 		return err
 	},
@@ -77,4 +100,6 @@ var boardAddCmd = &cobra.Command{
 func init() {
 	boardCmd.AddCommand(boardAddCmd)
 	boardAddCmd.Flags().String("template", "", "Template name (from configured board templates)")
+	boardAddCmd.Flags().String("id-strategy", "", "Task ID strategy: sequential, hash or ulid (default: sequential)")
+	cli.CompleteFlag(boardAddCmd, "template", cli.CompleteTemplates(cli.TemplateBoard))
 }
@@ -37,4 +37,5 @@ var boardArchiveCmd = &cobra.Command{
 func init() {
 	boardArchiveCmd.Flags().Bool("force", false, "Skip confirmation prompt")
 	boardCmd.AddCommand(boardArchiveCmd)
+	boardArchiveCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteBoardIDs)
 }
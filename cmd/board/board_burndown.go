@@ -0,0 +1,139 @@
+package board
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	boardpkg "mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var boardBurndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Show per-day remaining estimated work for a board",
+	Long: "Show the scope, done and remaining estimated work of a board per day. The table (or\n" +
+		"--output json|yaml|csv) lists the days; --chart draws them as an ASCII chart instead.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		fromStr, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return err
+		}
+		toStr, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return err
+		}
+		boardID, err := cmd.Flags().GetString("board")
+		if err != nil {
+			return err
+		}
+		chart, err := cmd.Flags().GetBool("chart")
+		if err != nil {
+			return err
+		}
+		noGit, err := cmd.Flags().GetBool("no-git")
+		if err != nil {
+			return err
+		}
+		if chart && format != output.FormatTable {
+			return fmt.Errorf("--chart cannot be combined with --output %s", format)
+		}
+
+		toDate := time.Now()
+		if strings.TrimSpace(toStr) != "" {
+			toDate, err = time.Parse("2006-01-02", toStr)
+			if err != nil {
+				return err
+			}
+		}
+		fromDate := toDate.AddDate(0, 0, -13)
+		if strings.TrimSpace(fromStr) != "" {
+			fromDate, err = time.Parse("2006-01-02", fromStr)
+			if err != nil {
+				return err
+			}
+		}
+
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		repo, err := boardpkg.NewRepositoryForBoardWithStorage(workingDir, boardID, storageRoot)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		config, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return err
+		}
+		unit, err := boardpkg.NormalizeEstimateUnit(config.EstimateUnit)
+		if err != nil {
+			return err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return err
+		}
+		archived, err := repo.ListArchivedTasksContext(ctx)
+		if err != nil && !errors.Is(err, boardpkg.ErrStoreNotInitialized) {
+			return err
+		}
+		var completions map[string]time.Time
+		if !noGit {
+			completions, err = boardpkg.GitCompletionDates(ctx, tasks, archived)
+			if err != nil {
+				return err
+			}
+		}
+		points, err := boardpkg.Burndown(tasks, boardpkg.BurndownOptions{
+			From:        fromDate,
+			To:          toDate,
+			Archived:    archived,
+			Completions: completions,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case format != output.FormatTable:
+			records := output.FromBurndown(points, repo.BoardID(), unit)
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindBurndown, records)
+		case chart:
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), boardpkg.FormatBurndownChart(points, unit))
+			return err
+		default:
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), boardpkg.FormatBurndownTable(points, unit))
+			return err
+		}
+	},
+}
+
+func init() {
+	boardCmd.AddCommand(boardBurndownCmd)
+	boardBurndownCmd.Flags().String("from", "", "Start date YYYY-MM-DD (default: 13 days before --to)")
+	boardBurndownCmd.Flags().String("to", "", "End date YYYY-MM-DD (default: today)")
+	boardBurndownCmd.Flags().String("board", "", "Board ID (default: active board)")
+	cli.CompleteFlag(boardBurndownCmd, "board", cli.CompleteBoardIDs)
+	boardBurndownCmd.Flags().Bool("chart", false, "Draw an ASCII chart instead of the table")
+	boardBurndownCmd.Flags().Bool("no-git", false, "Do not consult git history for missing completion dates")
+	cli.SupportOutput(boardBurndownCmd)
+}
@@ -36,4 +36,5 @@ var boardDeleteCmd = &cobra.Command{
 func init() {
 	boardDeleteCmd.Flags().Bool("force", false, "Skip confirmation prompt")
 	boardCmd.AddCommand(boardDeleteCmd)
+	boardDeleteCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteBoardIDs)
 }
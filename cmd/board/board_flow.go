@@ -0,0 +1,189 @@
+package board
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	boardpkg "mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+type flowReport struct {
+	BoardID    string          `json:"board_id"`
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	CycleTimes []flowJSONCycle `json:"cycle_times"`
+	Average    float64         `json:"average_days"`
+	Median     float64         `json:"median_days"`
+	P85        float64         `json:"p85_days"`
+	Statuses   []string        `json:"statuses"`
+	Cumulative []flowJSONDay   `json:"cumulative"`
+}
+
+type flowJSONCycle struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Started   string  `json:"started"`
+	Completed string  `json:"completed"`
+	Days      float64 `json:"days"`
+}
+
+type flowJSONDay struct {
+	Date   string         `json:"date"`
+	Counts map[string]int `json:"counts"`
+}
+
+var boardFlowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Show cycle time and cumulative flow for a board",
+	Long: "Show the cycle time of tasks completed in the window (from leaving the first column to\n" +
+		"reaching done) and how many tasks were in each status at the end of every day. Status\n" +
+		"changes come from the git history of the task files, following moves into archive/tasks;\n" +
+		"tasks without history fall back to their created, status_changed and completed dates.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromStr, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return err
+		}
+		toStr, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return err
+		}
+		boardID, err := cmd.Flags().GetString("board")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		noGit, err := cmd.Flags().GetBool("no-git")
+		if err != nil {
+			return err
+		}
+		format = strings.ToLower(strings.TrimSpace(format))
+		switch format {
+		case "table", "json":
+		default:
+			return fmt.Errorf("invalid format %q (expected table or json)", format)
+		}
+
+		toDate := time.Now()
+		if strings.TrimSpace(toStr) != "" {
+			toDate, err = time.Parse("2006-01-02", toStr)
+			if err != nil {
+				return err
+			}
+		}
+		fromDate := toDate.AddDate(0, 0, -13)
+		if strings.TrimSpace(fromStr) != "" {
+			fromDate, err = time.Parse("2006-01-02", fromStr)
+			if err != nil {
+				return err
+			}
+		}
+
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		repo, err := boardpkg.NewRepositoryForBoardWithStorage(workingDir, boardID, storageRoot)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		config, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return err
+		}
+		archived, err := repo.ListArchivedTasksContext(ctx)
+		if err != nil && !errors.Is(err, boardpkg.ErrStoreNotInitialized) {
+			return err
+		}
+		histories := map[string][]boardpkg.TaskChange{}
+		if !noGit {
+			all, err := repo.TaskHistoriesContext(ctx)
+			if err != nil && !errors.Is(err, git.ErrNotRepository) {
+				return err
+			}
+			for _, history := range all {
+				histories[history.ID] = history.Changes
+			}
+		}
+		columns := make([]string, 0, len(config.Columns))
+		for _, column := range config.Columns {
+			columns = append(columns, column.Key)
+		}
+		report, err := boardpkg.Flow(tasks, boardpkg.FlowOptions{
+			From:      fromDate,
+			To:        toDate,
+			Columns:   columns,
+			Archived:  archived,
+			Histories: histories,
+		})
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			out := flowReport{
+				BoardID:    repo.BoardID(),
+				From:       fromDate.Format("2006-01-02"),
+				To:         toDate.Format("2006-01-02"),
+				CycleTimes: make([]flowJSONCycle, 0, len(report.CycleTimes)),
+				Average:    report.Average,
+				Median:     report.Median,
+				P85:        report.P85,
+				Statuses:   report.Statuses,
+				Cumulative: make([]flowJSONDay, 0, len(report.Cumulative)),
+			}
+			for _, cycle := range report.CycleTimes {
+				out.CycleTimes = append(out.CycleTimes, flowJSONCycle{
+					ID:        cycle.ID,
+					Title:     cycle.Title,
+					Started:   cycle.Started.Format("2006-01-02"),
+					Completed: cycle.Completed.Format("2006-01-02"),
+					Days:      cycle.Days,
+				})
+			}
+			for _, day := range report.Cumulative {
+				out.Cumulative = append(out.Cumulative, flowJSONDay{Date: day.Date.Format("2006-01-02"), Counts: day.Counts})
+			}
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetEscapeHTML(false)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(out)
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), boardpkg.FormatFlowTable(report))
+		return err
+	},
+}
+
+func init() {
+	boardCmd.AddCommand(boardFlowCmd)
+	boardFlowCmd.Flags().String("from", "", "Start date YYYY-MM-DD (default: 13 days before --to)")
+	boardFlowCmd.Flags().String("to", "", "End date YYYY-MM-DD (default: today)")
+	boardFlowCmd.Flags().String("board", "", "Board ID (default: active board)")
+	cli.CompleteFlag(boardFlowCmd, "board", cli.CompleteBoardIDs)
+	boardFlowCmd.Flags().String("format", "table", "Output format: table|json")
+	boardFlowCmd.Flags().Bool("no-git", false, "Do not consult git history for status changes")
+}
@@ -7,6 +7,7 @@ import (
 	"os/signal"
 
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -15,6 +16,10 @@ var boardListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List boards",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		repo, err := cli.BoardRepoFromCwd()
 		if err != nil {
 			return err
@@ -25,6 +30,13 @@ var boardListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if format != output.FormatTable {
+			records := make([]output.Board, 0, len(boards))
+			for _, boardItem := range boards {
+				records = append(records, output.FromBoard(boardItem, active))
+			}
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindBoards, records)
+		}
 		if len(boards) == 0 {
 			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No boards found.")
 			return err
@@ -55,4 +67,6 @@ var boardListCmd = &cobra.Command{
 
 func init() {
 	boardCmd.AddCommand(boardListCmd)
+	cli.SupportOutput(boardListCmd)
+	cli.SupportRevision(boardListCmd)
 }
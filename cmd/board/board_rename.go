@@ -36,4 +36,5 @@ var boardRenameCmd = &cobra.Command{
 
 func init() {
 	boardCmd.AddCommand(boardRenameCmd)
+	boardRenameCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteBoardIDs)
 }
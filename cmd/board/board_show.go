@@ -9,15 +9,27 @@ import (
 
 	boardpkg "mochi-sticky/internal/board"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
 
 var boardShowCmd = &cobra.Command{
-	Use:   "show <id>",
-	Short: "Show board details",
-	Args:  cobra.ExactArgs(1),
+	Use:   "show [id]",
+	Short: "Show board details (default: active board)",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		laneSpec, err := cmd.Flags().GetString("lanes")
+		if err != nil {
+			return err
+		}
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		if format != output.FormatTable && strings.TrimSpace(laneSpec) != "" {
+			return fmt.Errorf("--lanes is only available with --output table (use 'task list --output %s' for task data)", format)
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -37,7 +49,10 @@ var boardShowCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		id := args[0]
+		id := active
+		if len(args) == 1 {
+			id = args[0]
+		}
 		for _, boardItem := range boards {
 			if boardItem.ID != id {
 				continue
@@ -50,6 +65,16 @@ var boardShowCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
+			if format != output.FormatTable {
+				config, err := boardRepo.LoadConfigContext(ctx)
+				if err != nil {
+					return err
+				}
+				record := output.FromBoard(boardItem, active)
+				record.Description = strings.TrimRight(description, "\n")
+				record.Context = &config.Context
+				return output.WriteRecord(cmd.OutOrStdout(), format, output.KindBoard, record)
+			}
 			activeMark := ""
 			if boardItem.ID == active {
 				activeMark = " (active)"
@@ -91,12 +116,40 @@ var boardShowCmd = &cobra.Command{
 			); err != nil {
 				return err
 			}
-			return nil
+			if strings.TrimSpace(laneSpec) == "" {
+				return nil
+			}
+			if laneSpec == boardLanesFromConfig {
+				laneSpec = config.Lanes.GroupBy
+			}
+			group, err := boardpkg.ParseLaneGroup(laneSpec)
+			if err != nil {
+				return err
+			}
+			if group.IsZero() {
+				return fmt.Errorf("no lane grouping configured for board %s (set lanes.group_by in config.yaml or pass --lanes tag|priority|assignee|sprint|field:<name>)", boardItem.ID)
+			}
+			tasks, err := boardRepo.GetAllTasksContext(ctx)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "\nLanes by %s:\n", group); err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(cmd.OutOrStdout(), boardpkg.FormatLanes(config.Columns, tasks, group, config.Lanes.Order))
+			return err
 		}
-		return fmt.Errorf("board not found: %s", id)
+		return fmt.Errorf("board: %s: %w", id, boardpkg.ErrBoardNotFound)
 	},
 }
 
+// boardLanesFromConfig is the --lanes value that selects the grouping from the board config.
+const boardLanesFromConfig = "config"
+
 func init() {
 	boardCmd.AddCommand(boardShowCmd)
+	boardShowCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteBoardIDs)
+	cli.SupportOutput(boardShowCmd)
+	cli.SupportRevision(boardShowCmd)
+	boardShowCmd.Flags().String("lanes", "", "Group tasks into swimlanes: tag|priority|assignee|sprint|field:<name>|config")
 }
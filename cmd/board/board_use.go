@@ -32,4 +32,5 @@ var boardUseCmd = &cobra.Command{
 
 func init() {
 	boardCmd.AddCommand(boardUseCmd)
+	boardUseCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteBoardIDs)
 }
@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"mochi-sticky/internal/board"
 	"mochi-sticky/internal/cli"
@@ -17,7 +18,11 @@ import (
 var addCmd = &cobra.Command{
 	Use:   "add [title]",
 	Short: "Add a new task",
-	Args:  cobra.MinimumNArgs(1),
+	Long: "Add a new task. Inline tokens in the title set metadata and are removed from it:\n" +
+		"#tag, !1-3 (priority), >status, ^YYYY-MM-DD (due date) and +T-000012 (dependency),\n" +
+		"e.g. 'Fix login race #backend !1 >doing'. Prefixes are configured under quick_capture in\n" +
+		"the board config; --literal (or a leading \\ on a word) keeps the title as typed.",
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		title := strings.Join(args, " ")
 		task, err := board.NewTask(title)
@@ -36,6 +41,42 @@ var addCmd = &cobra.Command{
 			return err
 		}
 		task.Priority = priority
+		dueInput, err := cmd.Flags().GetString("due")
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(dueInput) != "" {
+			due, err := time.Parse("2006-01-02", strings.TrimSpace(dueInput))
+			if err != nil {
+				return fmt.Errorf("board: due date %q must be YYYY-MM-DD: %w", dueInput, board.ErrInvalidDue)
+			}
+			task.Due = board.Date{Time: due}
+		}
+		literal, err := cmd.Flags().GetBool("literal")
+		if err != nil {
+			return err
+		}
+		estimate, err := cmd.Flags().GetFloat64("estimate")
+		if err != nil {
+			return err
+		}
+		task.Estimate = estimate
+		sprintRef, err := cmd.Flags().GetString("sprint")
+		if err != nil {
+			return err
+		}
+		assignee, err := cmd.Flags().GetString("assignee")
+		if err != nil {
+			return err
+		}
+		task.Assignee = strings.TrimSpace(assignee)
+		fields, err := cmd.Flags().GetStringToString("field")
+		if err != nil {
+			return err
+		}
+		if len(fields) > 0 {
+			task.Fields = fields
+		}
 
 		workingDir, err := os.Getwd()
 		if err != nil {
@@ -71,6 +112,15 @@ var addCmd = &cobra.Command{
 		}
 		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 		defer stop()
+		if !literal {
+			if err := applyQuickCapture(ctx, cmd, repo, &task); err != nil {
+				return err
+			}
+		}
+		task.Sprint, err = repo.ResolveSprintRefContext(ctx, sprintRef)
+		if err != nil {
+			return err
+		}
 		created, err := repo.CreateTaskContext(ctx, task)
 		if err != nil {
 			return err
@@ -81,9 +131,50 @@ var addCmd = &cobra.Command{
 	},
 }
 
+// applyQuickCapture moves the inline tokens of the task title into its metadata. Statuses
+// must name a column and dependencies an existing task; values passed as flags win over tokens.
+func applyQuickCapture(ctx context.Context, cmd *cobra.Command, repo *board.Repository, task *board.Task) error {
+	config, err := repo.LoadConfigContext(ctx)
+	if err != nil {
+		return err
+	}
+	tasks, err := repo.GetAllTasksContext(ctx)
+	if err != nil {
+		return err
+	}
+	archived, err := repo.ListArchivedTasksContext(ctx)
+	if err != nil {
+		return err
+	}
+	scope := board.NewQuickCaptureScope(config.Columns, append(tasks, archived...))
+	capture, err := board.ParseQuickCapture(task.Title, config.QuickCapture, scope)
+	if err != nil {
+		return fmt.Errorf("%w (use --literal to keep the title as typed)", err)
+	}
+	if strings.TrimSpace(capture.Title) == "" {
+		return fmt.Errorf("board: title is empty after removing quick-capture tokens: %w", board.ErrInvalidTitle)
+	}
+	flagged := *task
+	capture.Apply(task)
+	if cmd.Flags().Changed("priority") {
+		task.Priority = flagged.Priority
+	}
+	if cmd.Flags().Changed("due") {
+		task.Due = flagged.Due
+	}
+	return nil
+}
+
 func init() {
 	taskCmd.AddCommand(addCmd)
 	addCmd.Flags().String("tags", "", "Comma-separated tags")
 	addCmd.Flags().Int("priority", board.DefaultPriority, "Priority (1-3)")
+	addCmd.Flags().Float64("estimate", 0, "Estimate in the board's unit (points or hours)")
+	addCmd.Flags().String("sprint", "", "Sprint ID (or \"active\")")
+	addCmd.Flags().String("assignee", "", "Assignee")
+	addCmd.Flags().StringToString("field", nil, "Custom field key=value (repeatable)")
 	addCmd.Flags().String("template", "", "Template name (from configured task templates)")
+	cli.CompleteFlag(addCmd, "template", cli.CompleteTemplates(cli.TemplateTask))
+	addCmd.Flags().String("due", "", "Due date (YYYY-MM-DD)")
+	addCmd.Flags().Bool("literal", false, "Do not parse quick-capture tokens (#tag !1 >status ^date +T-1) from the title")
 }
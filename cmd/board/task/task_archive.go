@@ -148,4 +148,7 @@ func init() {
 	archiveCmd.AddCommand(archiveRestoreCmd)
 	archiveCmd.AddCommand(archiveDeleteCmd)
 	taskCmd.AddCommand(archiveCmd)
+	archiveTaskCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+	archiveRestoreCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteArchivedTaskIDs)
+	archiveDeleteCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteArchivedTaskIDs)
 }
@@ -0,0 +1,40 @@
+package taskcmd
+
+import (
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+// addBoardsFlags registers the cross-board selection flags on a task command.
+func addBoardsFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all-boards", false, "Read every non-archived board")
+	cmd.Flags().StringSlice("boards", nil, "Read the listed boards (comma-separated or repeatable)")
+	cli.CompleteFlag(cmd, "boards", cli.CompleteBoardIDs)
+}
+
+// selectedBoards resolves --all-boards/--boards. ok is false when neither flag is set and the
+// command should use the active board.
+func selectedBoards(cmd *cobra.Command, workingDir, storageRoot string) ([]board.Board, bool, error) {
+	all, err := cmd.Flags().GetBool("all-boards")
+	if err != nil {
+		return nil, false, err
+	}
+	ids, err := cmd.Flags().GetStringSlice("boards")
+	if err != nil {
+		return nil, false, err
+	}
+	if !all && len(ids) == 0 {
+		return nil, false, nil
+	}
+	boardRepo, err := board.NewBoardRepositoryWithStorage(workingDir, storageRoot)
+	if err != nil {
+		return nil, false, err
+	}
+	boards, err := boardRepo.SelectBoards(ids, all)
+	if err != nil {
+		return nil, false, err
+	}
+	return boards, true, nil
+}
@@ -0,0 +1,91 @@
+package taskcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk --filter <expr> [changes]",
+	Short: "Apply status, tag, priority or archive changes to every matching task",
+	Long: "Apply the same changes to every task matching --filter.\n\n" +
+		"The filter uses the task query language of task list --query, for example\n" +
+		"--filter 'status:todo tag:backend -tag:blocked'. Use --filter all to match every task.\n" +
+		"All writes happen under one repository lock and are rolled back if any of them fails.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := cmd.Flags().GetString("filter")
+		if err != nil {
+			return err
+		}
+		query, err := board.ParseBulkFilter(filter)
+		if err != nil {
+			var queryErr *board.QueryError
+			if errors.As(err, &queryErr) {
+				return fmt.Errorf("%w\n%s", err, queryErr.Pointer())
+			}
+			return err
+		}
+		status, err := cmd.Flags().GetString("set-status")
+		if err != nil {
+			return err
+		}
+		addTags, err := cmd.Flags().GetStringSlice("add-tag")
+		if err != nil {
+			return err
+		}
+		removeTags, err := cmd.Flags().GetStringSlice("remove-tag")
+		if err != nil {
+			return err
+		}
+		priority, err := cmd.Flags().GetInt("set-priority")
+		if err != nil {
+			return err
+		}
+		archive, err := cmd.Flags().GetBool("archive")
+		if err != nil {
+			return err
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+		repo, err := cli.RepoFromCwd()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		results, err := repo.BulkUpdateContext(ctx, query, board.BulkChange{
+			Status:     status,
+			AddTags:    addTags,
+			RemoveTags: removeTags,
+			Priority:   priority,
+			Archive:    archive,
+		}, dryRun)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), board.FormatBulkResults(results, dryRun))
+		return err
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(bulkCmd)
+	bulkCmd.Flags().String("filter", "", "Query selecting the tasks (task query language, or 'all')")
+	bulkCmd.Flags().String("set-status", "", "Move matching tasks to this status")
+	bulkCmd.Flags().StringSlice("add-tag", nil, "Tags to add (repeatable or comma-separated)")
+	bulkCmd.Flags().StringSlice("remove-tag", nil, "Tags to remove (repeatable or comma-separated)")
+	bulkCmd.Flags().Int("set-priority", 0, "Set priority (1-3)")
+	bulkCmd.Flags().Bool("archive", false, "Archive matching tasks")
+	bulkCmd.Flags().Bool("dry-run", false, "Preview the changes without writing")
+}
@@ -36,4 +36,5 @@ var deleteCmd = &cobra.Command{
 func init() {
 	deleteCmd.Flags().Bool("force", false, "Skip confirmation prompt")
 	taskCmd.AddCommand(deleteCmd)
+	deleteCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
 }
@@ -57,4 +57,5 @@ var taskDepsCmd = &cobra.Command{
 func init() {
 	taskDepsCmd.Flags().StringVar(&depsSetFlag, "set", "", "Comma-separated list of dependency IDs to set")
 	taskCmd.AddCommand(taskDepsCmd)
+	taskDepsCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
 }
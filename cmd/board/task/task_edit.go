@@ -0,0 +1,220 @@
+package taskcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// editFlags are the task edit flags that change the task directly instead of opening the editor.
+var editFlags = []string{"title", "add-tag", "remove-tag", "set-tags", "body", "append"}
+
+var editCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Edit a task's title, tags or body, or open it in your editor",
+	Long: "Edit a task's title, tags or body with flags. Without flags the task file opens in your\n" +
+		"editor; the frontmatter is validated when the editor exits and invalid edits are rejected.\n" +
+		"Pass - to --body or --append to read the text from stdin.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		flagEdit := false
+		for _, name := range editFlags {
+			flagEdit = flagEdit || cmd.Flags().Changed(name)
+		}
+		if !flagEdit {
+			task, err := repo.GetTaskByID(id)
+			if err != nil {
+				return err
+			}
+			return editTaskInEditor(cmd, repo, storageRoot, task)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		title, err := cmd.Flags().GetString("title")
+		if err != nil {
+			return err
+		}
+		addTags, err := cmd.Flags().GetStringSlice("add-tag")
+		if err != nil {
+			return err
+		}
+		removeTags, err := cmd.Flags().GetStringSlice("remove-tag")
+		if err != nil {
+			return err
+		}
+		setTags, err := cmd.Flags().GetString("set-tags")
+		if err != nil {
+			return err
+		}
+		body, err := cmd.Flags().GetString("body")
+		if err != nil {
+			return err
+		}
+		appendText, err := cmd.Flags().GetString("append")
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("title") && strings.TrimSpace(title) == "" {
+			return fmt.Errorf("board: %w", board.ErrInvalidTitle)
+		}
+		if body == "-" || appendText == "-" {
+			data, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("failed to read stdin: %w", err)
+			}
+			if body == "-" {
+				body = string(data)
+			} else {
+				appendText = string(data)
+			}
+		}
+
+		err = repo.EditTaskContext(ctx, id, func(task *board.Task) {
+			if cmd.Flags().Changed("title") {
+				task.Title = title
+			}
+			if cmd.Flags().Changed("set-tags") || len(addTags) > 0 || len(removeTags) > 0 {
+				tags := task.Tags
+				if cmd.Flags().Changed("set-tags") {
+					tags = board.ParseTags(setTags)
+				}
+				task.Tags = editTags(tags, addTags, removeTags)
+			}
+			if cmd.Flags().Changed("body") {
+				task.Content = body
+			}
+			if cmd.Flags().Changed("append") {
+				task.Content = appendContent(task.Content, appendText)
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Updated task %s\n", id)
+		return err
+	},
+}
+
+// editTags adds and then removes tags; removal matches the normalized tag.
+func editTags(tags, add, remove []string) []string {
+	removed := map[string]bool{}
+	for _, tag := range board.NormalizeTags(remove) {
+		removed[tag] = true
+	}
+	out := make([]string, 0, len(tags)+len(add))
+	for _, tag := range board.NormalizeTags(append(append([]string{}, tags...), add...)) {
+		if !removed[tag] {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// appendContent adds text to the task body as a new paragraph.
+func appendContent(content, text string) string {
+	text = strings.Trim(text, "\n")
+	trimmed := strings.TrimRight(content, "\n")
+	if strings.TrimSpace(trimmed) == "" {
+		return text + "\n"
+	}
+	return trimmed + "\n\n" + text + "\n"
+}
+
+// editTaskInEditor opens the task file in the configured editor and validates the result.
+// An invalid edit restores the original file and keeps the rejected version in a temp file.
+func editTaskInEditor(cmd *cobra.Command, repo *board.Repository, storageRoot string, task board.Task) error {
+	cfg, err := storage.LoadConfigFromRoot(storageRoot)
+	if err != nil {
+		return err
+	}
+	editorOverride, err := cmd.Flags().GetString("editor")
+	if err != nil {
+		return err
+	}
+	editor := cli.ResolveEditorOverride(editorOverride, cfg.Editor)
+	if strings.TrimSpace(editor) == "" {
+		editor = "nano"
+	}
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		return fmt.Errorf("editor is required")
+	}
+
+	original, err := os.ReadFile(task.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read task %s: %w", task.FilePath, err)
+	}
+	editCmd := exec.Command(parts[0], append(parts[1:], task.FilePath)...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return err
+	}
+
+	edited, err := os.ReadFile(task.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read task %s: %w", task.FilePath, err)
+	}
+	if bytes.Equal(original, edited) {
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "No changes to task %s\n", task.ID)
+		return err
+	}
+	if _, validateErr := repo.ValidateTaskEdit(task, edited); validateErr != nil {
+		if err := os.WriteFile(task.FilePath, original, 0o644); err != nil {
+			return fmt.Errorf("%w (restoring %s also failed: %v)", validateErr, task.FilePath, err)
+		}
+		rejected, err := os.CreateTemp("", task.ID+"-*.md")
+		if err != nil {
+			return fmt.Errorf("%w (edit discarded)", validateErr)
+		}
+		_, writeErr := rejected.Write(edited)
+		closeErr := rejected.Close()
+		if writeErr != nil || closeErr != nil {
+			return fmt.Errorf("%w (edit discarded)", validateErr)
+		}
+		return fmt.Errorf("%w (edit discarded; your version was saved to %s)", validateErr, rejected.Name())
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Updated task %s\n", task.ID)
+	return err
+}
+
+func init() {
+	taskCmd.AddCommand(editCmd)
+	editCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+	editCmd.Flags().String("title", "", "New title")
+	editCmd.Flags().StringSlice("add-tag", nil, "Add a tag (repeatable or comma-separated)")
+	editCmd.Flags().StringSlice("remove-tag", nil, "Remove a tag (repeatable or comma-separated)")
+	editCmd.Flags().String("set-tags", "", "Replace all tags (comma-separated; empty clears)")
+	editCmd.Flags().String("body", "", "Replace the task body (- reads stdin)")
+	editCmd.Flags().String("append", "", "Append a paragraph to the task body (- reads stdin)")
+	editCmd.Flags().String("editor", "", "Override the editor command")
+	editCmd.MarkFlagsMutuallyExclusive("body", "append")
+}
@@ -0,0 +1,52 @@
+package taskcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate <id> <value>",
+	Short: "Set a task estimate (0 clears it)",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		estimate, err := board.ParseEstimate(args[1])
+		if err != nil {
+			return err
+		}
+		repo, err := cli.RepoFromCwd()
+		if err != nil {
+			return err
+		}
+		config, err := repo.LoadConfig()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if err := repo.UpdateTaskEstimateContext(ctx, id, estimate); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(
+			cmd.OutOrStdout(),
+			"Updated estimate for %s to %s %s\n",
+			id,
+			board.FormatEstimate(estimate),
+			board.EstimateUnitLabel(config.EstimateUnit),
+		)
+		return err
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(estimateCmd)
+	estimateCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+}
@@ -0,0 +1,103 @@
+package taskcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var finishCmd = &cobra.Command{
+	Use:   "finish <id>",
+	Short: "Finish work on a task and print its branch",
+	Long: "Move the task to the review column (a column whose key contains \"review\"), or to the\n" +
+		"first done column when the board has none, and print the branch recorded by\n" +
+		"`task start` so it can be pushed and opened as a pull request.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		status, err := cmd.Flags().GetString("status")
+		if err != nil {
+			return err
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		task, err := repo.GetTaskByID(id)
+		if err != nil {
+			return err
+		}
+		boardConfig, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(status) == "" {
+			status, err = boardConfig.FinishColumn()
+		} else {
+			err = boardConfig.ValidateStatus(status)
+		}
+		if err != nil {
+			return err
+		}
+		if err := repo.UpdateTaskStatusContext(ctx, task.ID, status); err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if _, err := fmt.Fprintf(out, "Moved task %s to %s\n", task.ID, status); err != nil {
+			return err
+		}
+		branch := task.Branch
+		if branch == "" {
+			branch, err = currentBranch(ctx, storageRoot)
+			if err != nil {
+				return err
+			}
+		}
+		if branch == "" {
+			_, err = fmt.Fprintln(out, "No branch recorded for this task.")
+			return err
+		}
+		_, err = fmt.Fprintf(out, "Branch: %s\nPush it and open a pull request:\n  git push -u origin %s\n", branch, branch)
+		return err
+	},
+}
+
+// currentBranch returns the branch checked out in the working tree holding storageRoot, or an
+// empty string outside a git repository.
+func currentBranch(ctx context.Context, storageRoot string) (string, error) {
+	repo, err := git.Open(ctx, storageRoot)
+	if errors.Is(err, git.ErrNotRepository) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return repo.CurrentBranch(ctx)
+}
+
+func init() {
+	taskCmd.AddCommand(finishCmd)
+	finishCmd.Flags().String("status", "", "Status to move the task to (default: review column, else done)")
+	finishCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+	cli.CompleteFlag(finishCmd, "status", cli.CompleteStatuses)
+}
@@ -0,0 +1,128 @@
+package taskcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [id]",
+	Short: "Show how a task changed over its git history",
+	Long: "Walk the git history of a task file, following renames such as the move into archive/tasks,\n" +
+		"and print when its status, priority, tags and title changed and who made each commit.\n" +
+		"With --all, print the timeline of every active and archived task on the board.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return err
+		}
+		if all == (len(args) == 1) {
+			return fmt.Errorf("task history: specify either a task ID or --all")
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		var histories []board.TaskHistory
+		if all {
+			histories, err = repo.TaskHistoriesContext(ctx)
+		} else {
+			var history board.TaskHistory
+			history, err = repo.TaskHistoryContext(ctx, args[0])
+			histories = []board.TaskHistory{history}
+		}
+		if err != nil {
+			return err
+		}
+
+		if format != output.FormatTable {
+			rows := output.FromTaskHistories(histories)
+			if all {
+				return output.WriteReport(cmd.OutOrStdout(), format, output.KindTaskHistories, histories, rows)
+			}
+			return output.WriteReport(cmd.OutOrStdout(), format, output.KindTaskHistory, histories[0], rows)
+		}
+		return writeTaskHistories(cmd.OutOrStdout(), histories)
+	},
+}
+
+// writeTaskHistories prints one timeline line per commit, with a heading per task.
+func writeTaskHistories(out io.Writer, histories []board.TaskHistory) error {
+	lines := make([]string, 0)
+	for i, history := range histories {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		heading := history.ID + " " + history.Title
+		if history.Archived {
+			heading += " (archived)"
+		}
+		lines = append(lines, heading)
+		if len(history.Changes) == 0 {
+			lines = append(lines, "  (no committed history)")
+		}
+		for _, change := range history.Changes {
+			prefix := fmt.Sprintf("  %s  %s  %s  ", change.Commit.Date.Format("2006-01-02 15:04"), change.Commit.ShortHash(), change.Commit.Author)
+			lines = append(lines, prefix+describeTaskChange(change))
+		}
+	}
+	_, err := fmt.Fprintln(out, strings.Join(lines, "\n"))
+	return err
+}
+
+func describeTaskChange(change board.TaskChange) string {
+	parts := make([]string, 0, len(change.Changes))
+	for _, field := range change.Changes {
+		switch {
+		case change.Created:
+			parts = append(parts, field.Field+" "+field.To)
+		case field.Field == "path":
+			parts = append(parts, "moved to "+field.To)
+		default:
+			parts = append(parts, fmt.Sprintf("%s: %s -> %s", field.Field, orNone(field.From), orNone(field.To)))
+		}
+	}
+	if change.Created {
+		return "created (" + strings.Join(parts, ", ") + ")"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+func init() {
+	taskCmd.AddCommand(historyCmd)
+	cli.SupportOutput(historyCmd)
+	historyCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+	historyCmd.Flags().Bool("all", false, "Show the history of every active and archived task")
+}
@@ -1,13 +1,17 @@
 package taskcmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 	"time"
 
 	"mochi-sticky/internal/board"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -16,19 +20,19 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List tasks",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		workingDir, err := os.Getwd()
+		format, err := cli.OutputFormat()
 		if err != nil {
 			return err
 		}
-		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
-		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
 		if err != nil {
 			return err
 		}
-		tasks, err := repo.GetAllTasks()
+		boards, crossBoard, err := selectedBoards(cmd, workingDir, storageRoot)
 		if err != nil {
 			return err
 		}
@@ -72,6 +76,10 @@ var listCmd = &cobra.Command{
 			}
 			toDate = parsed
 		}
+		sprintRef, err := cmd.Flags().GetString("sprint")
+		if err != nil {
+			return err
+		}
 		sortBy, err := cmd.Flags().GetString("sort")
 		if err != nil {
 			return err
@@ -80,8 +88,27 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-
-		tasks = board.FilterAndSortTasks(tasks, board.ListOptions{
+		queryStr, err := cmd.Flags().GetString("query")
+		if err != nil {
+			return err
+		}
+		query, err := board.ParseQuery(queryStr)
+		if err != nil {
+			var queryErr *board.QueryError
+			if errors.As(err, &queryErr) {
+				return fmt.Errorf("%w\n%s", err, queryErr.Pointer())
+			}
+			return err
+		}
+		viewName, err := cmd.Flags().GetString("view")
+		if err != nil {
+			return err
+		}
+		linkFilter, err := commitLinkFilter(cmd)
+		if err != nil {
+			return err
+		}
+		opts := board.ListOptions{
 			Status:  statusFilter,
 			Title:   titleFilter,
 			Tags:    board.NormalizeTags(tagFilters),
@@ -90,26 +117,177 @@ var listCmd = &cobra.Command{
 			To:      toDate,
 			SortBy:  sortBy,
 			Desc:    desc,
-		})
+		}
+		if crossBoard {
+			if strings.TrimSpace(viewName) != "" {
+				return fmt.Errorf("--view applies to a single board and cannot be combined with --all-boards/--boards")
+			}
+			return listBoardsTasks(cmd, format, workingDir, storageRoot, boards, opts, sprintRef, query, linkFilter)
+		}
 
+		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		tasks, err := repo.GetAllTasks()
+		if err != nil {
+			return err
+		}
+		opts.Sprint, err = repo.ResolveSprintRef(sprintRef)
+		if err != nil {
+			return err
+		}
+		var view board.View
+		if strings.TrimSpace(viewName) != "" {
+			view, err = repo.GetView(viewName)
+			if err != nil {
+				return err
+			}
+		}
+
+		tasks = board.FilterAndSortTasks(tasks, opts)
+		tasks, err = view.Apply(tasks)
+		if err != nil {
+			return err
+		}
+		tasks = query.Apply(tasks)
+		tasks, err = filterLinkedTasks(cmd, workingDir, storageRoot, tasks, linkFilter)
+		if err != nil {
+			return err
+		}
+
+		if format != output.FormatTable {
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindTasks, output.FromTasks(tasks, repo.BoardID(), workingDir))
+		}
 		if len(tasks) == 0 {
 			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No tasks found.")
 			return err
 		}
-		table := board.FormatTasksTable(tasks)
-		_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
+		config, err := repo.LoadConfig()
+		if err != nil {
+			return err
+		}
+		table := board.FormatViewTasks(view, config.Columns, tasks, config.Lanes.Order)
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), table); err != nil {
+			return err
+		}
+		if !board.HasEstimates(tasks) {
+			return nil
+		}
+		totals := board.EstimateTotalsByColumn(config.Columns, tasks)
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), board.FormatEstimateTotals(totals, config.EstimateUnit))
 		return err
 	},
 }
 
+// listBoardsTasks lists tasks across boards. Each board is filtered concurrently (sprint
+// references such as "active" resolve per board); the merged result is then sorted, so ties
+// keep the board order.
+func listBoardsTasks(cmd *cobra.Command, format output.Format, workingDir, storageRoot string, boards []board.Board, opts board.ListOptions, sprintRef string, query board.Query, linkFilter string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	boardOpts := opts
+	boardOpts.SortBy = ""
+	boardOpts.Desc = false
+	tasks, err := board.CollectTasksContext(ctx, workingDir, storageRoot, boards, func(ctx context.Context, repo *board.Repository) ([]board.Task, error) {
+		sprintID, err := repo.ResolveSprintRefContext(ctx, sprintRef)
+		if errors.Is(err, board.ErrSprintNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		filtered := boardOpts
+		filtered.Sprint = sprintID
+		return board.FilterAndSortTasks(tasks, filtered), nil
+	})
+	if err != nil {
+		return err
+	}
+	tasks = query.Apply(board.FilterAndSortTasks(tasks, board.ListOptions{SortBy: opts.SortBy, Desc: opts.Desc}))
+	tasks, err = filterLinkedTasks(cmd, workingDir, storageRoot, tasks, linkFilter)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable {
+		return output.WriteList(cmd.OutOrStdout(), format, output.KindTasks, output.FromTasks(tasks, "", workingDir))
+	}
+	if len(tasks) == 0 {
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), "No tasks found.")
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), board.FormatBoardTasksTable(tasks))
+	return err
+}
+
+// Commit link filters selected by --has-commits and --no-commits.
+const (
+	linkedWithCommits    = "has-commits"
+	linkedWithoutCommits = "no-commits"
+)
+
+// commitLinkFilter returns the commit link filter requested by the flags, or "".
+func commitLinkFilter(cmd *cobra.Command) (string, error) {
+	hasCommits, err := cmd.Flags().GetBool(linkedWithCommits)
+	if err != nil {
+		return "", err
+	}
+	noCommits, err := cmd.Flags().GetBool(linkedWithoutCommits)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case hasCommits:
+		return linkedWithCommits, nil
+	case noCommits:
+		return linkedWithoutCommits, nil
+	default:
+		return "", nil
+	}
+}
+
+// filterLinkedTasks keeps the tasks with (or without) commits referencing their ID.
+func filterLinkedTasks(cmd *cobra.Command, workingDir, storageRoot string, tasks []board.Task, linkFilter string) ([]board.Task, error) {
+	if linkFilter == "" {
+		return tasks, nil
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	linked, err := cli.TaskCommits(ctx, workingDir, storageRoot)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]board.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if (len(linked[task.ID]) > 0) == (linkFilter == linkedWithCommits) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered, nil
+}
+
 func init() {
 	taskCmd.AddCommand(listCmd)
+	cli.SupportOutput(listCmd)
+	cli.SupportRevision(listCmd)
 	listCmd.Flags().String("status", "", "Filter tasks by status key")
 	listCmd.Flags().String("title", "", "Filter tasks by title (substring match)")
 	listCmd.Flags().StringSlice("tag", nil, "Filter tasks by tag (repeatable)")
 	listCmd.Flags().String("tag-mode", "any", "Tag match mode: any|all")
+	listCmd.Flags().String("sprint", "", "Filter tasks by sprint ID (or \"active\")")
 	listCmd.Flags().String("from", "", "Filter tasks created on/after YYYY-MM-DD")
 	listCmd.Flags().String("to", "", "Filter tasks created on/before YYYY-MM-DD")
-	listCmd.Flags().String("sort", "", "Sort by: status, created, title, priority")
+	listCmd.Flags().String("sort", "", "Sort by: status, created, title, priority, rank")
 	listCmd.Flags().Bool("desc", false, "Sort in descending order")
+	addBoardsFlags(listCmd)
+	cli.CompleteFlag(listCmd, "status", cli.CompleteStatuses)
+	listCmd.Flags().String("view", "", "Apply a saved view (see 'view list'); other filters narrow it further")
+	listCmd.Flags().String("query", "", "Filter and sort with a query, e.g. 'status:doing tag:backend priority<=2 sort:-priority'")
+	listCmd.Flags().Bool(linkedWithCommits, false, "Only tasks referenced by a git commit message")
+	listCmd.Flags().Bool(linkedWithoutCommits, false, "Only tasks no git commit message references")
+	listCmd.MarkFlagsMutuallyExclusive(linkedWithCommits, linkedWithoutCommits)
 }
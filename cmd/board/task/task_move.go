@@ -45,4 +45,5 @@ var moveCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(moveCmd)
+	moveCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs, cli.CompleteStatuses)
 }
@@ -50,4 +50,5 @@ var priorityCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(priorityCmd)
+	priorityCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
 }
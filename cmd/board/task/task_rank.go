@@ -0,0 +1,51 @@
+package taskcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var rankCmd = &cobra.Command{
+	Use:   "rank <id> (--before <id> | --after <id>)",
+	Short: "Reorder a task within its column",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		before, err := cmd.Flags().GetString("before")
+		if err != nil {
+			return err
+		}
+		after, err := cmd.Flags().GetString("after")
+		if err != nil {
+			return err
+		}
+		repo, err := cli.RepoFromCwd()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		rank, err := repo.RankTaskContext(ctx, id, board.RankPlacement{Before: before, After: after})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Ranked %s (%s)\n", id, rank)
+		return err
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(rankCmd)
+	rankCmd.Flags().String("before", "", "Place the task before this task")
+	rankCmd.Flags().String("after", "", "Place the task after this task")
+	rankCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+	cli.CompleteFlag(rankCmd, "before", cli.CompleteTaskIDs)
+	cli.CompleteFlag(rankCmd, "after", cli.CompleteTaskIDs)
+}
@@ -1,11 +1,14 @@
 package taskcmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
 	"mochi-sticky/internal/board"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -15,6 +18,10 @@ var taskReadyCmd = &cobra.Command{
 	Short: "List tasks whose dependencies are satisfied",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -23,6 +30,29 @@ var taskReadyCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		boards, crossBoard, err := selectedBoards(cmd, workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		if crossBoard {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			tasks, err := board.CollectTasksContext(ctx, workingDir, storageRoot, boards, func(ctx context.Context, repo *board.Repository) ([]board.Task, error) {
+				return repo.ListReadyTasksContext(ctx)
+			})
+			if err != nil {
+				return err
+			}
+			if format != output.FormatTable {
+				return output.WriteList(cmd.OutOrStdout(), format, output.KindTasks, output.FromTasks(tasks, "", workingDir))
+			}
+			for _, t := range tasks {
+				if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s %s %s\n", t.BoardID, t.ID, t.Title); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
 		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
 		if err != nil {
 			return err
@@ -31,6 +61,9 @@ var taskReadyCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if format != output.FormatTable {
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindTasks, output.FromTasks(tasks, repo.BoardID(), workingDir))
+		}
 		for _, t := range tasks {
 			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", t.ID, t.Title); err != nil {
 				return err
@@ -42,4 +75,7 @@ var taskReadyCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(taskReadyCmd)
+	cli.SupportOutput(taskReadyCmd)
+	cli.SupportRevision(taskReadyCmd)
+	addBoardsFlags(taskReadyCmd)
 }
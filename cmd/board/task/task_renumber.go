@@ -0,0 +1,120 @@
+package taskcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var renumberCmd = &cobra.Command{
+	Use:   "renumber --fix-duplicates",
+	Short: "Give tasks that share an ID new IDs",
+	Long: "Find tasks that share an ID but have different UIDs, as left behind when two branches\n" +
+		"created tasks from the same next_id. The task whose file is named after the ID (else the\n" +
+		"oldest) keeps it; the others get new IDs from the board's id_strategy and their files are\n" +
+		"renamed. References to an old ID are only rewritten where they provably mean the renumbered\n" +
+		"task: depends_on in tasks and wiki pages or ADRs added on the branch being merged (or merged\n" +
+		"by HEAD) that added it. Other references are listed as ambiguous and left alone; run with\n" +
+		"--dry-run first to review them.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		branch, err := cli.MergedBranch(ctx, storageRoot)
+		if err != nil {
+			return err
+		}
+		results, err := repo.FixDuplicateIDsContext(ctx, dryRun, branch)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if len(results) == 0 {
+			_, err := fmt.Fprintln(out, "No duplicate task IDs.")
+			return err
+		}
+		rewritten, ambiguous, err := board.RewriteTaskReferences([]string{cli.WikiRoot(storageRoot), cli.AdrRoot(storageRoot)}, results, branch, dryRun)
+		if err != nil {
+			return err
+		}
+
+		lines := make([]string, 0)
+		for _, result := range results {
+			lines = append(lines, fmt.Sprintf("%s -> %s  %s (uid %s)", result.OldID, result.NewID, result.Title, board.ShortUID(result.UID)))
+			if len(result.Dependents) > 0 {
+				lines = append(lines, "  depends_on in "+strings.Join(result.Dependents, ", "))
+			}
+			for _, path := range referencingFiles(storageRoot, rewritten, result) {
+				lines = append(lines, "  mentioned in "+path)
+			}
+			if len(result.Ambiguous) > 0 {
+				lines = append(lines, fmt.Sprintf("  ambiguous: depends_on in %s (left as %s)", strings.Join(result.Ambiguous, ", "), result.OldID))
+			}
+			for _, path := range referencingFiles(storageRoot, ambiguous, result) {
+				lines = append(lines, fmt.Sprintf("  ambiguous: mentioned in %s (left as %s)", path, result.OldID))
+			}
+		}
+		verb := "Renumbered"
+		if dryRun {
+			verb = "Would renumber"
+		}
+		count := fmt.Sprintf("%d tasks", len(results))
+		if len(results) == 1 {
+			count = "1 task"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", verb, count))
+		_, err = fmt.Fprintln(out, strings.Join(lines, "\n"))
+		return err
+	},
+}
+
+// referencingFiles returns the files in references that mention the old ID of result,
+// relative to storageRoot and sorted.
+func referencingFiles(storageRoot string, references map[string][]string, result board.Renumbered) []string {
+	files := make([]string, 0)
+	for path, oldIDs := range references {
+		if slices.Contains(oldIDs, result.OldID) {
+			if rel, err := filepath.Rel(storageRoot, path); err == nil {
+				path = rel
+			}
+			files = append(files, filepath.ToSlash(path))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+func init() {
+	taskCmd.AddCommand(renumberCmd)
+	renumberCmd.Flags().Bool("fix-duplicates", false, "Renumber tasks that share an ID with another task")
+	renumberCmd.Flags().Bool("dry-run", false, "Print the changes without writing them")
+	if err := renumberCmd.MarkFlagRequired("fix-duplicates"); err != nil {
+		panic(err)
+	}
+}
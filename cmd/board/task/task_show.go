@@ -1,11 +1,18 @@
 package taskcmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strings"
 
 	"mochi-sticky/internal/board"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -13,9 +20,15 @@ import (
 var showCmd = &cobra.Command{
 	Use:   "show <id>",
 	Short: "Show task details",
-	Args:  cobra.ExactArgs(1),
+	Long: "Show task details, followed by the git commits whose message references the task ID\n" +
+		"(matched with git.task_pattern in mochi-sticky.yaml; default " + git.DefaultTaskPattern + ").",
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -24,6 +37,13 @@ var showCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		boards, crossBoard, err := selectedBoards(cmd, workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		if crossBoard {
+			return showBoardsTask(cmd, format, workingDir, storageRoot, boards, id)
+		}
 		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
 		if err != nil {
 			return err
@@ -32,12 +52,88 @@ var showCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		linked, err := cli.TaskCommits(ctx, workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatTable {
+			record := output.FromTask(task, repo.BoardID(), workingDir)
+			record.Commits = output.FromCommits(linked[task.ID])
+			return output.WriteRecord(cmd.OutOrStdout(), format, output.KindTask, record)
+		}
+		return writeTaskDetail(cmd.OutOrStdout(), task, linked[task.ID])
+	},
+}
 
-		_, err = fmt.Fprintln(cmd.OutOrStdout(), board.FormatTaskDetail(task))
+// writeTaskDetail prints a task followed by the commits that reference it.
+func writeTaskDetail(out io.Writer, task board.Task, commits []git.Commit) error {
+	if _, err := fmt.Fprintln(out, board.FormatTaskDetail(task)); err != nil {
 		return err
-	},
+	}
+	if len(commits) == 0 {
+		return nil
+	}
+	lines := []string{"", "Commits:"}
+	for _, commit := range commits {
+		lines = append(lines, fmt.Sprintf("  %s  %s  %s  %s", commit.ShortHash(), commit.Date.Format("2006-01-02"), commit.Author, commit.Subject))
+	}
+	_, err := fmt.Fprintln(out, strings.Join(lines, "\n"))
+	return err
+}
+
+// showBoardsTask shows every task with the ID on the selected boards; IDs are only unique
+// within a board, so structured output is a task list.
+func showBoardsTask(cmd *cobra.Command, format output.Format, workingDir, storageRoot string, boards []board.Board, id string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	tasks, err := board.CollectTasksContext(ctx, workingDir, storageRoot, boards, func(ctx context.Context, repo *board.Repository) ([]board.Task, error) {
+		task, err := repo.GetTaskByID(id)
+		if errors.Is(err, board.ErrTaskNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []board.Task{task}, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return fmt.Errorf("board: %s: %w", id, board.ErrTaskNotFound)
+	}
+	linked, err := cli.TaskCommits(ctx, workingDir, storageRoot)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable {
+		records := make([]output.Task, 0, len(tasks))
+		for _, task := range tasks {
+			record := output.FromTask(task, "", workingDir)
+			record.Commits = output.FromCommits(linked[task.ID])
+			records = append(records, record)
+		}
+		return output.WriteList(cmd.OutOrStdout(), format, output.KindTasks, records)
+	}
+	for i, task := range tasks {
+		if i > 0 {
+			if _, err := fmt.Fprintln(cmd.OutOrStdout()); err != nil {
+				return err
+			}
+		}
+		if err := writeTaskDetail(cmd.OutOrStdout(), task, linked[task.ID]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func init() {
 	taskCmd.AddCommand(showCmd)
+	showCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+	cli.SupportOutput(showCmd)
+	cli.SupportRevision(showCmd)
+	addBoardsFlags(showCmd)
 }
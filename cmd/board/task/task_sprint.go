@@ -0,0 +1,48 @@
+package taskcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint <id> [sprint-id|active]",
+	Short: "Assign a task to a sprint (omit the sprint to clear it)",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		repo, err := cli.RepoFromCwd()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		sprintID := ""
+		if len(args) == 2 {
+			sprintID, err = repo.ResolveSprintRefContext(ctx, args[1])
+			if err != nil {
+				return err
+			}
+		}
+		if err := repo.UpdateTaskSprintContext(ctx, id, sprintID); err != nil {
+			return err
+		}
+		if sprintID == "" {
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Removed %s from its sprint\n", id)
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Assigned %s to sprint %s\n", id, sprintID)
+		return err
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(sprintCmd)
+	sprintCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+}
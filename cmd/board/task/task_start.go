@@ -0,0 +1,116 @@
+package taskcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start work on a task in a new git branch",
+	Long: "Create and check out a git branch for the task, move it to the first active column and\n" +
+		"record the branch in its frontmatter. The branch name comes from --branch-pattern, then\n" +
+		"git.branch_pattern in mochi-sticky.yaml, then \"" + board.DefaultBranchPattern + "\"; placeholders are {id},\n" +
+		"{slug} and {board}. An existing branch of that name is checked out instead. The working\n" +
+		"tree must be clean.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		pattern, err := cmd.Flags().GetString("branch-pattern")
+		if err != nil {
+			return err
+		}
+		status, err := cmd.Flags().GetString("status")
+		if err != nil {
+			return err
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("branch-pattern") {
+			config, err := cli.LoadStorageConfig(workingDir)
+			if err != nil {
+				return err
+			}
+			pattern = config.Git.BranchPattern
+		}
+		repo, err := board.NewRepositoryWithStorage(workingDir, storageRoot)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		task, err := repo.GetTaskByID(id)
+		if err != nil {
+			return err
+		}
+		boardConfig, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(status) == "" {
+			status, err = boardConfig.StartColumn()
+		} else {
+			err = boardConfig.ValidateStatus(status)
+		}
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		gitRepo, err := git.Open(ctx, storageRoot)
+		if err != nil {
+			return err
+		}
+		dirty, err := gitRepo.IsDirty(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("git: %w; commit or stash them first", git.ErrDirtyWorktree)
+		}
+		if task.BoardID == "" {
+			task.BoardID = repo.BoardID()
+		}
+		branch := board.BranchName(pattern, task)
+		exists, err := gitRepo.BranchExists(ctx, branch)
+		if err != nil {
+			return err
+		}
+		if err := gitRepo.CheckoutBranch(ctx, branch, !exists); err != nil {
+			return err
+		}
+		if err := repo.StartTaskContext(ctx, task.ID, status, branch); err != nil {
+			return err
+		}
+
+		verb := "Created"
+		if exists {
+			verb = "Switched to"
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s branch %s\nMoved task %s to %s\n", verb, branch, task.ID, status)
+		return err
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(startCmd)
+	startCmd.Flags().String("branch-pattern", "", "Branch name pattern with {id}, {slug} and {board} placeholders")
+	startCmd.Flags().String("status", "", "Status to move the task to (default: first active column)")
+	startCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteTaskIDs)
+	cli.CompleteFlag(startCmd, "status", cli.CompleteStatuses)
+}
@@ -9,6 +9,7 @@ import (
 
 	"mochi-sticky/internal/board"
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,10 @@ var statusesCmd = &cobra.Command{
 	Use:   "statuses",
 	Short: "List available status keys",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -35,6 +40,15 @@ var statusesCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if format != output.FormatTable {
+			records := make([]output.Status, 0, len(config.Columns))
+			for _, column := range config.Columns {
+				if strings.TrimSpace(column.Key) != "" {
+					records = append(records, output.Status{Key: column.Key, Title: column.Title})
+				}
+			}
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindStatuses, records)
+		}
 		if len(config.Columns) == 0 {
 			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No statuses found.")
 			return err
@@ -58,4 +72,6 @@ var statusesCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(statusesCmd)
+	cli.SupportOutput(statusesCmd)
+	cli.SupportRevision(statusesCmd)
 }
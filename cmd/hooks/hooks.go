@@ -0,0 +1,180 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Move tasks from commit message keywords with git hooks",
+	Long: "Install commit-msg and post-commit hooks that act on keywords in commit messages:\n" +
+		"`closes T-000012` moves the task to done and `refs T-000012` appends a reference to the\n" +
+		"commit to its body. Keywords are configured under hooks.keywords in mochi-sticky.yaml.",
+}
+
+// hookNames lists the hooks written by `hooks install`.
+var hookNames = []string{"commit-msg", "post-commit"}
+
+// Register attaches hooks commands to the root command.
+func Register(root *cobra.Command) {
+	root.AddCommand(hooksCmd)
+}
+
+func init() {
+	hooksCmd.PersistentFlags().String("board", "", "Board ID (default: active board)")
+	cli.CompleteFlag(hooksCmd, "board", cli.CompleteBoardIDs)
+}
+
+// hookEnv is what the hooks commands work on: the storage root, the git repository that
+// holds it and the selected board.
+type hookEnv struct {
+	workingDir  string
+	storageRoot string
+	boardID     string
+	git         *git.Repo
+}
+
+func loadHookEnv(ctx context.Context, cmd *cobra.Command) (hookEnv, error) {
+	boardID, err := cmd.Flags().GetString("board")
+	if err != nil {
+		return hookEnv{}, err
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return hookEnv{}, err
+	}
+	storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+	if err != nil {
+		return hookEnv{}, err
+	}
+	repo, err := git.Open(ctx, storageRoot)
+	if err != nil {
+		return hookEnv{}, err
+	}
+	return hookEnv{workingDir: workingDir, storageRoot: storageRoot, boardID: boardID, git: repo}, nil
+}
+
+func (env hookEnv) boardRepo() (*board.Repository, error) {
+	return board.NewRepositoryForBoardWithStorage(env.workingDir, env.boardID, env.storageRoot)
+}
+
+// triggers returns the task actions message requests under the configured keywords.
+func (env hookEnv) triggers(message string) ([]git.Trigger, error) {
+	config, err := cli.LoadStorageConfig(env.workingDir)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := git.CompileTaskPattern(config.Git.TaskPattern)
+	if err != nil {
+		return nil, err
+	}
+	keywords := git.DefaultKeywords()
+	if len(config.Hooks.Keywords) > 0 {
+		keywords = make(map[string]git.KeywordAction, len(config.Hooks.Keywords))
+		for name, keyword := range config.Hooks.Keywords {
+			keywords[name] = git.KeywordAction{Status: strings.TrimSpace(keyword.Status), Ref: keyword.Ref}
+		}
+	}
+	return git.ParseTriggers(message, keywords, pattern), nil
+}
+
+// checkTriggers reports every trigger naming a task that is not on the board or a status
+// that is not one of its columns.
+func checkTriggers(ctx context.Context, repo *board.Repository, triggers []git.Trigger) error {
+	config, err := repo.LoadConfigContext(ctx)
+	if err != nil {
+		return err
+	}
+	for _, trigger := range triggers {
+		if _, err := repo.GetTaskByID(trigger.TaskID); err != nil {
+			return fmt.Errorf("hooks: %s %s: %w", trigger.Keyword, trigger.TaskID, err)
+		}
+		if trigger.Status != "" {
+			if err := config.ValidateStatus(trigger.Status); err != nil {
+				return fmt.Errorf("hooks: %s %s: %w", trigger.Keyword, trigger.TaskID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// hookScript renders the named hook. It runs command with the storage root (relative to the
+// repository root, where git runs hooks, when it lies inside) and skips silently when the
+// command is not installed so commits never depend on it.
+func hookScript(name, command, storageRoot, repoRoot, boardID string) string {
+	storageArg := storageRoot
+	if rel, err := filepath.Rel(repoRoot, storageRoot); err == nil && !strings.HasPrefix(rel, "..") {
+		storageArg = filepath.ToSlash(rel)
+	}
+	args := []string{shellQuote(command), "--storage", shellQuote(storageArg), "hooks"}
+	if boardID != "" {
+		args = append(args, "--board", shellQuote(boardID))
+	}
+	if name == "commit-msg" {
+		args = append(args, "verify", `"$1"`)
+	} else {
+		args = append(args, "apply")
+	}
+	return strings.Join([]string{
+		"#!/bin/sh",
+		git.HookMarker + " (remove with `mochi-sticky hooks uninstall`)",
+		"command -v " + shellQuote(command) + " >/dev/null 2>&1 || exit 0",
+		"exec " + strings.Join(args, " "),
+		"",
+	}, "\n")
+}
+
+// mergeDriverCommand is the merge driver command line registered in .git/config; git fills
+// in the ancestor, ours, theirs and the path being merged.
+func mergeDriverCommand(command string) string {
+	return shellQuote(command) + " merge-driver %O %A %B %P"
+}
+
+// mergeDriverPatterns returns the .gitattributes patterns, relative to the repository root,
+// of the files the merge driver handles: task files, board configs and the board registry.
+func mergeDriverPatterns(env hookEnv) ([]string, error) {
+	storageRel, err := repoRelative(env.git.Root(), env.storageRoot)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := cli.ResolveConfigPaths(env.workingDir, env.storageRoot)
+	if err != nil {
+		return nil, err
+	}
+	registryRel, err := repoRelative(env.git.Root(), paths.Boards)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		storageRel + "/boards/*/tasks/*.md",
+		storageRel + "/boards/*/archive/tasks/*.md",
+		storageRel + "/boards/*/config.yaml",
+		registryRel,
+	}, nil
+}
+
+// repoRelative returns path relative to the repository root with forward slashes.
+func repoRelative(repoRoot, path string) (string, error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	rel, err := filepath.Rel(repoRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("hooks: %s is outside the git repository %s", path, repoRoot)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,146 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+// commitsHeading starts the body section that collects commit references.
+const commitsHeading = "## Commits"
+
+var applyCmd = &cobra.Command{
+	Use:   "apply [rev]",
+	Short: "Apply the task keywords of a commit (run by the post-commit hook)",
+	Long: "Apply the task keywords of a commit (default HEAD): move each named task to the keyword's\n" +
+		"status and append a reference to the commit under a \"## Commits\" heading of its body.\n" +
+		"--dry-run prints the actions instead; with --message it checks a message before committing.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+		message, err := cmd.Flags().GetString("message")
+		if err != nil {
+			return err
+		}
+		fromMessage := cmd.Flags().Changed("message")
+		if fromMessage && !dryRun {
+			return fmt.Errorf("--message requires --dry-run")
+		}
+		if fromMessage && len(args) > 0 {
+			return fmt.Errorf("--message cannot be combined with a revision")
+		}
+		rev := "HEAD"
+		if len(args) > 0 {
+			rev = args[0]
+		}
+		cmd.SilenceUsage = true
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		env, err := loadHookEnv(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		var commit git.Commit
+		if !fromMessage {
+			commit, message, err = env.git.ReadCommit(ctx, rev)
+			if err != nil {
+				return err
+			}
+		}
+		triggers, err := env.triggers(message)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if len(triggers) == 0 {
+			if dryRun {
+				_, err := fmt.Fprintln(out, "No task keywords found.")
+				return err
+			}
+			return nil
+		}
+		repo, err := env.boardRepo()
+		if err != nil {
+			return err
+		}
+		if err := checkTriggers(ctx, repo, triggers); err != nil {
+			return err
+		}
+		for _, trigger := range triggers {
+			task, err := repo.GetTaskByID(trigger.TaskID)
+			if err != nil {
+				return err
+			}
+			var actions []string
+			if trigger.Status != "" && trigger.Status != task.Status {
+				if !dryRun {
+					if err := repo.UpdateTaskStatusContext(ctx, task.ID, trigger.Status); err != nil {
+						return err
+					}
+				}
+				actions = append(actions, fmt.Sprintf("move %s -> %s", task.Status, trigger.Status))
+			}
+			if trigger.Ref {
+				content, changed := appendCommitRef(task.Content, commit)
+				if changed && !dryRun {
+					if err := repo.UpdateTaskContentContext(ctx, task.ID, content); err != nil {
+						return err
+					}
+				}
+				if changed || dryRun {
+					actions = append(actions, "reference commit "+commitLabel(commit))
+				}
+			}
+			if len(actions) == 0 {
+				actions = append(actions, "nothing to do")
+			}
+			prefix := ""
+			if dryRun {
+				prefix = "would "
+			}
+			if _, err := fmt.Fprintf(out, "%s (%s): %s%s\n", task.ID, trigger.Keyword, prefix, strings.Join(actions, ", ")); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// appendCommitRef adds a line for commit under the Commits heading at the end of content,
+// creating the heading when missing. It reports false when the commit is already listed.
+func appendCommitRef(content string, commit git.Commit) (string, bool) {
+	if commit.Hash != "" && strings.Contains(content, "- "+commit.ShortHash()+" ") {
+		return content, false
+	}
+	trimmed := strings.TrimRight(content, "\n")
+	if !strings.Contains(content, commitsHeading) {
+		if trimmed != "" {
+			trimmed += "\n\n"
+		}
+		trimmed += commitsHeading
+	}
+	line := fmt.Sprintf("- %s %s (%s)", commit.ShortHash(), commit.Subject, commit.Date.Format("2006-01-02"))
+	return trimmed + "\n" + line + "\n", true
+}
+
+func commitLabel(commit git.Commit) string {
+	if commit.Hash == "" {
+		return "(new commit)"
+	}
+	return commit.ShortHash()
+}
+
+func init() {
+	hooksCmd.AddCommand(applyCmd)
+	applyCmd.Flags().Bool("dry-run", false, "Print the actions without changing tasks")
+	applyCmd.Flags().String("message", "", "Commit message to check instead of a commit (requires --dry-run)")
+}
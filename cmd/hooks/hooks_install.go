@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Write the commit-msg and post-commit hooks into the git repository",
+	Long: "Write commit-msg (rejects keywords naming unknown tasks or statuses) and post-commit\n" +
+		"(applies the keywords of the new commit) hooks. Installing again updates them; hooks\n" +
+		"mochi-sticky did not write are kept unless --force is given. --merge-driver also registers\n" +
+		"`mochi-sticky merge-driver` in .git/config and assigns it to task files, board configs and\n" +
+		"the board registry in .gitattributes.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return err
+		}
+		command, err := cmd.Flags().GetString("command")
+		if err != nil {
+			return err
+		}
+		mergeDriver, err := cmd.Flags().GetBool("merge-driver")
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		env, err := loadHookEnv(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		for _, name := range hookNames {
+			script := hookScript(name, command, env.storageRoot, env.git.Root(), env.boardID)
+			result, err := env.git.InstallHook(ctx, name, script, force)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", name, result); err != nil {
+				return err
+			}
+		}
+		if !mergeDriver {
+			return nil
+		}
+		patterns, err := mergeDriverPatterns(env)
+		if err != nil {
+			return err
+		}
+		result, err := env.git.InstallMergeDriver(ctx, git.MergeDriverName, "mochi-sticky task and board merge", mergeDriverCommand(command), patterns)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "merge-driver: %s\n", result)
+		return err
+	},
+}
+
+func init() {
+	hooksCmd.AddCommand(installCmd)
+	installCmd.Flags().Bool("force", false, "Replace hooks that were not written by mochi-sticky")
+	installCmd.Flags().String("command", "mochi-sticky", "Command the hooks run")
+	installCmd.Flags().Bool("merge-driver", false, "Also register the merge driver for task and board files")
+}
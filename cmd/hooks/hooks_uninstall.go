@@ -0,0 +1,45 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the hooks and merge driver written by 'hooks install'",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		env, err := loadHookEnv(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		for _, name := range hookNames {
+			result, err := env.git.UninstallHook(ctx, name)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", name, result); err != nil {
+				return err
+			}
+		}
+		result, err := env.git.UninstallMergeDriver(ctx, git.MergeDriverName)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "merge-driver: %s\n", result)
+		return err
+	},
+}
+
+func init() {
+	hooksCmd.AddCommand(uninstallCmd)
+}
@@ -0,0 +1,50 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// scissorsLine starts the diff git appends to the message file with `commit --verbose`.
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <message-file>",
+	Short: "Check the task keywords of a commit message file (run by the commit-msg hook)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("hooks: failed to read commit message: %w", err)
+		}
+		message, _, _ := strings.Cut(string(data), scissorsLine)
+		cmd.SilenceUsage = true
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		env, err := loadHookEnv(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		triggers, err := env.triggers(message)
+		if err != nil || len(triggers) == 0 {
+			return err
+		}
+		repo, err := env.boardRepo()
+		if err != nil {
+			return err
+		}
+		if err := checkTriggers(ctx, repo, triggers); err != nil {
+			return fmt.Errorf("%w\n(fix the commit message, or skip the hooks with git commit --no-verify)", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	hooksCmd.AddCommand(verifyCmd)
+}
@@ -173,3 +173,19 @@ func TestADRLintCommandReportsOK(t *testing.T) {
 		t.Fatalf("expected lint to report OK, got:\n%s", out)
 	}
 }
+
+func TestADRStatusesCommandStructuredOutput(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupADRStorage(t)
+
+	// Act
+	out, err := runMochiSticky(t, repoRoot, storageRoot, "adr", "statuses", "-o", "csv")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("adr statuses: %v", err)
+	}
+	if !strings.HasPrefix(out, "key,title\nproposed,Proposed\n") {
+		t.Fatalf("unexpected statuses csv:\n%s", out)
+	}
+}
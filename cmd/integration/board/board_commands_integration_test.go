@@ -2,8 +2,11 @@ package integration
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"mochi-sticky/internal/board"
 )
@@ -165,3 +168,452 @@ func TestBoardDeleteCommandRemovesBoard(t *testing.T) {
 		t.Fatalf("expected deleted board to be removed, got:\n%s", listOut)
 	}
 }
+
+func TestStatusCommandExitsOnThresholds(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	blocker := createTask(t, repoRoot, storageRoot, "Migrate schema", nil, 2)
+	createTask(t, repoRoot, storageRoot, "Fix login +"+blocker, nil, 1)
+	config := "status:\n  fail_on:\n    - blocked_p1>0\n"
+	if err := os.WriteFile(filepath.Join(storageRoot, "mochi-sticky.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	// Act
+	passOut, passErr := runMochiSticky(t, repoRoot, storageRoot, "status", "--fail-on", "blocked>5")
+	failOut, failErr := runMochiSticky(t, repoRoot, storageRoot, "status", "-o", "json")
+	aliasOut, aliasErr := runMochiSticky(t, repoRoot, storageRoot, "status", "--json")
+
+	// Assert
+	if passErr != nil || !strings.Contains(passOut, "blocked 1 (P1 1)") || !strings.Contains(passOut, "blocked>5") {
+		t.Fatalf("expected passing status, got %v:\n%s", passErr, passOut)
+	}
+	if failErr == nil || !strings.Contains(failOut, `"kind": "status_report"`) || !strings.Contains(failOut, `"threshold": "blocked_p1>0"`) {
+		t.Fatalf("expected configured threshold to fail, got %v:\n%s", failErr, failOut)
+	}
+	if aliasErr == nil || aliasOut != failOut {
+		t.Fatalf("expected --json to match -o json, got %v:\n%s", aliasErr, aliasOut)
+	}
+}
+
+func TestTaskCommandsLinkGitCommits(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	linked := createTask(t, repoRoot, storageRoot, "Fix parser", nil, 0)
+	unlinked := createTask(t, repoRoot, storageRoot, "Write docs", nil, 0)
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	runGit(t, workTree, "add", ".")
+	runGit(t, workTree, "commit", "-q", "-m", linked+": fix parser")
+
+	// Act
+	showOut, showErr := runMochiSticky(t, repoRoot, storageRoot, "task", "show", linked)
+	hasOut, hasErr := runMochiSticky(t, repoRoot, storageRoot, "task", "list", "--has-commits")
+	noOut, noErr := runMochiSticky(t, repoRoot, storageRoot, "task", "list", "--no-commits")
+	_, bothErr := runMochiSticky(t, repoRoot, storageRoot, "task", "list", "--has-commits", "--no-commits")
+
+	// Assert
+	if showErr != nil || !strings.Contains(showOut, "Commits:") || !strings.Contains(showOut, "Dev  "+linked+": fix parser") {
+		t.Fatalf("expected linked commit in task show, got %v:\n%s", showErr, showOut)
+	}
+	if hasErr != nil || !strings.Contains(hasOut, linked) || strings.Contains(hasOut, unlinked) {
+		t.Fatalf("expected only the linked task, got %v:\n%s", hasErr, hasOut)
+	}
+	if noErr != nil || strings.Contains(noOut, linked) || !strings.Contains(noOut, unlinked) {
+		t.Fatalf("expected only the unlinked task, got %v:\n%s", noErr, noOut)
+	}
+	if bothErr == nil {
+		t.Fatalf("expected --has-commits and --no-commits to conflict")
+	}
+}
+
+func TestHooksCommandsApplyCommitKeywords(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	closed := createTask(t, repoRoot, storageRoot, "Fix parser", nil, 0)
+	referenced := createTask(t, repoRoot, storageRoot, "Write docs", nil, 0)
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	messageFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(messageFile, []byte("Fix parser\n\ncloses T-999999\n"), 0o644); err != nil {
+		t.Fatalf("write message: %v", err)
+	}
+
+	// Act
+	installOut, installErr := runMochiSticky(t, repoRoot, storageRoot, "hooks", "install")
+	reinstallOut, reinstallErr := runMochiSticky(t, repoRoot, storageRoot, "hooks", "install")
+	script, scriptErr := os.ReadFile(filepath.Join(workTree, ".git", "hooks", "commit-msg"))
+	_, verifyErr := runMochiSticky(t, repoRoot, storageRoot, "hooks", "verify", messageFile)
+	message := "Fix parser\n\nCloses " + closed + ", refs " + referenced
+	dryOut, dryErr := runMochiSticky(t, repoRoot, storageRoot, "hooks", "apply", "--dry-run", "--message", message)
+	runGit(t, workTree, "add", ".")
+	runGit(t, workTree, "commit", "-q", "-m", message)
+	applyOut, applyErr := runMochiSticky(t, repoRoot, storageRoot, "hooks", "apply")
+	reapplyOut, reapplyErr := runMochiSticky(t, repoRoot, storageRoot, "hooks", "apply")
+	uninstallOut, uninstallErr := runMochiSticky(t, repoRoot, storageRoot, "hooks", "uninstall")
+
+	// Assert
+	if installErr != nil || !strings.Contains(installOut, "commit-msg: installed") || !strings.Contains(installOut, "post-commit: installed") {
+		t.Fatalf("unexpected install output: %v\n%s", installErr, installOut)
+	}
+	if reinstallErr != nil || !strings.Contains(reinstallOut, "commit-msg: unchanged") {
+		t.Fatalf("expected reinstall to be a no-op: %v\n%s", reinstallErr, reinstallOut)
+	}
+	if scriptErr != nil || !strings.Contains(string(script), "--storage '.sticky' hooks verify \"$1\"") {
+		t.Fatalf("unexpected commit-msg hook: %v\n%s", scriptErr, script)
+	}
+	if verifyErr == nil {
+		t.Fatalf("expected verify to reject an unknown task")
+	}
+	if dryErr != nil || !strings.Contains(dryOut, closed+" (closes): would move todo -> done, reference commit (new commit)") {
+		t.Fatalf("unexpected dry run: %v\n%s", dryErr, dryOut)
+	}
+	if applyErr != nil || !strings.Contains(applyOut, closed+" (closes): move todo -> done") {
+		t.Fatalf("unexpected apply output: %v\n%s", applyErr, applyOut)
+	}
+	if task := readTask(t, storageRoot, closed); task.Status != "done" || !strings.Contains(task.Content, "## Commits\n- ") {
+		t.Fatalf("expected the closed task to be done with a commit reference, got %s:\n%s", task.Status, task.Content)
+	}
+	if task := readTask(t, storageRoot, referenced); task.Status != "todo" || !strings.Contains(task.Content, "Fix parser (") {
+		t.Fatalf("expected only a reference on %s, got %s:\n%s", referenced, task.Status, task.Content)
+	}
+	if reapplyErr != nil || !strings.Contains(reapplyOut, "nothing to do") {
+		t.Fatalf("expected a second apply to change nothing: %v\n%s", reapplyErr, reapplyOut)
+	}
+	if uninstallErr != nil || !strings.Contains(uninstallOut, "commit-msg: removed") {
+		t.Fatalf("unexpected uninstall output: %v\n%s", uninstallErr, uninstallOut)
+	}
+}
+
+func TestTaskStartAndFinishUseGitBranch(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	id := createTask(t, repoRoot, storageRoot, "Add login page", nil, 0)
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	if err := os.WriteFile(filepath.Join(workTree, "wip.txt"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	// Act
+	_, dirtyErr := runMochiSticky(t, repoRoot, storageRoot, "task", "start", id)
+	if err := os.Remove(filepath.Join(workTree, "wip.txt")); err != nil {
+		t.Fatalf("remove file: %v", err)
+	}
+	runGit(t, workTree, "add", ".")
+	runGit(t, workTree, "commit", "-q", "-m", "Add board")
+	startOut, startErr := runMochiSticky(t, repoRoot, storageRoot, "task", "start", id)
+	started := readTask(t, storageRoot, id)
+	finishOut, finishErr := runMochiSticky(t, repoRoot, storageRoot, "task", "finish", id)
+
+	// Assert
+	if dirtyErr == nil || !strings.Contains(dirtyErr.Error(), "uncommitted changes") {
+		t.Fatalf("expected a dirty working tree to be rejected, got %v", dirtyErr)
+	}
+	branch := id + "-add-login-page"
+	if startErr != nil || !strings.Contains(startOut, "Created branch "+branch) {
+		t.Fatalf("unexpected start output: %v\n%s", startErr, startOut)
+	}
+	if started.Status != "doing" || started.Branch != branch {
+		t.Fatalf("expected the task in doing on %s, got %s on %q", branch, started.Status, started.Branch)
+	}
+	if finishErr != nil || !strings.Contains(finishOut, "Branch: "+branch) {
+		t.Fatalf("unexpected finish output: %v\n%s", finishErr, finishOut)
+	}
+	if task := readTask(t, storageRoot, id); task.Status != "done" || task.Completed.IsZero() {
+		t.Fatalf("expected the finished task to be done, got %s", task.Status)
+	}
+}
+
+func TestMergeDriverMergesTaskFiles(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	id := createTask(t, repoRoot, storageRoot, "Fix parser", []string{"api"}, 0)
+	taskPath := filepath.Join(storageRoot, "boards", "default", "tasks", id+".md")
+	base, err := os.ReadFile(taskPath)
+	if err != nil {
+		t.Fatalf("read task: %v", err)
+	}
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		return path
+	}
+	basePath := write("base.md", string(base))
+	oursPath := write("ours.md", strings.Replace(string(base), "    - api\n", "    - api\n    - backend\n", 1)+"Ours body\n")
+	theirsPath := write("theirs.md", strings.Replace(string(base), "status: todo\n", "status: done\n", 1))
+	conflictOurs := write("conflict-ours.md", string(base)+"Ours body\n")
+	conflictTheirs := write("conflict-theirs.md", string(base)+"Theirs body\n")
+
+	// Act
+	_, mergeErr := runMochiSticky(t, repoRoot, storageRoot, "merge-driver", basePath, oursPath, theirsPath, id+".md")
+	merged, readErr := os.ReadFile(oursPath)
+	_, conflictErr := runMochiSticky(t, repoRoot, storageRoot, "merge-driver", basePath, conflictOurs, conflictTheirs)
+	conflicted, conflictReadErr := os.ReadFile(conflictOurs)
+
+	// Assert
+	if mergeErr != nil || readErr != nil {
+		t.Fatalf("expected a clean merge: %v %v", mergeErr, readErr)
+	}
+	for _, want := range []string{"status: done\n", "    - backend\n", "Ours body\n"} {
+		if !strings.Contains(string(merged), want) {
+			t.Fatalf("expected %q in the merged task:\n%s", want, merged)
+		}
+	}
+	if conflictErr == nil || !strings.Contains(conflictErr.Error(), "1 conflict(s)") {
+		t.Fatalf("expected the body conflict to be reported, got %v", conflictErr)
+	}
+	if conflictReadErr != nil || !strings.Contains(string(conflicted), "<<<<<<< ours\nOurs body\n=======\nTheirs body\n>>>>>>> theirs\n") {
+		t.Fatalf("expected conflict markers in the body: %v\n%s", conflictReadErr, conflicted)
+	}
+}
+
+func TestRenumberFixesDuplicateIDsFromMerge(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	id := createTask(t, repoRoot, storageRoot, "Ours", nil, 0)
+	tasksDir := filepath.Join(storageRoot, "boards", "default", "tasks")
+	taskPath := filepath.Join(tasksDir, id+".md")
+	ours, err := os.ReadFile(taskPath)
+	if err != nil {
+		t.Fatalf("read task: %v", err)
+	}
+	uid := readTask(t, storageRoot, id).UID
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.md")
+	theirsPath := filepath.Join(dir, "theirs.md")
+	theirs := strings.NewReplacer("uid: "+uid, "uid: 0f0f0f0f-theirs", "title: Ours", "title: Theirs").Replace(string(ours))
+	if err := os.WriteFile(basePath, nil, 0o644); err != nil {
+		t.Fatalf("write base: %v", err)
+	}
+	if err := os.WriteFile(theirsPath, []byte(theirs), 0o644); err != nil {
+		t.Fatalf("write theirs: %v", err)
+	}
+	wikiPage := filepath.Join(storageRoot, "wiki", "plan.md")
+	if err := os.MkdirAll(filepath.Dir(wikiPage), 0o755); err != nil {
+		t.Fatalf("create wiki: %v", err)
+	}
+	if err := os.WriteFile(wikiPage, []byte("Tracked in "+id+".\n"), 0o644); err != nil {
+		t.Fatalf("write wiki page: %v", err)
+	}
+
+	// Act
+	_, mergeErr := runMochiSticky(t, repoRoot, storageRoot, "merge-driver", basePath, taskPath, theirsPath, taskPath)
+	preview, previewErr := runMochiSticky(t, repoRoot, storageRoot, "task", "renumber", "--fix-duplicates", "--dry-run")
+	output, renumberErr := runMochiSticky(t, repoRoot, storageRoot, "task", "renumber", "--fix-duplicates")
+	page, readErr := os.ReadFile(wikiPage)
+
+	// Assert
+	if mergeErr == nil || !strings.Contains(mergeErr.Error(), "renumber --fix-duplicates") {
+		t.Fatalf("expected the add/add to be reported, got %v", mergeErr)
+	}
+	if previewErr != nil || !strings.Contains(preview, "Would renumber 1 task") {
+		t.Fatalf("unexpected dry run: %v\n%s", previewErr, preview)
+	}
+	if renumberErr != nil || !strings.Contains(output, id+" -> T-000002  Theirs (uid 0f0f0f0f)") || !strings.Contains(output, "ambiguous: mentioned in wiki/plan.md (left as "+id+")") {
+		t.Fatalf("unexpected renumber output: %v\n%s", renumberErr, output)
+	}
+	if readTask(t, storageRoot, id).Title != "Ours" || readTask(t, storageRoot, "T-000002").Title != "Theirs" {
+		t.Fatalf("expected ours to keep %s and theirs to move to T-000002", id)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, id+"-0f0f0f0f.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected the set-aside file to be renamed, got %v", err)
+	}
+	if readErr != nil || string(page) != "Tracked in "+id+".\n" {
+		t.Fatalf("expected the wiki reference outside the merged branch left alone: %v\n%s", readErr, page)
+	}
+}
+
+func TestAtFlagReadsStorageAtRevision(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	first := createTask(t, repoRoot, storageRoot, "Fix parser", nil, 0)
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	runGit(t, workTree, "add", ".")
+	runGit(t, workTree, "commit", "-q", "-m", "release")
+	runGit(t, workTree, "tag", "v1")
+	second := createTask(t, repoRoot, storageRoot, "Write docs", nil, 0)
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "task", "move", first, "done"); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+
+	// Act
+	listOut, listErr := runMochiSticky(t, repoRoot, storageRoot, "--at", "v1", "task", "list")
+	_, showErr := runMochiSticky(t, repoRoot, storageRoot, "--at", "v1", "task", "show", second)
+	_, moveErr := runMochiSticky(t, repoRoot, storageRoot, "--at", "v1", "task", "move", first, "doing")
+	_, revErr := runMochiSticky(t, repoRoot, storageRoot, "--at", "no-such-rev", "task", "list")
+
+	// Assert
+	if listErr != nil || !strings.Contains(listOut, first) || !strings.Contains(listOut, "todo") || strings.Contains(listOut, second) {
+		t.Fatalf("expected the board as of v1, got %v:\n%s", listErr, listOut)
+	}
+	if showErr == nil {
+		t.Fatalf("expected %s to be missing at v1", second)
+	}
+	if moveErr == nil || !strings.Contains(moveErr.Error(), "--at is only supported by read-only commands") {
+		t.Fatalf("expected task move to reject --at, got %v", moveErr)
+	}
+	if readTask(t, storageRoot, first).Status != "done" {
+		t.Fatalf("expected the working tree to be left alone")
+	}
+	if revErr == nil || !strings.Contains(revErr.Error(), "unknown revision") {
+		t.Fatalf("expected an unknown revision error, got %v", revErr)
+	}
+}
+
+func TestTaskHistoryFollowsMovesIntoArchive(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	id := createTask(t, repoRoot, storageRoot, "Fix parser", nil, 0)
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	runGit(t, workTree, "add", ".")
+	runGit(t, workTree, "commit", "-q", "-m", "add task")
+	for _, args := range [][]string{
+		{"task", "move", id, "doing"},
+		{"task", "move", id, "done"},
+		{"task", "archive", "task", id, "--force"},
+	} {
+		if _, err := runMochiSticky(t, repoRoot, storageRoot, args...); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		runGit(t, workTree, "add", "-A")
+		runGit(t, workTree, "commit", "-q", "-m", strings.Join(args[:2], " "))
+	}
+
+	// Act
+	out, err := runMochiSticky(t, repoRoot, storageRoot, "task", "history", id)
+	jsonOut, jsonErr := runMochiSticky(t, repoRoot, storageRoot, "task", "history", id, "-o", "json")
+	csvOut, csvErr := runMochiSticky(t, repoRoot, storageRoot, "task", "history", "--all", "-o", "csv")
+	flowOut, flowErr := runMochiSticky(t, repoRoot, storageRoot, "board", "flow", "--format", "json")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("task history: %v", err)
+	}
+	for _, want := range []string{id + " Fix parser (archived)", "created (", "status: todo -> doing", "status: doing -> done", "/archive/tasks/" + id + ".md"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in history:\n%s", want, out)
+		}
+	}
+	if jsonErr != nil || !strings.Contains(jsonOut, `"kind": "task_history"`) || !strings.Contains(jsonOut, `"archived": true`) {
+		t.Fatalf("expected a task_history document, got %v:\n%s", jsonErr, jsonOut)
+	}
+	if csvErr != nil || !strings.HasPrefix(csvOut, "task,date,commit,author,created,field,from,to\n") || !strings.Contains(csvOut, ",status,todo,doing\n") {
+		t.Fatalf("expected one csv row per field change, got %v:\n%s", csvErr, csvOut)
+	}
+	if flowErr != nil || !strings.Contains(flowOut, `"id": "`+id+`"`) {
+		t.Fatalf("expected %s in the flow cycle times, got %v:\n%s", id, flowErr, flowOut)
+	}
+}
+
+func TestReleaseNotesCollectsTasksSinceTag(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	shipped := createTask(t, repoRoot, storageRoot, "Old fix", nil, 0)
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "task", "move", shipped, "done"); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	runGit(t, workTree, "add", ".")
+	runGit(t, workTree, "commit", "-q", "-m", "first release")
+	runGit(t, workTree, "tag", "v1.0.0")
+	// Commit times have second precision; later commits must not share the tag's second.
+	time.Sleep(1100 * time.Millisecond)
+	released := createTask(t, repoRoot, storageRoot, "Fix login", nil, 0)
+	open := createTask(t, repoRoot, storageRoot, "Write docs", nil, 0)
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "task", "move", released, "done"); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	runGit(t, workTree, "add", "-A")
+	runGit(t, workTree, "commit", "-q", "-m", "fix login")
+
+	// Act
+	markdown, markdownErr := runMochiSticky(t, repoRoot, storageRoot, "release", "notes", "--since", "v1.0.0")
+	csvOut, csvErr := runMochiSticky(t, repoRoot, storageRoot, "release", "notes", "--since", "v1.0.0", "-o", "csv")
+	out, err := runMochiSticky(t, repoRoot, storageRoot, "release", "notes", "--since", "v1.0.0", "--name", "v1.1.0", "--write", "--stamp")
+	notes, notesErr := runMochiSticky(t, repoRoot, storageRoot, "wiki", "view", "releases/v1-1-0")
+	stamped, stampedErr := runMochiSticky(t, repoRoot, storageRoot, "task", "show", released)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("release notes: %v\n%s", err, out)
+	}
+	if markdownErr != nil || !strings.Contains(markdown, "Fix login ("+released+")") || !strings.HasSuffix(markdown, "\n") {
+		t.Fatalf("expected newline-terminated notes on stdout (%v):\n%q", markdownErr, markdown)
+	}
+	if csvErr != nil || !strings.HasPrefix(csvOut, "group,type,id,title,board,tags,date\n") || !strings.Contains(csvOut, ",task,"+released+",Fix login,default,,") {
+		t.Fatalf("expected the released task as csv (%v):\n%s", csvErr, csvOut)
+	}
+	if !strings.Contains(out, "Created wiki page releases/v1-1-0") || !strings.Contains(out, "Stamped 1 tasks with release v1.1.0") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	if notesErr != nil || !strings.Contains(notes, "Fix login ("+released+")") {
+		t.Fatalf("expected the released task in the notes (%v):\n%s", notesErr, notes)
+	}
+	for _, id := range []string{shipped, open} {
+		if strings.Contains(notes, id) {
+			t.Fatalf("expected %s to be left out of the notes:\n%s", id, notes)
+		}
+	}
+	if stampedErr != nil || !strings.Contains(stamped, "v1.1.0") {
+		t.Fatalf("expected the task to be stamped (%v):\n%s", stampedErr, stamped)
+	}
+}
+
+func TestScanTodosReportsStaleReferences(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	open := createTask(t, repoRoot, storageRoot, "Handle retries", nil, 0)
+	done := createTask(t, repoRoot, storageRoot, "Drop legacy API", nil, 0)
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "task", "move", done, "done"); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	files := map[string]string{
+		".gitignore":   "build/\n",
+		"src/main.go":  "package main\n\n// TODO(" + open + "): handle retries\n// FIXME(" + done + "): drop the fallback\n// TODO: add pagination\n",
+		"build/gen.go": "// TODO(T-999999): generated\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(workTree, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	// Act
+	csvOut, csvErr := runMochiSticky(t, repoRoot, storageRoot, "scan", "todos", workTree, "-o", "csv")
+	out, err := runMochiSticky(t, repoRoot, storageRoot, "scan", "todos", workTree, "--update-tasks", "--create-tasks")
+	source, readErr := os.ReadFile(filepath.Join(workTree, "src", "main.go"))
+	task := readTask(t, storageRoot, open)
+
+	// Assert
+	if err == nil || !strings.Contains(out, "stale task references") {
+		t.Fatalf("expected the stale reference to fail the scan, got %v:\n%s", err, out)
+	}
+	if !strings.Contains(out, "2 task references (1 stale)") || !strings.Contains(out, done+" done") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	if strings.Contains(out, "T-999999") {
+		t.Fatalf("expected ignored files to be skipped:\n%s", out)
+	}
+	if csvErr == nil || !strings.HasPrefix(csvOut, "path,line,marker,task,status,task_status,board,archived,text\n") || !strings.Contains(csvOut, ",3,TODO,"+open+",open,") {
+		t.Fatalf("expected the references as csv, got %v:\n%s", csvErr, csvOut)
+	}
+	if readErr != nil || strings.Contains(string(source), "// TODO: add pagination") {
+		t.Fatalf("expected the untracked TODO to be tagged (%v):\n%s", readErr, source)
+	}
+	if !strings.Contains(task.Content, "## Code references") || !strings.Contains(task.Content, "src/main.go:3") {
+		t.Fatalf("expected code references in the task body, got %q", task.Content)
+	}
+}
@@ -1,13 +1,16 @@
 package integration
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/testutil"
 )
 
 func TestTaskListCommandFilters(t *testing.T) {
@@ -120,6 +123,237 @@ func TestTaskShowCommandOutputsDetails(t *testing.T) {
 	}
 }
 
+func TestTaskCommandsStructuredOutput(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	taskID := createTask(t, repoRoot, storageRoot, "Structured task", []string{"ci"}, 1)
+
+	// Act
+	listOut, listErr := runMochiSticky(t, repoRoot, storageRoot, "task", "list", "--output", "json")
+	showOut, showErr := runMochiSticky(t, repoRoot, storageRoot, "task", "show", taskID, "-o", "csv")
+	missingOut, missingErr := runMochiSticky(t, repoRoot, storageRoot, "task", "show", "T-999999", "-o", "json")
+
+	// Assert
+	if listErr != nil || showErr != nil {
+		t.Fatalf("task list/show: %v / %v", listErr, showErr)
+	}
+	var list struct {
+		SchemaVersion int    `json:"schema_version"`
+		Kind          string `json:"kind"`
+		Data          []struct {
+			Board    string   `json:"board"`
+			ID       string   `json:"id"`
+			Title    string   `json:"title"`
+			Priority int      `json:"priority"`
+			Tags     []string `json:"tags"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(listOut), &list); err != nil {
+		t.Fatalf("decode task list: %v\n%s", err, listOut)
+	}
+	if list.SchemaVersion != 1 || list.Kind != "task_list" || len(list.Data) != 1 {
+		t.Fatalf("unexpected task list document: %+v", list)
+	}
+	if got := list.Data[0]; got.ID != taskID || got.Board != "default" || got.Priority != 1 || strings.Join(got.Tags, ",") != "ci" {
+		t.Fatalf("unexpected task record: %+v", got)
+	}
+	lines := strings.Split(strings.TrimSpace(showOut), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "board,id,uid,title,status") || !strings.HasPrefix(lines[1], "default,"+taskID+",") {
+		t.Fatalf("unexpected csv output:\n%s", showOut)
+	}
+	if missingErr == nil {
+		t.Fatalf("expected missing task to fail")
+	}
+	if !strings.Contains(missingOut, `"kind": "error"`) || !strings.Contains(missingOut, `"code": "not_found"`) {
+		t.Fatalf("expected a JSON error document, got:\n%s", missingOut)
+	}
+}
+
+func TestBoardReadCommandsStructuredOutput(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	taskID := createTask(t, repoRoot, storageRoot, "Ready task", nil, 0)
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "sprint", "create", "Sprint One"); err != nil {
+		t.Fatalf("sprint create: %v", err)
+	}
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "view", "save", "todo", "--query", "status:todo"); err != nil {
+		t.Fatalf("view save: %v", err)
+	}
+
+	// Act
+	readyOut, readyErr := runMochiSticky(t, repoRoot, storageRoot, "task", "ready", "-o", "json")
+	statusesOut, statusesErr := runMochiSticky(t, repoRoot, storageRoot, "task", "statuses", "-o", "csv")
+	sprintsOut, sprintsErr := runMochiSticky(t, repoRoot, storageRoot, "sprint", "list", "-o", "json")
+	viewOut, viewErr := runMochiSticky(t, repoRoot, storageRoot, "view", "show", "todo", "-o", "json")
+
+	// Assert
+	for name, err := range map[string]error{"task ready": readyErr, "task statuses": statusesErr, "sprint list": sprintsErr, "view show": viewErr} {
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	var ready struct {
+		Kind string `json:"kind"`
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(readyOut), &ready); err != nil || ready.Kind != "task_list" || len(ready.Data) != 1 || ready.Data[0].ID != taskID {
+		t.Fatalf("unexpected task ready document (%v):\n%s", err, readyOut)
+	}
+	if !strings.HasPrefix(statusesOut, "key,title\ntodo,Todo\n") {
+		t.Fatalf("unexpected statuses csv:\n%s", statusesOut)
+	}
+	var sprints struct {
+		Kind string `json:"kind"`
+		Data []struct {
+			Name  string `json:"name"`
+			State string `json:"state"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(sprintsOut), &sprints); err != nil || sprints.Kind != "sprint_list" || len(sprints.Data) != 1 || sprints.Data[0].State != "planned" {
+		t.Fatalf("unexpected sprint list document (%v):\n%s", err, sprintsOut)
+	}
+	var view struct {
+		Kind string `json:"kind"`
+		Data struct {
+			Query string `json:"query"`
+			Tasks []struct {
+				ID string `json:"id"`
+			} `json:"tasks"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(viewOut), &view); err != nil || view.Kind != "view" || view.Data.Query != "status:todo" || len(view.Data.Tasks) != 1 {
+		t.Fatalf("unexpected view document (%v):\n%s", err, viewOut)
+	}
+}
+
+func TestSearchCommandStructuredOutput(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	taskID := createTask(t, repoRoot, storageRoot, "Rotate UniqueSearchToken keys", nil, 0)
+
+	// Act
+	out, err := runMochiSticky(t, repoRoot, storageRoot, "search", "UniqueSearchToken", "--type", "task", "-o", "json")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	var doc struct {
+		Kind string `json:"kind"`
+		Data []struct {
+			Type  string `json:"type"`
+			ID    string `json:"id"`
+			Board string `json:"board"`
+			Line  int    `json:"line"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("decode search hits: %v\n%s", err, out)
+	}
+	if doc.Kind != "search_hit_list" || len(doc.Data) != 1 {
+		t.Fatalf("unexpected search document: %+v", doc)
+	}
+	if got := doc.Data[0]; got.Type != "task" || got.ID != taskID || got.Board != "default" || got.Line == 0 {
+		t.Fatalf("unexpected search hit: %+v", got)
+	}
+}
+
+func TestTaskAddCommandParsesQuickCaptureTokens(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	depID := createTask(t, repoRoot, storageRoot, "Dependency", nil, 0)
+
+	// Act
+	parsedOut, parsedErr := runMochiSticky(t, repoRoot, storageRoot, "task", "add", "Fix login race #backend #auth !1 >doing ^2026-11-01 +"+depID, "--tags", "web")
+	literalOut, literalErr := runMochiSticky(t, repoRoot, storageRoot, "task", "add", "--literal", "Keep #1 !as typed")
+	plusOut, plusErr := runMochiSticky(t, repoRoot, storageRoot, "task", "add", "Agree +1 on this")
+	latencyOut, latencyErr := runMochiSticky(t, repoRoot, storageRoot, "task", "add", "Latency >200ms on login")
+
+	// Assert
+	if parsedErr != nil || literalErr != nil || plusErr != nil || latencyErr != nil {
+		t.Fatalf("task add: %v %v %v %v\n%s\n%s\n%s\n%s", parsedErr, literalErr, plusErr, latencyErr, parsedOut, literalOut, plusOut, latencyOut)
+	}
+	parsed := readTask(t, storageRoot, parseCreatedTaskID(t, parsedOut))
+	if parsed.Title != "Fix login race" || parsed.Status != "doing" || parsed.Priority != 1 {
+		t.Fatalf("unexpected parsed task: %+v", parsed)
+	}
+	if strings.Join(parsed.Tags, ",") != "web,backend,auth" || strings.Join(parsed.DependsOn, ",") != depID {
+		t.Fatalf("unexpected tags or dependencies: %v %v", parsed.Tags, parsed.DependsOn)
+	}
+	if parsed.Due.Format("2006-01-02") != "2026-11-01" {
+		t.Fatalf("unexpected due date: %v", parsed.Due)
+	}
+	literal := readTask(t, storageRoot, parseCreatedTaskID(t, literalOut))
+	if literal.Title != "Keep #1 !as typed" || len(literal.Tags) != 0 {
+		t.Fatalf("unexpected literal task: %+v", literal)
+	}
+	plus := readTask(t, storageRoot, parseCreatedTaskID(t, plusOut))
+	if plus.Title != "Agree +1 on this" || len(plus.DependsOn) != 0 {
+		t.Fatalf("expected +1 to stay in the title, got %+v", plus)
+	}
+	latency := readTask(t, storageRoot, parseCreatedTaskID(t, latencyOut))
+	if latency.Title != "Latency >200ms on login" || latency.Status != "todo" {
+		t.Fatalf("expected >200ms to stay in the title, got %+v", latency)
+	}
+}
+
+func TestTaskEditCommandUpdatesFields(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	taskID := createTask(t, repoRoot, storageRoot, "Draft title", []string{"alpha", "beta"}, 2)
+
+	// Act
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "task", "edit", taskID, "--title", "Final title", "--add-tag", "gamma", "--remove-tag", "alpha"); err != nil {
+		t.Fatalf("task edit flags: %v", err)
+	}
+	if _, err := testutil.RunMochiStickyWithInput(t, repoRoot, storageRoot, "From stdin\n", "task", "edit", taskID, "--body", "-"); err != nil {
+		t.Fatalf("task edit body: %v", err)
+	}
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "task", "edit", taskID, "--append", "Appended"); err != nil {
+		t.Fatalf("task edit append: %v", err)
+	}
+	editorOut, editorErr := runMochiSticky(t, repoRoot, storageRoot, "task", "edit", taskID, "--editor", testutil.EditorCommandForTests())
+
+	// Assert
+	task := readTask(t, storageRoot, taskID)
+	if task.Title != "Final title" || strings.Join(task.Tags, ",") != "beta,gamma" {
+		t.Fatalf("unexpected task after edit: %+v", task)
+	}
+	if strings.TrimSpace(task.Content) != "From stdin\n\nAppended" {
+		t.Fatalf("unexpected body: %q", task.Content)
+	}
+	if editorErr != nil || !strings.Contains(editorOut, "No changes to task "+taskID) {
+		t.Fatalf("expected unchanged editor run, got %v:\n%s", editorErr, editorOut)
+	}
+}
+
+func TestTaskEditCommandRejectsInvalidEditorChanges(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor script requires a POSIX shell")
+	}
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	taskID := createTask(t, repoRoot, storageRoot, "Guarded", nil, 2)
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	script := "#!/bin/sh\nsed 's/^status: todo/status: someday/' \"$1\" > \"$1.tmp\" && mv \"$1.tmp\" \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0o755); err != nil {
+		t.Fatalf("write editor: %v", err)
+	}
+
+	// Act
+	out, err := runMochiSticky(t, repoRoot, storageRoot, "task", "edit", taskID, "--editor", editor)
+
+	// Assert
+	if err == nil || !strings.Contains(out, "invalid status") || !strings.Contains(out, "edit discarded") {
+		t.Fatalf("expected the edit to be rejected, got %v:\n%s", err, out)
+	}
+	if task := readTask(t, storageRoot, taskID); task.Status != "todo" {
+		t.Fatalf("expected the original task to be restored, got status %q", task.Status)
+	}
+}
+
 func TestTaskMoveCommandUpdatesStatus(t *testing.T) {
 	// Arrange
 	repoRoot, storageRoot := setupStorage(t)
@@ -343,3 +577,37 @@ func TestTaskArchiveCommandsFlow(t *testing.T) {
 		t.Fatalf("expected empty archive message, got:\n%s", afterDeleteOut)
 	}
 }
+
+func TestShellCompletionReadsStorage(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupStorage(t)
+	taskID := createTask(t, repoRoot, storageRoot, "Complete me", nil, 0)
+	boardID := createBoard(t, repoRoot, storageRoot, "Roadmap")
+	templateDir := filepath.Join(storageRoot, "templates", "task")
+	if err := os.MkdirAll(templateDir, 0o755); err != nil {
+		t.Fatalf("mkdir templates: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "bug.md"), []byte("## Steps\n"), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	// Act
+	idsOut, idsErr := runMochiSticky(t, repoRoot, storageRoot, "__complete", "task", "move", "")
+	statusOut, statusErr := runMochiSticky(t, repoRoot, storageRoot, "__complete", "task", "move", taskID, "do")
+	boardsOut, boardsErr := runMochiSticky(t, repoRoot, storageRoot, "__complete", "task", "list", "--boards", "default,")
+	templateOut, templateErr := runMochiSticky(t, repoRoot, storageRoot, "__complete", "task", "add", "x", "--template", "")
+
+	// Assert
+	if idsErr != nil || !strings.Contains(idsOut, taskID+"\tComplete me") {
+		t.Fatalf("expected task ID with title, got %v:\n%s", idsErr, idsOut)
+	}
+	if statusErr != nil || !strings.Contains(statusOut, "doing\t") || !strings.Contains(statusOut, "done\t") || strings.Contains(statusOut, "todo") {
+		t.Fatalf("expected matching column keys, got %v:\n%s", statusErr, statusOut)
+	}
+	if boardsErr != nil || !strings.Contains(boardsOut, "default,"+boardID+"\tRoadmap") {
+		t.Fatalf("expected board IDs after the comma, got %v:\n%s", boardsErr, boardsOut)
+	}
+	if templateErr != nil || !strings.Contains(templateOut, "bug\n") {
+		t.Fatalf("expected template names, got %v:\n%s", templateErr, templateOut)
+	}
+}
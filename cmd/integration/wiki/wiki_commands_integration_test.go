@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -382,3 +383,78 @@ func TestWikiLintCommandReportsNoIssues(t *testing.T) {
 		t.Fatalf("expected lint to report no issues, got:\n%s", out)
 	}
 }
+
+func TestWikiHistoryAndDiffCommands(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupWikiStorage(t)
+	slug := createWikiPage(t, repoRoot, storageRoot, "Setup Guide")
+	workTree := filepath.Dir(storageRoot)
+	runGit(t, workTree, "init", "-q")
+	writeWikiContent(t, storageRoot, slug, "Install the CLI.\n")
+	runGit(t, workTree, "add", ".")
+	runGit(t, workTree, "commit", "-q", "-m", "Add setup guide")
+	writeWikiContent(t, storageRoot, slug, "Install the CLI with go install.\n")
+	runGit(t, workTree, "commit", "-q", "-am", "Explain go install")
+
+	// Act
+	historyOut, historyErr := runMochiSticky(t, repoRoot, storageRoot, "wiki", "history", slug, "--patch", "--ignore-frontmatter")
+	jsonOut, jsonErr := runMochiSticky(t, repoRoot, storageRoot, "wiki", "history", slug, "--limit", "1", "-o", "json")
+	csvOut, csvErr := runMochiSticky(t, repoRoot, storageRoot, "wiki", "history", "-o", "csv")
+	diffOut, diffErr := runMochiSticky(t, repoRoot, storageRoot, "wiki", "diff", slug, "HEAD~1", "HEAD", "--ignore-frontmatter")
+	oldOut, oldErr := runMochiSticky(t, repoRoot, storageRoot, "--at", "HEAD~1", "wiki", "view", slug)
+
+	// Assert
+	if historyErr != nil {
+		t.Fatalf("wiki history: %v", historyErr)
+	}
+	for _, want := range []string{"Dev  Explain go install", "Dev  Add setup guide", "+Install the CLI with go install."} {
+		if !strings.Contains(historyOut, want) {
+			t.Fatalf("expected %q in history:\n%s", want, historyOut)
+		}
+	}
+	var doc struct {
+		Kind string `json:"kind"`
+		Data []struct {
+			Slug    string `json:"slug"`
+			Subject string `json:"subject"`
+		} `json:"data"`
+	}
+	if jsonErr != nil {
+		t.Fatalf("wiki history -o json: %v", jsonErr)
+	}
+	if err := json.Unmarshal([]byte(jsonOut), &doc); err != nil {
+		t.Fatalf("decode history: %v\n%s", err, jsonOut)
+	}
+	if doc.Kind != "wiki_revision_list" || len(doc.Data) != 1 || doc.Data[0].Slug != slug || doc.Data[0].Subject != "Explain go install" {
+		t.Fatalf("unexpected history document: %+v", doc)
+	}
+	if csvErr != nil || !strings.HasPrefix(csvOut, "commit,author,date,subject,pages\n") || !strings.Contains(csvOut, ",Add setup guide,"+slug+"\n") {
+		t.Fatalf("expected recent wiki changes as csv, got %v:\n%s", csvErr, csvOut)
+	}
+	if diffErr != nil || !strings.Contains(diffOut, "-Install the CLI.\n+Install the CLI with go install.") {
+		t.Fatalf("unexpected diff (%v):\n%s", diffErr, diffOut)
+	}
+	if oldErr != nil || !strings.Contains(oldOut, "Install the CLI.") || strings.Contains(oldOut, "go install") {
+		t.Fatalf("expected the old version (%v):\n%s", oldErr, oldOut)
+	}
+}
+
+func TestWikiSectionsCommandStructuredOutput(t *testing.T) {
+	// Arrange
+	repoRoot, storageRoot := setupWikiStorage(t)
+	slug := createWikiPage(t, repoRoot, storageRoot, "Intro", "--slug", "guides/intro", "--section", "Guides")
+	if _, err := runMochiSticky(t, repoRoot, storageRoot, "wiki", "index"); err != nil {
+		t.Fatalf("wiki index: %v", err)
+	}
+
+	// Act
+	out, err := runMochiSticky(t, repoRoot, storageRoot, "wiki", "sections", "-o", "csv")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("wiki sections: %v", err)
+	}
+	if !strings.HasPrefix(out, "slug,title,order,tags,depends_on,related_to,pages\n") || !strings.Contains(out, ","+slug) {
+		t.Fatalf("unexpected sections csv:\n%s", out)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/git"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs> [path]",
+	Short: "Merge task and board files during a git merge (run by git)",
+	Long: "Three-way merge of a task file, board config or board registry, registered with\n" +
+		"`hooks install --merge-driver` and run by git as `merge-driver %O %A %B %P`. Task\n" +
+		"frontmatter merges field by field (tags and depends_on as sets, the later status change\n" +
+		"wins), next_id takes the larger value and the registry merges by board ID. What is left,\n" +
+		"such as both sides editing the body, gets standard conflict markers. The result replaces\n" +
+		"<ours>; the command exits non-zero when conflicts remain. When both branches added a\n" +
+		"different task under the same ID, ours is kept and theirs is written next to it as\n" +
+		"<ID>-<uid>.md for `task renumber --fix-duplicates` to move to a new ID.",
+	Args: cobra.RangeArgs(3, 4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oursPath := args[1]
+		name := oursPath
+		if len(args) == 4 {
+			name = args[3]
+		}
+		var sides board.MergeSides
+		for i, dest := range []*[]byte{&sides.Base, &sides.Ours, &sides.Theirs} {
+			data, err := os.ReadFile(args[i])
+			if err != nil {
+				return fmt.Errorf("merge-driver: failed to read %s: %w", args[i], err)
+			}
+			*dest = data
+		}
+		cmd.SilenceUsage = true
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if theirs, ok := board.DuplicateTaskAdd(sides); ok && len(args) == 4 {
+			aside := filepath.Join(filepath.Dir(name), theirs.ID+"-"+board.ShortUID(theirs.UID)+".md")
+			if err := os.WriteFile(aside, sides.Theirs, 0o644); err != nil {
+				return fmt.Errorf("merge-driver: failed to write %s: %w", aside, err)
+			}
+			return fmt.Errorf("merge-driver: %s: both sides added %s; kept ours and wrote theirs to %s, run `mochi-sticky task renumber --fix-duplicates`: %w", name, theirs.ID, aside, git.ErrMergeConflict)
+		}
+
+		merged := sides
+		if resolve := mergeResolver(sides.Ours); resolve != nil {
+			// Files that do not parse (e.g. left with conflict markers) fall back to a plain
+			// line merge.
+			if resolved, err := resolve(sides); err == nil {
+				merged = resolved
+			}
+		}
+		result, conflicts, err := git.MergeFile(ctx, merged.Base, merged.Ours, merged.Theirs, git.MergeLabels{Ours: "ours", Base: "base", Theirs: "theirs"})
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(oursPath, result, 0o644); err != nil {
+			return fmt.Errorf("merge-driver: failed to write %s: %w", oursPath, err)
+		}
+		if conflicts > 0 {
+			return fmt.Errorf("merge-driver: %s: %d conflict(s): %w", name, conflicts, git.ErrMergeConflict)
+		}
+		return nil
+	},
+}
+
+// mergeResolver picks the field-wise merge for a file from its content: task files start
+// with frontmatter, a board registry lists boards and a board config holds next_id. Other
+// files get nil and are merged line by line.
+func mergeResolver(data []byte) func(board.MergeSides) (board.MergeSides, error) {
+	if bytes.HasPrefix(data, []byte("---")) {
+		return board.MergeTaskFiles
+	}
+	var keys map[string]any
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return nil
+	}
+	if _, ok := keys["boards"]; ok {
+		return board.MergeRegistryFiles
+	}
+	if _, ok := keys["next_id"]; ok {
+		return board.MergeConfigFiles
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(mergeDriverCmd)
+}
@@ -0,0 +1,11 @@
+package cmd
+
+import (
+	"mochi-sticky/internal/cli"
+)
+
+var outputFlag string
+
+func init() {
+	cli.SetOutputFlagRef(&outputFlag)
+}
@@ -0,0 +1,15 @@
+package release
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Prepare releases from completed tasks and ADRs",
+}
+
+// Register attaches release commands to the root command.
+func Register(root *cobra.Command) {
+	root.AddCommand(releaseCmd)
+}
@@ -0,0 +1,274 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+	releasepkg "mochi-sticky/internal/release"
+	"mochi-sticky/internal/shared"
+	"mochi-sticky/internal/templates"
+	"mochi-sticky/internal/wiki"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultTemplate is the wiki template release notes are rendered from.
+const defaultTemplate = "release-notes"
+
+type notesReport struct {
+	Name   string              `json:"name"`
+	Since  string              `json:"since"`
+	Until  string              `json:"until"`
+	Groups []releasepkg.Group  `json:"groups"`
+	Tasks  []releasepkg.Task   `json:"tasks"`
+	ADRs   []releasepkg.ADR    `json:"adrs"`
+	Page   *notesPageReference `json:"page,omitempty"`
+}
+
+type notesPageReference struct {
+	Slug string `json:"slug"`
+	Path string `json:"path"`
+}
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Generate release notes from completed tasks and accepted ADRs",
+	Long: "Collect the tasks that entered a done column or were archived between --since and --until,\n" +
+		"and the ADRs accepted in that window, and render them with the release-notes wiki template.\n" +
+		"Bounds are YYYY-MM-DD dates or git revisions (a tag such as v1.1.0 covers everything\n" +
+		"committed after it). Status changes come from the git history of the task and ADR files;\n" +
+		"without git, the completed, status_changed and ADR dates are used. --write saves the notes\n" +
+		"as a wiki page and --stamp records --name in the release field of every listed task.",
+	Example: "  mochi-sticky release notes --since v1.1.0 --name v1.2.0\n" +
+		"  mochi-sticky release notes --since 2026-10-01 --until 2026-10-15 --group-by board\n" +
+		"  mochi-sticky release notes --since v1.1.0 --name v1.2.0 --write --stamp",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		since, err := cmd.Flags().GetString("since")
+		if err != nil {
+			return err
+		}
+		until, err := cmd.Flags().GetString("until")
+		if err != nil {
+			return err
+		}
+		boards, err := cmd.Flags().GetStringSlice("board")
+		if err != nil {
+			return err
+		}
+		groupByInput, err := cmd.Flags().GetString("group-by")
+		if err != nil {
+			return err
+		}
+		tagOrder, err := cmd.Flags().GetStringSlice("tag-order")
+		if err != nil {
+			return err
+		}
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return err
+		}
+		templateName, err := cmd.Flags().GetString("template")
+		if err != nil {
+			return err
+		}
+		write, err := cmd.Flags().GetBool("write")
+		if err != nil {
+			return err
+		}
+		slugInput, err := cmd.Flags().GetString("slug")
+		if err != nil {
+			return err
+		}
+		stamp, err := cmd.Flags().GetBool("stamp")
+		if err != nil {
+			return err
+		}
+		noGit, err := cmd.Flags().GetBool("no-git")
+		if err != nil {
+			return err
+		}
+		groupBy, err := releasepkg.ParseGroupBy(groupByInput)
+		if err != nil {
+			return err
+		}
+		name = strings.TrimSpace(name)
+		if stamp && name == "" {
+			return fmt.Errorf("--stamp requires --name")
+		}
+
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		sinceTime, err := releasepkg.ResolveTime(ctx, storageRoot, since, false)
+		if err != nil {
+			return err
+		}
+		untilTime := time.Now()
+		if strings.TrimSpace(until) != "" {
+			if untilTime, err = releasepkg.ResolveTime(ctx, storageRoot, until, true); err != nil {
+				return err
+			}
+		}
+		template, err := loadTemplate(workingDir, storageRoot, templateName)
+		if err != nil {
+			return err
+		}
+
+		sources := releasepkg.Sources{
+			BaseDir:     workingDir,
+			StorageRoot: storageRoot,
+			ADRRoot:     cli.AdrRoot(storageRoot),
+			Boards:      boards,
+		}
+		notes, err := releasepkg.Collect(ctx, sources, releasepkg.Options{Since: sinceTime, Until: untilTime, NoGit: noGit})
+		if err != nil {
+			return err
+		}
+		groups := releasepkg.Groups(notes, groupBy, tagOrder)
+		page := releasepkg.Render(template, name, notes, groups)
+
+		var reference *notesPageReference
+		if write {
+			slug := strings.TrimSpace(slugInput)
+			if slug == "" {
+				slug = page.Slug
+			}
+			if reference, err = writePage(workingDir, storageRoot, slug, page); err != nil {
+				return err
+			}
+		}
+		if stamp {
+			if err := releasepkg.Stamp(ctx, sources, notes, name); err != nil {
+				return err
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		if format != output.FormatTable {
+			report := notesReport{
+				Name:   releasepkg.DefaultName,
+				Since:  notes.Since.Format(time.RFC3339),
+				Until:  notes.Until.Format(time.RFC3339),
+				Groups: groups,
+				Tasks:  notes.Tasks,
+				ADRs:   notes.ADRs,
+				Page:   reference,
+			}
+			if name != "" {
+				report.Name = name
+			}
+			return output.WriteReport(out, format, output.KindReleaseNotes, report, output.FromReleaseNotes(groups, notes.ADRs))
+		}
+		if reference == nil {
+			_, err = fmt.Fprint(out, page.Content)
+			if err == nil && !strings.HasSuffix(page.Content, "\n") {
+				_, err = fmt.Fprintln(out)
+			}
+		} else {
+			_, err = fmt.Fprintf(out, "Created wiki page %s\n", reference.Slug)
+		}
+		if err == nil && stamp {
+			_, err = fmt.Fprintf(out, "Stamped %d tasks with release %s\n", len(notes.Tasks), name)
+		}
+		return err
+	},
+}
+
+// loadTemplate reads the named template from the configured wiki templates, falling back to the
+// bundled release-notes template when the repository has none.
+func loadTemplate(workingDir, storageRoot, name string) (wiki.Page, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = defaultTemplate
+	}
+	templatePaths, err := cli.ResolveTemplatePaths(workingDir, storageRoot)
+	if err != nil {
+		return wiki.Page{}, err
+	}
+	templateDir := templatePaths.Wiki
+	if strings.TrimSpace(templateDir) == "" {
+		templateDir = filepath.Join(cli.WikiRoot(storageRoot), "templates")
+	}
+	page, err := wiki.LoadPage(filepath.Join(templateDir, name+".md"))
+	if err == nil {
+		return page, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) || name != defaultTemplate {
+		return wiki.Page{}, fmt.Errorf("template not found: %s", name)
+	}
+	data, err := templates.Bundled("wiki/" + defaultTemplate + ".md")
+	if err != nil {
+		return wiki.Page{}, err
+	}
+	return wiki.ParsePage(data)
+}
+
+// writePage saves the notes as a new wiki page. The reference path is relative to workingDir
+// when the page is below it.
+func writePage(workingDir, storageRoot, slug string, page wiki.Page) (*notesPageReference, error) {
+	slug, err := wiki.NormalizeSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	root := cli.WikiRoot(storageRoot)
+	path := filepath.Join(root, filepath.FromSlash(slug)+".md")
+	if err := shared.EnsureInDir(root, path); err != nil {
+		return nil, err
+	}
+	if _, err := shared.Files().Stat(path); err == nil {
+		return nil, fmt.Errorf("page already exists: %s", slug)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	page.Slug = slug
+	if err := wiki.SavePage(path, page); err != nil {
+		return nil, err
+	}
+	if rel, err := filepath.Rel(workingDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		path = rel
+	}
+	return &notesPageReference{Slug: slug, Path: filepath.ToSlash(path)}, nil
+}
+
+func init() {
+	releaseCmd.AddCommand(releaseNotesCmd)
+	cli.SupportOutput(releaseNotesCmd)
+	releaseNotesCmd.Flags().String("since", "", "Start of the release: git revision (exclusive) or YYYY-MM-DD (required)")
+	releaseNotesCmd.Flags().String("until", "", "End of the release: git revision (inclusive) or YYYY-MM-DD (default: now)")
+	releaseNotesCmd.Flags().StringSlice("board", nil, "Board IDs to include (default: all non-archived boards)")
+	cli.CompleteFlag(releaseNotesCmd, "board", cli.CompleteBoardIDs)
+	releaseNotesCmd.Flags().String("group-by", releasepkg.GroupByTag, "Group tasks by: tag|board")
+	releaseNotesCmd.Flags().StringSlice("tag-order", nil, "Tags to list first when grouping by tag, e.g. feature,bug")
+	releaseNotesCmd.Flags().String("name", "", "Release name, e.g. v1.2.0 (fills the template's <Version>)")
+	releaseNotesCmd.Flags().String("template", defaultTemplate, "Wiki template name")
+	cli.CompleteFlag(releaseNotesCmd, "template", cli.CompleteTemplates(cli.TemplateWiki))
+	releaseNotesCmd.Flags().Bool("write", false, "Save the notes as a new wiki page")
+	releaseNotesCmd.Flags().String("slug", "", "Slug of the written page (default: the template slug, e.g. releases/<version>)")
+	releaseNotesCmd.Flags().Bool("stamp", false, "Record --name in the release field of every listed task")
+	releaseNotesCmd.Flags().Bool("no-git", false, "Do not consult git history for status changes")
+	if err := releaseNotesCmd.MarkFlagRequired("since"); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,11 @@
+package cmd
+
+import (
+	"mochi-sticky/internal/cli"
+)
+
+var atFlag string
+
+func init() {
+	cli.SetAtFlagRef(&atFlag)
+}
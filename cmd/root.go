@@ -1,14 +1,20 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 
 	"mochi-sticky/cmd/adr"
 	"mochi-sticky/cmd/board"
 	taskcmd "mochi-sticky/cmd/board/task"
+	"mochi-sticky/cmd/hooks"
+	"mochi-sticky/cmd/release"
+	"mochi-sticky/cmd/scan"
+	"mochi-sticky/cmd/sprint"
 	"mochi-sticky/cmd/tui"
+	"mochi-sticky/cmd/view"
 	"mochi-sticky/cmd/wiki"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -16,15 +22,26 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "mochi-sticky",
 	Short: "mochi-sticky is a file-based Kanban board and wiki for developers",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := cli.CheckOutput(cmd); err != nil {
+			return err
+		}
+		return cli.CheckRevision(cmd)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	},
 }
 
-// Execute runs the root command.
+// Execute runs the root command. Errors are written to stderr, as an error document when
+// --output json or yaml is selected.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
+		format, formatErr := cli.OutputFormat()
+		if formatErr != nil {
+			format = output.FormatTable
+		}
+		_ = output.WriteError(os.Stderr, format, err)
 		os.Exit(1)
 	}
 }
@@ -36,9 +53,27 @@ func init() {
 		"",
 		"Storage root for boards/wiki/adrs (overrides config/env)",
 	)
+	rootCmd.PersistentFlags().StringVarP(
+		&outputFlag,
+		"output",
+		"o",
+		string(output.FormatTable),
+		"Output format for list/show/view commands: table|json|yaml|csv",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&atFlag,
+		"at",
+		"",
+		"Read boards, wiki and ADRs as of a git revision (read-only; list/show commands, wiki export, tui)",
+	)
 	adr.Register(rootCmd)
 	board.Register(rootCmd)
 	taskcmd.Register(rootCmd)
+	hooks.Register(rootCmd)
+	release.Register(rootCmd)
+	scan.Register(rootCmd)
+	sprint.Register(rootCmd)
+	view.Register(rootCmd)
 	wiki.Register(rootCmd)
 	tui.Register(rootCmd)
 }
@@ -0,0 +1,15 @@
+package scan
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Scan source code for task references",
+}
+
+// Register attaches scan commands to the root command.
+func Register(root *cobra.Command) {
+	root.AddCommand(scanCmd)
+}
@@ -0,0 +1,170 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/output"
+	scanpkg "mochi-sticky/internal/scan"
+
+	"github.com/spf13/cobra"
+)
+
+type todosReport struct {
+	scanpkg.Report
+	Updated []string              `json:"updated,omitempty"`
+	Created []scanpkg.CreatedTask `json:"created,omitempty"`
+}
+
+var todosCmd = &cobra.Command{
+	Use:   "todos [paths...]",
+	Short: "Find task references in TODO comments",
+	Long: "Scan source files for TODO, FIXME, HACK and XXX comments and the tasks they name, such as\n" +
+		"`// TODO(T-000012): handle retries`. Task IDs follow git.task_pattern from the storage\n" +
+		"config. Inside a git working tree only files git tracks or would track are read, so\n" +
+		".gitignore is respected; the storage root is always skipped. References to tasks that do\n" +
+		"not exist or are already done are stale and make the command exit non-zero.\n" +
+		"--update-tasks lists each reference (file:line) under \"Code references\" in the task body;\n" +
+		"--create-tasks turns comments that name no task into tasks and adds the new ID to the comment.",
+	Example: "  mochi-sticky scan todos\n" +
+		"  mochi-sticky scan todos internal cmd -o json\n" +
+		"  mochi-sticky scan todos --update-tasks --create-tasks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		boards, err := cmd.Flags().GetStringSlice("board")
+		if err != nil {
+			return err
+		}
+		updateTasks, err := cmd.Flags().GetBool("update-tasks")
+		if err != nil {
+			return err
+		}
+		createTasks, err := cmd.Flags().GetBool("create-tasks")
+		if err != nil {
+			return err
+		}
+		if createTasks && len(boards) > 1 {
+			return fmt.Errorf("--create-tasks accepts a single --board")
+		}
+
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		config, err := cli.LoadStorageConfig(workingDir)
+		if err != nil {
+			return err
+		}
+		pattern, err := git.CompileTaskPattern(config.Git.TaskPattern)
+		if err != nil {
+			return err
+		}
+		// Stale references are a result, not a usage error.
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		sources := scanpkg.Sources{BaseDir: workingDir, StorageRoot: storageRoot, Boards: boards}
+		report, err := scanpkg.Collect(ctx, sources, scanpkg.Options{Paths: args, Pattern: pattern})
+		if err != nil {
+			return err
+		}
+
+		result := todosReport{Report: report}
+		if createTasks {
+			var repo *board.Repository
+			if len(boards) == 1 {
+				repo, err = board.NewRepositoryForBoardWithStorage(workingDir, boards[0], storageRoot)
+			} else {
+				repo, err = board.NewRepositoryWithStorage(workingDir, storageRoot)
+			}
+			if err != nil {
+				return err
+			}
+			if result.Created, err = scanpkg.CreateTasks(ctx, repo, report); err != nil {
+				return err
+			}
+		}
+		if updateTasks {
+			if result.Updated, err = scanpkg.UpdateTasks(ctx, sources, report); err != nil {
+				return err
+			}
+		}
+
+		if format != output.FormatTable {
+			if err := output.WriteReport(cmd.OutOrStdout(), format, output.KindTodoReport, result, output.FromTodoReferences(report.References)); err != nil {
+				return err
+			}
+		} else if err := writeTodosReport(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+		if report.Stale == 0 {
+			return nil
+		}
+		return fmt.Errorf("scan: %d of %d task references: %w", report.Stale, len(report.References), scanpkg.ErrStaleReferences)
+	},
+}
+
+func writeTodosReport(out io.Writer, result todosReport) error {
+	lines := []string{fmt.Sprintf("Scanned %d files: %d task references (%d stale), %d untracked TODOs",
+		result.Files, len(result.References), result.Stale, len(result.Untracked))}
+	if result.Stale > 0 {
+		lines = append(lines, "", "Stale references:")
+		for _, reference := range result.References {
+			if !reference.Stale() {
+				continue
+			}
+			state := reference.Status
+			switch {
+			case reference.Archived:
+				state += " (archived)"
+			case reference.TaskStatus != "" && reference.TaskStatus != reference.Status:
+				state += " (" + reference.TaskStatus + ")"
+			}
+			lines = append(lines, fmt.Sprintf("  %s  %s %s  %s", reference.Location(), reference.TaskID, state, reference.Text))
+		}
+	}
+	if len(result.Untracked) > 0 && len(result.Created) == 0 {
+		lines = append(lines, "", "Untracked TODOs:")
+		for _, comment := range result.Untracked {
+			lines = append(lines, fmt.Sprintf("  %s:%d  %s  %s", comment.Path, comment.Line, comment.Marker, comment.Text))
+		}
+	}
+	if len(result.Created) > 0 {
+		lines = append(lines, "", "Created tasks:")
+		for _, task := range result.Created {
+			lines = append(lines, fmt.Sprintf("  %s  %s  (%s:%d)", task.ID, task.Title, task.Path, task.Line))
+		}
+	}
+	if len(result.Updated) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Updated code references of %d tasks", len(result.Updated)))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	scanCmd.AddCommand(todosCmd)
+	cli.SupportOutput(todosCmd)
+	todosCmd.Flags().StringSlice("board", nil, "Board IDs to resolve references against (default: all non-archived boards)")
+	cli.CompleteFlag(todosCmd, "board", cli.CompleteBoardIDs)
+	todosCmd.Flags().Bool("update-tasks", false, "Write a \"Code references\" section (file:line) into each referenced task")
+	todosCmd.Flags().Bool("create-tasks", false, "Create tasks from TODO comments that name no task and tag the comments with the new IDs")
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+	"mochi-sticky/internal/search"
+
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search tasks, wiki pages and ADRs",
+	Long: "Search task bodies, wiki pages and ADRs in one pass. Every word must match; wrap words in\n" +
+		"double quotes to search for a phrase. Hits are ranked with title matches first and show the\n" +
+		"best matching line with its line number.",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		types, err := cmd.Flags().GetStringSlice("type")
+		if err != nil {
+			return err
+		}
+		status, err := cmd.Flags().GetString("status")
+		if err != nil {
+			return err
+		}
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			return err
+		}
+		if limit < 0 {
+			return fmt.Errorf("search: --limit must be zero or greater")
+		}
+		boardID, err := cmd.Flags().GetString("board")
+		if err != nil {
+			return err
+		}
+		types, err = search.ParseTypes(types)
+		if err != nil {
+			return err
+		}
+
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := resolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		sources := search.Sources{
+			WikiRoot: cli.WikiRoot(storageRoot),
+			ADRRoot:  cli.AdrRoot(storageRoot),
+		}
+		if len(types) == 0 || containsString(types, search.TypeTask) {
+			repo, err := board.NewRepositoryForBoardWithStorage(workingDir, boardID, storageRoot)
+			if err != nil {
+				return err
+			}
+			sources.Board = repo
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		hits, err := search.SearchContext(ctx, sources, search.Options{
+			Query:  strings.Join(args, " "),
+			Types:  types,
+			Status: status,
+			Limit:  limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		if format != output.FormatTable {
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindSearchHits, output.FromSearchHits(hits, workingDir))
+		}
+		if len(hits) == 0 {
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No matches")
+			return err
+		}
+		for _, hit := range hits {
+			heading := fmt.Sprintf("%-4s %s  %s", hit.Type, hit.ID, hit.Title)
+			if hit.Status != "" {
+				heading += " [" + hit.Status + "]"
+			}
+			if _, err := fmt.Fprintln(cmd.OutOrStdout(), heading); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "     %s:%d: %s\n", displayPath(workingDir, hit.Path), hit.Line, hit.Snippet); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// displayPath shortens path relative to the working directory when it lives beneath it.
+func displayPath(workingDir, path string) string {
+	rel, err := filepath.Rel(workingDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	cli.SupportOutput(searchCmd)
+	cli.SupportRevision(searchCmd)
+	searchCmd.Flags().StringSlice("type", nil, "Limit results to types (task, wiki, adr; comma-separated)")
+	searchCmd.Flags().String("status", "", "Only include items with these statuses (comma-separated)")
+	searchCmd.Flags().Int("limit", 0, "Maximum number of hits (0 = all)")
+	searchCmd.Flags().String("board", "", "Board whose tasks are searched (defaults to the active board)")
+	cli.CompleteFlag(searchCmd, "board", cli.CompleteBoardIDs)
+}
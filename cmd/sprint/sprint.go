@@ -0,0 +1,42 @@
+package sprint
+
+import (
+	"os"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Plan and track sprints for a board",
+}
+
+// Register attaches sprint commands to the root command.
+func Register(root *cobra.Command) {
+	root.AddCommand(sprintCmd)
+}
+
+func init() {
+	sprintCmd.PersistentFlags().String("board", "", "Board ID (default: active board)")
+	cli.CompleteFlag(sprintCmd, "board", cli.CompleteBoardIDs)
+}
+
+// repoFromFlags opens the repository for the --board flag (or the active board).
+func repoFromFlags(cmd *cobra.Command) (*board.Repository, error) {
+	boardID, err := cmd.Flags().GetString("board")
+	if err != nil {
+		return nil, err
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+	if err != nil {
+		return nil, err
+	}
+	return board.NewRepositoryForBoardWithStorage(workingDir, boardID, storageRoot)
+}
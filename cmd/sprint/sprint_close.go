@@ -0,0 +1,66 @@
+package sprint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"mochi-sticky/internal/board"
+
+	"github.com/spf13/cobra"
+)
+
+var closeCmd = &cobra.Command{
+	Use:   "close <id>",
+	Short: "Close the active sprint and roll unfinished tasks into the next sprint",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		next, err := cmd.Flags().GetString("next")
+		if err != nil {
+			return err
+		}
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		closed, err := repo.CloseSprintContext(ctx, args[0], next)
+		if err != nil {
+			return err
+		}
+		config, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return err
+		}
+		summary := closed.Summary
+		label := board.EstimateUnitLabel(config.EstimateUnit)
+		if _, err := fmt.Fprintf(
+			cmd.OutOrStdout(),
+			"Closed sprint %s: %d/%d tasks completed, velocity %s %s\n",
+			closed.ID,
+			summary.Completed,
+			summary.Committed,
+			board.FormatEstimate(summary.Velocity),
+			label,
+		); err != nil {
+			return err
+		}
+		if len(summary.RolledOver) == 0 {
+			return nil
+		}
+		target := summary.RolledTo
+		if target == "" {
+			target = "no sprint (no planned sprint available)"
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Rolled over to %s: %s\n", target, strings.Join(summary.RolledOver, ", "))
+		return err
+	},
+}
+
+func init() {
+	sprintCmd.AddCommand(closeCmd)
+	closeCmd.Flags().String("next", "", "Sprint ID that receives unfinished tasks (default: earliest planned sprint)")
+}
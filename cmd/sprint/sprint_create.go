@@ -0,0 +1,83 @@
+package sprint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"mochi-sticky/internal/board"
+
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a planned sprint",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return err
+		}
+		goal, err := cmd.Flags().GetString("goal")
+		if err != nil {
+			return err
+		}
+		startStr, err := cmd.Flags().GetString("start")
+		if err != nil {
+			return err
+		}
+		endStr, err := cmd.Flags().GetString("end")
+		if err != nil {
+			return err
+		}
+		start, err := parseDate(startStr)
+		if err != nil {
+			return err
+		}
+		end, err := parseDate(endStr)
+		if err != nil {
+			return err
+		}
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		created, err := repo.CreateSprintContext(ctx, board.Sprint{
+			ID:    id,
+			Name:  args[0],
+			Goal:  goal,
+			Start: start,
+			End:   end,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Created sprint %s (%s)\n", created.ID, created.Name)
+		return err
+	},
+}
+
+func init() {
+	sprintCmd.AddCommand(createCmd)
+	createCmd.Flags().String("id", "", "Sprint ID (default: slug of the name)")
+	createCmd.Flags().String("goal", "", "Sprint goal")
+	createCmd.Flags().String("start", "", "Start date YYYY-MM-DD")
+	createCmd.Flags().String("end", "", "End date YYYY-MM-DD")
+}
+
+func parseDate(value string) (board.Date, error) {
+	if strings.TrimSpace(value) == "" {
+		return board.Date{}, nil
+	}
+	parsed, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return board.Date{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", value)
+	}
+	return board.Date{Time: parsed}, nil
+}
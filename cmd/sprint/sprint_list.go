@@ -0,0 +1,51 @@
+package sprint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sprints",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		sprints, err := repo.ListSprintsContext(ctx)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatTable {
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindSprints, output.FromSprints(sprints, repo.BoardID()))
+		}
+		if len(sprints) == 0 {
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "No sprints found.")
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), board.FormatSprintsTable(sprints))
+		return err
+	},
+}
+
+func init() {
+	sprintCmd.AddCommand(listCmd)
+	cli.SupportOutput(listCmd)
+	cli.SupportRevision(listCmd)
+}
@@ -0,0 +1,81 @@
+package sprint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show sprint details and its tasks (default: active sprint)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		var sprint board.Sprint
+		if len(args) == 1 {
+			sprint, err = repo.GetSprintContext(ctx, args[0])
+			if err != nil {
+				return err
+			}
+		} else {
+			active, ok, err := repo.ActiveSprintContext(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("no active sprint")
+			}
+			sprint = active
+		}
+		config, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return err
+		}
+		sprintTasks := board.SprintTasks(tasks, sprint.ID)
+		if format != output.FormatTable {
+			workingDir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			record := output.FromSprint(sprint, repo.BoardID())
+			record.Tasks = output.FromTasks(board.FilterAndSortTasks(sprintTasks, board.ListOptions{SortBy: "status"}), repo.BoardID(), workingDir)
+			return output.WriteRecord(cmd.OutOrStdout(), format, output.KindSprint, record)
+		}
+		if _, err := fmt.Fprint(cmd.OutOrStdout(), board.FormatSprintDetail(sprint, sprintTasks, config.EstimateUnit)); err != nil {
+			return err
+		}
+		if len(sprintTasks) == 0 {
+			return nil
+		}
+		sprintTasks = board.FilterAndSortTasks(sprintTasks, board.ListOptions{SortBy: "status"})
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", board.FormatTasksTable(sprintTasks))
+		return err
+	},
+}
+
+func init() {
+	sprintCmd.AddCommand(showCmd)
+	cli.SupportOutput(showCmd)
+	cli.SupportRevision(showCmd)
+}
@@ -0,0 +1,34 @@
+package sprint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start a planned sprint",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		started, err := repo.StartSprintContext(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Started sprint %s on %s\n", started.ID, started.Start.Format("2006-01-02"))
+		return err
+	},
+}
+
+func init() {
+	sprintCmd.AddCommand(startCmd)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+	"mochi-sticky/internal/status"
+
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Summarize the health of boards, ADRs and the wiki",
+	Long: "Summarize every non-archived board (column counts, WIP, blocked, ready and overdue tasks, the\n" +
+		"oldest task in progress), ADRs by status with pending proposals, and wiki pages with lint totals.\n" +
+		"Thresholds such as blocked_p1>0 (from --fail-on or status.fail_on in mochi-sticky.yaml) make the\n" +
+		"command exit non-zero when they match. Metrics: " + strings.Join(status.MetricNames(), ", ") + ".",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyStatusJSONAlias(cmd); err != nil {
+			return err
+		}
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		failOn, err := cmd.Flags().GetStringSlice("fail-on")
+		if err != nil {
+			return err
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := resolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("fail-on") {
+			config, err := loadStorageConfig(workingDir)
+			if err != nil {
+				return err
+			}
+			failOn = config.Status.FailOn
+		}
+		thresholds, err := status.ParseThresholds(failOn)
+		if err != nil {
+			return err
+		}
+		// A matching threshold is a result, not a usage error.
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		report, err := status.Collect(ctx, status.Sources{
+			BaseDir:     workingDir,
+			StorageRoot: storageRoot,
+			WikiRoot:    cli.WikiRoot(storageRoot),
+			ADRRoot:     cli.AdrRoot(storageRoot),
+		}, time.Now())
+		if err != nil {
+			return err
+		}
+		report.Breaches = report.Check(thresholds)
+
+		if format != output.FormatTable {
+			if err := output.WriteReport(cmd.OutOrStdout(), format, output.KindStatusReport, report, output.FromStatusReport(report)); err != nil {
+				return err
+			}
+		} else if err := writeStatusReport(cmd.OutOrStdout(), report, thresholds); err != nil {
+			return err
+		}
+		if len(report.Breaches) == 0 {
+			return nil
+		}
+		failed := make([]string, 0, len(report.Breaches))
+		for _, breach := range report.Breaches {
+			failed = append(failed, fmt.Sprintf("%s (actual %d)", breach.Threshold, breach.Actual))
+		}
+		return fmt.Errorf("status: %s: %w", strings.Join(failed, ", "), status.ErrThresholdExceeded)
+	},
+}
+
+// applyStatusJSONAlias turns --json into --output json, which it predates.
+func applyStatusJSONAlias(cmd *cobra.Command) error {
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil || !asJSON {
+		return err
+	}
+	format, err := cli.OutputFormat()
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable && format != output.FormatJSON {
+		return fmt.Errorf("status: --json conflicts with --output %s", format)
+	}
+	if err := cmd.Flags().Set("output", string(output.FormatJSON)); err != nil {
+		return err
+	}
+	return cli.CheckOutput(cmd)
+}
+
+func writeStatusReport(out io.Writer, report status.Report, thresholds []status.Threshold) error {
+	lines := []string{"Status as of " + report.Date, "", "Boards:"}
+	if len(report.Boards) == 0 {
+		lines = append(lines, "  (none)")
+	}
+	for _, item := range report.Boards {
+		lines = append(lines, fmt.Sprintf("  %s (%s): %d tasks, %d open", item.ID, item.Name, item.Total, item.Open))
+		columns := make([]string, 0, len(item.Columns))
+		for _, column := range item.Columns {
+			columns = append(columns, fmt.Sprintf("%s %d", column.Key, column.Count))
+		}
+		lines = append(lines,
+			"    Columns: "+strings.Join(columns, ", "),
+			fmt.Sprintf("    WIP %d, ready %d, blocked %d (P1 %d), overdue %d", item.WIP, item.Ready, item.Blocked, item.BlockedP1, len(item.Overdue)),
+		)
+		if oldest := item.OldestInProgress; oldest != nil {
+			lines = append(lines, fmt.Sprintf("    Oldest in progress: %s %s [%s] %s", oldest.ID, oldest.Title, oldest.Status, statusDays(oldest.AgeDays, "old")))
+		}
+		for _, task := range item.Overdue {
+			lines = append(lines, fmt.Sprintf("    Overdue: %s %s (due %s, %s)", task.ID, task.Title, task.Due, statusDays(task.AgeDays, "late")))
+		}
+	}
+
+	counts := make([]string, 0, len(report.ADRs.ByStatus))
+	for _, count := range report.ADRs.ByStatus {
+		counts = append(counts, fmt.Sprintf("%s %d", count.Status, count.Count))
+	}
+	lines = append(lines, "", fmt.Sprintf("ADRs: %d", report.ADRs.Total))
+	if len(counts) > 0 {
+		lines = append(lines, "  "+strings.Join(counts, ", "))
+	}
+	for _, item := range report.ADRs.Proposed {
+		line := fmt.Sprintf("  Pending proposal: %s %s", item.ID, item.Title)
+		if item.Date != "" {
+			line += " (" + item.Date + ")"
+		}
+		lines = append(lines, line)
+	}
+
+	wikiReport := report.Wiki
+	lines = append(lines, "",
+		fmt.Sprintf("Wiki pages: %d", wikiReport.Total),
+		fmt.Sprintf("  draft %d, published %d, archived %d", wikiReport.Draft, wikiReport.Published, wikiReport.Archived),
+		fmt.Sprintf("  Lint issues: %d (%d errors, %d warnings)", wikiReport.LintIssues, wikiReport.LintErrors, wikiReport.LintWarnings),
+	)
+
+	if len(thresholds) > 0 {
+		breached := make(map[string]bool, len(report.Breaches))
+		for _, breach := range report.Breaches {
+			breached[breach.Threshold] = true
+		}
+		lines = append(lines, "", "Thresholds:")
+		metrics := report.Totals.Metrics()
+		for _, threshold := range thresholds {
+			result := "ok"
+			if breached[threshold.String()] {
+				result = "FAILED"
+			}
+			lines = append(lines, fmt.Sprintf("  %-20s %-6s (actual %d)", threshold.String(), result, metrics[threshold.Metric]))
+		}
+	}
+	_, err := fmt.Fprintln(out, strings.Join(lines, "\n"))
+	return err
+}
+
+func statusDays(days int, suffix string) string {
+	if days == 1 {
+		return "1 day " + suffix
+	}
+	return fmt.Sprintf("%d days %s", days, suffix)
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	cli.SupportOutput(statusCmd)
+	cli.SupportRevision(statusCmd)
+	statusCmd.Flags().Bool("json", false, "Alias for --output json")
+	statusCmd.Flags().StringSlice("fail-on", nil, "Exit non-zero when a threshold matches, e.g. blocked_p1>0 (repeatable; overrides status.fail_on)")
+}
@@ -48,12 +48,13 @@ var tuiCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		return tui.RunWithEditor(repo, editor)
+		return tui.RunAtRevision(repo, editor, cli.Revision())
 	},
 }
 
 func Register(root *cobra.Command) {
 	root.AddCommand(tuiCmd)
+	cli.SupportRevision(tuiCmd)
 	tuiCmd.Flags().String("editor", "", "Override the editor command")
 	tuiCmd.Flags().String("set-editor", "", "Persist the editor command in settings")
 }
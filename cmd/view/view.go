@@ -0,0 +1,42 @@
+package view
+
+import (
+	"os"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Manage saved views (named filters, sorts, columns and groupings)",
+}
+
+// Register attaches view commands to the root command.
+func Register(root *cobra.Command) {
+	root.AddCommand(viewCmd)
+}
+
+func init() {
+	viewCmd.PersistentFlags().String("board", "", "Board ID (default: active board)")
+	cli.CompleteFlag(viewCmd, "board", cli.CompleteBoardIDs)
+}
+
+// repoFromFlags opens the repository for the --board flag (or the active board).
+func repoFromFlags(cmd *cobra.Command) (*board.Repository, error) {
+	boardID, err := cmd.Flags().GetString("board")
+	if err != nil {
+		return nil, err
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+	if err != nil {
+		return nil, err
+	}
+	return board.NewRepositoryForBoardWithStorage(workingDir, boardID, storageRoot)
+}
@@ -0,0 +1,51 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved views",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		views, err := repo.ListViewsContext(ctx)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatTable {
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindViews, output.FromViews(views, repo.BoardID()))
+		}
+		if len(views) == 0 {
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "No views found.")
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), board.FormatViewsTable(views))
+		return err
+	},
+}
+
+func init() {
+	viewCmd.AddCommand(listCmd)
+	cli.SupportOutput(listCmd)
+	cli.SupportRevision(listCmd)
+}
@@ -0,0 +1,72 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+
+	"github.com/spf13/cobra"
+)
+
+var saveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save (or replace) a named view",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query, err := cmd.Flags().GetString("query")
+		if err != nil {
+			return err
+		}
+		sortSpec, err := cmd.Flags().GetString("sort")
+		if err != nil {
+			return err
+		}
+		columns, err := cmd.Flags().GetStringSlice("columns")
+		if err != nil {
+			return err
+		}
+		groupBy, err := cmd.Flags().GetString("group-by")
+		if err != nil {
+			return err
+		}
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		view := board.View{
+			Name:    args[0],
+			Query:   query,
+			Sort:    sortSpec,
+			Columns: columns,
+			GroupBy: groupBy,
+		}
+		replaced, err := repo.SaveViewContext(ctx, view)
+		if err != nil {
+			var queryErr *board.QueryError
+			if errors.As(err, &queryErr) {
+				return fmt.Errorf("%w\n%s", err, queryErr.Pointer())
+			}
+			return err
+		}
+		verb := "Saved"
+		if replaced {
+			verb = "Updated"
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s view %s\n", verb, view.Name)
+		return err
+	},
+}
+
+func init() {
+	viewCmd.AddCommand(saveCmd)
+	saveCmd.Flags().String("query", "", "Task query, e.g. 'priority:1 status:review'")
+	saveCmd.Flags().String("sort", "", "Sort keys, e.g. '-priority,created'")
+	saveCmd.Flags().StringSlice("columns", nil, "Visible column keys (repeatable or comma-separated)")
+	saveCmd.Flags().String("group-by", "", "Swimlane grouping: tag, priority, assignee, sprint or field:<name>")
+}
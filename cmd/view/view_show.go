@@ -0,0 +1,72 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a saved view and the tasks it selects",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		repo, err := repoFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		view, err := repo.GetViewContext(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		config, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return err
+		}
+		tasks, err = view.Apply(tasks)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatTable {
+			workingDir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			record := output.FromView(view, repo.BoardID())
+			record.Tasks = output.FromTasks(tasks, repo.BoardID(), workingDir)
+			return output.WriteRecord(cmd.OutOrStdout(), format, output.KindView, record)
+		}
+		if _, err := fmt.Fprint(cmd.OutOrStdout(), board.FormatViewDetail(view)); err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "\nNo tasks found.")
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", board.FormatViewTasks(view, config.Columns, tasks, config.Lanes.Order))
+		return err
+	},
+}
+
+func init() {
+	viewCmd.AddCommand(showCmd)
+	cli.SupportOutput(showCmd)
+	cli.SupportRevision(showCmd)
+}
@@ -133,4 +133,6 @@ func init() {
 	wikiCreateCmd.Flags().String("tags", "", "Comma-separated tags")
 	wikiCreateCmd.Flags().String("status", "published", "Status: draft|published|archived")
 	wikiCreateCmd.Flags().String("template", "", "Template name (from configured wiki templates)")
+	cli.CompleteFlag(wikiCreateCmd, "section", cli.CompleteWikiSections)
+	cli.CompleteFlag(wikiCreateCmd, "template", cli.CompleteTemplates(cli.TemplateWiki))
 }
@@ -64,5 +64,6 @@ var wikiDeleteCmd = &cobra.Command{
 
 func init() {
 	wikiCmd.AddCommand(wikiDeleteCmd)
+	wikiDeleteCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteWikiSlugs)
 	wikiDeleteCmd.Flags().Bool("update-index", false, "Remove page from _index.yaml when present")
 }
@@ -0,0 +1,61 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/wiki"
+
+	"github.com/spf13/cobra"
+)
+
+var wikiDiffCmd = &cobra.Command{
+	Use:   "diff <slug> [rev1] [rev2]",
+	Short: "Show a unified diff of a wiki page between revisions",
+	Long: "Show a unified diff of a wiki page's Markdown. With no revision, compare HEAD with the working\n" +
+		"tree; with one, compare that revision with the working tree; with two, compare them.\n" +
+		"--ignore-frontmatter compares the page bodies only.",
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ignoreFrontmatter, err := cmd.Flags().GetBool("ignore-frontmatter")
+		if err != nil {
+			return err
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		opts := wiki.DiffOptions{IgnoreFrontmatter: ignoreFrontmatter}
+		if len(args) > 1 {
+			opts.From = args[1]
+		}
+		if len(args) > 2 {
+			opts.To = args[2]
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		diff, err := wiki.Diff(ctx, wikiRoot(storageRoot), args[0], opts)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No changes.")
+			return err
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), diff)
+		return err
+	},
+}
+
+func init() {
+	wikiCmd.AddCommand(wikiDiffCmd)
+	wikiDiffCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteWikiSlugs)
+	wikiDiffCmd.Flags().Bool("ignore-frontmatter", false, "Compare the page bodies only")
+}
@@ -66,5 +66,6 @@ var wikiEditCmd = &cobra.Command{
 
 func init() {
 	wikiCmd.AddCommand(wikiEditCmd)
+	wikiEditCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteWikiSlugs)
 	wikiEditCmd.Flags().String("editor", "", "Override the editor command")
 }
@@ -298,6 +298,7 @@ var wikiExportCmd = &cobra.Command{
 
 func init() {
 	wikiCmd.AddCommand(wikiExportCmd)
+	cli.SupportRevision(wikiExportCmd)
 	wikiExportCmd.Flags().String("format", "md", "Export format (md|pdf)")
 	wikiExportCmd.Flags().String("output", "", "Output path (defaults to <storage>/wiki/export.md)")
 	wikiExportCmd.Flags().String("page", "", "Export a single page by slug")
@@ -314,6 +315,9 @@ func init() {
 	wikiExportCmd.Flags().String("template", "", "PDF template path (defaults to configured wiki_pdf template)")
 	wikiExportCmd.Flags().StringSlice("root", nil, "Additional wiki roots (path[:prefix])")
 	wikiExportCmd.Flags().String("prefix", "", "Prefix for main wiki root when combining")
+	cli.CompleteFlag(wikiExportCmd, "page", cli.CompleteWikiSlugs)
+	cli.CompleteFlag(wikiExportCmd, "section", cli.CompleteWikiSections)
+	cli.CompleteFlag(wikiExportCmd, "filter-section", cli.CompleteWikiSections)
 }
 
 func splitTagFilter(value string) []string {
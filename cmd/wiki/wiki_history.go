@@ -0,0 +1,138 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/output"
+	"mochi-sticky/internal/wiki"
+
+	"github.com/spf13/cobra"
+)
+
+var wikiHistoryCmd = &cobra.Command{
+	Use:   "history [slug]",
+	Short: "List the commits that changed a wiki page",
+	Long: "List the commits that changed a wiki page, newest first, with their authors and dates,\n" +
+		"following renames. Without a slug, list the latest commits that changed any wiki page and\n" +
+		"the pages each one touched.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			return err
+		}
+		if limit < 0 {
+			return fmt.Errorf("wiki: --limit must be zero or greater")
+		}
+		patch, err := cmd.Flags().GetBool("patch")
+		if err != nil {
+			return err
+		}
+		ignoreFrontmatter, err := cmd.Flags().GetBool("ignore-frontmatter")
+		if err != nil {
+			return err
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		storageRoot, err := cli.ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return err
+		}
+		root := wikiRoot(storageRoot)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		out := cmd.OutOrStdout()
+
+		if len(args) == 0 {
+			if patch {
+				return fmt.Errorf("wiki: --patch requires a slug")
+			}
+			changes, err := wiki.RecentChanges(ctx, root, limit)
+			if err != nil {
+				return err
+			}
+			if format != output.FormatTable {
+				return output.WriteList(out, format, output.KindWikiChanges, output.FromWikiChanges(changes))
+			}
+			if len(changes) == 0 {
+				_, err := fmt.Fprintln(out, "No committed wiki changes.")
+				return err
+			}
+			lines := make([]string, 0, len(changes))
+			for _, change := range changes {
+				line := historyLine(change.Commit)
+				if len(change.Slugs) > 0 {
+					line += " (" + strings.Join(change.Slugs, ", ") + ")"
+				}
+				lines = append(lines, line)
+			}
+			_, err = fmt.Fprintln(out, strings.Join(lines, "\n"))
+			return err
+		}
+
+		slug := args[0]
+		revisions, err := wiki.History(ctx, root, slug)
+		if err != nil {
+			return err
+		}
+		shown := len(revisions)
+		if limit > 0 && shown > limit {
+			shown = limit
+		}
+		if format != output.FormatTable {
+			records := make([]output.WikiRevision, 0, shown)
+			for i, revision := range revisions[:shown] {
+				record := output.FromWikiRevision(slug, revision)
+				if patch {
+					record.Diff = wiki.RevisionDiff(slug, revisions, i, ignoreFrontmatter)
+				}
+				records = append(records, record)
+			}
+			return output.WriteList(out, format, output.KindWikiRevisions, records)
+		}
+		if len(revisions) == 0 {
+			_, err := fmt.Fprintf(out, "No committed history for %s.\n", slug)
+			return err
+		}
+		lines := make([]string, 0, shown)
+		for i, revision := range revisions[:shown] {
+			line := historyLine(revision.Commit)
+			if revision.Deleted {
+				line += " (deleted)"
+			}
+			lines = append(lines, line)
+			if patch {
+				if diff := wiki.RevisionDiff(slug, revisions, i, ignoreFrontmatter); diff != "" {
+					lines = append(lines, "", strings.TrimSuffix(diff, "\n"), "")
+				}
+			}
+		}
+		_, err = fmt.Fprintln(out, strings.TrimSuffix(strings.Join(lines, "\n"), "\n"))
+		return err
+	},
+}
+
+func historyLine(commit git.Commit) string {
+	return fmt.Sprintf("%s  %s  %s  %s", commit.Date.Format("2006-01-02 15:04"), commit.ShortHash(), commit.Author, commit.Subject)
+}
+
+func init() {
+	wikiCmd.AddCommand(wikiHistoryCmd)
+	cli.SupportOutput(wikiHistoryCmd)
+	wikiHistoryCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteWikiSlugs)
+	wikiHistoryCmd.Flags().Int("limit", 0, "Show at most this many commits (0 for all)")
+	wikiHistoryCmd.Flags().BoolP("patch", "p", false, "Show the diff each commit made to the page")
+	wikiHistoryCmd.Flags().Bool("ignore-frontmatter", false, "Leave frontmatter out of --patch diffs")
+}
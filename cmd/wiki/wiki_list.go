@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 	"mochi-sticky/internal/shared"
 	"mochi-sticky/internal/wiki"
 
@@ -19,6 +20,10 @@ var wikiListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List wiki pages",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -148,20 +153,22 @@ var wikiListCmd = &cobra.Command{
 			Query:           queryFilter,
 			CaseInsensitive: true,
 		})
+		if format != output.FormatTable {
+			records := make([]output.WikiPage, 0, len(pages))
+			for _, page := range pages {
+				record := output.FromWikiPage(page, listedSlug(page, root, templatePaths.Wiki), workingDir)
+				record.Content = ""
+				records = append(records, record)
+			}
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindWikiPages, records)
+		}
 		if len(pages) == 0 {
 			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No wiki pages found.")
 			return err
 		}
 
 		for _, page := range pages {
-			slug := strings.TrimSpace(page.Slug)
-			if slug == "" && page.FilePath != "" {
-				if shared.IsSubpath(templatePaths.Wiki, page.FilePath) {
-					slug = slugFromPath(templatePaths.Wiki, page.FilePath)
-				} else {
-					slug = slugFromPath(root, page.FilePath)
-				}
-			}
+			slug := listedSlug(page, root, templatePaths.Wiki)
 			if slug == "" {
 				slug = "(missing slug)"
 			}
@@ -173,8 +180,24 @@ var wikiListCmd = &cobra.Command{
 	},
 }
 
+// listedSlug returns the page slug, deriving it from the file path (under the wiki or
+// templates root) when the frontmatter has none.
+func listedSlug(page wiki.Page, root, templatesRoot string) string {
+	slug := strings.TrimSpace(page.Slug)
+	if slug == "" && page.FilePath != "" {
+		if shared.IsSubpath(templatesRoot, page.FilePath) {
+			slug = slugFromPath(templatesRoot, page.FilePath)
+		} else {
+			slug = slugFromPath(root, page.FilePath)
+		}
+	}
+	return slug
+}
+
 func init() {
 	wikiCmd.AddCommand(wikiListCmd)
+	cli.SupportOutput(wikiListCmd)
+	cli.SupportRevision(wikiListCmd)
 	wikiListCmd.Flags().String("status", "", "Filter by status (draft|published|archived)")
 	wikiListCmd.Flags().Bool("include-templates", false, "Include template pages in list output")
 	wikiListCmd.Flags().String("title", "", "Filter by title substring")
@@ -182,4 +205,5 @@ func init() {
 	wikiListCmd.Flags().String("tag-mode", "any", "Tag filter mode (any|all)")
 	wikiListCmd.Flags().String("section", "", "Filter by section")
 	wikiListCmd.Flags().String("query", "", "Filter by keyword query")
+	cli.CompleteFlag(wikiListCmd, "section", cli.CompleteWikiSections)
 }
@@ -78,4 +78,5 @@ var wikiManifestCmd = &cobra.Command{
 
 func init() {
 	wikiCmd.AddCommand(wikiManifestCmd)
+	cli.SupportRevision(wikiManifestCmd)
 }
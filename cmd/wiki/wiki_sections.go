@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 	"mochi-sticky/internal/wiki"
 
 	"github.com/spf13/cobra"
@@ -17,6 +18,10 @@ var wikiSectionsCmd = &cobra.Command{
 	Use:   "sections",
 	Short: "List wiki sections",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -68,6 +73,13 @@ var wikiSectionsCmd = &cobra.Command{
 			LinkType:   linkType,
 			LinkTarget: linkTarget,
 		})
+		if format != output.FormatTable {
+			records := make([]output.WikiSection, 0, len(sections))
+			for _, section := range sections {
+				records = append(records, output.FromWikiSection(section))
+			}
+			return output.WriteList(cmd.OutOrStdout(), format, output.KindWikiSections, records)
+		}
 		if len(sections) == 0 {
 			_, err := fmt.Fprintln(cmd.OutOrStdout(), "No wiki sections found.")
 			return err
@@ -98,6 +110,8 @@ var wikiSectionsCmd = &cobra.Command{
 
 func init() {
 	wikiCmd.AddCommand(wikiSectionsCmd)
+	cli.SupportOutput(wikiSectionsCmd)
+	cli.SupportRevision(wikiSectionsCmd)
 	wikiSectionsCmd.Flags().String("tags", "", "Filter by tags (comma-separated)")
 	wikiSectionsCmd.Flags().String("tag-mode", "any", "Tag filter mode (any|all)")
 	wikiSectionsCmd.Flags().String("link-type", "", "Filter by link type (depends_on|related_to)")
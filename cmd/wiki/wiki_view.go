@@ -3,8 +3,10 @@ package wiki
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"mochi-sticky/internal/cli"
+	"mochi-sticky/internal/output"
 	"mochi-sticky/internal/wiki"
 
 	"github.com/spf13/cobra"
@@ -16,6 +18,10 @@ var wikiViewCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		slug := args[0]
+		format, err := cli.OutputFormat()
+		if err != nil {
+			return err
+		}
 		workingDir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -33,6 +39,12 @@ var wikiViewCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if format != output.FormatTable {
+			if strings.TrimSpace(page.Slug) != "" {
+				slug = page.Slug
+			}
+			return output.WriteRecord(cmd.OutOrStdout(), format, output.KindWikiPage, output.FromWikiPage(page, slug, workingDir))
+		}
 		if page.Content == "" {
 			return nil
 		}
@@ -43,4 +55,7 @@ var wikiViewCmd = &cobra.Command{
 
 func init() {
 	wikiCmd.AddCommand(wikiViewCmd)
+	wikiViewCmd.ValidArgsFunction = cli.CompleteArgs(cli.CompleteWikiSlugs)
+	cli.SupportOutput(wikiViewCmd)
+	cli.SupportRevision(wikiViewCmd)
 }
@@ -4,11 +4,12 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	"mochi-sticky/internal/shared"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -172,7 +173,7 @@ func RenderADR(adr ADR) ([]byte, error) {
 
 // LoadADR reads a markdown file from disk and parses it into an ADR.
 func LoadADR(path string) (ADR, error) {
-	data, err := os.ReadFile(path)
+	data, err := shared.Files().ReadFile(path)
 	if err != nil {
 		return ADR{}, fmt.Errorf("adr: failed to read adr %s: %w", path, err)
 	}
@@ -199,10 +200,10 @@ func SaveADR(path string, adr ADR) error {
 	if err != nil {
 		return err
 	}
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+	if err := shared.Files().MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return fmt.Errorf("adr: failed to create adr dir %s: %w", filepath.Dir(path), err)
 	}
-	if err := os.WriteFile(path, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(path, data, 0o644); err != nil {
 		return fmt.Errorf("adr: failed to write adr %s: %w", path, err)
 	}
 	return nil
@@ -115,7 +115,7 @@ func loadConfigContext(ctx context.Context, root string) (Config, error) {
 		return Config{}, ctx.Err()
 	default:
 	}
-	data, err := os.ReadFile(path)
+	data, err := shared.Files().ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return normalizeConfig(DefaultConfig())
@@ -162,7 +162,7 @@ func saveConfigContext(ctx context.Context, root string, cfg Config) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(root, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(root, 0o755); err != nil {
 		return fmt.Errorf("adr: failed to create adr root %s: %w", root, err)
 	}
 	select {
@@ -170,7 +170,7 @@ func saveConfigContext(ctx context.Context, root string, cfg Config) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(path, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(path, data, 0o644); err != nil {
 		return fmt.Errorf("adr: failed to write config %s: %w", path, err)
 	}
 	return nil
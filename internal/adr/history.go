@@ -0,0 +1,67 @@
+package adr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mochi-sticky/internal/git"
+)
+
+// StatusChange records an ADR entering a status in a commit.
+type StatusChange struct {
+	Status string
+	Commit git.Commit
+}
+
+// StatusChanges returns the status changes recorded by successive revisions of an ADR file,
+// oldest first. The first revision counts as entering its status; deletions and revisions
+// that fail to parse are skipped.
+func StatusChanges(revisions []git.FileRevision) []StatusChange {
+	changes := make([]StatusChange, 0)
+	previous := ""
+	for _, revision := range revisions {
+		if revision.Data == nil {
+			continue
+		}
+		record, err := ParseADR(revision.Data)
+		if err != nil {
+			continue
+		}
+		status := strings.TrimSpace(record.Status)
+		if status == "" || strings.EqualFold(status, previous) {
+			continue
+		}
+		changes = append(changes, StatusChange{Status: status, Commit: revision.Commit})
+		previous = status
+	}
+	return changes
+}
+
+// StatusHistories returns the status changes of every ADR, keyed by ADR ID.
+func (r *Repository) StatusHistories() (map[int][]StatusChange, error) {
+	return r.StatusHistoriesContext(context.Background())
+}
+
+// StatusHistoriesContext returns the status changes of every ADR from the git history of its
+// file, keyed by ADR ID, honoring ctx cancellation. It fails with git.ErrNotRepository when the
+// ADRs are not in a git working tree.
+func (r *Repository) StatusHistoriesContext(ctx context.Context) (map[int][]StatusChange, error) {
+	records, err := r.ListADRsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.Open(ctx, r.root)
+	if err != nil {
+		return nil, err
+	}
+	histories := make(map[int][]StatusChange, len(records))
+	for _, record := range records {
+		revisions, err := repo.FileHistory(ctx, record.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("adr: history of %s: %w", FormatID(record.ID), err)
+		}
+		histories[record.ID] = StatusChanges(revisions)
+	}
+	return histories, nil
+}
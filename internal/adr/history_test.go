@@ -0,0 +1,43 @@
+package adr
+
+import (
+	"testing"
+	"time"
+
+	"mochi-sticky/internal/git"
+)
+
+func TestStatusChangesRecordsTransitions(t *testing.T) {
+	// Arrange
+	render := func(status string) []byte {
+		data, err := RenderADR(ADR{ID: 1, Title: "Use SQLite", Status: status, Content: "Body"})
+		if err != nil {
+			t.Fatalf("render: %v", err)
+		}
+		return data
+	}
+	day := func(n int) git.Commit {
+		return git.Commit{Hash: string(rune('a' + n)), Date: time.Date(2026, 3, n, 12, 0, 0, 0, time.UTC)}
+	}
+	revisions := []git.FileRevision{
+		{Commit: day(1), Data: render("proposed")},
+		{Commit: day(2), Data: render("proposed")},
+		{Commit: day(3), Data: []byte("not frontmatter")},
+		{Commit: day(4), Data: render("accepted")},
+		{Commit: day(5)},
+	}
+
+	// Act
+	changes := StatusChanges(revisions)
+
+	// Assert
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+	if changes[0].Status != "proposed" || changes[0].Commit.Date.Day() != 1 {
+		t.Fatalf("unexpected first change: %+v", changes[0])
+	}
+	if changes[1].Status != "accepted" || changes[1].Commit.Date.Day() != 4 {
+		t.Fatalf("unexpected second change: %+v", changes[1])
+	}
+}
@@ -68,7 +68,7 @@ func (r *Repository) InitStoreContext(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(r.root, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.root, 0o755); err != nil {
 		return fmt.Errorf("adr: failed to create adr root %s: %w", r.root, err)
 	}
 	select {
@@ -76,7 +76,7 @@ func (r *Repository) InitStoreContext(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(r.templatesDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.templatesDir, 0o755); err != nil {
 		return fmt.Errorf("adr: failed to create templates dir %s: %w", r.templatesDir, err)
 	}
 
@@ -85,7 +85,7 @@ func (r *Repository) InitStoreContext(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	if _, err := os.Stat(r.configPath); err == nil {
+	if _, err := shared.Files().Stat(r.configPath); err == nil {
 		return nil
 	} else if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("adr: failed to stat config %s: %w", r.configPath, err)
@@ -100,7 +100,7 @@ func (r *Repository) InitStoreContext(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(r.configPath, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(r.configPath, data, 0o644); err != nil {
 		return fmt.Errorf("adr: failed to write config %s: %w", r.configPath, err)
 	}
 	return nil
@@ -156,14 +156,14 @@ func (r *Repository) listADRsLockedContext(ctx context.Context) ([]ADR, error) {
 		return nil, ctx.Err()
 	default:
 	}
-	if _, err := os.Stat(r.root); err != nil {
+	if _, err := shared.Files().Stat(r.root); err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("adr: failed to stat adr root %s: %w", r.root, err)
 	}
 
-	entries, err := os.ReadDir(r.root)
+	entries, err := shared.Files().ReadDir(r.root)
 	if err != nil {
 		return nil, fmt.Errorf("adr: failed to read adr root %s: %w", r.root, err)
 	}
@@ -247,10 +247,10 @@ func (r *Repository) CreateADRContext(ctx context.Context, title string, opts Cr
 		return ADR{}, ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(r.root, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.root, 0o755); err != nil {
 		return ADR{}, fmt.Errorf("adr: failed to create adr root %s: %w", r.root, err)
 	}
-	if err := os.MkdirAll(r.templatesDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.templatesDir, 0o755); err != nil {
 		return ADR{}, fmt.Errorf("adr: failed to create templates dir %s: %w", r.templatesDir, err)
 	}
 
@@ -319,7 +319,7 @@ func (r *Repository) CreateADRContext(ctx context.Context, title string, opts Cr
 		return ADR{}, ctx.Err()
 	default:
 	}
-	if _, err := os.Stat(filePath); err == nil {
+	if _, err := shared.Files().Stat(filePath); err == nil {
 		return ADR{}, fmt.Errorf("adr: adr already exists: %s", filename)
 	} else if err != nil && !os.IsNotExist(err) {
 		return ADR{}, fmt.Errorf("adr: failed to stat adr file %s: %w", filePath, err)
@@ -365,7 +365,7 @@ func (r *Repository) DeleteADRContext(ctx context.Context, id int) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.Remove(path); err != nil {
+	if err := shared.Files().Remove(path); err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("adr: %w", ErrADRNotFound)
 		}
@@ -413,7 +413,7 @@ func (r *Repository) findPathByIDLocked(id int) (string, error) {
 	if id <= 0 {
 		return "", fmt.Errorf("adr: %w", ErrInvalidID)
 	}
-	if _, err := os.Stat(r.root); err != nil {
+	if _, err := shared.Files().Stat(r.root); err != nil {
 		if os.IsNotExist(err) {
 			return "", fmt.Errorf("adr: %w", ErrADRNotFound)
 		}
@@ -421,7 +421,7 @@ func (r *Repository) findPathByIDLocked(id int) (string, error) {
 	}
 
 	prefix := FormatID(id)
-	entries, err := os.ReadDir(r.root)
+	entries, err := shared.Files().ReadDir(r.root)
 	if err != nil {
 		return "", fmt.Errorf("adr: failed to read adr root %s: %w", r.root, err)
 	}
@@ -452,13 +452,13 @@ func (r *Repository) findPathByIDLocked(id int) (string, error) {
 }
 
 func (r *Repository) maxIDLocked() (int, error) {
-	if _, err := os.Stat(r.root); err != nil {
+	if _, err := shared.Files().Stat(r.root); err != nil {
 		if os.IsNotExist(err) {
 			return 0, nil
 		}
 		return 0, fmt.Errorf("adr: failed to stat adr root %s: %w", r.root, err)
 	}
-	entries, err := os.ReadDir(r.root)
+	entries, err := shared.Files().ReadDir(r.root)
 	if err != nil {
 		return 0, fmt.Errorf("adr: failed to read adr root %s: %w", r.root, err)
 	}
@@ -3,7 +3,6 @@ package board
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"time"
 
@@ -31,7 +30,7 @@ func (r *Repository) ArchiveTaskContext(ctx context.Context, id string) (Task, e
 	if err := ensureDirExists(r.tasksDir); err != nil {
 		return Task{}, err
 	}
-	if err := os.MkdirAll(r.archiveTasks, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.archiveTasks, 0o755); err != nil {
 		return Task{}, fmt.Errorf("board: failed to create archive tasks directory: %w", err)
 	}
 
@@ -44,7 +43,7 @@ func (r *Repository) ArchiveTaskContext(ctx context.Context, id string) (Task, e
 	if err := shared.EnsureInDir(r.archiveTasks, dest); err != nil {
 		return Task{}, err
 	}
-	if err := os.Rename(path, dest); err != nil {
+	if err := shared.Files().Rename(path, dest); err != nil {
 		return Task{}, fmt.Errorf("board: failed to archive task %s: %w", id, err)
 	}
 	task.FilePath = dest
@@ -73,7 +72,7 @@ func (r *Repository) RestoreTaskContext(ctx context.Context, id string) (Task, e
 	if err := ensureDirExists(r.archiveTasks); err != nil {
 		return Task{}, err
 	}
-	if err := os.MkdirAll(r.tasksDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.tasksDir, 0o755); err != nil {
 		return Task{}, fmt.Errorf("board: failed to create tasks directory: %w", err)
 	}
 
@@ -86,7 +85,7 @@ func (r *Repository) RestoreTaskContext(ctx context.Context, id string) (Task, e
 	if err := shared.EnsureInDir(r.tasksDir, dest); err != nil {
 		return Task{}, err
 	}
-	if err := os.Rename(path, dest); err != nil {
+	if err := shared.Files().Rename(path, dest); err != nil {
 		return Task{}, fmt.Errorf("board: failed to restore task %s: %w", id, err)
 	}
 	task.FilePath = dest
@@ -123,7 +122,7 @@ func (r *Repository) ArchiveBeforeContext(ctx context.Context, cutoff time.Time)
 	if err := ensureDirExists(r.tasksDir); err != nil {
 		return nil, err
 	}
-	if err := os.MkdirAll(r.archiveTasks, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.archiveTasks, 0o755); err != nil {
 		return nil, fmt.Errorf("board: failed to create archive tasks directory: %w", err)
 	}
 
@@ -150,7 +149,7 @@ func (r *Repository) ArchiveBeforeContext(ctx context.Context, cutoff time.Time)
 		if err := shared.EnsureInDir(r.archiveTasks, dest); err != nil {
 			return nil, err
 		}
-		if err := os.Rename(src, dest); err != nil {
+		if err := shared.Files().Rename(src, dest); err != nil {
 			return nil, fmt.Errorf("board: failed to archive task %s: %w", task.ID, err)
 		}
 		task.FilePath = dest
@@ -184,7 +183,7 @@ func (r *Repository) DeleteArchivedTaskContext(ctx context.Context, id string) e
 	if err != nil {
 		return err
 	}
-	if err := os.Remove(path); err != nil {
+	if err := shared.Files().Remove(path); err != nil {
 		return fmt.Errorf("board: failed to delete archived task %s: %w", id, err)
 	}
 	return nil
@@ -215,14 +214,14 @@ func (r *Repository) DeleteTaskContext(ctx context.Context, id string) error {
 	if err != nil {
 		return err
 	}
-	if err := os.Remove(path); err != nil {
+	if err := shared.Files().Remove(path); err != nil {
 		return fmt.Errorf("board: failed to delete task %s: %w", id, err)
 	}
 	return nil
 }
 
 func (r *Repository) findTaskFileLockedContext(ctx context.Context, dir, id string) (string, Task, error) {
-	entries, err := os.ReadDir(dir)
+	entries, err := shared.Files().ReadDir(dir)
 	if err != nil {
 		return "", Task{}, fmt.Errorf("board: failed to read tasks directory: %w", err)
 	}
@@ -240,7 +239,7 @@ func (r *Repository) findTaskFileLockedContext(ctx context.Context, dir, id stri
 		if err := shared.EnsureInDir(dir, path); err != nil {
 			return "", Task{}, err
 		}
-		data, err := os.ReadFile(path)
+		data, err := shared.Files().ReadFile(path)
 		if err != nil {
 			return "", Task{}, fmt.Errorf("board: failed to read task file %s: %w", path, err)
 		}
@@ -258,7 +257,7 @@ func (r *Repository) findTaskFileLockedContext(ctx context.Context, dir, id stri
 }
 
 func (r *Repository) readTasksFromDirContext(ctx context.Context, dir string) ([]Task, error) {
-	entries, err := os.ReadDir(dir)
+	entries, err := shared.Files().ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("board: failed to read tasks directory: %w", err)
 	}
@@ -277,7 +276,7 @@ func (r *Repository) readTasksFromDirContext(ctx context.Context, dir string) ([
 		if err := shared.EnsureInDir(dir, path); err != nil {
 			return nil, err
 		}
-		data, err := os.ReadFile(path)
+		data, err := shared.Files().ReadFile(path)
 		if err != nil {
 			return nil, fmt.Errorf("board: failed to read task file %s: %w", path, err)
 		}
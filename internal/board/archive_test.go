@@ -1,6 +1,9 @@
 package board
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestArchiveRestoreDeleteTask(t *testing.T) {
 	// Arrange
@@ -86,3 +89,42 @@ func TestDeleteArchivedTask(t *testing.T) {
 		t.Fatalf("expected 0 archived tasks, got %d", len(archived))
 	}
 }
+
+func TestSetTaskFieldOnArchivedTask(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	active, _ := NewTask("Active")
+	activeTask, err := repo.CreateTask(active)
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	archived, _ := NewTask("Archived")
+	archivedTask, err := repo.CreateTask(archived)
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if _, err := repo.ArchiveTask(archivedTask.ID); err != nil {
+		t.Fatalf("archive task: %v", err)
+	}
+
+	// Act
+	activeErr := repo.SetTaskField(activeTask.ID, "release", "v1.2.0")
+	archivedErr := repo.SetTaskField(archivedTask.ID, "release", "v1.2.0")
+	missingErr := repo.SetTaskField("T-999999", "release", "v1.2.0")
+
+	// Assert
+	if activeErr != nil || archivedErr != nil {
+		t.Fatalf("set field: %v, %v", activeErr, archivedErr)
+	}
+	tasks, err := repo.GetAllTasks()
+	if err != nil || len(tasks) != 1 || tasks[0].Fields["release"] != "v1.2.0" {
+		t.Fatalf("expected the active task to be stamped, got %+v (%v)", tasks, err)
+	}
+	archivedTasks, err := repo.ListArchivedTasks()
+	if err != nil || len(archivedTasks) != 1 || archivedTasks[0].Fields["release"] != "v1.2.0" {
+		t.Fatalf("expected the archived task to be stamped, got %+v (%v)", archivedTasks, err)
+	}
+	if !errors.Is(missingErr, ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", missingErr)
+	}
+}
@@ -44,7 +44,7 @@ func (r *Repository) LoadBoardDescriptionContext(ctx context.Context) (string, e
 		return "", ctx.Err()
 	default:
 	}
-	data, err := os.ReadFile(path)
+	data, err := shared.Files().ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return "", nil
@@ -80,7 +80,7 @@ func (r *Repository) UpdateBoardDescriptionContext(ctx context.Context, descript
 
 	trimmed := strings.TrimSpace(description)
 	if trimmed == "" {
-		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		if err := shared.Files().Remove(path); err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("board: failed to remove board description: %w", err)
 		}
 		return nil
@@ -91,7 +91,7 @@ func (r *Repository) UpdateBoardDescriptionContext(ctx context.Context, descript
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(r.boardDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.boardDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create board directory: %w", err)
 	}
 	select {
@@ -99,7 +99,7 @@ func (r *Repository) UpdateBoardDescriptionContext(ctx context.Context, descript
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(path, []byte(description), 0o644); err != nil {
+	if err := shared.Files().WriteFile(path, []byte(description), 0o644); err != nil {
 		return fmt.Errorf("board: failed to write board description: %w", err)
 	}
 	return nil
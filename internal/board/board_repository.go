@@ -60,8 +60,8 @@ func NewBoardRepositoryWithStorage(baseDir, storageRoot string) (*BoardRepositor
 	registryPath := paths.Boards
 	if strings.TrimSpace(cfg.Paths.Boards) == "" {
 		legacyRegistry := filepath.Join(stickyDir, "boards.yaml")
-		if _, err := os.Stat(registryPath); err != nil && os.IsNotExist(err) {
-			if _, legacyErr := os.Stat(legacyRegistry); legacyErr == nil {
+		if _, err := shared.Files().Stat(registryPath); err != nil && os.IsNotExist(err) {
+			if _, legacyErr := shared.Files().Stat(legacyRegistry); legacyErr == nil {
 				registryPath = legacyRegistry
 			}
 		}
@@ -103,7 +103,7 @@ func (b *BoardRepository) loadRegistryContext(ctx context.Context) (BoardRegistr
 		return BoardRegistry{}, ctx.Err()
 	default:
 	}
-	data, err := os.ReadFile(registryPath)
+	data, err := shared.Files().ReadFile(registryPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return BoardRegistry{}, fmt.Errorf("board: %w", ErrStoreNotInitialized)
@@ -142,7 +142,7 @@ func (b *BoardRepository) saveRegistryContext(ctx context.Context, registry Boar
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(registryPath, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(registryPath, data, 0o644); err != nil {
 		return fmt.Errorf("board: failed to write board registry: %w", err)
 	}
 	return nil
@@ -401,7 +401,7 @@ func (b *BoardRepository) DeleteBoardContext(ctx context.Context, boardID string
 		return ctx.Err()
 	default:
 	}
-	if err := os.RemoveAll(boardDir); err != nil {
+	if err := shared.Files().RemoveAll(boardDir); err != nil {
 		return fmt.Errorf("board: failed to delete board data: %w", err)
 	}
 	return nil
@@ -438,7 +438,7 @@ func (b *BoardRepository) ensureRegistryContext(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	if _, err := os.Stat(registryPath); err == nil {
+	if _, err := shared.Files().Stat(registryPath); err == nil {
 		return nil
 	} else if !os.IsNotExist(err) {
 		return fmt.Errorf("board: failed to stat board registry: %w", err)
@@ -450,7 +450,7 @@ func (b *BoardRepository) ensureRegistryContext(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(boardsDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(boardsDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create boards directory: %w", err)
 	}
 
@@ -482,7 +482,7 @@ func (b *BoardRepository) ensureRegistryContext(ctx context.Context) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(boardDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(boardDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create default board directory: %w", err)
 	}
 	return nil
@@ -506,7 +506,7 @@ func (b *BoardRepository) initBoardLockedContext(ctx context.Context, board Boar
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(tasksDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(tasksDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create board tasks directory: %w", err)
 	}
 	archiveTasks := filepath.Join(boardDir, "archive", "tasks")
@@ -515,7 +515,7 @@ func (b *BoardRepository) initBoardLockedContext(ctx context.Context, board Boar
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(archiveTasks, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(archiveTasks, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create board archive tasks directory: %w", err)
 	}
 
@@ -523,7 +523,7 @@ func (b *BoardRepository) initBoardLockedContext(ctx context.Context, board Boar
 	if err := shared.EnsureInDir(boardDir, configPath); err != nil {
 		return err
 	}
-	if _, err := os.Stat(configPath); err == nil {
+	if _, err := shared.Files().Stat(configPath); err == nil {
 		return nil
 	} else if !os.IsNotExist(err) {
 		return fmt.Errorf("board: failed to stat board config: %w", err)
@@ -538,7 +538,7 @@ func (b *BoardRepository) initBoardLockedContext(ctx context.Context, board Boar
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(configPath, data, 0o644); err != nil {
 		return fmt.Errorf("board: failed to write board config: %w", err)
 	}
 	return nil
@@ -59,7 +59,7 @@ func (r *Repository) loadBoardRegistryContext(ctx context.Context) (BoardRegistr
 		return BoardRegistry{}, ctx.Err()
 	default:
 	}
-	data, err := os.ReadFile(registryPath)
+	data, err := shared.Files().ReadFile(registryPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return BoardRegistry{}, fmt.Errorf("board: %w", ErrStoreNotInitialized)
@@ -99,7 +99,7 @@ func (r *Repository) saveBoardRegistryContext(ctx context.Context, registry Boar
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(registryPath, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(registryPath, data, 0o644); err != nil {
 		return fmt.Errorf("board: failed to write board registry: %w", err)
 	}
 	return nil
@@ -0,0 +1,266 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/shared"
+)
+
+// BulkFilterAll is the filter expression that matches every task on the board.
+const BulkFilterAll = "all"
+
+// BulkChange describes the edits applied to every task matched by a bulk operation.
+// Zero values leave the corresponding field untouched.
+type BulkChange struct {
+	Status     string
+	AddTags    []string
+	RemoveTags []string
+	Priority   int
+	Archive    bool
+}
+
+// BulkResult reports what a bulk operation did (or would do) to one task.
+type BulkResult struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Changes  []string `json:"changes,omitempty"`
+	Archived bool     `json:"archived,omitempty"`
+}
+
+// IsEmpty reports whether the change would not modify any task.
+func (c BulkChange) IsEmpty() bool {
+	return strings.TrimSpace(c.Status) == "" && len(normalizeTags(c.AddTags)) == 0 &&
+		len(normalizeTags(c.RemoveTags)) == 0 && c.Priority == 0 && !c.Archive
+}
+
+// ParseBulkFilter parses the filter of a bulk operation with the task query language (see
+// ParseQuery). The expression "all" matches every task; an empty filter is rejected so a
+// forgotten filter never touches the whole board.
+func ParseBulkFilter(expr string) (Query, error) {
+	trimmed := strings.TrimSpace(expr)
+	if trimmed == "" {
+		return Query{}, fmt.Errorf("board: filter is required (use %q to match every task): %w", BulkFilterAll, ErrInvalidFilter)
+	}
+	if strings.EqualFold(trimmed, BulkFilterAll) {
+		return Query{Raw: trimmed}, nil
+	}
+	query, err := ParseQuery(trimmed)
+	if err != nil {
+		return Query{}, err
+	}
+	if query.Root == nil {
+		return Query{}, fmt.Errorf("board: filter %q selects no tasks (use %q to match every task): %w", trimmed, BulkFilterAll, ErrInvalidFilter)
+	}
+	return query, nil
+}
+
+// BulkUpdate applies change to every task matched by query.
+func (r *Repository) BulkUpdate(query Query, change BulkChange, dryRun bool) ([]BulkResult, error) {
+	return r.BulkUpdateContext(context.Background(), query, change, dryRun)
+}
+
+// BulkUpdateContext applies change to every task matched by query under a single repository
+// lock, honoring ctx cancellation. With dryRun set nothing is written. If any write fails, the
+// task files already rewritten or archived are restored before the error is returned.
+func (r *Repository) BulkUpdateContext(ctx context.Context, query Query, change BulkChange, dryRun bool) ([]BulkResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if change.IsEmpty() {
+		return nil, fmt.Errorf("board: no changes requested: %w", ErrInvalidBulk)
+	}
+	priority := 0
+	if change.Priority != 0 {
+		normalized, err := normalizePriority(change.Priority)
+		if err != nil {
+			return nil, err
+		}
+		priority = normalized
+	}
+	if err := ensureDirExists(r.tasksDir); err != nil {
+		return nil, err
+	}
+	tasks, err := r.readTasksFromDirContext(ctx, r.tasksDir)
+	if err != nil {
+		return nil, err
+	}
+	matched := query.Apply(tasks)
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	status := strings.TrimSpace(change.Status)
+	addTags := normalizeTags(change.AddTags)
+	removeTags := normalizeTags(change.RemoveTags)
+	now := r.now()
+
+	results := make([]BulkResult, 0, len(matched))
+	updated := make([]Task, 0, len(matched))
+	dirty := make([]bool, 0, len(matched))
+	for _, task := range matched {
+		result := BulkResult{ID: task.ID, Title: task.Title, Archived: change.Archive}
+		next := task
+		if status != "" && !strings.EqualFold(task.Status, status) {
+			result.Changes = append(result.Changes, fmt.Sprintf("status %s -> %s", task.Status, status))
+			applyStatusChange(&next, status, now)
+		}
+		if priority != 0 && effectivePriority(task.Priority) != priority {
+			result.Changes = append(result.Changes, fmt.Sprintf("priority %d -> %d", effectivePriority(task.Priority), priority))
+			next.Priority = priority
+		}
+		next.Tags, result.Changes = bulkEditTags(task.Tags, addTags, removeTags, result.Changes)
+		dirty = append(dirty, len(result.Changes) > 0)
+		if change.Archive {
+			result.Changes = append(result.Changes, "archived")
+		}
+		results = append(results, result)
+		updated = append(updated, next)
+	}
+	if dryRun {
+		return results, nil
+	}
+	if change.Archive {
+		if err := shared.Files().MkdirAll(r.archiveTasks, 0o755); err != nil {
+			return nil, fmt.Errorf("board: failed to create archive tasks directory: %w", err)
+		}
+	}
+	if _, err := r.applyBulkLockedContext(ctx, matched, updated, dirty, change.Archive); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func bulkEditTags(tags, add, remove, changes []string) ([]string, []string) {
+	removed := make(map[string]struct{}, len(remove))
+	for _, tag := range remove {
+		removed[strings.ToLower(tag)] = struct{}{}
+	}
+	out := make([]string, 0, len(tags)+len(add))
+	present := make(map[string]struct{}, len(tags)+len(add))
+	for _, tag := range tags {
+		if _, drop := removed[strings.ToLower(tag)]; drop {
+			changes = append(changes, "-tag "+tag)
+			continue
+		}
+		present[strings.ToLower(tag)] = struct{}{}
+		out = append(out, tag)
+	}
+	for _, tag := range add {
+		if _, exists := present[strings.ToLower(tag)]; exists {
+			continue
+		}
+		present[strings.ToLower(tag)] = struct{}{}
+		out = append(out, tag)
+		changes = append(changes, "+tag "+tag)
+	}
+	if len(out) == 0 {
+		return nil, changes
+	}
+	return out, changes
+}
+
+// bulkWrite records how to undo one applied bulk edit.
+type bulkWrite struct {
+	path     string
+	original []byte
+	archived string
+}
+
+// applyBulkLockedContext writes after[i] over every dirty task in before and archives them when
+// archive is set. On error the edits already applied are undone; on success they are returned
+// so a caller whose next step fails can undo them with restoreBulkWrites.
+func (r *Repository) applyBulkLockedContext(ctx context.Context, before, after []Task, dirty []bool, archive bool) (applied []bulkWrite, err error) {
+	defer func() {
+		if err != nil {
+			restoreBulkWrites(applied)
+			applied = nil
+		}
+	}()
+
+	for i, task := range before {
+		select {
+		case <-ctx.Done():
+			return applied, ctx.Err()
+		default:
+		}
+		changed := dirty[i]
+		if !changed && !archive {
+			continue
+		}
+		original, readErr := shared.Files().ReadFile(task.FilePath)
+		if readErr != nil {
+			return applied, fmt.Errorf("board: failed to read task file %s: %w", task.FilePath, readErr)
+		}
+		// Record the undo first: a failed write may already have truncated the file.
+		applied = append(applied, bulkWrite{path: task.FilePath, original: original})
+		if changed {
+			content, renderErr := r.parser.Render(after[i])
+			if renderErr != nil {
+				return applied, fmt.Errorf("board: failed to render task %s: %w", task.ID, renderErr)
+			}
+			if writeErr := shared.Files().WriteFile(task.FilePath, content, 0o644); writeErr != nil {
+				return applied, fmt.Errorf("board: failed to write task file %s: %w", task.FilePath, writeErr)
+			}
+		}
+		if !archive {
+			continue
+		}
+		dest := filepath.Join(r.archiveTasks, filepath.Base(task.FilePath))
+		if ensureErr := shared.EnsureInDir(r.archiveTasks, dest); ensureErr != nil {
+			return applied, ensureErr
+		}
+		if _, statErr := shared.Files().Stat(dest); statErr == nil {
+			return applied, fmt.Errorf("board: failed to archive task %s: %s already exists", task.ID, dest)
+		}
+		if renameErr := shared.Files().Rename(task.FilePath, dest); renameErr != nil {
+			return applied, fmt.Errorf("board: failed to archive task %s: %w", task.ID, renameErr)
+		}
+		applied[len(applied)-1].archived = dest
+	}
+	return applied, nil
+}
+
+// restoreBulkWrites undoes applied bulk edits, newest first.
+func restoreBulkWrites(applied []bulkWrite) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		undo := applied[i]
+		if undo.archived != "" {
+			_ = shared.Files().Rename(undo.archived, undo.path)
+		}
+		_ = shared.Files().WriteFile(undo.path, undo.original, 0o644)
+	}
+}
+
+// FormatBulkResults renders a per-task summary of a bulk operation.
+func FormatBulkResults(results []BulkResult, dryRun bool) string {
+	var b strings.Builder
+	changed := 0
+	for _, result := range results {
+		summary := "unchanged"
+		if len(result.Changes) > 0 {
+			summary = strings.Join(result.Changes, ", ")
+			changed++
+		}
+		fmt.Fprintf(&b, "%s %s: %s\n", result.ID, result.Title, summary)
+	}
+	verb := "Updated"
+	if dryRun {
+		verb = "Would update"
+	}
+	fmt.Fprintf(&b, "%s %s of %d matched\n", verb, pluralTasks(changed), len(results))
+	return b.String()
+}
+
+func pluralTasks(count int) string {
+	if count == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", count)
+}
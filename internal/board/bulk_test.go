@@ -0,0 +1,190 @@
+package board
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"mochi-sticky/internal/shared"
+)
+
+func TestParseBulkFilter(t *testing.T) {
+	// Arrange
+	tasks := []Task{
+		{ID: "T-000001", Title: "Login page", Status: "todo", Tags: []string{"api"}},
+		{ID: "T-000002", Title: "Logout", Status: "todo", Tags: []string{"ui"}},
+		{ID: "T-000003", Title: "Login API", Status: "done", Tags: []string{"api"}},
+	}
+
+	// Act
+	query, err := ParseBulkFilter(`status:todo tag:api,ui -title:logout`)
+	all, allErr := ParseBulkFilter("all")
+	_, emptyErr := ParseBulkFilter("  ")
+	_, sortErr := ParseBulkFilter("sort:priority")
+	_, unknownErr := ParseBulkFilter("owner:ana")
+
+	// Assert
+	if err != nil || allErr != nil {
+		t.Fatalf("unexpected errors: %v %v", err, allErr)
+	}
+	if matched := query.Apply(tasks); len(matched) != 1 || matched[0].ID != "T-000001" {
+		t.Fatalf("expected the query language to select T-000001, got %+v", matched)
+	}
+	if matched := all.Apply(tasks); len(matched) != len(tasks) {
+		t.Fatalf("expected all to match every task, got %d", len(matched))
+	}
+	for name, err := range map[string]error{"empty": emptyErr, "sort only": sortErr} {
+		if !errors.Is(err, ErrInvalidFilter) {
+			t.Fatalf("%s: expected ErrInvalidFilter, got %v", name, err)
+		}
+	}
+	if !errors.Is(unknownErr, ErrInvalidQuery) {
+		t.Fatalf("expected ErrInvalidQuery, got %v", unknownErr)
+	}
+}
+
+func TestBulkUpdateDryRunAndApply(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	first, _ := repo.CreateTask(Task{Title: "First", Status: "todo", Priority: 3, Tags: []string{"api", "old"}})
+	second, _ := repo.CreateTask(Task{Title: "Second", Status: "doing", Priority: 1, Tags: []string{"api"}})
+	other, _ := repo.CreateTask(Task{Title: "Other", Status: "todo", Tags: []string{"ui"}})
+	change := BulkChange{Status: "doing", AddTags: []string{"backend"}, RemoveTags: []string{"old"}, Priority: 1}
+	query, err := ParseBulkFilter("tag:api")
+	if err != nil {
+		t.Fatalf("parse filter: %v", err)
+	}
+
+	// Act
+	preview, previewErr := repo.BulkUpdate(query, change, true)
+	unchanged, _ := repo.GetTaskByID(first.ID)
+	applied, applyErr := repo.BulkUpdate(query, change, false)
+	updatedFirst, _ := repo.GetTaskByID(first.ID)
+	updatedSecond, _ := repo.GetTaskByID(second.ID)
+	untouched, _ := repo.GetTaskByID(other.ID)
+
+	// Assert
+	if previewErr != nil || applyErr != nil {
+		t.Fatalf("unexpected errors: %v %v", previewErr, applyErr)
+	}
+	wantChanges := []string{"status todo -> doing", "priority 3 -> 1", "-tag old", "+tag backend"}
+	if len(preview) != 2 || !reflect.DeepEqual(preview[0].Changes, wantChanges) {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+	if !reflect.DeepEqual(preview, applied) {
+		t.Fatalf("expected preview to match applied results:\n%+v\n%+v", preview, applied)
+	}
+	if unchanged.Status != "todo" {
+		t.Fatalf("expected dry run to leave task untouched, got %+v", unchanged)
+	}
+	if updatedFirst.Status != "doing" || updatedFirst.Priority != 1 || !reflect.DeepEqual(updatedFirst.Tags, []string{"api", "backend"}) {
+		t.Fatalf("unexpected first task %+v", updatedFirst)
+	}
+	if !reflect.DeepEqual(updatedSecond.Tags, []string{"api", "backend"}) {
+		t.Fatalf("unexpected second task tags %v", updatedSecond.Tags)
+	}
+	if untouched.Status != "todo" || !reflect.DeepEqual(untouched.Tags, []string{"ui"}) {
+		t.Fatalf("expected unmatched task untouched, got %+v", untouched)
+	}
+}
+
+func TestBulkUpdateRollsBackOnFailure(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	first, _ := repo.CreateTask(Task{Title: "First", Status: "todo"})
+	second, _ := repo.CreateTask(Task{Title: "Second", Status: "todo"})
+	firstContent, err := os.ReadFile(first.FilePath)
+	if err != nil {
+		t.Fatalf("read task: %v", err)
+	}
+	blocker := filepath.Join(repo.archiveTasks, filepath.Base(second.FilePath))
+	if err := os.MkdirAll(blocker, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	// Act
+	_, bulkErr := repo.BulkUpdate(Query{}, BulkChange{Status: "done", Archive: true}, false)
+	restored, readErr := os.ReadFile(first.FilePath)
+	archived, _ := repo.ListArchivedTasks()
+
+	// Assert
+	if bulkErr == nil {
+		t.Fatalf("expected bulk update to fail")
+	}
+	if readErr != nil || string(restored) != string(firstContent) {
+		t.Fatalf("expected first task restored, err=%v content=%q", readErr, restored)
+	}
+	if len(archived) != 0 {
+		t.Fatalf("expected no archived tasks after rollback, got %d", len(archived))
+	}
+}
+
+// truncatingFS fails the first write to failPath after truncating the file, like a write that
+// dies part-way.
+type truncatingFS struct {
+	shared.OSFS
+	failPath string
+	failed   bool
+}
+
+func (f *truncatingFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if name == f.failPath && !f.failed {
+		f.failed = true
+		if err := os.WriteFile(name, data[:len(data)/2], perm); err != nil {
+			return err
+		}
+		return errors.New("disk full")
+	}
+	return f.OSFS.WriteFile(name, data, perm)
+}
+
+func TestBulkUpdateRestoresFileWhoseWriteFailed(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	first, _ := repo.CreateTask(Task{Title: "First", Status: "todo"})
+	second, _ := repo.CreateTask(Task{Title: "Second", Status: "todo"})
+	originals := map[string][]byte{}
+	for _, task := range []Task{first, second} {
+		data, err := os.ReadFile(task.FilePath)
+		if err != nil {
+			t.Fatalf("read task: %v", err)
+		}
+		originals[task.FilePath] = data
+	}
+	restore := shared.UseFiles(&truncatingFS{failPath: second.FilePath})
+	defer restore()
+
+	// Act
+	_, bulkErr := repo.BulkUpdate(Query{}, BulkChange{Status: "done"}, false)
+
+	// Assert
+	if bulkErr == nil {
+		t.Fatalf("expected bulk update to fail")
+	}
+	for path, want := range originals {
+		got, err := os.ReadFile(path)
+		if err != nil || string(got) != string(want) {
+			t.Fatalf("expected %s restored, err=%v content=%q", filepath.Base(path), err, got)
+		}
+	}
+}
+
+func TestBulkUpdateRequiresChange(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+
+	// Act
+	_, err := repo.BulkUpdate(Query{}, BulkChange{}, true)
+	_, priorityErr := repo.BulkUpdate(Query{}, BulkChange{Priority: 7}, true)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidBulk) {
+		t.Fatalf("expected ErrInvalidBulk, got %v", err)
+	}
+	if !errors.Is(priorityErr, ErrInvalidPriority) {
+		t.Fatalf("expected ErrInvalidPriority, got %v", priorityErr)
+	}
+}
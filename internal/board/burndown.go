@@ -0,0 +1,160 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const burndownChartWidth = 40
+
+// BurndownPoint captures scope and remaining work at the end of a single day.
+type BurndownPoint struct {
+	Date      time.Time
+	Scope     float64
+	Remaining float64
+	Done      float64
+}
+
+// BurndownOptions controls the reporting window and completion lookups for Burndown.
+// Completions supplies completion dates (keyed by task ID) for tasks whose frontmatter
+// lacks one, typically resolved from git history.
+type BurndownOptions struct {
+	From        time.Time
+	To          time.Time
+	Archived    []Task
+	Completions map[string]time.Time
+}
+
+// Burndown computes per-day remaining work for the given tasks between opts.From and opts.To
+// (inclusive). Only estimated tasks contribute. A task enters scope on its created date and
+// leaves the remaining total on its completion date; archived tasks always count as completed.
+// Done tasks without any known completion date are treated as completed before the window.
+func Burndown(tasks []Task, opts BurndownOptions) ([]BurndownPoint, error) {
+	from := truncateDay(opts.From)
+	to := truncateDay(opts.To)
+	if from.IsZero() || to.IsZero() {
+		return nil, fmt.Errorf("board: burndown requires from and to dates")
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("board: burndown end %s is before start %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	type entry struct {
+		estimate  float64
+		created   time.Time
+		completed time.Time
+		done      bool
+	}
+	entries := make([]entry, 0, len(tasks)+len(opts.Archived))
+	add := func(task Task, archived bool) {
+		if task.Estimate <= 0 {
+			return
+		}
+		e := entry{estimate: task.Estimate, created: truncateDay(task.Created.Time)}
+		switch {
+		case !task.Completed.IsZero():
+			e.done = true
+			e.completed = truncateDay(task.Completed.Time)
+		case !opts.Completions[task.ID].IsZero():
+			e.done = true
+			e.completed = truncateDay(opts.Completions[task.ID])
+		case archived || IsDoneStatus(task.Status):
+			e.done = true
+		}
+		entries = append(entries, e)
+	}
+	for _, task := range tasks {
+		add(task, false)
+	}
+	for _, task := range opts.Archived {
+		add(task, true)
+	}
+
+	var points []BurndownPoint
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		point := BurndownPoint{Date: day}
+		for _, e := range entries {
+			if !e.created.IsZero() && e.created.After(day) {
+				continue
+			}
+			point.Scope += e.estimate
+			if e.done && !e.completed.After(day) {
+				point.Done += e.estimate
+				continue
+			}
+			point.Remaining += e.estimate
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// FormatBurndownTable renders burndown points into the same ASCII table style as task lists.
+func FormatBurndownTable(points []BurndownPoint, unit string) string {
+	label := EstimateUnitLabel(unit)
+	headers := []string{"Date", "Scope (" + label + ")", "Done (" + label + ")", "Remaining (" + label + ")"}
+	rows := make([][]string, 0, len(points))
+	for _, point := range points {
+		rows = append(rows, []string{
+			point.Date.Format("2006-01-02"),
+			FormatEstimate(point.Scope),
+			FormatEstimate(point.Done),
+			FormatEstimate(point.Remaining),
+		})
+	}
+	return formatTable(headers, rows)
+}
+
+// FormatBurndownChart renders remaining work as a horizontal ASCII bar chart, one row per day.
+func FormatBurndownChart(points []BurndownPoint, unit string) string {
+	peak := 0.0
+	for _, point := range points {
+		if point.Scope > peak {
+			peak = point.Scope
+		}
+	}
+	label := EstimateUnitLabel(unit)
+	var b strings.Builder
+	for i, point := range points {
+		bar := 0
+		if peak > 0 {
+			bar = int(point.Remaining/peak*burndownChartWidth + 0.5)
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s | %s%s %s %s",
+			point.Date.Format("2006-01-02"),
+			strings.Repeat("#", bar),
+			strings.Repeat(" ", burndownChartWidth-bar),
+			FormatEstimate(point.Remaining),
+			label,
+		)
+	}
+	return b.String()
+}
+
+// applyStatusChange sets the task status, stamps when it changed and stamps or clears the
+// completion date when the task enters or leaves a done status.
+func applyStatusChange(task *Task, status string, now time.Time) {
+	wasDone := IsDoneStatus(task.Status)
+	if task.Status != status {
+		task.StatusChanged = now.UTC().Truncate(time.Second)
+	}
+	task.Status = status
+	switch done := IsDoneStatus(status); {
+	case done && (!wasDone || task.Completed.IsZero()):
+		task.Completed = Date{Time: now}
+	case !done:
+		task.Completed = Date{}
+	}
+}
+
+func truncateDay(value time.Time) time.Time {
+	if value.IsZero() {
+		return time.Time{}
+	}
+	year, month, day := value.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,231 @@
+package board
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(value string) time.Time {
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func TestBurndown(t *testing.T) {
+	// Arrange
+	tasks := []Task{
+		{ID: "T-1", Status: "todo", Estimate: 3, Created: Date{Time: day("2026-10-01")}},
+		{ID: "T-2", Status: "done", Estimate: 2, Created: Date{Time: day("2026-10-01")}, Completed: Date{Time: day("2026-10-02")}},
+		{ID: "T-3", Status: "doing", Estimate: 5, Created: Date{Time: day("2026-10-03")}},
+		{ID: "T-4", Status: "done", Estimate: 1, Created: Date{Time: day("2026-10-01")}},
+		{ID: "T-5", Status: "todo", Created: Date{Time: day("2026-10-01")}},
+	}
+	archived := []Task{
+		{ID: "T-6", Status: "done", Estimate: 4, Created: Date{Time: day("2026-10-01")}},
+	}
+
+	// Act
+	points, err := Burndown(tasks, BurndownOptions{
+		From:        day("2026-10-01"),
+		To:          day("2026-10-03"),
+		Archived:    archived,
+		Completions: map[string]time.Time{"T-6": day("2026-10-03")},
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("burndown: %v", err)
+	}
+	want := []BurndownPoint{
+		{Date: day("2026-10-01"), Scope: 10, Done: 1, Remaining: 9},
+		{Date: day("2026-10-02"), Scope: 10, Done: 3, Remaining: 7},
+		{Date: day("2026-10-03"), Scope: 15, Done: 7, Remaining: 8},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("expected %d points, got %d", len(want), len(points))
+	}
+	for i := range want {
+		if !points[i].Date.Equal(want[i].Date) || points[i].Scope != want[i].Scope ||
+			points[i].Done != want[i].Done || points[i].Remaining != want[i].Remaining {
+			t.Fatalf("point %d: expected %+v, got %+v", i, want[i], points[i])
+		}
+	}
+}
+
+func TestBurndownRejectsInvertedRange(t *testing.T) {
+	// Act
+	_, err := Burndown(nil, BurndownOptions{From: day("2026-10-05"), To: day("2026-10-01")})
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected error for inverted range")
+	}
+}
+
+func TestFormatBurndownChart(t *testing.T) {
+	// Arrange
+	points := []BurndownPoint{
+		{Date: day("2026-10-01"), Scope: 4, Remaining: 4},
+		{Date: day("2026-10-02"), Scope: 4, Done: 2, Remaining: 2},
+	}
+
+	// Act
+	chart := FormatBurndownChart(points, EstimateUnitHours)
+
+	// Assert
+	lines := strings.Split(chart, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", chart)
+	}
+	if strings.Count(lines[0], "#") != burndownChartWidth || strings.Count(lines[1], "#") != burndownChartWidth/2 {
+		t.Fatalf("unexpected bar widths: %q", chart)
+	}
+	if !strings.HasSuffix(lines[1], "2 h") {
+		t.Fatalf("expected hours label, got %q", lines[1])
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{input: "", want: 0},
+		{input: "3", want: 3},
+		{input: " 1.5 ", want: 1.5},
+		{input: "-1", wantErr: true},
+		{input: "abc", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseEstimate(tc.input)
+		if tc.wantErr {
+			if !errors.Is(err, ErrInvalidEstimate) {
+				t.Fatalf("%q: expected ErrInvalidEstimate, got %v", tc.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.input, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%q: expected %v, got %v", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestEstimateTotalsByColumn(t *testing.T) {
+	// Arrange
+	columns := []Column{{Key: "todo", Title: "To Do"}, {Key: "doing", Title: "Doing"}, {Key: "done", Title: "Done"}}
+	tasks := []Task{
+		{Status: "todo", Estimate: 2},
+		{Status: "todo", Estimate: 1.5},
+		{Status: "done", Estimate: 3},
+		{Status: "blocked", Estimate: 1},
+	}
+
+	// Act
+	totals := EstimateTotalsByColumn(columns, tasks)
+	line := FormatEstimateTotals(totals, "")
+
+	// Assert
+	if len(totals) != 4 || totals[3].Key != "unknown" {
+		t.Fatalf("expected unknown bucket, got %+v", totals)
+	}
+	if totals[0].Total != 3.5 || totals[0].Count != 2 {
+		t.Fatalf("unexpected todo totals: %+v", totals[0])
+	}
+	want := "Estimates: todo 3.5 pts • done 3 pts • unknown 1 pts • total 7.5 pts"
+	if line != want {
+		t.Fatalf("expected %q, got %q", want, line)
+	}
+}
+
+func TestNormalizeEstimateUnit(t *testing.T) {
+	if unit, err := NormalizeEstimateUnit("H"); err != nil || unit != EstimateUnitHours {
+		t.Fatalf("expected hours, got %q (%v)", unit, err)
+	}
+	if _, err := NormalizeEstimateUnit("days"); !errors.Is(err, ErrInvalidEstimateUnit) {
+		t.Fatalf("expected ErrInvalidEstimateUnit, got %v", err)
+	}
+}
+
+func TestUpdateTaskStatusStampsCompletion(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	repo.now = func() time.Time { return day("2026-10-10") }
+	task, _ := NewTask("Estimated")
+	task.Estimate = 2
+	created, err := repo.CreateTask(task)
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	// Act
+	doneErr := repo.UpdateTaskStatus(created.ID, "done")
+	done, _ := repo.GetTaskByID(created.ID)
+	reopenErr := repo.UpdateTaskStatus(created.ID, "todo")
+	reopened, _ := repo.GetTaskByID(created.ID)
+
+	// Assert
+	if doneErr != nil || reopenErr != nil {
+		t.Fatalf("update status: %v / %v", doneErr, reopenErr)
+	}
+	if done.Estimate != 2 {
+		t.Fatalf("expected estimate to persist, got %v", done.Estimate)
+	}
+	if !done.Completed.Equal(day("2026-10-10")) {
+		t.Fatalf("expected completion stamp, got %v", done.Completed)
+	}
+	if !reopened.Completed.IsZero() {
+		t.Fatalf("expected completion cleared on reopen, got %v", reopened.Completed)
+	}
+}
+
+func TestUpdateTaskEstimate(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	task, _ := NewTask("Estimate me")
+	created, err := repo.CreateTask(task)
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	// Act
+	updateErr := repo.UpdateTaskEstimate(created.ID, 5)
+	invalidErr := repo.UpdateTaskEstimate(created.ID, -2)
+	loaded, _ := repo.GetTaskByID(created.ID)
+
+	// Assert
+	if updateErr != nil {
+		t.Fatalf("update estimate: %v", updateErr)
+	}
+	if !errors.Is(invalidErr, ErrInvalidEstimate) {
+		t.Fatalf("expected ErrInvalidEstimate, got %v", invalidErr)
+	}
+	if loaded.Estimate != 5 {
+		t.Fatalf("expected estimate 5, got %v", loaded.Estimate)
+	}
+}
+
+func TestFormatTasksTableShowsEstimateOnlyWhenUsed(t *testing.T) {
+	// Arrange
+	plain := []Task{{ID: "T-1", Title: "Fix login", Status: "todo", Priority: 2}}
+	estimated := []Task{plain[0], {ID: "T-2", Title: "Write docs", Status: "todo", Priority: 2, Estimate: 3}}
+
+	// Act
+	withoutEstimates := FormatTasksTable(plain)
+	withEstimates := FormatTasksTable(estimated)
+
+	// Assert
+	if strings.Contains(withoutEstimates, "Estimate") {
+		t.Fatalf("expected no Estimate column without estimates:\n%s", withoutEstimates)
+	}
+	if !strings.Contains(withEstimates, "Estimate") {
+		t.Fatalf("expected an Estimate column:\n%s", withEstimates)
+	}
+}
@@ -0,0 +1,102 @@
+package board
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"mochi-sticky/internal/git"
+)
+
+// doneStatusPattern matches a frontmatter line that sets one of the doneStatuses in a git
+// diff, in any letter case as IsDoneStatus accepts. git -G takes POSIX regular expressions,
+// so each letter becomes a bracket expression such as [Dd].
+var doneStatusPattern = func() string {
+	alternatives := make([]string, 0, len(doneStatuses))
+	for _, status := range doneStatuses {
+		var word strings.Builder
+		for _, r := range status {
+			upper, lower := unicode.ToUpper(r), unicode.ToLower(r)
+			if upper == lower {
+				word.WriteString(regexp.QuoteMeta(string(r)))
+				continue
+			}
+			word.WriteString("[" + string(upper) + string(lower) + "]")
+		}
+		alternatives = append(alternatives, word.String())
+	}
+	return `^status:[[:space:]]*["']?(` + strings.Join(alternatives, "|") + `)["']?[[:space:]]*$`
+}()
+
+// GitCompletionDates resolves completion dates for done or archived tasks that lack a
+// `completed` frontmatter value by asking git when the status last flipped to done.
+// Archived tasks without such a commit fall back to the commit that last touched the file.
+// Lookups are best-effort: when git is unavailable or the files are untracked the task is
+// simply omitted from the result. Only ctx cancellation is reported as an error.
+func GitCompletionDates(ctx context.Context, tasks []Task, archived []Task) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	repos := make(map[string]*git.Repo)
+	repoFor := func(path string) (*git.Repo, error) {
+		dir := filepath.Dir(path)
+		if repo, ok := repos[dir]; ok {
+			return repo, nil
+		}
+		// A directory outside git is cached as nil so it is not probed again.
+		repo, err := git.Open(ctx, dir)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			repo = nil
+		}
+		repos[dir] = repo
+		return repo, nil
+	}
+	lookup := func(task Task, isArchived bool) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if !task.Completed.IsZero() || strings.TrimSpace(task.FilePath) == "" {
+			return nil
+		}
+		if !isArchived && !IsDoneStatus(task.Status) {
+			return nil
+		}
+		repo, err := repoFor(task.FilePath)
+		if err != nil || repo == nil {
+			return err
+		}
+		pickaxes := []string{doneStatusPattern}
+		if isArchived {
+			pickaxes = append(pickaxes, "")
+		}
+		for _, pickaxe := range pickaxes {
+			when, ok, err := repo.LastChange(ctx, task.FilePath, pickaxe)
+			if err != nil {
+				// Best-effort: only cancellation is reported.
+				return ctx.Err()
+			}
+			if ok {
+				result[task.ID] = when
+				return nil
+			}
+		}
+		return nil
+	}
+	for _, task := range tasks {
+		if err := lookup(task, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, task := range archived {
+		if err := lookup(task, true); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -28,10 +28,15 @@ type BoardContext struct {
 
 // Config defines the sticky board configuration.
 type Config struct {
-	ConfigVersion int          `yaml:"config_version" json:"config_version"`
-	NextID        int          `yaml:"next_id" json:"next_id"`
-	Columns       []Column     `yaml:"columns" json:"columns"`
-	Context       BoardContext `yaml:"context,omitempty" json:"context,omitempty"`
+	ConfigVersion int                `yaml:"config_version" json:"config_version"`
+	NextID        int                `yaml:"next_id" json:"next_id"`
+	IDStrategy    string             `yaml:"id_strategy,omitempty" json:"id_strategy,omitempty"`
+	Columns       []Column           `yaml:"columns" json:"columns"`
+	EstimateUnit  string             `yaml:"estimate_unit,omitempty" json:"estimate_unit,omitempty"`
+	Lanes         LaneConfig         `yaml:"lanes,omitempty" json:"lanes,omitempty"`
+	Views         []View             `yaml:"views,omitempty" json:"views,omitempty"`
+	Context       BoardContext       `yaml:"context,omitempty" json:"context,omitempty"`
+	QuickCapture  QuickCaptureConfig `yaml:"quick_capture,omitempty" json:"quick_capture,omitempty"`
 }
 
 // DefaultConfig returns the default board configuration.
@@ -113,7 +118,7 @@ func (r *Repository) loadConfigContext(ctx context.Context) (Config, error) {
 		return Config{}, ctx.Err()
 	default:
 	}
-	data, err := os.ReadFile(configPath)
+	data, err := shared.Files().ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return Config{}, fmt.Errorf("board: %w", ErrStoreNotInitialized)
@@ -162,7 +167,7 @@ func (r *Repository) saveConfigContext(ctx context.Context, cfg Config) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(configPath, data, 0o644); err != nil {
 		return fmt.Errorf("board: failed to write config file: %w", err)
 	}
 	return nil
@@ -210,6 +215,9 @@ func normalizeConfig(cfg Config) Config {
 	if cfg.NextID <= 0 {
 		cfg.NextID = 1
 	}
+	cfg.EstimateUnit = strings.ToLower(strings.TrimSpace(cfg.EstimateUnit))
+	cfg.IDStrategy = strings.ToLower(strings.TrimSpace(cfg.IDStrategy))
+	cfg.Lanes.GroupBy = strings.TrimSpace(cfg.Lanes.GroupBy)
 	if len(cfg.Columns) == 0 {
 		cfg.Columns = DefaultConfig().Columns
 		return cfg
@@ -243,3 +251,15 @@ func normalizeConfig(cfg Config) Config {
 	}
 	return cfg
 }
+
+// ValidateStatus reports whether status is a column key of cfg.
+func (cfg Config) ValidateStatus(status string) error {
+	keys := make([]string, 0, len(cfg.Columns))
+	for _, column := range cfg.Columns {
+		if column.Key == status {
+			return nil
+		}
+		keys = append(keys, column.Key)
+	}
+	return fmt.Errorf("board: status %q is not one of %s: %w", status, strings.Join(keys, ", "), ErrInvalidStatus)
+}
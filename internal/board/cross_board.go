@@ -0,0 +1,106 @@
+package board
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SelectBoards returns the boards a cross-board command reads: every non-archived board in
+// registry order when all is set, otherwise the boards named by ids in the given order.
+func (b *BoardRepository) SelectBoards(ids []string, all bool) ([]Board, error) {
+	return b.SelectBoardsContext(context.Background(), ids, all)
+}
+
+// SelectBoardsContext resolves the boards for a cross-board command, honoring ctx cancellation.
+func (b *BoardRepository) SelectBoardsContext(ctx context.Context, ids []string, all bool) ([]Board, error) {
+	registry, err := b.LoadRegistryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if all {
+		boards := make([]Board, 0, len(registry.Boards))
+		for _, board := range registry.Boards {
+			if !board.Archived {
+				boards = append(boards, board)
+			}
+		}
+		return boards, nil
+	}
+	boards := make([]Board, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, raw := range ids {
+		for _, id := range strings.Split(raw, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			board, err := findBoard(registry, id)
+			if err != nil {
+				return nil, fmt.Errorf("board: %s: %w", id, ErrBoardNotFound)
+			}
+			boards = append(boards, board)
+		}
+	}
+	return boards, nil
+}
+
+// CollectTasks reads tasks from every board concurrently.
+func CollectTasks(baseDir, storageRoot string, boards []Board, read func(context.Context, *Repository) ([]Task, error)) ([]Task, error) {
+	return CollectTasksContext(context.Background(), baseDir, storageRoot, boards, read)
+}
+
+// CollectTasksContext runs read against a repository for every board concurrently and
+// concatenates the results in board order, so the output does not depend on which board
+// finishes first. The first failure cancels the remaining reads and is reported in preference
+// to the cancellations it caused.
+func CollectTasksContext(ctx context.Context, baseDir, storageRoot string, boards []Board, read func(context.Context, *Repository) ([]Task, error)) ([]Task, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]Task, len(boards))
+	errs := make([]error, len(boards))
+	var wg sync.WaitGroup
+	for i, board := range boards {
+		wg.Add(1)
+		go func(i int, boardID string) {
+			defer wg.Done()
+			repo, err := NewRepositoryForBoardWithStorage(baseDir, boardID, storageRoot)
+			if err == nil {
+				results[i], err = read(ctx, repo)
+			}
+			if err != nil {
+				errs[i] = fmt.Errorf("board %s: %w", boardID, err)
+				cancel()
+			}
+		}(i, board.ID)
+	}
+	wg.Wait()
+
+	var canceled error
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case errors.Is(err, context.Canceled):
+			if canceled == nil {
+				canceled = err
+			}
+		default:
+			return nil, err
+		}
+	}
+	if canceled != nil {
+		return nil, canceled
+	}
+	var tasks []Task
+	for _, boardTasks := range results {
+		tasks = append(tasks, boardTasks...)
+	}
+	return tasks, nil
+}
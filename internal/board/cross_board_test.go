@@ -0,0 +1,102 @@
+package board
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCollectTasksKeepsBoardOrder(t *testing.T) {
+	// Arrange
+	boardRepo, baseDir, storageRoot := setupBoardRepo(t)
+	work, err := boardRepo.CreateBoard("Work")
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	archived, err := boardRepo.CreateBoard("Old")
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if _, err := boardRepo.ArchiveBoard(archived.ID); err != nil {
+		t.Fatalf("archive board: %v", err)
+	}
+	boards, err := boardRepo.SelectBoards(nil, true)
+	if err != nil {
+		t.Fatalf("select boards: %v", err)
+	}
+	for _, b := range boards {
+		repo, err := NewRepositoryForBoardWithStorage(baseDir, b.ID, storageRoot)
+		if err != nil {
+			t.Fatalf("repo for %s: %v", b.ID, err)
+		}
+		for _, title := range []string{b.ID + " one", b.ID + " two"} {
+			if _, err := repo.CreateTask(Task{Title: title}); err != nil {
+				t.Fatalf("create task: %v", err)
+			}
+		}
+	}
+
+	// Act
+	tasks, err := CollectTasks(baseDir, storageRoot, boards, func(ctx context.Context, repo *Repository) ([]Task, error) {
+		return repo.GetAllTasksContext(ctx)
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("collect tasks: %v", err)
+	}
+	if len(boards) != 2 || boards[1].ID != work.ID {
+		t.Fatalf("expected default and work boards, got %+v", boards)
+	}
+	var got []string
+	for _, task := range tasks {
+		got = append(got, task.BoardID+"/"+task.Title)
+	}
+	want := []string{
+		boards[0].ID + "/" + boards[0].ID + " one", boards[0].ID + "/" + boards[0].ID + " two",
+		work.ID + "/" + work.ID + " one", work.ID + "/" + work.ID + " two",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSelectBoardsAndCollectErrors(t *testing.T) {
+	// Arrange
+	boardRepo, baseDir, storageRoot := setupBoardRepo(t)
+	work, err := boardRepo.CreateBoard("Work")
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	failure := errors.New("boom")
+
+	// Act
+	selected, err := boardRepo.SelectBoards([]string{work.ID + ",default", work.ID}, false)
+	_, missingErr := boardRepo.SelectBoards([]string{"nope"}, false)
+	_, collectErr := CollectTasks(baseDir, storageRoot, selected, func(ctx context.Context, repo *Repository) ([]Task, error) {
+		if repo.BoardID() == "default" {
+			return nil, failure
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("select boards: %v", err)
+	}
+	if len(selected) != 2 || selected[0].ID != work.ID || selected[1].ID != "default" {
+		t.Fatalf("expected boards in flag order without duplicates, got %+v", selected)
+	}
+	if !errors.Is(missingErr, ErrBoardNotFound) {
+		t.Fatalf("expected ErrBoardNotFound, got %v", missingErr)
+	}
+	if !errors.Is(collectErr, failure) {
+		t.Fatalf("expected the failing board's error over cancellations, got %v", collectErr)
+	}
+}
@@ -2,6 +2,7 @@ package board
 
 import (
 	"fmt"
+	"slices"
 )
 
 // IsReady reports whether all dependencies are satisfied (dependents exist and are done).
@@ -13,7 +14,7 @@ func IsReady(task Task, index map[string]Task) (bool, []string) {
 	var unmet []string
 	for _, dep := range task.DependsOn {
 		depTask, ok := index[dep]
-		if !ok || !isDoneStatus(depTask.Status) {
+		if !ok || !IsDoneStatus(depTask.Status) {
 			unmet = append(unmet, dep)
 		}
 	}
@@ -53,13 +54,22 @@ func ValidateNoCycles(tasks []Task) error {
 	return nil
 }
 
-func isDoneStatus(status string) bool {
-	switch normalized := normalizeStatus(status); normalized {
-	case "done", "archived":
-		return true
-	default:
-		return false
+// validateDependencyIDs rejects dependency entries that are not valid task IDs.
+func validateDependencyIDs(deps []string) error {
+	for _, dep := range deps {
+		if err := validateID(dep); err != nil {
+			return fmt.Errorf("board: dependency %q is invalid: %w", dep, ErrInvalidDependency)
+		}
 	}
+	return nil
+}
+
+// doneStatuses are the normalized statuses that count as finished.
+var doneStatuses = []string{"done", "archived"}
+
+// IsDoneStatus reports whether status counts as finished: done or archived.
+func IsDoneStatus(status string) bool {
+	return slices.Contains(doneStatuses, normalizeStatus(status))
 }
 
 func normalizeStatus(status string) string {
@@ -1,6 +1,9 @@
 package board
 
-import "testing"
+import (
+	"regexp"
+	"testing"
+)
 
 func TestIsReadyAndValidateNoCycles(t *testing.T) {
 	tasks := []Task{
@@ -29,3 +32,15 @@ func TestValidateNoCyclesDetectsCycle(t *testing.T) {
 		t.Fatalf("expected cycle error")
 	}
 }
+
+func TestDoneStatusPatternMatchesDoneStatuses(t *testing.T) {
+	pattern := regexp.MustCompile(doneStatusPattern)
+	for _, line := range []string{"status: done", "status: Done", `status: "archived"`} {
+		if !pattern.MatchString(line) {
+			t.Fatalf("expected %q to match %s", line, doneStatusPattern)
+		}
+	}
+	if pattern.MatchString("status: doing") || pattern.MatchString("status: done-ish") {
+		t.Fatalf("expected other statuses not to match %s", doneStatusPattern)
+	}
+}
@@ -21,15 +21,30 @@ func FormatTaskDetail(task Task) string {
 	writeLine("Title", task.Title)
 	writeLine("Status", task.Status)
 	writeLine("Priority", fmt.Sprintf("%d", effectivePriority(task.Priority)))
+	if task.Estimate > 0 {
+		writeLine("Estimate", FormatEstimate(task.Estimate))
+	}
+	writeLine("Sprint", task.Sprint)
+	writeLine("Assignee", task.Assignee)
+	writeLine("Branch", task.Branch)
 	if len(task.Tags) > 0 {
 		writeLine("Tags", strings.Join(task.Tags, ", "))
 	}
 	if len(task.DependsOn) > 0 {
 		writeLine("Depends On", strings.Join(task.DependsOn, ", "))
 	}
+	for _, key := range sortedFieldKeys(task.Fields) {
+		writeLine(key, task.Fields[key])
+	}
 	if !task.Created.IsZero() {
 		writeLine("Created", task.Created.Format("2006-01-02"))
 	}
+	if !task.Completed.IsZero() {
+		writeLine("Completed", task.Completed.Format("2006-01-02"))
+	}
+	if !task.Due.IsZero() {
+		writeLine("Due", task.Due.Format("2006-01-02"))
+	}
 	writeLine("Path", task.FilePath)
 
 	if strings.TrimSpace(task.Content) != "" {
@@ -43,6 +58,57 @@ func FormatTaskDetail(task Task) string {
 	return b.String()
 }
 
+// FormatSprintDetail renders a sprint's metadata, its closing summary (when closed) and the
+// estimate totals of the tasks assigned to it.
+func FormatSprintDetail(sprint Sprint, tasks []Task, unit string) string {
+	var b strings.Builder
+	writeLine := func(label, value string) {
+		if strings.TrimSpace(value) == "" {
+			return
+		}
+		fmt.Fprintf(&b, "%s: %s\n", label, value)
+	}
+	label := EstimateUnitLabel(unit)
+
+	writeLine("ID", sprint.ID)
+	writeLine("Name", sprint.Name)
+	writeLine("State", sprint.State)
+	writeLine("Goal", sprint.Goal)
+	if !sprint.Start.IsZero() {
+		writeLine("Start", sprint.Start.Format("2006-01-02"))
+	}
+	if !sprint.End.IsZero() {
+		writeLine("End", sprint.End.Format("2006-01-02"))
+	}
+	done := 0
+	doneEstimate := 0.0
+	for _, task := range tasks {
+		if IsDoneStatus(task.Status) {
+			done++
+			doneEstimate += task.Estimate
+		}
+	}
+	writeLine("Tasks", fmt.Sprintf("%d (%d done)", len(tasks), done))
+	if HasEstimates(tasks) {
+		writeLine("Estimate", fmt.Sprintf("%s %s (%s %s done)", FormatEstimate(SumEstimates(tasks)), label, FormatEstimate(doneEstimate), label))
+	}
+	if summary := sprint.Summary; summary != nil {
+		b.WriteString("\nSummary\n")
+		writeLine("Closed", summary.Closed.Format("2006-01-02"))
+		writeLine("Committed", fmt.Sprintf("%d tasks, %s %s", summary.Committed, FormatEstimate(summary.CommittedEstimate), label))
+		writeLine("Completed", fmt.Sprintf("%d tasks", summary.Completed))
+		writeLine("Velocity", fmt.Sprintf("%s %s", FormatEstimate(summary.Velocity), label))
+		if len(summary.RolledOver) > 0 {
+			target := summary.RolledTo
+			if target == "" {
+				target = "backlog"
+			}
+			writeLine("Rolled Over", fmt.Sprintf("%s -> %s", strings.Join(summary.RolledOver, ", "), target))
+		}
+	}
+	return b.String()
+}
+
 // TaskBoardLabel returns the most descriptive board name for the task.
 func TaskBoardLabel(task Task) string {
 	if trimmed := strings.TrimSpace(task.BoardName); trimmed != "" {
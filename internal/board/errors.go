@@ -25,4 +25,34 @@ var (
 	ErrInvalidPriority = errors.New("invalid priority")
 	// ErrInvalidDependency indicates dependency list is invalid (cycle or bad id).
 	ErrInvalidDependency = errors.New("invalid dependency")
+	// ErrInvalidStatus indicates a task status that is not a column of its board.
+	ErrInvalidStatus = errors.New("invalid status")
+	// ErrInvalidDue indicates a due date that is not a YYYY-MM-DD date.
+	ErrInvalidDue = errors.New("invalid due date")
+	// ErrInvalidEstimate indicates a task estimate is negative or not a number.
+	ErrInvalidEstimate = errors.New("invalid estimate")
+	// ErrInvalidEstimateUnit indicates an unsupported estimate unit in the board config.
+	ErrInvalidEstimateUnit = errors.New("invalid estimate unit")
+	// ErrInvalidIDStrategy indicates an unsupported id_strategy in the board config.
+	ErrInvalidIDStrategy = errors.New("invalid id strategy")
+	// ErrSprintNotFound indicates a sprint with the given ID does not exist on the board.
+	ErrSprintNotFound = errors.New("sprint not found")
+	// ErrInvalidSprint indicates sprint fields (name, ID or dates) are invalid.
+	ErrInvalidSprint = errors.New("invalid sprint")
+	// ErrSprintState indicates a sprint transition is not allowed from its current state.
+	ErrSprintState = errors.New("invalid sprint state")
+	// ErrInvalidRank indicates a malformed rank or an impossible rank placement.
+	ErrInvalidRank = errors.New("invalid rank")
+	// ErrInvalidFilter indicates a malformed task filter expression.
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrInvalidQuery indicates a task query that failed to parse; see QueryError for the position.
+	ErrInvalidQuery = errors.New("invalid query")
+	// ErrInvalidBulk indicates a bulk operation without any requested change.
+	ErrInvalidBulk = errors.New("invalid bulk operation")
+	// ErrInvalidLaneGroup indicates an unsupported swimlane grouping spec.
+	ErrInvalidLaneGroup = errors.New("invalid lane grouping")
+	// ErrViewNotFound indicates a saved view with the given name does not exist on the board.
+	ErrViewNotFound = errors.New("view not found")
+	// ErrInvalidView indicates a saved view with a missing name or an invalid query, column or grouping.
+	ErrInvalidView = errors.New("invalid view")
 )
@@ -0,0 +1,161 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EstimateUnitPoints measures task estimates in story points (default).
+	EstimateUnitPoints = "points"
+	// EstimateUnitHours measures task estimates in hours.
+	EstimateUnitHours = "hours"
+)
+
+// ColumnEstimate captures the number of tasks and the summed estimate for a column.
+type ColumnEstimate struct {
+	Key   string
+	Title string
+	Count int
+	Total float64
+}
+
+// NormalizeEstimateUnit validates an estimate unit, defaulting to points when empty.
+func NormalizeEstimateUnit(unit string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", EstimateUnitPoints, "point", "pts", "sp":
+		return EstimateUnitPoints, nil
+	case EstimateUnitHours, "hour", "h":
+		return EstimateUnitHours, nil
+	default:
+		return "", fmt.Errorf("board: %q: %w", unit, ErrInvalidEstimateUnit)
+	}
+}
+
+// EstimateUnitLabel returns the short suffix used when printing estimates (pts or h).
+func EstimateUnitLabel(unit string) string {
+	normalized, err := NormalizeEstimateUnit(unit)
+	if err != nil {
+		return strings.TrimSpace(unit)
+	}
+	if normalized == EstimateUnitHours {
+		return "h"
+	}
+	return "pts"
+}
+
+// ParseEstimate parses a user-supplied estimate (e.g. "3", "1.5").
+func ParseEstimate(input string) (float64, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil {
+		return 0, fmt.Errorf("board: %q: %w", input, ErrInvalidEstimate)
+	}
+	return normalizeEstimate(value)
+}
+
+// FormatEstimate renders an estimate without trailing zeros (3, 1.5).
+func FormatEstimate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// SumEstimates returns the total estimate across tasks.
+func SumEstimates(tasks []Task) float64 {
+	total := 0.0
+	for _, task := range tasks {
+		total += task.Estimate
+	}
+	return total
+}
+
+// HasEstimates reports whether any task carries a non-zero estimate.
+func HasEstimates(tasks []Task) bool {
+	for _, task := range tasks {
+		if task.Estimate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// EstimateTotalsByColumn groups tasks by status in column order and sums their estimates.
+// Tasks whose status does not match a configured column are reported under "unknown".
+func EstimateTotalsByColumn(columns []Column, tasks []Task) []ColumnEstimate {
+	totals := make([]ColumnEstimate, 0, len(columns))
+	index := make(map[string]int, len(columns))
+	for _, column := range columns {
+		index[strings.ToLower(column.Key)] = len(totals)
+		totals = append(totals, ColumnEstimate{Key: column.Key, Title: column.Title})
+	}
+	unknown := -1
+	for _, task := range tasks {
+		idx, ok := index[strings.ToLower(task.Status)]
+		if !ok {
+			if unknown == -1 {
+				totals = append(totals, ColumnEstimate{Key: "unknown", Title: "Unknown"})
+				unknown = len(totals) - 1
+			}
+			idx = unknown
+		}
+		totals[idx].Count++
+		totals[idx].Total += task.Estimate
+	}
+	return totals
+}
+
+// FormatEstimateTotals renders per-column totals as a single summary line.
+func FormatEstimateTotals(totals []ColumnEstimate, unit string) string {
+	label := EstimateUnitLabel(unit)
+	parts := make([]string, 0, len(totals))
+	sum := 0.0
+	for _, total := range totals {
+		if total.Count == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %s", total.Key, FormatEstimate(total.Total), label))
+		sum += total.Total
+	}
+	parts = append(parts, fmt.Sprintf("total %s %s", FormatEstimate(sum), label))
+	return "Estimates: " + strings.Join(parts, " • ")
+}
+
+// UpdateTaskEstimate sets a task's estimate by ID (0 clears it).
+func (r *Repository) UpdateTaskEstimate(id string, estimate float64) error {
+	return r.UpdateTaskEstimateContext(context.Background(), id, estimate)
+}
+
+// UpdateTaskEstimateContext sets a task's estimate by ID, honoring ctx cancellation.
+func (r *Repository) UpdateTaskEstimateContext(ctx context.Context, id string, estimate float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if err := validateID(id); err != nil {
+		return err
+	}
+	normalized, err := normalizeEstimate(estimate)
+	if err != nil {
+		return err
+	}
+	return r.updateTaskLockedContext(ctx, id, func(task *Task) error {
+		task.Estimate = normalized
+		return nil
+	})
+}
+
+func normalizeEstimate(value float64) (float64, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) || value < 0 {
+		return 0, fmt.Errorf("board: %w", ErrInvalidEstimate)
+	}
+	return value, nil
+}
@@ -0,0 +1,245 @@
+package board
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatusTransition records a task entering a status.
+type StatusTransition struct {
+	Status string
+	At     time.Time
+}
+
+// FlowOptions controls the reporting window and history lookups for Flow. Histories supplies
+// git timelines (keyed by task ID, see TaskHistoriesContext); tasks without one fall back to
+// their created, status_changed and completed frontmatter.
+type FlowOptions struct {
+	From      time.Time
+	To        time.Time
+	Columns   []string
+	Archived  []Task
+	Histories map[string][]TaskChange
+}
+
+// CycleTime is how long a task completed within the window took from leaving the first
+// column to reaching done.
+type CycleTime struct {
+	ID        string
+	Title     string
+	Started   time.Time
+	Completed time.Time
+	Days      float64
+}
+
+// FlowDay counts tasks per status at the end of a single day.
+type FlowDay struct {
+	Date   time.Time
+	Counts map[string]int
+}
+
+// FlowReport holds cycle times and cumulative flow for a board.
+type FlowReport struct {
+	CycleTimes []CycleTime
+	Average    float64
+	Median     float64
+	P85        float64
+	// Statuses lists the column keys followed by any other status a task passed through.
+	Statuses   []string
+	Cumulative []FlowDay
+}
+
+// StatusTimeline returns the statuses a task went through, oldest first. Git history is
+// preferred; the created frontmatter date moves the first transition earlier when the task
+// predates its first commit, and the completed date pins the final move into done. Without
+// history the task is assumed to start in initial on its created date and to reach its
+// current status at status_changed (or completed for done tasks).
+func StatusTimeline(task Task, history []TaskChange, initial string) []StatusTransition {
+	timeline := make([]StatusTransition, 0)
+	for _, change := range history {
+		for _, field := range change.Changes {
+			if field.Field == "status" {
+				timeline = append(timeline, StatusTransition{Status: field.To, At: change.Commit.Date})
+			}
+		}
+	}
+	created := task.Created.Time
+	if len(timeline) == 0 {
+		reached := task.StatusChanged
+		if IsDoneStatus(task.Status) && !task.Completed.IsZero() {
+			reached = task.Completed.Time
+		}
+		if reached.IsZero() {
+			reached = created
+		}
+		if !created.IsZero() && initial != "" && task.Status != initial && created.Before(reached) {
+			timeline = append(timeline, StatusTransition{Status: initial, At: created})
+		}
+		if !reached.IsZero() {
+			timeline = append(timeline, StatusTransition{Status: task.Status, At: reached})
+		}
+		return timeline
+	}
+	if !created.IsZero() && truncateDay(created).Before(truncateDay(timeline[0].At)) {
+		timeline[0].At = created
+	}
+	// completed only has day precision, so it wins only when it names a different day than the
+	// commit, such as a task completed before it was committed, and never reorders transitions.
+	last := len(timeline) - 1
+	completed := truncateDay(task.Completed.Time)
+	if IsDoneStatus(timeline[last].Status) && !completed.IsZero() && !completed.Equal(truncateDay(timeline[last].At)) &&
+		(last == 0 || completed.After(timeline[last-1].At)) {
+		timeline[last].At = completed
+	}
+	return timeline
+}
+
+// Flow computes cycle times for tasks completed between opts.From and opts.To (inclusive)
+// and the number of tasks per status at the end of each day of the window. Cycle time runs
+// from the first transition out of the first column to the last transition into done.
+func Flow(tasks []Task, opts FlowOptions) (FlowReport, error) {
+	from := truncateDay(opts.From)
+	to := truncateDay(opts.To)
+	if from.IsZero() || to.IsZero() {
+		return FlowReport{}, fmt.Errorf("board: flow requires from and to dates")
+	}
+	if to.Before(from) {
+		return FlowReport{}, fmt.Errorf("board: flow end %s is before start %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+	initial := ""
+	if len(opts.Columns) > 0 {
+		initial = opts.Columns[0]
+	}
+	end := to.AddDate(0, 0, 1)
+
+	report := FlowReport{Statuses: append([]string(nil), opts.Columns...), CycleTimes: make([]CycleTime, 0)}
+	known := make(map[string]bool, len(opts.Columns))
+	for _, status := range opts.Columns {
+		known[status] = true
+	}
+	all := append(append([]Task(nil), tasks...), opts.Archived...)
+	timelines := make([][]StatusTransition, 0, len(all))
+	for _, task := range all {
+		timeline := StatusTimeline(task, opts.Histories[task.ID], initial)
+		timelines = append(timelines, timeline)
+		for _, transition := range timeline {
+			if !known[transition.Status] {
+				known[transition.Status] = true
+				report.Statuses = append(report.Statuses, transition.Status)
+			}
+		}
+		if cycle, ok := cycleTime(task, timeline, initial); ok && !cycle.Completed.Before(from) && cycle.Completed.Before(end) {
+			report.CycleTimes = append(report.CycleTimes, cycle)
+		}
+	}
+	sort.SliceStable(report.CycleTimes, func(i, j int) bool {
+		return report.CycleTimes[i].Completed.Before(report.CycleTimes[j].Completed)
+	})
+	report.Average, report.Median, report.P85 = cycleTimeStats(report.CycleTimes)
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		point := FlowDay{Date: day, Counts: make(map[string]int, len(report.Statuses))}
+		for _, status := range report.Statuses {
+			point.Counts[status] = 0
+		}
+		dayEnd := day.AddDate(0, 0, 1)
+		for _, timeline := range timelines {
+			status := ""
+			for _, transition := range timeline {
+				if !transition.At.Before(dayEnd) {
+					break
+				}
+				status = transition.Status
+			}
+			if status != "" {
+				point.Counts[status]++
+			}
+		}
+		report.Cumulative = append(report.Cumulative, point)
+	}
+	return report, nil
+}
+
+func cycleTime(task Task, timeline []StatusTransition, initial string) (CycleTime, bool) {
+	if len(timeline) == 0 || !IsDoneStatus(timeline[len(timeline)-1].Status) {
+		return CycleTime{}, false
+	}
+	cycle := CycleTime{ID: task.ID, Title: task.Title}
+	for _, transition := range timeline {
+		if transition.Status != initial {
+			cycle.Started = transition.At
+			break
+		}
+	}
+	for i := len(timeline) - 1; i > 0 && IsDoneStatus(timeline[i-1].Status); i-- {
+		// A move from done to archived is not a new completion.
+		timeline = timeline[:i]
+	}
+	cycle.Completed = timeline[len(timeline)-1].At
+	cycle.Days = math.Round(cycle.Completed.Sub(cycle.Started).Hours()/24*10) / 10
+	return cycle, true
+}
+
+// cycleTimeStats returns the average, median and 85th percentile (nearest rank) in days.
+func cycleTimeStats(cycles []CycleTime) (float64, float64, float64) {
+	if len(cycles) == 0 {
+		return 0, 0, 0
+	}
+	days := make([]float64, 0, len(cycles))
+	total := 0.0
+	for _, cycle := range cycles {
+		days = append(days, cycle.Days)
+		total += cycle.Days
+	}
+	sort.Float64s(days)
+	median := days[len(days)/2]
+	if len(days)%2 == 0 {
+		median = (days[len(days)/2-1] + days[len(days)/2]) / 2
+	}
+	p85 := days[int(math.Ceil(0.85*float64(len(days))))-1]
+	return math.Round(total/float64(len(days))*10) / 10, median, p85
+}
+
+// FormatFlowTable renders cycle times, their summary and cumulative flow as ASCII tables.
+func FormatFlowTable(report FlowReport) string {
+	rows := make([][]string, 0, len(report.CycleTimes))
+	for _, cycle := range report.CycleTimes {
+		rows = append(rows, []string{
+			cycle.ID,
+			cycle.Title,
+			cycle.Started.Format("2006-01-02"),
+			cycle.Completed.Format("2006-01-02"),
+			formatDays(cycle.Days),
+		})
+	}
+	var b strings.Builder
+	b.WriteString("Cycle time:\n")
+	if len(rows) == 0 {
+		b.WriteString("  (no tasks completed in this window)\n")
+	} else {
+		b.WriteString(formatTable([]string{"ID", "Title", "Started", "Completed", "Days"}, rows))
+		fmt.Fprintf(&b, "\nAverage %s days, median %s, 85th percentile %s\n",
+			formatDays(report.Average), formatDays(report.Median), formatDays(report.P85))
+	}
+
+	headers := append([]string{"Date"}, report.Statuses...)
+	rows = make([][]string, 0, len(report.Cumulative))
+	for _, day := range report.Cumulative {
+		row := []string{day.Date.Format("2006-01-02")}
+		for _, status := range report.Statuses {
+			row = append(row, strconv.Itoa(day.Counts[status]))
+		}
+		rows = append(rows, row)
+	}
+	b.WriteString("\nCumulative flow:\n")
+	b.WriteString(formatTable(headers, rows))
+	return b.String()
+}
+
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', 1, 64)
+}
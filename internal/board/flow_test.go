@@ -0,0 +1,78 @@
+package board
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mochi-sticky/internal/git"
+)
+
+func statusChange(at time.Time, from, to string) TaskChange {
+	return TaskChange{Commit: git.Commit{Date: at}, Created: from == "", Changes: []FieldChange{{Field: "status", From: from, To: to}}}
+}
+
+func TestFlow(t *testing.T) {
+	// Arrange
+	tasks := []Task{
+		{ID: "T-1", Title: "From git", Status: "done", Created: Date{Time: day("2026-10-01")}},
+		{ID: "T-2", Title: "Frontmatter only", Status: "done", Created: Date{Time: day("2026-10-02")}, Completed: Date{Time: day("2026-10-03")}},
+		{ID: "T-3", Title: "Waiting", Status: "todo", Created: Date{Time: day("2026-10-02")}},
+	}
+	archived := []Task{{ID: "T-4", Title: "Old", Status: "done", Created: Date{Time: day("2026-09-01")}}}
+	histories := map[string][]TaskChange{
+		"T-1": {
+			statusChange(day("2026-10-01").Add(9*time.Hour), "", "todo"),
+			statusChange(day("2026-10-02").Add(9*time.Hour), "todo", "doing"),
+			statusChange(day("2026-10-04").Add(21*time.Hour), "doing", "review"),
+			statusChange(day("2026-10-05").Add(9*time.Hour), "review", "done"),
+		},
+		"T-4": {
+			statusChange(day("2026-09-01"), "", "todo"),
+			statusChange(day("2026-09-02"), "todo", "done"),
+		},
+	}
+
+	// Act
+	report, err := Flow(tasks, FlowOptions{
+		From:      day("2026-10-01"),
+		To:        day("2026-10-05"),
+		Columns:   []string{"todo", "doing", "done"},
+		Archived:  archived,
+		Histories: histories,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("flow: %v", err)
+	}
+	if len(report.CycleTimes) != 2 || report.CycleTimes[0].ID != "T-2" || report.CycleTimes[1].ID != "T-1" {
+		t.Fatalf("expected T-2 then T-1 completed in the window, got %+v", report.CycleTimes)
+	}
+	if report.CycleTimes[0].Days != 0 || report.CycleTimes[1].Days != 3 {
+		t.Fatalf("expected cycle times of 0 and 3 days, got %+v", report.CycleTimes)
+	}
+	if report.Average != 1.5 || report.Median != 1.5 || report.P85 != 3 {
+		t.Fatalf("unexpected stats: average %v median %v p85 %v", report.Average, report.Median, report.P85)
+	}
+	if strings.Join(report.Statuses, ",") != "todo,doing,done,review" {
+		t.Fatalf("expected columns followed by other statuses, got %v", report.Statuses)
+	}
+	first, last := report.Cumulative[0].Counts, report.Cumulative[4].Counts
+	if first["todo"] != 1 || first["done"] != 1 || first["doing"] != 0 {
+		t.Fatalf("unexpected counts on the first day: %v", first)
+	}
+	if last["todo"] != 1 || last["done"] != 3 || last["review"] != 0 {
+		t.Fatalf("unexpected counts on the last day: %v", last)
+	}
+}
+
+func TestFlowRejectsInvertedRange(t *testing.T) {
+	// Act
+	_, err := Flow(nil, FlowOptions{From: day("2026-10-05"), To: day("2026-10-01")})
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected an error for an end before the start")
+	}
+}
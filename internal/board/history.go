@@ -0,0 +1,181 @@
+package board
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mochi-sticky/internal/git"
+)
+
+// FieldChange is a change to one task field between two revisions of its file. Tags are
+// comma-separated; Field "path" records the file moving, such as into archive/tasks.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to"`
+}
+
+// TaskChange is a commit that changed a task's status, priority, tags, title or location.
+// The commit that added the file is marked Created and lists the initial values.
+type TaskChange struct {
+	Commit  git.Commit    `json:"commit"`
+	Path    string        `json:"path"`
+	Created bool          `json:"created,omitempty"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// TaskHistory is the timeline of a task reconstructed from the git history of its file.
+type TaskHistory struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Archived bool         `json:"archived,omitempty"`
+	Changes  []TaskChange `json:"changes"`
+}
+
+// TaskChanges reconstructs the changes to a task's tracked fields from the revisions of its
+// file, oldest first (see git.Repo.FileHistory). Revisions that do not parse, such as ones
+// committed with conflict markers, are skipped, as are commits that only touched other
+// fields or the body.
+func TaskChanges(revisions []git.FileRevision) []TaskChange {
+	parser := &Parser{}
+	changes := make([]TaskChange, 0)
+	var previous *Task
+	previousPath := ""
+	for _, revision := range revisions {
+		if revision.Data == nil {
+			previous = nil
+			continue
+		}
+		task, err := parser.Parse(revision.Data)
+		if err != nil {
+			continue
+		}
+		change := TaskChange{Commit: revision.Commit, Path: revision.Path}
+		if previous == nil {
+			change.Created = true
+			change.Changes = []FieldChange{
+				{Field: "title", To: task.Title},
+				{Field: "status", To: task.Status},
+				{Field: "priority", To: strconv.Itoa(task.Priority)},
+			}
+			if len(task.Tags) > 0 {
+				change.Changes = append(change.Changes, FieldChange{Field: "tags", To: strings.Join(task.Tags, ",")})
+			}
+		} else {
+			change.Changes = diffTrackedFields(*previous, task)
+			if path.Dir(previousPath) != path.Dir(revision.Path) {
+				change.Changes = append(change.Changes, FieldChange{Field: "path", From: previousPath, To: revision.Path})
+			}
+		}
+		previous, previousPath = &task, revision.Path
+		if len(change.Changes) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func diffTrackedFields(before, after Task) []FieldChange {
+	changes := make([]FieldChange, 0)
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+	add("title", before.Title, after.Title)
+	add("status", before.Status, after.Status)
+	add("priority", strconv.Itoa(before.Priority), strconv.Itoa(after.Priority))
+	add("tags", strings.Join(before.Tags, ","), strings.Join(after.Tags, ","))
+	return changes
+}
+
+// TaskHistory returns the git timeline of an active or archived task; see
+// TaskHistoryContext.
+func (r *Repository) TaskHistory(id string) (TaskHistory, error) {
+	return r.TaskHistoryContext(context.Background(), id)
+}
+
+// TaskHistoryContext returns the timeline of an active or archived task from the git history
+// of its file, following renames such as the move into archive/tasks. It fails with
+// git.ErrNotRepository when the board is not in a git working tree and honors ctx
+// cancellation.
+func (r *Repository) TaskHistoryContext(ctx context.Context, id string) (TaskHistory, error) {
+	task, archived, err := r.findActiveOrArchivedTaskContext(ctx, id)
+	if err != nil {
+		return TaskHistory{}, err
+	}
+	repo, err := git.Open(ctx, r.tasksDir)
+	if err != nil {
+		return TaskHistory{}, err
+	}
+	return taskHistory(ctx, repo, task, archived)
+}
+
+// TaskHistories returns the git timeline of every task; see TaskHistoriesContext.
+func (r *Repository) TaskHistories() ([]TaskHistory, error) {
+	return r.TaskHistoriesContext(context.Background())
+}
+
+// TaskHistoriesContext returns the git timelines of every active and archived task, ordered
+// by ID. It fails with git.ErrNotRepository when the board is not in a git working tree and
+// honors ctx cancellation.
+func (r *Repository) TaskHistoriesContext(ctx context.Context) ([]TaskHistory, error) {
+	tasks, err := r.GetAllTasksContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := r.ListArchivedTasksContext(ctx)
+	if err != nil && !errors.Is(err, ErrStoreNotInitialized) {
+		return nil, err
+	}
+	repo, err := git.Open(ctx, r.tasksDir)
+	if err != nil {
+		return nil, err
+	}
+	histories := make([]TaskHistory, 0, len(tasks)+len(archived))
+	for i, task := range append(tasks, archived...) {
+		history, err := taskHistory(ctx, repo, task, i >= len(tasks))
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, history)
+	}
+	sort.SliceStable(histories, func(i, j int) bool { return histories[i].ID < histories[j].ID })
+	return histories, nil
+}
+
+func taskHistory(ctx context.Context, repo *git.Repo, task Task, archived bool) (TaskHistory, error) {
+	revisions, err := repo.FileHistory(ctx, task.FilePath)
+	if err != nil {
+		return TaskHistory{}, fmt.Errorf("board: history of %s: %w", task.ID, err)
+	}
+	return TaskHistory{ID: task.ID, Title: task.Title, Archived: archived, Changes: TaskChanges(revisions)}, nil
+}
+
+// findActiveOrArchivedTaskContext looks for the task in the active tasks directory first and
+// then in archive/tasks, reporting whether it was archived.
+func (r *Repository) findActiveOrArchivedTaskContext(ctx context.Context, id string) (Task, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := validateID(id); err != nil {
+		return Task{}, false, err
+	}
+	if err := ensureDirExists(r.tasksDir); err != nil {
+		return Task{}, false, err
+	}
+	_, task, err := r.findTaskFileLockedContext(ctx, r.tasksDir, id)
+	if err == nil || !errors.Is(err, ErrTaskNotFound) {
+		return task, false, err
+	}
+	if ensureDirExists(r.archiveTasks) != nil {
+		return Task{}, false, err
+	}
+	_, task, err = r.findTaskFileLockedContext(ctx, r.archiveTasks, id)
+	return task, err == nil, err
+}
@@ -0,0 +1,45 @@
+package board
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"mochi-sticky/internal/git"
+)
+
+func taskRevision(hash, author, path string, at time.Time, status string, priority int, tags string) git.FileRevision {
+	data := fmt.Sprintf("---\nid: T-1\ntitle: Task\nstatus: %s\npriority: %d\ntags: [%s]\ncreated: 2026-10-01\n---\n", status, priority, tags)
+	return git.FileRevision{Commit: git.Commit{Hash: hash, Author: author, Date: at}, Path: path, Data: []byte(data)}
+}
+
+func TestTaskChanges(t *testing.T) {
+	// Arrange
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	revisions := []git.FileRevision{
+		taskRevision("a", "Alice", "tasks/T-1.md", at, "todo", 2, ""),
+		taskRevision("b", "Bob", "tasks/T-1.md", at.Add(time.Hour), "doing", 1, "api"),
+		{Commit: git.Commit{Hash: "c"}, Path: "tasks/T-1.md", Data: []byte("<<<<<<< ours\n")},
+		taskRevision("d", "Alice", "tasks/T-1.md", at.Add(2*time.Hour), "doing", 1, "api"),
+		taskRevision("e", "Alice", "archive/tasks/T-1.md", at.Add(3*time.Hour), "done", 1, "api"),
+	}
+
+	// Act
+	changes := TaskChanges(revisions)
+
+	// Assert
+	if len(changes) != 3 {
+		t.Fatalf("expected created, edit and archive changes, got %+v", changes)
+	}
+	if !changes[0].Created || changes[0].Commit.Author != "Alice" || len(changes[0].Changes) != 3 {
+		t.Fatalf("expected a creation with initial values, got %+v", changes[0])
+	}
+	want := []FieldChange{{Field: "status", From: "todo", To: "doing"}, {Field: "priority", From: "2", To: "1"}, {Field: "tags", To: "api"}}
+	if fmt.Sprint(changes[1].Changes) != fmt.Sprint(want) || changes[1].Commit.Author != "Bob" {
+		t.Fatalf("expected %v by Bob, got %+v", want, changes[1])
+	}
+	moved := changes[2].Changes
+	if len(moved) != 2 || moved[0].Field != "status" || moved[1] != (FieldChange{Field: "path", From: "tasks/T-1.md", To: "archive/tasks/T-1.md"}) {
+		t.Fatalf("expected the status change and move into the archive, got %+v", moved)
+	}
+}
@@ -0,0 +1,105 @@
+package board
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// IDStrategySequential numbers tasks T-000001, T-000002, ... from next_id (default).
+	IDStrategySequential = "sequential"
+	// IDStrategyHash names tasks after a short hash of their UID, e.g. T-3F9C2AB.
+	IDStrategyHash = "hash"
+	// IDStrategyULID names tasks with a ULID, which sorts by creation time.
+	IDStrategyULID = "ulid"
+)
+
+// minHashIDLength is the number of hex digits of a hash ID; longer prefixes are used only
+// when a shorter one is taken.
+const minHashIDLength = 7
+
+// crockford is the Crockford base32 alphabet ULIDs are written in.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NormalizeIDStrategy validates a board's id_strategy, defaulting to sequential when empty.
+func NormalizeIDStrategy(strategy string) (string, error) {
+	switch value := strings.ToLower(strings.TrimSpace(strategy)); value {
+	case "", IDStrategySequential:
+		return IDStrategySequential, nil
+	case IDStrategyHash, IDStrategyULID:
+		return value, nil
+	default:
+		return "", fmt.Errorf("board: %q: %w", strategy, ErrInvalidIDStrategy)
+	}
+}
+
+// ShortUID returns the first eight characters of a task UID, enough to tell two tasks that
+// share an ID apart.
+func ShortUID(uid string) string {
+	if len(uid) > 8 {
+		return uid[:8]
+	}
+	return uid
+}
+
+// allocateID returns a new task ID under the config's id_strategy that is not in taken. The
+// sequential strategy advances config.NextID past the returned ID; the caller saves it.
+func allocateID(config *Config, uid string, now time.Time, taken func(string) bool) (string, error) {
+	strategy, err := NormalizeIDStrategy(config.IDStrategy)
+	if err != nil {
+		return "", err
+	}
+	switch strategy {
+	case IDStrategyHash:
+		sum := sha256.Sum256([]byte(uid))
+		digits := strings.ToUpper(hex.EncodeToString(sum[:]))
+		for length := minHashIDLength; length <= len(digits); length++ {
+			if id := "T-" + digits[:length]; !taken(id) {
+				return id, nil
+			}
+		}
+		return "", fmt.Errorf("board: no free hash id for uid %s: %w", uid, ErrInvalidID)
+	case IDStrategyULID:
+		for {
+			id, err := newULID(now)
+			if err != nil {
+				return "", fmt.Errorf("board: failed to generate task id: %w", err)
+			}
+			if !taken("T-" + id) {
+				return "T-" + id, nil
+			}
+		}
+	default:
+		for {
+			id := formatSequentialID(config.NextID)
+			config.NextID++
+			if !taken(id) {
+				return id, nil
+			}
+		}
+	}
+}
+
+// newULID returns a ULID: 48 bits of milliseconds since the epoch followed by 80 random bits,
+// as 26 Crockford base32 characters.
+func newULID(now time.Time) (string, error) {
+	var raw [16]byte
+	binary.BigEndian.PutUint64(raw[:8], uint64(now.UnixMilli())<<16)
+	if _, err := rand.Read(raw[6:]); err != nil {
+		return "", err
+	}
+	hi := binary.BigEndian.Uint64(raw[:8])
+	lo := binary.BigEndian.Uint64(raw[8:])
+	var out [26]byte
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:]), nil
+}
@@ -36,14 +36,14 @@ func (r *Repository) InitStoreContext(ctx context.Context) error {
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
-	if err := os.MkdirAll(r.tasksDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.tasksDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create tasks directory: %w", err)
 	}
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
 	wikiDir := filepath.Join(r.stickyDir, "wiki")
-	if err := os.MkdirAll(wikiDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(wikiDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create wiki directory: %w", err)
 	}
 	if err := checkCtx(ctx); err != nil {
@@ -64,7 +64,7 @@ func (r *Repository) InitStoreContext(ctx context.Context) error {
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
-	if err := os.MkdirAll(r.archiveTasks, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.archiveTasks, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create archive tasks directory: %w", err)
 	}
 	if err := r.ensureGitignoreContext(ctx); err != nil {
@@ -84,7 +84,7 @@ func (r *Repository) writeDefaultConfigContext(ctx context.Context) error {
 	if err := shared.EnsureInDir(r.boardDir, configPath); err != nil {
 		return err
 	}
-	if _, err := os.Stat(configPath); err == nil {
+	if _, err := shared.Files().Stat(configPath); err == nil {
 		return nil
 	} else if !os.IsNotExist(err) {
 		return fmt.Errorf("board: failed to stat config file: %w", err)
@@ -97,7 +97,7 @@ func (r *Repository) writeDefaultConfigContext(ctx context.Context) error {
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
-	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(configPath, data, 0o644); err != nil {
 		return fmt.Errorf("board: failed to write config file: %w", err)
 	}
 	return nil
@@ -113,12 +113,12 @@ func (r *Repository) ensureGitignoreContext(ctx context.Context) error {
 	}
 
 	desiredLines := []string{".sticky/debug.log", "debug.log"}
-	if _, err := os.Stat(gitignorePath); os.IsNotExist(err) {
+	if _, err := shared.Files().Stat(gitignorePath); os.IsNotExist(err) {
 		content := strings.Join(desiredLines, "\n") + "\n"
 		if err := checkCtx(ctx); err != nil {
 			return err
 		}
-		if err := os.WriteFile(gitignorePath, []byte(content), 0o644); err != nil {
+		if err := shared.Files().WriteFile(gitignorePath, []byte(content), 0o644); err != nil {
 			return fmt.Errorf("board: failed to write .gitignore: %w", err)
 		}
 		return nil
@@ -126,7 +126,7 @@ func (r *Repository) ensureGitignoreContext(ctx context.Context) error {
 		return fmt.Errorf("board: failed to stat .gitignore: %w", err)
 	}
 
-	data, err := os.ReadFile(gitignorePath)
+	data, err := shared.Files().ReadFile(gitignorePath)
 	if err != nil {
 		return fmt.Errorf("board: failed to read .gitignore: %w", err)
 	}
@@ -145,7 +145,7 @@ func (r *Repository) ensureGitignoreContext(ctx context.Context) error {
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
-	if err := os.WriteFile(gitignorePath, []byte(content), 0o644); err != nil {
+	if err := shared.Files().WriteFile(gitignorePath, []byte(content), 0o644); err != nil {
 		return fmt.Errorf("board: failed to update .gitignore: %w", err)
 	}
 	return nil
@@ -168,7 +168,7 @@ func (r *Repository) ensureBoardRegistryContext(ctx context.Context) error {
 	if err := shared.EnsureInDir(r.stickyDir, registryPath); err != nil {
 		return err
 	}
-	if _, err := os.Stat(registryPath); err == nil {
+	if _, err := shared.Files().Stat(registryPath); err == nil {
 		return nil
 	} else if !os.IsNotExist(err) {
 		return fmt.Errorf("board: failed to stat board registry: %w", err)
@@ -178,7 +178,7 @@ func (r *Repository) ensureBoardRegistryContext(ctx context.Context) error {
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
-	if err := os.MkdirAll(boardsDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(boardsDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create boards directory: %w", err)
 	}
 
@@ -211,7 +211,7 @@ func (r *Repository) ensureBoardRegistryContext(ctx context.Context) error {
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
-	if err := os.MkdirAll(boardDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(boardDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create default board directory: %w", err)
 	}
 	return nil
@@ -234,7 +234,7 @@ func (r *Repository) migrateLegacyLayoutLockedContext(ctx context.Context) error
 	if err := checkCtx(ctx); err != nil {
 		return err
 	}
-	if err := os.MkdirAll(boardDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(boardDir, 0o755); err != nil {
 		return fmt.Errorf("board: failed to create board directory: %w", err)
 	}
 
@@ -249,7 +249,7 @@ func (r *Repository) migrateLegacyLayoutLockedContext(ctx context.Context) error
 		} else if targetExists {
 			return fmt.Errorf("board: failed to migrate legacy tasks: %w", shared.ErrInvalidPath)
 		}
-		if err := os.Rename(legacyTasks, tasksDir); err != nil {
+		if err := shared.Files().Rename(legacyTasks, tasksDir); err != nil {
 			return fmt.Errorf("board: failed to move legacy tasks: %w", err)
 		}
 	}
@@ -265,7 +265,7 @@ func (r *Repository) migrateLegacyLayoutLockedContext(ctx context.Context) error
 		} else if targetExists {
 			return fmt.Errorf("board: failed to migrate legacy config: %w", shared.ErrInvalidPath)
 		}
-		if err := os.Rename(legacyConfig, configPath); err != nil {
+		if err := shared.Files().Rename(legacyConfig, configPath); err != nil {
 			return fmt.Errorf("board: failed to move legacy config: %w", err)
 		}
 	}
@@ -0,0 +1,245 @@
+package board
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LaneByTag groups tasks by their first tag (honoring the configured lane order).
+	LaneByTag = "tag"
+	// LaneByPriority groups tasks by effective priority.
+	LaneByPriority = "priority"
+	// LaneByAssignee groups tasks by the assignee frontmatter field.
+	LaneByAssignee = "assignee"
+	// LaneBySprint groups tasks by sprint ID.
+	LaneBySprint = "sprint"
+	// LaneByField groups tasks by a custom frontmatter field (spec "field:<name>").
+	LaneByField = "field"
+
+	// LaneNoneKey is the lane key used for tasks without a value for the grouping.
+	LaneNoneKey = ""
+)
+
+// LaneConfig configures swimlanes for a board in config.yaml.
+type LaneConfig struct {
+	GroupBy   string   `yaml:"group_by,omitempty" json:"group_by,omitempty"`
+	Order     []string `yaml:"order,omitempty" json:"order,omitempty"`
+	Collapsed []string `yaml:"collapsed,omitempty" json:"collapsed,omitempty"`
+}
+
+// LaneGroup describes how tasks are grouped into swimlanes.
+type LaneGroup struct {
+	Kind  string
+	Field string
+}
+
+// Lane is a horizontal group of tasks sharing a grouping value.
+type Lane struct {
+	Key   string
+	Title string
+	Tasks []Task
+}
+
+// ParseLaneGroup parses a grouping spec: tag, priority, assignee, sprint or field:<name>.
+// An empty spec (or "none") returns the zero LaneGroup, which disables lanes.
+func ParseLaneGroup(spec string) (LaneGroup, error) {
+	trimmed := strings.TrimSpace(spec)
+	lower := strings.ToLower(trimmed)
+	switch lower {
+	case "", "none":
+		return LaneGroup{}, nil
+	case LaneByTag, "tags":
+		return LaneGroup{Kind: LaneByTag}, nil
+	case LaneByPriority:
+		return LaneGroup{Kind: LaneByPriority}, nil
+	case LaneByAssignee:
+		return LaneGroup{Kind: LaneByAssignee}, nil
+	case LaneBySprint:
+		return LaneGroup{Kind: LaneBySprint}, nil
+	}
+	if strings.HasPrefix(lower, LaneByField+":") {
+		name := strings.TrimSpace(trimmed[len(LaneByField)+1:])
+		if name == "" {
+			return LaneGroup{}, fmt.Errorf("board: lane field name is required: %w", ErrInvalidLaneGroup)
+		}
+		return LaneGroup{Kind: LaneByField, Field: name}, nil
+	}
+	return LaneGroup{}, fmt.Errorf("board: %q (expected tag, priority, assignee, sprint or field:<name>): %w", spec, ErrInvalidLaneGroup)
+}
+
+// IsZero reports whether the grouping is disabled.
+func (g LaneGroup) IsZero() bool {
+	return g.Kind == ""
+}
+
+// String renders the grouping back into its spec form.
+func (g LaneGroup) String() string {
+	if g.Kind == LaneByField {
+		return LaneByField + ":" + g.Field
+	}
+	return g.Kind
+}
+
+// LaneKey returns the lane a task belongs to. Tasks with several tags land in the first tag
+// listed in order, falling back to their first tag. An empty key means "no value".
+func LaneKey(task Task, group LaneGroup, order []string) string {
+	switch group.Kind {
+	case LaneByTag:
+		if len(task.Tags) == 0 {
+			return LaneNoneKey
+		}
+		for _, preferred := range order {
+			for _, tag := range task.Tags {
+				if strings.EqualFold(tag, preferred) {
+					return preferred
+				}
+			}
+		}
+		return strings.ToLower(task.Tags[0])
+	case LaneByPriority:
+		return strconv.Itoa(effectivePriority(task.Priority))
+	case LaneByAssignee:
+		return strings.TrimSpace(task.Assignee)
+	case LaneBySprint:
+		return strings.TrimSpace(task.Sprint)
+	case LaneByField:
+		for key, value := range task.Fields {
+			if strings.EqualFold(key, group.Field) {
+				return strings.TrimSpace(value)
+			}
+		}
+		return LaneNoneKey
+	default:
+		return LaneNoneKey
+	}
+}
+
+// LaneTitle returns the display title for a lane key.
+func LaneTitle(key string, group LaneGroup) string {
+	if key == LaneNoneKey {
+		switch group.Kind {
+		case LaneByField:
+			return fmt.Sprintf("(no %s)", group.Field)
+		case LaneByTag:
+			return "(untagged)"
+		default:
+			return fmt.Sprintf("(no %s)", group.Kind)
+		}
+	}
+	if group.Kind == LaneByPriority {
+		return "P" + key
+	}
+	return key
+}
+
+// LaneKeys returns the ordered lane keys for tasks: keys listed in order first, then the
+// remaining keys sorted (priorities numerically), and the empty "no value" lane last.
+func LaneKeys(tasks []Task, group LaneGroup, order []string) []string {
+	present := make(map[string]struct{})
+	hasNone := false
+	for _, task := range tasks {
+		key := LaneKey(task, group, order)
+		if key == LaneNoneKey {
+			hasNone = true
+			continue
+		}
+		present[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(present)+1)
+	for _, preferred := range order {
+		for key := range present {
+			if strings.EqualFold(key, preferred) {
+				keys = append(keys, key)
+				delete(present, key)
+				break
+			}
+		}
+	}
+	rest := make([]string, 0, len(present))
+	for key := range present {
+		rest = append(rest, key)
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		if group.Kind == LaneByPriority {
+			left, _ := strconv.Atoi(rest[i])
+			right, _ := strconv.Atoi(rest[j])
+			return left < right
+		}
+		return strings.ToLower(rest[i]) < strings.ToLower(rest[j])
+	})
+	keys = append(keys, rest...)
+	if hasNone {
+		keys = append(keys, LaneNoneKey)
+	}
+	return keys
+}
+
+// GroupLanes splits tasks into ordered lanes, preserving the task order within each lane.
+func GroupLanes(tasks []Task, group LaneGroup, order []string) []Lane {
+	keys := LaneKeys(tasks, group, order)
+	lanes := make([]Lane, len(keys))
+	index := make(map[string]int, len(keys))
+	for i, key := range keys {
+		lanes[i] = Lane{Key: key, Title: LaneTitle(key, group)}
+		index[key] = i
+	}
+	for _, task := range tasks {
+		idx := index[LaneKey(task, group, order)]
+		lanes[idx].Tasks = append(lanes[idx].Tasks, task)
+	}
+	return lanes
+}
+
+// FormatLanes renders tasks grouped into swimlanes, listing each column's tasks per lane.
+func FormatLanes(columns []Column, tasks []Task, group LaneGroup, order []string) string {
+	var b strings.Builder
+	for i, lane := range GroupLanes(tasks, group, order) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "== %s (%d) ==\n", lane.Title, len(lane.Tasks))
+		for _, column := range EstimateTotalsByColumn(columns, lane.Tasks) {
+			if column.Count == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "  %s (%d)\n", column.Title, column.Count)
+			for _, task := range lane.Tasks {
+				if !laneColumnMatches(task, column.Key, columns) {
+					continue
+				}
+				fmt.Fprintf(&b, "    - %s P%d %s\n", task.ID, effectivePriority(task.Priority), task.Title)
+			}
+		}
+	}
+	return b.String()
+}
+
+// laneColumnMatches reports whether task belongs to the column key, mapping statuses that do
+// not match any configured column to the "unknown" bucket used by EstimateTotalsByColumn.
+func laneColumnMatches(task Task, key string, columns []Column) bool {
+	if strings.EqualFold(task.Status, key) {
+		return true
+	}
+	if key != "unknown" {
+		return false
+	}
+	for _, column := range columns {
+		if strings.EqualFold(task.Status, column.Key) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedFieldKeys returns custom field names in a stable order for display.
+func sortedFieldKeys(fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+package board
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLaneGroup(t *testing.T) {
+	cases := []struct {
+		spec    string
+		want    LaneGroup
+		wantErr error
+	}{
+		{spec: "", want: LaneGroup{}},
+		{spec: "none", want: LaneGroup{}},
+		{spec: "Tag", want: LaneGroup{Kind: LaneByTag}},
+		{spec: "priority", want: LaneGroup{Kind: LaneByPriority}},
+		{spec: "assignee", want: LaneGroup{Kind: LaneByAssignee}},
+		{spec: "sprint", want: LaneGroup{Kind: LaneBySprint}},
+		{spec: "field:Team", want: LaneGroup{Kind: LaneByField, Field: "Team"}},
+		{spec: "field:", wantErr: ErrInvalidLaneGroup},
+		{spec: "color", wantErr: ErrInvalidLaneGroup},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.spec, func(t *testing.T) {
+			// Act
+			got, err := ParseLaneGroup(tc.spec)
+
+			// Assert
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupLanesHonorsOrder(t *testing.T) {
+	// Arrange
+	tasks := []Task{
+		{ID: "T-1", Tags: []string{"frontend", "urgent"}},
+		{ID: "T-2"},
+		{ID: "T-3", Tags: []string{"backend"}},
+		{ID: "T-4", Tags: []string{"Urgent"}},
+		{ID: "T-5", Tags: []string{"api"}},
+	}
+	group := LaneGroup{Kind: LaneByTag}
+
+	// Act
+	lanes := GroupLanes(tasks, group, []string{"urgent"})
+
+	// Assert
+	var got []string
+	for _, lane := range lanes {
+		ids := make([]string, 0, len(lane.Tasks))
+		for _, task := range lane.Tasks {
+			ids = append(ids, task.ID)
+		}
+		got = append(got, lane.Title+":"+strings.Join(ids, ","))
+	}
+	want := []string{"urgent:T-1,T-4", "api:T-5", "backend:T-3", "(untagged):T-2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected lanes:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestFormatLanesByField(t *testing.T) {
+	// Arrange
+	columns := []Column{{Key: "todo", Title: "Todo"}, {Key: "done", Title: "Done"}}
+	tasks := []Task{
+		{ID: "T-1", Title: "One", Status: "todo", Priority: 1, Fields: map[string]string{"team": "core"}},
+		{ID: "T-2", Title: "Two", Status: "done", Priority: 2, Fields: map[string]string{"team": "core"}},
+		{ID: "T-3", Title: "Three", Status: "todo", Priority: 3},
+	}
+
+	// Act
+	out := FormatLanes(columns, tasks, LaneGroup{Kind: LaneByField, Field: "team"}, nil)
+
+	// Assert
+	want := "== core (2) ==\n  Todo (1)\n    - T-1 P1 One\n  Done (1)\n    - T-2 P2 Two\n\n== (no team) (1) ==\n  Todo (1)\n    - T-3 P3 Three\n"
+	if out != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestParserRoundTripsAssigneeAndFields(t *testing.T) {
+	// Arrange
+	parser := &Parser{}
+	task := Task{
+		ID:       "T-1",
+		Title:    "Owned",
+		Status:   "todo",
+		Assignee: "ana",
+		Fields:   map[string]string{"team": "core"},
+	}
+
+	// Act
+	rendered, err := parser.Render(task)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	parsed, err := parser.Parse(rendered)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if parsed.Assignee != "ana" || parsed.Fields["team"] != "core" {
+		t.Fatalf("unexpected round trip: %+v", parsed)
+	}
+}
@@ -13,6 +13,7 @@ type ListOptions struct {
 	Title   string
 	Tags    []string
 	TagMode string
+	Sprint  string
 	From    time.Time
 	To      time.Time
 	SortBy  string
@@ -30,7 +31,8 @@ func FilterAndSortTasks(tasks []Task, opts ListOptions) []Task {
 func filterTasks(tasks []Task, opts ListOptions) []Task {
 	status := strings.TrimSpace(opts.Status)
 	title := strings.TrimSpace(opts.Title)
-	if status == "" && title == "" && len(opts.Tags) == 0 && opts.From.IsZero() && opts.To.IsZero() {
+	sprint := strings.TrimSpace(opts.Sprint)
+	if status == "" && title == "" && sprint == "" && len(opts.Tags) == 0 && opts.From.IsZero() && opts.To.IsZero() {
 		return append([]Task(nil), tasks...)
 	}
 
@@ -47,6 +49,9 @@ func filterTasks(tasks []Task, opts ListOptions) []Task {
 		if titleLower != "" && !strings.Contains(strings.ToLower(task.Title), titleLower) {
 			continue
 		}
+		if sprint != "" && task.Sprint != sprint {
+			continue
+		}
 		if len(tagFilters) > 0 && !matchesTags(task.Tags, tagFilters, tagMode) {
 			continue
 		}
@@ -79,6 +84,16 @@ func sortTasks(tasks []Task, opts ListOptions) {
 			return left < right
 		case "title":
 			return strings.ToLower(tasks[i].Title) < strings.ToLower(tasks[j].Title)
+		case "rank":
+			if cmp, ok := CompareRank(tasks[i], tasks[j]); ok && cmp != 0 {
+				return cmp < 0
+			}
+			left := effectivePriority(tasks[i].Priority)
+			right := effectivePriority(tasks[j].Priority)
+			if left != right {
+				return left < right
+			}
+			return tasks[i].ID < tasks[j].ID
 		default:
 			return tasks[i].ID < tasks[j].ID
 		}
@@ -0,0 +1,287 @@
+package board
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MergeSides holds the three versions of a file in a three-way merge: the common ancestor
+// and the two sides being merged.
+type MergeSides struct {
+	Base   []byte
+	Ours   []byte
+	Theirs []byte
+}
+
+// MergeTaskFiles resolves a three-way merge of a task file field by field. Fields changed on
+// one side take that side's value; tags and depends_on keep every entry added on either side
+// and drop entries removed on either side; when both sides moved the task, the status with
+// the later status_changed wins and brings its completion date along. The returned versions
+// agree on every resolved field, so a line-based merge of them (see git.MergeFile) only
+// leaves conflict markers on fields both sides changed differently and on the body.
+func MergeTaskFiles(sides MergeSides) (MergeSides, error) {
+	parser := &Parser{}
+	var base Task
+	if len(bytes.TrimSpace(sides.Base)) > 0 {
+		parsed, err := parser.Parse(sides.Base)
+		if err != nil {
+			return MergeSides{}, fmt.Errorf("board: merge base: %w", err)
+		}
+		base = parsed
+	}
+	ours, err := parser.Parse(sides.Ours)
+	if err != nil {
+		return MergeSides{}, fmt.Errorf("board: merge ours: %w", err)
+	}
+	theirs, err := parser.Parse(sides.Theirs)
+	if err != nil {
+		return MergeSides{}, fmt.Errorf("board: merge theirs: %w", err)
+	}
+
+	mergeField(&base.ID, &ours.ID, &theirs.ID)
+	mergeField(&base.UID, &ours.UID, &theirs.UID)
+	mergeField(&base.Title, &ours.Title, &theirs.Title)
+	mergeStatus(&base, &ours, &theirs)
+	mergeField(&base.Priority, &ours.Priority, &theirs.Priority)
+	mergeField(&base.Estimate, &ours.Estimate, &theirs.Estimate)
+	mergeField(&base.Sprint, &ours.Sprint, &theirs.Sprint)
+	mergeField(&base.Assignee, &ours.Assignee, &theirs.Assignee)
+	mergeField(&base.Branch, &ours.Branch, &theirs.Branch)
+	mergeField(&base.Rank, &ours.Rank, &theirs.Rank)
+	mergeField(&base.Created, &ours.Created, &theirs.Created)
+	mergeField(&base.Due, &ours.Due, &theirs.Due)
+	tags := mergeSet(base.Tags, ours.Tags, theirs.Tags)
+	base.Tags, ours.Tags, theirs.Tags = tags, tags, tags
+	deps := mergeSet(base.DependsOn, ours.DependsOn, theirs.DependsOn)
+	base.DependsOn, ours.DependsOn, theirs.DependsOn = deps, deps, deps
+	mergeFields(&base, &ours, &theirs)
+
+	var merged MergeSides
+	for _, version := range []struct {
+		task Task
+		dest *[]byte
+	}{{base, &merged.Base}, {ours, &merged.Ours}, {theirs, &merged.Theirs}} {
+		data, err := parser.Render(version.task)
+		if err != nil {
+			return MergeSides{}, fmt.Errorf("board: failed to render merged task %s: %w", version.task.ID, err)
+		}
+		*version.dest = data
+	}
+	return merged, nil
+}
+
+// DuplicateTaskAdd reports whether sides is an add/add of two different tasks under the
+// same ID: no common ancestor and both files parse as tasks with the same ID but different
+// UIDs. It returns their side of the pair; such files cannot be merged and one of them needs
+// a new ID (see FixDuplicateIDs).
+func DuplicateTaskAdd(sides MergeSides) (Task, bool) {
+	if len(bytes.TrimSpace(sides.Base)) > 0 {
+		return Task{}, false
+	}
+	parser := &Parser{}
+	ours, err := parser.Parse(sides.Ours)
+	if err != nil {
+		return Task{}, false
+	}
+	theirs, err := parser.Parse(sides.Theirs)
+	if err != nil {
+		return Task{}, false
+	}
+	if ours.ID == "" || ours.ID != theirs.ID || ours.UID == "" || theirs.UID == "" || ours.UID == theirs.UID {
+		return Task{}, false
+	}
+	return theirs, true
+}
+
+// MergeConfigFiles resolves a three-way merge of a board config. next_id takes the larger of
+// both sides so IDs allocated on either branch are never handed out again; other settings are
+// left to the line-based merge.
+func MergeConfigFiles(sides MergeSides) (MergeSides, error) {
+	configs := make([]Config, 3)
+	for i, data := range [][]byte{sides.Base, sides.Ours, sides.Theirs} {
+		if len(bytes.TrimSpace(data)) == 0 && i == 0 {
+			continue
+		}
+		cfg, err := ParseConfig(data)
+		if err != nil {
+			return MergeSides{}, err
+		}
+		configs[i] = cfg
+	}
+	nextID := max(configs[1].NextID, configs[2].NextID)
+	rendered := make([][]byte, 3)
+	for i := range configs {
+		if i == 0 && len(bytes.TrimSpace(sides.Base)) == 0 {
+			continue
+		}
+		configs[i].NextID = nextID
+		data, err := yaml.Marshal(configs[i])
+		if err != nil {
+			return MergeSides{}, fmt.Errorf("board: failed to marshal config: %w", err)
+		}
+		rendered[i] = data
+	}
+	return MergeSides{Base: rendered[0], Ours: rendered[1], Theirs: rendered[2]}, nil
+}
+
+// MergeRegistryFiles resolves a three-way merge of the board registry. Boards added on either
+// side are kept (ours first, in order), boards removed on one side and unchanged on the other
+// are dropped, and board fields and the active board merge like task fields.
+func MergeRegistryFiles(sides MergeSides) (MergeSides, error) {
+	registries := make([]BoardRegistry, 3)
+	for i, data := range [][]byte{sides.Base, sides.Ours, sides.Theirs} {
+		if err := yaml.Unmarshal(data, &registries[i]); err != nil {
+			return MergeSides{}, fmt.Errorf("board: failed to parse board registry: %w", err)
+		}
+	}
+	base, ours, theirs := registries[0], registries[1], registries[2]
+	mergeField(&base.Active, &ours.Active, &theirs.Active)
+
+	find := func(boards []Board, id string) (Board, bool) {
+		for _, item := range boards {
+			if item.ID == id {
+				return item, true
+			}
+		}
+		return Board{}, false
+	}
+	ids := make([]string, 0, len(ours.Boards)+len(theirs.Boards))
+	for _, item := range slices.Concat(ours.Boards, theirs.Boards) {
+		if !slices.Contains(ids, item.ID) {
+			ids = append(ids, item.ID)
+		}
+	}
+	baseBoards := make([]Board, 0, len(ids))
+	ourBoards := make([]Board, 0, len(ids))
+	theirBoards := make([]Board, 0, len(ids))
+	for _, id := range ids {
+		baseBoard, inBase := find(base.Boards, id)
+		ourBoard, inOurs := find(ours.Boards, id)
+		theirBoard, inTheirs := find(theirs.Boards, id)
+		if inBase && (!inOurs && theirBoard == baseBoard || !inTheirs && ourBoard == baseBoard) {
+			// Deleted on one side and untouched on the other.
+			continue
+		}
+		if !inOurs {
+			ourBoard = theirBoard
+		}
+		if !inTheirs {
+			theirBoard = ourBoard
+		}
+		if !inBase {
+			baseBoard = ourBoard
+		}
+		mergeField(&baseBoard.Name, &ourBoard.Name, &theirBoard.Name)
+		mergeField(&baseBoard.Path, &ourBoard.Path, &theirBoard.Path)
+		mergeField(&baseBoard.Archived, &ourBoard.Archived, &theirBoard.Archived)
+		mergeField(&baseBoard.Created, &ourBoard.Created, &theirBoard.Created)
+		baseBoards = append(baseBoards, baseBoard)
+		ourBoards = append(ourBoards, ourBoard)
+		theirBoards = append(theirBoards, theirBoard)
+	}
+	base.Boards, ours.Boards, theirs.Boards = baseBoards, ourBoards, theirBoards
+
+	var merged MergeSides
+	for _, version := range []struct {
+		registry BoardRegistry
+		dest     *[]byte
+	}{{base, &merged.Base}, {ours, &merged.Ours}, {theirs, &merged.Theirs}} {
+		data, err := yaml.Marshal(version.registry)
+		if err != nil {
+			return MergeSides{}, fmt.Errorf("board: failed to marshal board registry: %w", err)
+		}
+		*version.dest = data
+	}
+	return merged, nil
+}
+
+// mergeField resolves one field of a three-way merge in place: when only one side changed it,
+// all three take that value. When both changed it differently the values are left for the
+// line-based merge to report.
+func mergeField[T comparable](base, ours, theirs *T) {
+	switch {
+	case *ours == *theirs:
+		*base = *ours
+	case *base == *ours:
+		*base, *ours = *theirs, *theirs
+	case *base == *theirs:
+		*base, *theirs = *ours, *ours
+	}
+}
+
+// mergeStatus resolves the status with its change and completion dates. When both sides
+// moved the task, the later status_changed wins (a missing one is the oldest); equal
+// timestamps are left as a conflict.
+func mergeStatus(base, ours, theirs *Task) {
+	take := func(from Task, to ...*Task) {
+		for _, task := range to {
+			task.Status, task.StatusChanged, task.Completed = from.Status, from.StatusChanged, from.Completed
+		}
+	}
+	switch {
+	case ours.Status == theirs.Status:
+		if theirs.StatusChanged.After(ours.StatusChanged) {
+			take(*theirs, base, ours)
+		} else {
+			take(*ours, base, theirs)
+		}
+	case ours.Status == base.Status:
+		take(*theirs, base, ours)
+	case theirs.Status == base.Status:
+		take(*ours, base, theirs)
+	case ours.StatusChanged.After(theirs.StatusChanged):
+		take(*ours, base, theirs)
+	case theirs.StatusChanged.After(ours.StatusChanged):
+		take(*theirs, base, ours)
+	}
+}
+
+// mergeSet merges two edited lists against their base: entries added on either side are
+// kept and entries removed on either side are dropped. Order follows ours, then theirs.
+func mergeSet(base, ours, theirs []string) []string {
+	var merged []string
+	for _, item := range slices.Concat(ours, theirs) {
+		if slices.Contains(merged, item) {
+			continue
+		}
+		inOurs, inTheirs := slices.Contains(ours, item), slices.Contains(theirs, item)
+		if inOurs && inTheirs || !slices.Contains(base, item) {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// mergeFields merges custom fields key by key; an absent key is an empty value.
+func mergeFields(base, ours, theirs *Task) {
+	keys := make([]string, 0)
+	for _, fields := range []map[string]string{base.Fields, ours.Fields, theirs.Fields} {
+		for key := range fields {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	merged := []map[string]string{{}, {}, {}}
+	for _, key := range keys {
+		values := []string{base.Fields[key], ours.Fields[key], theirs.Fields[key]}
+		mergeField(&values[0], &values[1], &values[2])
+		for i, value := range values {
+			if value != "" {
+				merged[i][key] = value
+			}
+		}
+	}
+	for i, task := range []*Task{base, ours, theirs} {
+		task.Fields = merged[i]
+		if len(task.Fields) == 0 {
+			task.Fields = nil
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package board
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMergeTaskFilesResolvesFrontmatter(t *testing.T) {
+	// Arrange
+	parser := &Parser{}
+	render := func(task Task) []byte {
+		data, err := parser.Render(task)
+		if err != nil {
+			t.Fatalf("render: %v", err)
+		}
+		return data
+	}
+	moved := func(hour int) time.Time { return time.Date(2026, 10, 18, hour, 0, 0, 0, time.UTC) }
+	base := Task{ID: "T-000001", Title: "Fix parser", Status: "todo", Priority: 2, Tags: []string{"api", "old"}, Content: "Body\n"}
+	ours := base
+	ours.Status, ours.StatusChanged = "doing", moved(9)
+	ours.Tags = []string{"api", "old", "backend"}
+	ours.Priority = 1
+	theirs := base
+	theirs.Status, theirs.StatusChanged = "done", moved(11)
+	theirs.Completed = Date{Time: moved(0)}
+	theirs.Tags = []string{"api", "urgent"}
+	theirs.DependsOn = []string{"T-000002"}
+
+	// Act
+	merged, err := MergeTaskFiles(MergeSides{Base: render(base), Ours: render(ours), Theirs: render(theirs)})
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	results := make([]Task, 0, 3)
+	for _, data := range [][]byte{merged.Base, merged.Ours, merged.Theirs} {
+		task, err := parser.Parse(data)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		results = append(results, task)
+	}
+
+	// Assert
+	for _, task := range results {
+		if task.Status != "done" || !task.StatusChanged.Equal(moved(11)) || task.Completed.IsZero() {
+			t.Fatalf("expected the later status change to win, got %s at %s", task.Status, task.StatusChanged)
+		}
+		if !slices.Equal(task.Tags, []string{"api", "backend", "urgent"}) {
+			t.Fatalf("expected added tags kept and removed tags dropped, got %v", task.Tags)
+		}
+		if task.Priority != 1 || !slices.Equal(task.DependsOn, []string{"T-000002"}) {
+			t.Fatalf("expected one-sided changes to apply, got priority %d deps %v", task.Priority, task.DependsOn)
+		}
+	}
+}
+
+func TestMergeTaskFilesLeavesConflictingTitles(t *testing.T) {
+	// Arrange
+	parser := &Parser{}
+	render := func(title string) []byte {
+		data, err := parser.Render(Task{ID: "T-000001", Title: title, Status: "todo", Priority: 2})
+		if err != nil {
+			t.Fatalf("render: %v", err)
+		}
+		return data
+	}
+
+	// Act
+	merged, err := MergeTaskFiles(MergeSides{Base: render("Base"), Ours: render("Ours"), Theirs: render("Theirs")})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if !strings.Contains(string(merged.Ours), "title: Ours") || !strings.Contains(string(merged.Theirs), "title: Theirs") {
+		t.Fatalf("expected both titles to remain for the line merge:\n%s\n%s", merged.Ours, merged.Theirs)
+	}
+}
+
+func TestMergeConfigFilesKeepsHighestNextID(t *testing.T) {
+	// Arrange
+	render := func(nextID int) []byte {
+		cfg := DefaultConfig()
+		cfg.NextID = nextID
+		data, err := yaml.Marshal(cfg)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return data
+	}
+
+	// Act
+	merged, err := MergeConfigFiles(MergeSides{Base: render(5), Ours: render(7), Theirs: render(9)})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	for _, data := range [][]byte{merged.Base, merged.Ours, merged.Theirs} {
+		if cfg, err := ParseConfig(data); err != nil || cfg.NextID != 9 {
+			t.Fatalf("expected next_id 9, got %d (%v)", cfg.NextID, err)
+		}
+	}
+}
+
+func TestMergeRegistryFilesMergesBoards(t *testing.T) {
+	// Arrange
+	render := func(ids ...string) []byte {
+		registry := BoardRegistry{Active: "default"}
+		for _, id := range ids {
+			registry.Boards = append(registry.Boards, Board{ID: id, Name: id, Path: "boards/" + id})
+		}
+		data, err := yaml.Marshal(registry)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return data
+	}
+
+	// Act
+	merged, err := MergeRegistryFiles(MergeSides{
+		Base:   render("default", "old"),
+		Ours:   render("default", "old", "web"),
+		Theirs: render("default", "api"),
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	var registry BoardRegistry
+	if err := yaml.Unmarshal(merged.Ours, &registry); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	ids := make([]string, 0, len(registry.Boards))
+	for _, item := range registry.Boards {
+		ids = append(ids, item.ID)
+	}
+	if !slices.Equal(ids, []string{"default", "web", "api"}) {
+		t.Fatalf("expected added boards kept and the deleted one dropped, got %v", ids)
+	}
+	if string(merged.Ours) != string(merged.Theirs) {
+		t.Fatalf("expected both sides to agree:\n%s\n%s", merged.Ours, merged.Theirs)
+	}
+}
+
+func TestDuplicateTaskAddDetectsDifferentTasksUnderOneID(t *testing.T) {
+	// Arrange
+	parser := &Parser{}
+	ours, err := parser.Render(Task{ID: "T-000002", UID: "aaaa", Title: "Ours", Status: "todo"})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	theirs, err := parser.Render(Task{ID: "T-000002", UID: "bbbb", Title: "Theirs", Status: "todo"})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	// Act
+	duplicate, isDuplicate := DuplicateTaskAdd(MergeSides{Ours: ours, Theirs: theirs})
+	_, sameTask := DuplicateTaskAdd(MergeSides{Ours: ours, Theirs: ours})
+	_, withBase := DuplicateTaskAdd(MergeSides{Base: ours, Ours: ours, Theirs: theirs})
+
+	// Assert
+	if !isDuplicate || duplicate.Title != "Theirs" {
+		t.Fatalf("expected their task reported, got %v %+v", isDuplicate, duplicate)
+	}
+	if sameTask || withBase {
+		t.Fatalf("expected only an add/add of different UIDs to count, got %v %v", sameTask, withBase)
+	}
+}
@@ -5,19 +5,29 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type taskFrontmatter struct {
-	ID       string   `yaml:"id"`
-	UID      string   `yaml:"uid,omitempty"`
-	Title    string   `yaml:"title"`
-	Status   string   `yaml:"status"`
-	Priority int      `yaml:"priority"`
-	Tags     []string `yaml:"tags"`
-	Created  Date     `yaml:"created"`
-	Depends  []string `yaml:"depends_on"`
+	ID            string            `yaml:"id"`
+	UID           string            `yaml:"uid,omitempty"`
+	Title         string            `yaml:"title"`
+	Status        string            `yaml:"status"`
+	StatusChanged time.Time         `yaml:"status_changed,omitempty"`
+	Priority      int               `yaml:"priority"`
+	Estimate      float64           `yaml:"estimate,omitempty"`
+	Sprint        string            `yaml:"sprint,omitempty"`
+	Assignee      string            `yaml:"assignee,omitempty"`
+	Branch        string            `yaml:"branch,omitempty"`
+	Rank          string            `yaml:"rank,omitempty"`
+	Tags          []string          `yaml:"tags"`
+	Created       Date              `yaml:"created"`
+	Completed     Date              `yaml:"completed,omitempty"`
+	Due           Date              `yaml:"due,omitempty"`
+	Depends       []string          `yaml:"depends_on"`
+	Fields        map[string]string `yaml:"fields,omitempty"`
 }
 
 // Parser reads and writes task files.
@@ -36,15 +46,24 @@ func (p *Parser) Parse(data []byte) (Task, error) {
 	}
 
 	task := Task{
-		ID:        fm.ID,
-		UID:       fm.UID,
-		Title:     fm.Title,
-		Status:    fm.Status,
-		Priority:  fm.Priority,
-		Tags:      fm.Tags,
-		Created:   fm.Created,
-		DependsOn: normalizeIDs(fm.Depends),
-		Content:   body,
+		ID:            fm.ID,
+		UID:           fm.UID,
+		Title:         fm.Title,
+		Status:        fm.Status,
+		StatusChanged: fm.StatusChanged,
+		Priority:      fm.Priority,
+		Estimate:      fm.Estimate,
+		Sprint:        fm.Sprint,
+		Assignee:      fm.Assignee,
+		Branch:        fm.Branch,
+		Rank:          fm.Rank,
+		Tags:          fm.Tags,
+		Created:       fm.Created,
+		Completed:     fm.Completed,
+		Due:           fm.Due,
+		DependsOn:     normalizeIDs(fm.Depends),
+		Fields:        fm.Fields,
+		Content:       body,
 	}
 	return task, nil
 }
@@ -52,14 +71,23 @@ func (p *Parser) Parse(data []byte) (Task, error) {
 // Render converts a Task into markdown content with YAML frontmatter.
 func (p *Parser) Render(task Task) ([]byte, error) {
 	fm := taskFrontmatter{
-		ID:       task.ID,
-		UID:      task.UID,
-		Title:    task.Title,
-		Status:   task.Status,
-		Priority: task.Priority,
-		Tags:     task.Tags,
-		Created:  task.Created,
-		Depends:  normalizeIDs(task.DependsOn),
+		ID:            task.ID,
+		UID:           task.UID,
+		Title:         task.Title,
+		Status:        task.Status,
+		StatusChanged: task.StatusChanged,
+		Priority:      task.Priority,
+		Estimate:      task.Estimate,
+		Sprint:        task.Sprint,
+		Assignee:      task.Assignee,
+		Branch:        task.Branch,
+		Rank:          task.Rank,
+		Tags:          task.Tags,
+		Created:       task.Created,
+		Completed:     task.Completed,
+		Due:           task.Due,
+		Depends:       normalizeIDs(task.DependsOn),
+		Fields:        task.Fields,
 	}
 	yamlBytes, err := yaml.Marshal(fm)
 	if err != nil {
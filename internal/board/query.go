@@ -0,0 +1,758 @@
+package board
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Query is a parsed task query. Root is nil when the query has no filter terms.
+//
+// The language is a list of terms combined with AND; OR, parentheses and "-" (or NOT) negation
+// are supported. Terms are field:value[,value] (any value matches), comparisons such as
+// priority<=2 or created>=2026-09-01, bare words or "quoted phrases" matched against the title,
+// ID and content, and a sort:-priority,created directive.
+type Query struct {
+	Raw  string
+	Root QueryNode
+	Sort []QuerySort
+}
+
+// QueryNode is a node of the query AST.
+type QueryNode interface {
+	Match(task Task) bool
+	String() string
+}
+
+// QueryAnd matches when every term matches.
+type QueryAnd struct {
+	Terms []QueryNode
+}
+
+// QueryOr matches when any term matches.
+type QueryOr struct {
+	Terms []QueryNode
+}
+
+// QueryNot negates a term.
+type QueryNot struct {
+	Term QueryNode
+}
+
+// QueryField compares a task field with one or more values. Op is ":" (any value equals or
+// contains), "=", "!=", "<", "<=", ">" or ">=".
+type QueryField struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+// QueryText matches a word or phrase against the task ID, title and content.
+type QueryText struct {
+	Text string
+}
+
+// QuerySort is one key of a sort directive.
+type QuerySort struct {
+	Field string
+	Desc  bool
+}
+
+// QueryError reports a query parse error at a byte offset of the input.
+type QueryError struct {
+	Query string
+	Pos   int
+	Token string
+	Msg   string
+}
+
+func (e *QueryError) Error() string {
+	column := utf8.RuneCountInString(e.Query[:e.Pos]) + 1
+	if e.Token == "" {
+		return fmt.Sprintf("board: query: %s at column %d", e.Msg, column)
+	}
+	return fmt.Sprintf("board: query: %s at column %d (%q)", e.Msg, column, e.Token)
+}
+
+func (e *QueryError) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+// Pointer renders the query with a caret line under the offending token.
+func (e *QueryError) Pointer() string {
+	width := max(1, utf8.RuneCountInString(e.Token))
+	return e.Query + "\n" + strings.Repeat(" ", utf8.RuneCountInString(e.Query[:e.Pos])) + strings.Repeat("^", width)
+}
+
+type queryFieldKind int
+
+const (
+	queryString queryFieldKind = iota
+	queryContains
+	queryNumber
+	queryDate
+	queryPresence
+)
+
+var queryFields = map[string]queryFieldKind{
+	"status":    queryString,
+	"tag":       queryString,
+	"sprint":    queryString,
+	"assignee":  queryString,
+	"id":        queryString,
+	"title":     queryContains,
+	"content":   queryContains,
+	"priority":  queryNumber,
+	"estimate":  queryNumber,
+	"created":   queryDate,
+	"completed": queryDate,
+	"has":       queryPresence,
+}
+
+var queryFieldAliases = map[string]string{
+	"tags": "tag",
+	"text": "content",
+	"body": "content",
+	"p":    "priority",
+}
+
+var queryPresenceValues = []string{"estimate", "sprint", "assignee", "tags", "rank", "deps", "completed", "content"}
+
+var querySortFields = []string{"id", "status", "title", "priority", "estimate", "created", "completed", "rank", "sprint", "assignee"}
+
+// ParseQuery parses a query string into an AST.
+func ParseQuery(input string) (Query, error) {
+	tokens, err := lexQuery(input)
+	if err != nil {
+		return Query{}, err
+	}
+	p := &queryParser{input: input, tokens: tokens}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return Query{}, err
+	}
+	if tok := p.peek(); tok.kind != queryTokEOF {
+		return Query{}, p.errorAt(tok, "unexpected token")
+	}
+	return Query{Raw: input, Root: root, Sort: p.sort}, nil
+}
+
+// IsZero reports whether the query neither filters nor sorts.
+func (q Query) IsZero() bool {
+	return q.Root == nil && len(q.Sort) == 0
+}
+
+// Match reports whether task satisfies the query filter.
+func (q Query) Match(task Task) bool {
+	if q.Root == nil {
+		return true
+	}
+	return q.Root.Match(task)
+}
+
+// Apply returns the tasks matching the query, ordered by its sort directive (input order is
+// kept when there is none or for ties).
+func (q Query) Apply(tasks []Task) []Task {
+	out := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		if q.Match(task) {
+			out = append(out, task)
+		}
+	}
+	if len(q.Sort) == 0 {
+		return out
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		for _, key := range q.Sort {
+			cmp := compareTasksBy(out[i], out[j], key.Field)
+			if cmp == 0 {
+				continue
+			}
+			if key.Desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	return out
+}
+
+// String renders the AST in a normalized form.
+func (q Query) String() string {
+	parts := make([]string, 0, 2)
+	if q.Root != nil {
+		parts = append(parts, q.Root.String())
+	}
+	if len(q.Sort) > 0 {
+		keys := make([]string, 0, len(q.Sort))
+		for _, key := range q.Sort {
+			if key.Desc {
+				keys = append(keys, "-"+key.Field)
+				continue
+			}
+			keys = append(keys, key.Field)
+		}
+		parts = append(parts, "sort:"+strings.Join(keys, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Match implements QueryNode.
+func (n QueryAnd) Match(task Task) bool {
+	for _, term := range n.Terms {
+		if !term.Match(task) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n QueryAnd) String() string {
+	return "(" + joinQueryNodes(n.Terms, " ") + ")"
+}
+
+// Match implements QueryNode.
+func (n QueryOr) Match(task Task) bool {
+	for _, term := range n.Terms {
+		if term.Match(task) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n QueryOr) String() string {
+	return "(" + joinQueryNodes(n.Terms, " OR ") + ")"
+}
+
+// Match implements QueryNode.
+func (n QueryNot) Match(task Task) bool {
+	return !n.Term.Match(task)
+}
+
+func (n QueryNot) String() string {
+	return "-" + n.Term.String()
+}
+
+// Match implements QueryNode.
+func (n QueryText) Match(task Task) bool {
+	needle := strings.ToLower(n.Text)
+	return strings.Contains(strings.ToLower(task.ID), needle) ||
+		strings.Contains(strings.ToLower(task.Title), needle) ||
+		strings.Contains(strings.ToLower(task.Content), needle)
+}
+
+func (n QueryText) String() string {
+	return strconv.Quote(n.Text)
+}
+
+// Match implements QueryNode.
+func (n QueryField) Match(task Task) bool {
+	if n.Op == "!=" {
+		return !QueryField{Field: n.Field, Op: "=", Values: n.Values}.Match(task)
+	}
+	for _, value := range n.Values {
+		if n.matchValue(task, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n QueryField) String() string {
+	return n.Field + n.Op + strings.Join(n.Values, ",")
+}
+
+func (n QueryField) matchValue(task Task, value string) bool {
+	switch n.Field {
+	case "status":
+		return strings.EqualFold(task.Status, value)
+	case "tag":
+		for _, tag := range task.Tags {
+			if strings.EqualFold(tag, value) {
+				return true
+			}
+		}
+		return false
+	case "sprint":
+		return strings.EqualFold(task.Sprint, value)
+	case "assignee":
+		return strings.EqualFold(task.Assignee, value)
+	case "id":
+		return strings.EqualFold(task.ID, value)
+	case "title":
+		return strings.Contains(strings.ToLower(task.Title), strings.ToLower(value))
+	case "content":
+		return strings.Contains(strings.ToLower(task.Content), strings.ToLower(value))
+	case "priority":
+		want, _ := strconv.ParseFloat(value, 64)
+		return compareQueryNumbers(float64(effectivePriority(task.Priority)), want, n.Op)
+	case "estimate":
+		want, _ := strconv.ParseFloat(value, 64)
+		return compareQueryNumbers(task.Estimate, want, n.Op)
+	case "created", "completed":
+		date := task.Created
+		if n.Field == "completed" {
+			date = task.Completed
+		}
+		if date.IsZero() {
+			return false
+		}
+		want, _ := time.Parse("2006-01-02", value)
+		left := truncateDay(date.Time)
+		switch n.Op {
+		case "<":
+			return left.Before(want)
+		case "<=":
+			return !left.After(want)
+		case ">":
+			return left.After(want)
+		case ">=":
+			return !left.Before(want)
+		default:
+			return left.Equal(want)
+		}
+	case "has":
+		return taskHas(task, value)
+	default:
+		if name, ok := strings.CutPrefix(n.Field, "field."); ok {
+			for key, fieldValue := range task.Fields {
+				if strings.EqualFold(key, name) {
+					return strings.EqualFold(strings.TrimSpace(fieldValue), value)
+				}
+			}
+		}
+		return false
+	}
+}
+
+func compareQueryNumbers(left, right float64, op string) bool {
+	switch op {
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	default:
+		return left == right
+	}
+}
+
+func taskHas(task Task, value string) bool {
+	switch value {
+	case "estimate":
+		return task.Estimate > 0
+	case "sprint":
+		return task.Sprint != ""
+	case "assignee":
+		return task.Assignee != ""
+	case "tags":
+		return len(task.Tags) > 0
+	case "rank":
+		return task.Rank != ""
+	case "deps":
+		return len(task.DependsOn) > 0
+	case "completed":
+		return !task.Completed.IsZero()
+	case "content":
+		return strings.TrimSpace(task.Content) != ""
+	default:
+		return false
+	}
+}
+
+func compareTasksBy(a, b Task, field string) int {
+	switch field {
+	case "status":
+		return strings.Compare(strings.ToLower(a.Status), strings.ToLower(b.Status))
+	case "title":
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case "priority":
+		return effectivePriority(a.Priority) - effectivePriority(b.Priority)
+	case "estimate":
+		switch {
+		case a.Estimate < b.Estimate:
+			return -1
+		case a.Estimate > b.Estimate:
+			return 1
+		}
+		return 0
+	case "created":
+		return a.Created.Compare(b.Created.Time)
+	case "completed":
+		return a.Completed.Compare(b.Completed.Time)
+	case "rank":
+		cmp, _ := CompareRank(a, b)
+		return cmp
+	case "sprint":
+		return strings.Compare(a.Sprint, b.Sprint)
+	case "assignee":
+		return strings.Compare(strings.ToLower(a.Assignee), strings.ToLower(b.Assignee))
+	default:
+		return strings.Compare(a.ID, b.ID)
+	}
+}
+
+func joinQueryNodes(nodes []QueryNode, sep string) string {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		parts = append(parts, node.String())
+	}
+	return strings.Join(parts, sep)
+}
+
+type queryTokenKind int
+
+const (
+	queryTokEOF queryTokenKind = iota
+	queryTokAtom
+	queryTokLParen
+	queryTokRParen
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+func lexQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	i := 0
+	for i < len(input) {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: queryTokLParen, text: "(", pos: i})
+			i += size
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: queryTokRParen, text: ")", pos: i})
+			i += size
+		default:
+			start := i
+			quoteAt := -1
+			for i < len(input) {
+				r, size = utf8.DecodeRuneInString(input[i:])
+				if quoteAt < 0 && (unicode.IsSpace(r) || r == '(' || r == ')') {
+					break
+				}
+				if r == '"' {
+					if quoteAt < 0 {
+						quoteAt = i
+					} else {
+						quoteAt = -1
+					}
+				}
+				i += size
+			}
+			if quoteAt >= 0 {
+				return nil, &QueryError{Query: input, Pos: quoteAt, Token: input[quoteAt:], Msg: "unterminated quote"}
+			}
+			tokens = append(tokens, queryToken{kind: queryTokAtom, text: input[start:i], pos: start})
+		}
+	}
+	return append(tokens, queryToken{kind: queryTokEOF, pos: len(input)}), nil
+}
+
+type queryParser struct {
+	input  string
+	tokens []queryToken
+	next   int
+	sort   []QuerySort
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+func (p *queryParser) advance() queryToken {
+	tok := p.tokens[p.next]
+	if tok.kind != queryTokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *queryParser) errorAt(tok queryToken, msg string) error {
+	return &QueryError{Query: p.input, Pos: tok.pos, Token: tok.text, Msg: msg}
+}
+
+func (p *queryParser) errorIn(tok queryToken, offset int, text, msg string) error {
+	return &QueryError{Query: p.input, Pos: tok.pos + offset, Token: text, Msg: msg}
+}
+
+func isQueryKeyword(tok queryToken, keyword string) bool {
+	return tok.kind == queryTokAtom && tok.text == keyword
+}
+
+// parseOr parses "and (OR and)*". depth counts enclosing parentheses and negations; sort
+// directives are only accepted at depth 0.
+func (p *queryParser) parseOr(depth int) (QueryNode, error) {
+	first, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	terms := []QueryNode{first}
+	for isQueryKeyword(p.peek(), "OR") {
+		orTok := p.advance()
+		next, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil || terms[0] == nil {
+			return nil, p.errorAt(orTok, "OR needs a term on both sides")
+		}
+		terms = append(terms, next)
+	}
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return QueryOr{Terms: terms}, nil
+}
+
+func (p *queryParser) parseAnd(depth int) (QueryNode, error) {
+	var terms []QueryNode
+	for {
+		tok := p.peek()
+		if tok.kind == queryTokEOF || tok.kind == queryTokRParen || isQueryKeyword(tok, "OR") {
+			break
+		}
+		if isQueryKeyword(tok, "AND") {
+			p.advance()
+			continue
+		}
+		term, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		if term != nil {
+			terms = append(terms, term)
+		}
+	}
+	switch len(terms) {
+	case 0:
+		return nil, nil
+	case 1:
+		return terms[0], nil
+	default:
+		return QueryAnd{Terms: terms}, nil
+	}
+}
+
+func (p *queryParser) parseUnary(depth int) (QueryNode, error) {
+	tok := p.peek()
+	if isQueryKeyword(tok, "NOT") || isQueryKeyword(tok, "-") {
+		p.advance()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if operand == nil {
+			return nil, p.errorAt(tok, "nothing to negate")
+		}
+		return QueryNot{Term: operand}, nil
+	}
+	if tok.kind == queryTokAtom && strings.HasPrefix(tok.text, "-") {
+		p.advance()
+		inner := queryToken{kind: queryTokAtom, text: tok.text[1:], pos: tok.pos + 1}
+		term, err := p.parseTerm(inner, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return QueryNot{Term: term}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *queryParser) parsePrimary(depth int) (QueryNode, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case queryTokLParen:
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		closing := p.peek()
+		if closing.kind != queryTokRParen {
+			return nil, p.errorAt(tok, "unclosed parenthesis")
+		}
+		p.advance()
+		if inner == nil {
+			return nil, p.errorAt(tok, "empty parentheses")
+		}
+		return inner, nil
+	case queryTokRParen:
+		return nil, p.errorAt(tok, "unexpected closing parenthesis")
+	case queryTokAtom:
+		return p.parseTerm(tok, depth)
+	default:
+		return nil, p.errorAt(tok, "unexpected end of query")
+	}
+}
+
+func (p *queryParser) parseTerm(tok queryToken, depth int) (QueryNode, error) {
+	text := tok.text
+	if strings.HasPrefix(text, "\"") {
+		phrase := unquoteQueryValue(text)
+		if strings.TrimSpace(phrase) == "" {
+			return nil, p.errorAt(tok, "empty phrase")
+		}
+		return QueryText{Text: phrase}, nil
+	}
+	opAt, op := findQueryOperator(text)
+	if opAt <= 0 {
+		return QueryText{Text: unquoteQueryValue(text)}, nil
+	}
+	rawField := text[:opAt]
+	field := strings.ToLower(rawField)
+	if alias, ok := queryFieldAliases[field]; ok {
+		field = alias
+	}
+	valueOffset := opAt + len(op)
+	rawValue := text[valueOffset:]
+	if field == "sort" {
+		return nil, p.parseSort(tok, rawValue, valueOffset, op, depth)
+	}
+	kind, known := queryFields[field]
+	if name, ok := strings.CutPrefix(field, "fields."); ok {
+		field = "field." + name
+	}
+	if name, ok := strings.CutPrefix(field, "field."); ok && name != "" {
+		kind, known = queryString, true
+		field = "field." + strings.ToLower(name)
+	}
+	if !known {
+		return nil, p.errorIn(tok, 0, rawField, "unknown field")
+	}
+	if (kind == queryString || kind == queryContains || kind == queryPresence) && op != ":" && op != "=" && op != "!=" {
+		return nil, p.errorIn(tok, opAt, op, fmt.Sprintf("operator %s is not supported for %s", op, field))
+	}
+	values, err := p.splitValues(tok, rawValue, valueOffset)
+	if err != nil {
+		return nil, err
+	}
+	offset := valueOffset
+	for _, value := range values {
+		if err := validateQueryValue(kind, value); err != nil {
+			return nil, p.errorIn(tok, offset, value, err.Error())
+		}
+		offset += len(value) + 1
+	}
+	if kind == queryPresence {
+		for i := range values {
+			values[i] = strings.ToLower(values[i])
+		}
+	}
+	return QueryField{Field: field, Op: op, Values: values}, nil
+}
+
+func (p *queryParser) parseSort(tok queryToken, rawValue string, offset int, op string, depth int) error {
+	if op != ":" {
+		return p.errorIn(tok, offset-len(op), op, "sort expects sort:field[,-field]")
+	}
+	if depth > 0 {
+		return p.errorAt(tok, "sort cannot be negated or nested")
+	}
+	values, err := p.splitValues(tok, rawValue, offset)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		key := QuerySort{Field: strings.ToLower(value)}
+		if rest, ok := strings.CutPrefix(key.Field, "-"); ok {
+			key = QuerySort{Field: rest, Desc: true}
+		}
+		if !containsString(querySortFields, key.Field) {
+			return p.errorIn(tok, offset, value, "unknown sort field")
+		}
+		p.sort = append(p.sort, key)
+		offset += len(value) + 1
+	}
+	return nil
+}
+
+func (p *queryParser) splitValues(tok queryToken, rawValue string, offset int) ([]string, error) {
+	if strings.HasPrefix(rawValue, "\"") {
+		value := unquoteQueryValue(rawValue)
+		if strings.TrimSpace(value) == "" {
+			return nil, p.errorIn(tok, offset, rawValue, "missing value")
+		}
+		return []string{value}, nil
+	}
+	if rawValue == "" {
+		return nil, p.errorAt(tok, "missing value")
+	}
+	values := strings.Split(rawValue, ",")
+	for _, value := range values {
+		if value == "" {
+			return nil, p.errorIn(tok, offset, rawValue, "empty value in list")
+		}
+	}
+	return values, nil
+}
+
+func validateQueryValue(kind queryFieldKind, value string) error {
+	switch kind {
+	case queryNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("expected a number")
+		}
+	case queryDate:
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("expected a YYYY-MM-DD date")
+		}
+	case queryPresence:
+		if !containsString(queryPresenceValues, strings.ToLower(value)) {
+			return fmt.Errorf("expected one of %s", strings.Join(queryPresenceValues, ", "))
+		}
+	}
+	return nil
+}
+
+// findQueryOperator returns the byte offset and text of the first field operator in an atom,
+// ignoring anything inside quotes. It returns -1 when the atom is plain text.
+func findQueryOperator(text string) (int, string) {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"':
+			return -1, ""
+		case ':':
+			return i, ":"
+		case '!':
+			if strings.HasPrefix(text[i:], "!=") {
+				return i, "!="
+			}
+		case '<', '>':
+			if strings.HasPrefix(text[i+1:], "=") {
+				return i, text[i : i+2]
+			}
+			return i, text[i : i+1]
+		case '=':
+			return i, "="
+		}
+	}
+	return -1, ""
+}
+
+func unquoteQueryValue(value string) string {
+	return strings.ReplaceAll(value, "\"", "")
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
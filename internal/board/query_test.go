@@ -0,0 +1,98 @@
+package board
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseQueryBuildsAST(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: `status:doing,review tag:backend -tag:wontfix priority<=2 created>=2026-09-01 "login bug" sort:-priority,created`,
+			want:  `(status:doing,review tag:backend -tag:wontfix priority<=2 created>=2026-09-01 "login bug") sort:-priority,created`,
+		},
+		{input: `tag:ui OR (p:1 NOT has:estimate)`, want: `(tag:ui OR (priority:1 -has:estimate))`},
+		{input: `title:"two words" field.Team:core`, want: `(title:two words field.team:core)`},
+		{input: ``, want: ``},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			// Act
+			query, err := ParseQuery(tc.input)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := query.String(); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrorsPointAtToken(t *testing.T) {
+	cases := []struct {
+		input  string
+		column int
+		token  string
+	}{
+		{input: `status:todo colour:red`, column: 13, token: "colour"},
+		{input: `priority<=high`, column: 11, token: "high"},
+		{input: `created>yesterday`, column: 9, token: "yesterday"},
+		{input: `tag<ui`, column: 4, token: "<"},
+		{input: `(status:todo`, column: 1, token: "("},
+		{input: `title:"open`, column: 7, token: `"open`},
+		{input: `-sort:title`, column: 2, token: "sort:title"},
+		{input: `sort:size`, column: 6, token: "size"},
+		{input: `tag:a OR`, column: 7, token: "OR"},
+		{input: `status:todo priority<`, column: 13, token: "priority<"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			// Act
+			_, err := ParseQuery(tc.input)
+
+			// Assert
+			var queryErr *QueryError
+			if !errors.As(err, &queryErr) || !errors.Is(err, ErrInvalidQuery) {
+				t.Fatalf("expected QueryError, got %v", err)
+			}
+			if queryErr.Pos+1 != tc.column || queryErr.Token != tc.token {
+				t.Fatalf("expected column %d token %q, got column %d token %q (%v)", tc.column, tc.token, queryErr.Pos+1, queryErr.Token, err)
+			}
+		})
+	}
+}
+
+func TestQueryApplyFiltersAndSorts(t *testing.T) {
+	// Arrange
+	tasks := []Task{
+		{ID: "T-1", Title: "Fix login bug", Status: "doing", Priority: 2, Tags: []string{"backend"}, Created: Date{Time: day("2026-09-05")}},
+		{ID: "T-2", Title: "Login page", Status: "review", Priority: 1, Tags: []string{"backend"}, Created: Date{Time: day("2026-09-10")}, Content: "The login bug again"},
+		{ID: "T-3", Title: "Login bug wontfix", Status: "doing", Priority: 1, Tags: []string{"backend", "wontfix"}, Created: Date{Time: day("2026-09-10")}},
+		{ID: "T-4", Title: "Old login bug", Status: "doing", Priority: 1, Tags: []string{"backend"}, Created: Date{Time: day("2026-08-01")}},
+		{ID: "T-5", Title: "Login bug", Status: "todo", Priority: 1, Tags: []string{"backend"}, Created: Date{Time: day("2026-09-10")}},
+	}
+	query, err := ParseQuery(`status:doing,review tag:backend -tag:wontfix priority<=2 created>=2026-09-01 "login bug" sort:-priority,created`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	// Act
+	result := query.Apply(tasks)
+
+	// Assert
+	if len(result) != 2 || result[0].ID != "T-1" || result[1].ID != "T-2" {
+		ids := make([]string, 0, len(result))
+		for _, task := range result {
+			ids = append(ids, task.ID)
+		}
+		t.Fatalf("expected [T-1 T-2], got %v", ids)
+	}
+}
@@ -0,0 +1,203 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuickCaptureConfig configures the inline tokens parsed from new task titles. Each field is
+// the prefix of one token; an empty prefix uses the default and "off" disables the token.
+type QuickCaptureConfig struct {
+	Disabled bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	Tag      string `yaml:"tag,omitempty" json:"tag,omitempty"`
+	Priority string `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Status   string `yaml:"status,omitempty" json:"status,omitempty"`
+	Due      string `yaml:"due,omitempty" json:"due,omitempty"`
+	Depends  string `yaml:"depends,omitempty" json:"depends,omitempty"`
+}
+
+// Default quick-capture prefixes.
+const (
+	QuickCaptureTag      = "#"
+	QuickCapturePriority = "!"
+	QuickCaptureStatus   = ">"
+	QuickCaptureDue      = "^"
+	QuickCaptureDepends  = "+"
+
+	quickCaptureOff = "off"
+)
+
+// QuickCapture holds the metadata parsed from a quick-capture title. Zero values mean the
+// token was absent.
+type QuickCapture struct {
+	Title     string
+	Tags      []string
+	Priority  int
+	Status    string
+	Due       Date
+	DependsOn []string
+}
+
+// QuickCaptureScope lists the values that the status and dependency tokens may name, so
+// ">200ms" or "+1" stay in the title unless they match a column or an existing task.
+type QuickCaptureScope struct {
+	Statuses []string
+	TaskIDs  []string
+}
+
+// NewQuickCaptureScope builds a scope from the board columns and its tasks.
+func NewQuickCaptureScope(columns []Column, tasks []Task) QuickCaptureScope {
+	scope := QuickCaptureScope{
+		Statuses: make([]string, 0, len(columns)),
+		TaskIDs:  make([]string, 0, len(tasks)),
+	}
+	for _, column := range columns {
+		scope.Statuses = append(scope.Statuses, column.Key)
+	}
+	for _, task := range tasks {
+		scope.TaskIDs = append(scope.TaskIDs, task.ID)
+	}
+	return scope
+}
+
+type quickCaptureToken struct {
+	prefix  string
+	example string
+	// accepts reports whether a value fits the token's grammar; nil accepts any value.
+	accepts func(string) bool
+	apply   func(*QuickCapture, string) error
+}
+
+// ParseQuickCapture extracts inline tokens from input, e.g.
+// "Fix login race #backend !1 >doing ^2026-11-01 +T-000012", and returns the remaining
+// words as the title. A word that is only a prefix or does not fit the token's grammar, such as
+// "#123" or "!!", is kept as text, as is a status or dependency that scope does not list, such
+// as ">200ms" or "+1". A leading backslash keeps a token literal ("\#1" becomes "#1").
+func ParseQuickCapture(input string, cfg QuickCaptureConfig, scope QuickCaptureScope) (QuickCapture, error) {
+	var capture QuickCapture
+	if cfg.Disabled {
+		capture.Title = strings.Join(strings.Fields(input), " ")
+		return capture, nil
+	}
+	tokens := cfg.tokens(scope)
+	words := make([]string, 0)
+	for _, word := range strings.Fields(input) {
+		if escaped, ok := strings.CutPrefix(word, `\`); ok && escaped != "" {
+			words = append(words, escaped)
+			continue
+		}
+		matched := false
+		for _, token := range tokens {
+			value, ok := strings.CutPrefix(word, token.prefix)
+			if !ok || value == "" || (token.accepts != nil && !token.accepts(value)) {
+				continue
+			}
+			if err := token.apply(&capture, value); err != nil {
+				return QuickCapture{}, err
+			}
+			matched = true
+			break
+		}
+		if !matched {
+			words = append(words, word)
+		}
+	}
+	capture.Title = strings.Join(words, " ")
+	capture.Tags = NormalizeTags(capture.Tags)
+	capture.DependsOn = normalizeIDs(capture.DependsOn)
+	return capture, nil
+}
+
+// Syntax describes the enabled tokens with example values, e.g. "#tag !1 >status".
+func (cfg QuickCaptureConfig) Syntax() string {
+	if cfg.Disabled {
+		return ""
+	}
+	parts := make([]string, 0, 5)
+	for _, token := range cfg.tokens(QuickCaptureScope{}) {
+		parts = append(parts, token.prefix+token.example)
+	}
+	return strings.Join(parts, " ")
+}
+
+// tokens returns the enabled tokens, longest prefix first so "!!" can coexist with "!". The
+// status and dependency tokens only accept values listed in scope.
+func (cfg QuickCaptureConfig) tokens(scope QuickCaptureScope) []quickCaptureToken {
+	prefix := func(value, fallback string) string {
+		value = strings.TrimSpace(value)
+		switch {
+		case value == "":
+			return fallback
+		case strings.EqualFold(value, quickCaptureOff):
+			return ""
+		default:
+			return value
+		}
+	}
+	all := []quickCaptureToken{
+		{prefix: prefix(cfg.Tag, QuickCaptureTag), example: "tag", accepts: func(value string) bool {
+			// "#123" is an issue reference, not a tag.
+			return strings.Trim(value, "0123456789") != ""
+		}, apply: func(c *QuickCapture, value string) error {
+			c.Tags = append(c.Tags, value)
+			return nil
+		}},
+		{prefix: prefix(cfg.Priority, QuickCapturePriority), example: "1", accepts: func(value string) bool {
+			return value == "1" || value == "2" || value == "3"
+		}, apply: func(c *QuickCapture, value string) error {
+			c.Priority, _ = strconv.Atoi(value)
+			return nil
+		}},
+		{prefix: prefix(cfg.Status, QuickCaptureStatus), example: "status", accepts: func(value string) bool {
+			return slices.Contains(scope.Statuses, value)
+		}, apply: func(c *QuickCapture, value string) error {
+			c.Status = value
+			return nil
+		}},
+		{prefix: prefix(cfg.Due, QuickCaptureDue), example: "YYYY-MM-DD", apply: func(c *QuickCapture, value string) error {
+			due, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return fmt.Errorf("board: quick-capture due date %q must be YYYY-MM-DD: %w", value, ErrInvalidDue)
+			}
+			c.Due = Date{Time: due}
+			return nil
+		}},
+		{prefix: prefix(cfg.Depends, QuickCaptureDepends), example: "T-000001", accepts: func(value string) bool {
+			return slices.Contains(scope.TaskIDs, value)
+		}, apply: func(c *QuickCapture, value string) error {
+			c.DependsOn = append(c.DependsOn, value)
+			return nil
+		}},
+	}
+	tokens := make([]quickCaptureToken, 0, len(all))
+	for _, token := range all {
+		if token.prefix != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return len(tokens[i].prefix) > len(tokens[j].prefix)
+	})
+	return tokens
+}
+
+// Apply copies the parsed title and metadata onto task. Tags and dependencies are added to
+// the task's own; priority, status and due date only replace the task's when present.
+func (c QuickCapture) Apply(task *Task) {
+	task.Title = c.Title
+	task.Tags = NormalizeTags(append(append([]string{}, task.Tags...), c.Tags...))
+	task.DependsOn = normalizeIDs(append(append([]string{}, task.DependsOn...), c.DependsOn...))
+	if c.Priority != 0 {
+		task.Priority = c.Priority
+	}
+	if c.Status != "" {
+		task.Status = c.Status
+	}
+	if !c.Due.IsZero() {
+		task.Due = c.Due
+	}
+}
@@ -0,0 +1,84 @@
+package board
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuickCapture(t *testing.T) {
+	due := Date{Time: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)}
+	scope := QuickCaptureScope{Statuses: []string{"todo", "doing", "done"}, TaskIDs: []string{"T-000012"}}
+	cases := []struct {
+		name    string
+		input   string
+		cfg     QuickCaptureConfig
+		want    QuickCapture
+		wantErr error
+	}{
+		{
+			name:  "all tokens",
+			input: "Fix login race #backend #auth !1 >doing ^2026-11-01 +T-000012",
+			want: QuickCapture{
+				Title:     "Fix login race",
+				Tags:      []string{"backend", "auth"},
+				Priority:  1,
+				Status:    "doing",
+				Due:       due,
+				DependsOn: []string{"T-000012"},
+			},
+		},
+		{name: "escaped and bare prefixes", input: `Ship \#1 fix ! now`, want: QuickCapture{Title: "Ship #1 fix ! now"}},
+		{name: "disabled", input: "Fix #backend !1", cfg: QuickCaptureConfig{Disabled: true}, want: QuickCapture{Title: "Fix #backend !1"}},
+		{
+			name:  "custom and disabled prefixes",
+			input: "Deploy @ops #7 p:3",
+			cfg:   QuickCaptureConfig{Tag: "@", Priority: "p:", Depends: "off"},
+			want:  QuickCapture{Title: "Deploy #7", Tags: []string{"ops"}, Priority: 3},
+		},
+		{name: "issue reference", input: "Fix issue #123 #ui", want: QuickCapture{Title: "Fix issue #123", Tags: []string{"ui"}}},
+		{name: "exclamation runs", input: "Ship it !! now !!! !4 !2", want: QuickCapture{Title: "Ship it !! now !!! !4", Priority: 2}},
+		{name: "bad due date", input: "Fix ^tomorrow", wantErr: ErrInvalidDue},
+		{name: "unknown dependency", input: "Agree +1 on this +T-000099", want: QuickCapture{Title: "Agree +1 on this +T-000099"}},
+		{name: "unknown status", input: "Latency >200ms on login >someday", want: QuickCapture{Title: "Latency >200ms on login >someday"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got, err := ParseQuickCapture(tc.input, tc.cfg, scope)
+
+			// Assert
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestQuickCaptureApplyMergesWithTask(t *testing.T) {
+	// Arrange
+	task := Task{Title: "raw", Status: "todo", Priority: DefaultPriority, Tags: []string{"api"}, DependsOn: []string{"T-000001"}}
+	capture := QuickCapture{Title: "Fix", Tags: []string{"auth", "api"}, Status: "doing", DependsOn: []string{"T-000002"}}
+
+	// Act
+	capture.Apply(&task)
+
+	// Assert
+	if task.Title != "Fix" || task.Status != "doing" || task.Priority != DefaultPriority {
+		t.Fatalf("unexpected task %+v", task)
+	}
+	if !reflect.DeepEqual(task.Tags, []string{"api", "auth"}) || !reflect.DeepEqual(task.DependsOn, []string{"T-000001", "T-000002"}) {
+		t.Fatalf("expected merged tags and dependencies, got %v %v", task.Tags, task.DependsOn)
+	}
+}
@@ -0,0 +1,253 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// rankDigits is the alphabet used for lexicographic ranks. Its byte order matches the digit
+// order, so ranks compare with plain string comparison.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// RankPlacement positions a task relative to another task in the same column.
+// Exactly one of Before or After must be set. Order optionally lists the column's task IDs as
+// displayed, which decides where unranked tasks sit; without it unranked tasks are ordered as by
+// `task list --sort rank` (priority, then ID).
+type RankPlacement struct {
+	Before string
+	After  string
+	Order  []string
+}
+
+// ValidateRank reports whether rank is a well-formed lexicographic rank. Ranks use 0-9 and a-z
+// and never end in "0", which keeps room to insert before any rank.
+func ValidateRank(rank string) error {
+	if rank == "" {
+		return nil
+	}
+	for _, ch := range rank {
+		if !strings.ContainsRune(rankDigits, ch) {
+			return fmt.Errorf("board: rank %q contains %q: %w", rank, ch, ErrInvalidRank)
+		}
+	}
+	if strings.HasSuffix(rank, "0") {
+		return fmt.Errorf("board: rank %q must not end in 0: %w", rank, ErrInvalidRank)
+	}
+	return nil
+}
+
+// RankBetween returns a rank that sorts strictly between lower and upper. An empty lower means
+// "before everything" and an empty upper means "after everything".
+func RankBetween(lower, upper string) (string, error) {
+	if err := ValidateRank(lower); err != nil {
+		return "", err
+	}
+	if err := ValidateRank(upper); err != nil {
+		return "", err
+	}
+	if upper != "" && lower >= upper {
+		return "", fmt.Errorf("board: rank %q is not before %q: %w", lower, upper, ErrInvalidRank)
+	}
+	return rankMidpoint(lower, upper, upper != ""), nil
+}
+
+// rankMidpoint computes the shortest rank between lower and upper (unbounded when !bounded).
+// lower < upper is assumed and neither ends in "0".
+func rankMidpoint(lower, upper string, bounded bool) string {
+	if bounded {
+		n := 0
+		for n < len(upper) && rankDigitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			return upper[:n] + rankMidpoint(rankTail(lower, n), upper[n:], true)
+		}
+	}
+	low := 0
+	if lower != "" {
+		low = strings.IndexByte(rankDigits, lower[0])
+	}
+	high := len(rankDigits)
+	if bounded {
+		high = strings.IndexByte(rankDigits, upper[0])
+	}
+	if high-low > 1 {
+		return string(rankDigits[(low+high+1)/2])
+	}
+	if bounded && len(upper) > 1 {
+		return upper[:1]
+	}
+	return string(rankDigits[low]) + rankMidpoint(rankTail(lower, 1), "", false)
+}
+
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+func rankTail(rank string, n int) string {
+	if n >= len(rank) {
+		return ""
+	}
+	return rank[n:]
+}
+
+// CompareRank orders two tasks by rank. Ranked tasks sort before unranked ones; ok is false when
+// neither task has a rank so callers can fall back to their own ordering.
+func CompareRank(a, b Task) (cmp int, ok bool) {
+	switch {
+	case a.Rank == "" && b.Rank == "":
+		return 0, false
+	case a.Rank == "":
+		return 1, true
+	case b.Rank == "":
+		return -1, true
+	default:
+		return strings.Compare(a.Rank, b.Rank), true
+	}
+}
+
+// RankTask moves a task before or after another task of the same column. Usually only the moved
+// task's rank is rewritten. It returns the new rank.
+func (r *Repository) RankTask(id string, placement RankPlacement) (string, error) {
+	return r.RankTaskContext(context.Background(), id, placement)
+}
+
+// RankTaskContext moves a task relative to another task of the same column, honoring ctx
+// cancellation. When the target slot lies among unranked tasks, which sort after every ranked
+// one, the unranked tasks ahead of the slot are ranked first in their current order, so the
+// anchor may be ranked as well.
+func (r *Repository) RankTaskContext(ctx context.Context, id string, placement RankPlacement) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+	if err := validateID(id); err != nil {
+		return "", err
+	}
+	before := strings.TrimSpace(placement.Before)
+	after := strings.TrimSpace(placement.After)
+	if (before == "") == (after == "") {
+		return "", fmt.Errorf("board: exactly one of before or after is required: %w", ErrInvalidRank)
+	}
+	anchorID := before
+	if anchorID == "" {
+		anchorID = after
+	}
+	if err := validateID(anchorID); err != nil {
+		return "", err
+	}
+	if anchorID == id {
+		return "", fmt.Errorf("board: cannot rank %s relative to itself: %w", id, ErrInvalidRank)
+	}
+	if err := ensureDirExists(r.tasksDir); err != nil {
+		return "", err
+	}
+	tasks, err := r.readTasksFromDirContext(ctx, r.tasksDir)
+	if err != nil {
+		return "", err
+	}
+
+	var moved, anchor *Task
+	for i := range tasks {
+		switch tasks[i].ID {
+		case id:
+			moved = &tasks[i]
+		case anchorID:
+			anchor = &tasks[i]
+		}
+	}
+	if moved == nil || anchor == nil {
+		return "", fmt.Errorf("board: %w", ErrTaskNotFound)
+	}
+	if !strings.EqualFold(moved.Status, anchor.Status) {
+		return "", fmt.Errorf("board: %s and %s are in different columns: %w", id, anchorID, ErrInvalidRank)
+	}
+
+	column := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.ID != id && strings.EqualFold(task.Status, anchor.Status) {
+			column = append(column, task)
+		}
+	}
+	sortColumnByRank(column, placement.Order)
+	slot := 0
+	for slot < len(column) && column[slot].ID != anchorID {
+		slot++
+	}
+	if after != "" {
+		slot++
+	}
+
+	// Rank the unranked tasks ahead of the slot so the moved task can follow them.
+	var lower string
+	for i := 0; i < slot; i++ {
+		if column[i].Rank == "" {
+			rank, err := RankBetween(lower, "")
+			if err != nil {
+				return "", err
+			}
+			if err := r.updateTaskLockedContext(ctx, column[i].ID, func(task *Task) error {
+				task.Rank = rank
+				return nil
+			}); err != nil {
+				return "", err
+			}
+			column[i].Rank = rank
+		}
+		lower = column[i].Rank
+	}
+	var upper string
+	if slot < len(column) {
+		upper = column[slot].Rank
+	}
+	rank, err := RankBetween(lower, upper)
+	if err != nil {
+		return "", err
+	}
+	if err := r.updateTaskLockedContext(ctx, id, func(task *Task) error {
+		task.Rank = rank
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	return rank, nil
+}
+
+// sortColumnByRank orders a column as displayed: ranked tasks by rank, then unranked tasks in
+// the given display order, falling back to priority and ID for tasks it does not list.
+func sortColumnByRank(tasks []Task, order []string) {
+	position := make(map[string]int, len(order))
+	for i, id := range order {
+		if _, ok := position[id]; !ok {
+			position[id] = i
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if cmp, ok := CompareRank(tasks[i], tasks[j]); ok && cmp != 0 {
+			return cmp < 0
+		}
+		left, leftOK := position[tasks[i].ID]
+		right, rightOK := position[tasks[j].ID]
+		if leftOK != rightOK {
+			return leftOK
+		}
+		if leftOK && left != right {
+			return left < right
+		}
+		leftPriority := effectivePriority(tasks[i].Priority)
+		rightPriority := effectivePriority(tasks[j].Priority)
+		if leftPriority != rightPriority {
+			return leftPriority < rightPriority
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
@@ -0,0 +1,170 @@
+package board
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	cases := []struct {
+		lower   string
+		upper   string
+		want    string
+		wantErr error
+	}{
+		{lower: "", upper: "", want: "i"},
+		{lower: "i", upper: "", want: "r"},
+		{lower: "", upper: "i", want: "9"},
+		{lower: "a", upper: "b", want: "ai"},
+		{lower: "a1", upper: "a2", want: "a1i"},
+		{lower: "az", upper: "b", want: "azi"},
+		{lower: "", upper: "01", want: "00i"},
+		{lower: "b", upper: "a", wantErr: ErrInvalidRank},
+		{lower: "a0", upper: "", wantErr: ErrInvalidRank},
+		{lower: "A", upper: "", wantErr: ErrInvalidRank},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.lower+"_"+tc.upper, func(t *testing.T) {
+			// Act
+			got, err := RankBetween(tc.lower, tc.upper)
+
+			// Assert
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+			if got <= tc.lower || (tc.upper != "" && got >= tc.upper) {
+				t.Fatalf("rank %q not between %q and %q", got, tc.lower, tc.upper)
+			}
+		})
+	}
+}
+
+func TestRankTaskRewritesOnlyMovedTask(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	first, _ := repo.CreateTask(Task{Title: "First", Status: "todo", Rank: "c"})
+	second, _ := repo.CreateTask(Task{Title: "Second", Status: "todo", Rank: "m"})
+	third, _ := repo.CreateTask(Task{Title: "Third", Status: "todo"})
+
+	// Act
+	unrankedRank, unrankedErr := repo.RankTask(third.ID, RankPlacement{Before: first.ID})
+	secondRank, secondErr := repo.RankTask(second.ID, RankPlacement{Before: first.ID})
+	secondBefore, readErr := os.ReadFile(second.FilePath)
+	firstRank, firstErr := repo.RankTask(first.ID, RankPlacement{After: third.ID})
+	secondAfter, _ := os.ReadFile(second.FilePath)
+	tasks, _ := repo.GetAllTasks()
+	sorted := FilterAndSortTasks(tasks, ListOptions{SortBy: "rank"})
+
+	// Assert
+	if unrankedErr != nil || secondErr != nil || firstErr != nil || readErr != nil {
+		t.Fatalf("unexpected errors: %v %v %v %v", unrankedErr, secondErr, firstErr, readErr)
+	}
+	if unrankedRank != "6" || secondRank != "9" || firstRank != "8" {
+		t.Fatalf("unexpected ranks %q %q %q", unrankedRank, secondRank, firstRank)
+	}
+	if string(secondBefore) != string(secondAfter) {
+		t.Fatalf("expected untouched task file to stay unchanged")
+	}
+	got := []string{sorted[0].ID, sorted[1].ID, sorted[2].ID}
+	want := []string{third.ID, first.ID, second.ID}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected order %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRankTaskRejectsInvalidPlacements(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	ranked, _ := repo.CreateTask(Task{Title: "Ranked", Status: "todo", Rank: "i"})
+	doing, _ := repo.CreateTask(Task{Title: "Doing", Status: "doing", Rank: "i"})
+
+	// Act
+	_, columnErr := repo.RankTask(ranked.ID, RankPlacement{Before: doing.ID})
+	_, bothErr := repo.RankTask(ranked.ID, RankPlacement{Before: doing.ID, After: doing.ID})
+	_, selfErr := repo.RankTask(ranked.ID, RankPlacement{Before: ranked.ID})
+	_, createErr := repo.CreateTask(Task{Title: "Bad", Rank: "a0"})
+
+	// Assert
+	for name, err := range map[string]error{
+		"other column": columnErr,
+		"both anchors": bothErr,
+		"self":         selfErr,
+		"create":       createErr,
+	} {
+		if !errors.Is(err, ErrInvalidRank) {
+			t.Fatalf("%s: expected ErrInvalidRank, got %v", name, err)
+		}
+	}
+}
+
+func TestRankTaskAmongUnrankedTasks(t *testing.T) {
+	cases := []struct {
+		name      string
+		move      int
+		placement func(ids []string) RankPlacement
+		want      []int
+	}{
+		{
+			name:      "down after an unranked task",
+			move:      0,
+			placement: func(ids []string) RankPlacement { return RankPlacement{After: ids[1]} },
+			want:      []int{1, 0, 2},
+		},
+		{
+			name:      "up before an unranked task",
+			move:      2,
+			placement: func(ids []string) RankPlacement { return RankPlacement{Before: ids[1]} },
+			want:      []int{0, 2, 1},
+		},
+		{
+			name: "display order decides the unranked order",
+			move: 0,
+			placement: func(ids []string) RankPlacement {
+				return RankPlacement{After: ids[1], Order: []string{ids[2], ids[0], ids[1]}}
+			},
+			want: []int{2, 1, 0},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, _, _ := setupRepo(t)
+			ids := make([]string, 0, 3)
+			for _, title := range []string{"First", "Second", "Third"} {
+				task, err := repo.CreateTask(Task{Title: title, Status: "todo"})
+				if err != nil {
+					t.Fatalf("create task: %v", err)
+				}
+				ids = append(ids, task.ID)
+			}
+
+			// Act
+			_, err := repo.RankTask(ids[tc.move], tc.placement(ids))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("rank task: %v", err)
+			}
+			tasks, _ := repo.GetAllTasks()
+			sorted := FilterAndSortTasks(tasks, ListOptions{SortBy: "rank"})
+			for i, index := range tc.want {
+				if sorted[i].ID != ids[index] {
+					t.Fatalf("position %d: expected %s, got %s", i, ids[index], sorted[i].ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package board
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReleaseWindowOptions selects the tasks ReleasedTasks reports. Histories supplies git
+// timelines keyed by task ID (see TaskHistoriesContext); tasks without one fall back to their
+// completed and status_changed frontmatter.
+type ReleaseWindowOptions struct {
+	From      time.Time
+	To        time.Time
+	Archived  []Task
+	Histories map[string][]TaskChange
+}
+
+// ReleasedTask is a task that entered a done status or was archived within a release window.
+type ReleasedTask struct {
+	Task     Task
+	At       time.Time
+	Archived bool
+}
+
+// ReleasedTasks returns the tasks that entered a done status or moved into archive/tasks
+// between opts.From (inclusive) and opts.To (exclusive), oldest first. At is the latest such
+// event within the window.
+func ReleasedTasks(tasks []Task, opts ReleaseWindowOptions) []ReleasedTask {
+	inWindow := func(at time.Time) bool {
+		return !at.IsZero() && !at.Before(opts.From) && at.Before(opts.To)
+	}
+	released := make([]ReleasedTask, 0)
+	all := append(append([]Task(nil), tasks...), opts.Archived...)
+	for i, task := range all {
+		archived := i >= len(tasks)
+		history := opts.Histories[task.ID]
+		events := doneEntries(StatusTimeline(task, history, ""))
+		if archived {
+			events = append(events, archiveEntries(task, history)...)
+		}
+		var at time.Time
+		for _, event := range events {
+			if inWindow(event) && event.After(at) {
+				at = event
+			}
+		}
+		if !at.IsZero() {
+			released = append(released, ReleasedTask{Task: task, At: at, Archived: archived})
+		}
+	}
+	sort.SliceStable(released, func(i, j int) bool {
+		if !released[i].At.Equal(released[j].At) {
+			return released[i].At.Before(released[j].At)
+		}
+		return released[i].Task.ID < released[j].Task.ID
+	})
+	return released
+}
+
+// doneEntries returns the times a timeline moved into a done status from any other status.
+func doneEntries(timeline []StatusTransition) []time.Time {
+	entries := make([]time.Time, 0)
+	for i, transition := range timeline {
+		if IsDoneStatus(transition.Status) && (i == 0 || !IsDoneStatus(timeline[i-1].Status)) {
+			entries = append(entries, transition.At)
+		}
+	}
+	return entries
+}
+
+// archiveEntries returns the times an archived task's file moved into archive/tasks. Without
+// history the move is assumed to happen when the task was completed or last changed status.
+func archiveEntries(task Task, history []TaskChange) []time.Time {
+	entries := make([]time.Time, 0)
+	inArchive := false
+	for _, change := range history {
+		archived := strings.HasPrefix(change.Path, "archive/tasks/") || strings.Contains(change.Path, "/archive/tasks/")
+		if archived && !inArchive {
+			entries = append(entries, change.Commit.Date)
+		}
+		inArchive = archived
+	}
+	if len(history) > 0 {
+		return entries
+	}
+	switch {
+	case !task.Completed.IsZero():
+		entries = append(entries, task.Completed.Time)
+	case !task.StatusChanged.IsZero():
+		entries = append(entries, task.StatusChanged)
+	}
+	return entries
+}
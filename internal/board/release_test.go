@@ -0,0 +1,54 @@
+package board
+
+import (
+	"testing"
+	"time"
+
+	"mochi-sticky/internal/git"
+)
+
+func TestReleasedTasks(t *testing.T) {
+	// Arrange
+	tasks := []Task{
+		{ID: "T-1", Title: "Done in window", Status: "done"},
+		{ID: "T-2", Title: "Done before", Status: "done", Completed: Date{Time: day("2026-09-20")}},
+		{ID: "T-3", Title: "Frontmatter only", Status: "done", Completed: Date{Time: day("2026-10-03")}},
+		{ID: "T-4", Title: "Still open", Status: "doing"},
+	}
+	archived := []Task{{ID: "T-5", Title: "Archived in window", Status: "done"}}
+	archivedAt := day("2026-10-04").Add(10 * time.Hour)
+	histories := map[string][]TaskChange{
+		"T-1": {
+			statusChange(day("2026-09-28"), "", "todo"),
+			statusChange(day("2026-10-02").Add(9*time.Hour), "todo", "done"),
+		},
+		"T-4": {
+			statusChange(day("2026-09-28"), "", "todo"),
+			statusChange(day("2026-10-02"), "todo", "doing"),
+		},
+		"T-5": {
+			statusChange(day("2026-09-10"), "", "todo"),
+			statusChange(day("2026-09-15"), "todo", "done"),
+			{Commit: git.Commit{Date: archivedAt}, Path: ".sticky/boards/main/archive/tasks/T-5.md"},
+		},
+	}
+
+	// Act
+	released := ReleasedTasks(tasks, ReleaseWindowOptions{
+		From:      day("2026-10-01"),
+		To:        day("2026-10-08"),
+		Archived:  archived,
+		Histories: histories,
+	})
+
+	// Assert
+	if len(released) != 3 {
+		t.Fatalf("expected 3 released tasks, got %+v", released)
+	}
+	if released[0].Task.ID != "T-1" || released[1].Task.ID != "T-3" || released[2].Task.ID != "T-5" {
+		t.Fatalf("expected T-1, T-3 and T-5 oldest first, got %+v", released)
+	}
+	if !released[2].Archived || !released[2].At.Equal(archivedAt) {
+		t.Fatalf("expected T-5 to be released when archived, got %+v", released[2])
+	}
+}
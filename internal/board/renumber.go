@@ -0,0 +1,314 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/shared"
+)
+
+// Renumbered records a task FixDuplicateIDs moved to a new ID.
+type Renumbered struct {
+	OldID string
+	NewID string
+	UID   string
+	Title string
+	// Path is the task file after the move.
+	Path string
+	// Dependents lists the tasks added on the renumbered task's branch whose depends_on pointed
+	// at OldID and now point at NewID.
+	Dependents []string
+	// Ambiguous lists the other tasks whose depends_on names OldID. They may mean the task that
+	// kept the ID and are left unchanged.
+	Ambiguous []string
+}
+
+// Branch describes what a merged branch added, so FixDuplicateIDs can tell which references
+// to an old ID were written on the renumbered task's own branch. The zero Branch claims
+// nothing, leaving every reference ambiguous.
+type Branch struct {
+	// TaskUIDs are the UIDs of the tasks the branch added.
+	TaskUIDs []string
+	// Added reports whether the file at path was added on the branch; nil means none was.
+	Added func(path string) bool
+}
+
+// hasTask reports whether task was added on the branch, by UID or else by file.
+func (b Branch) hasTask(task Task) bool {
+	if task.UID != "" && slices.Contains(b.TaskUIDs, task.UID) {
+		return true
+	}
+	return b.hasFile(task.FilePath)
+}
+
+func (b Branch) hasFile(path string) bool {
+	return b.Added != nil && b.Added(path)
+}
+
+// FixDuplicateIDs reassigns tasks that share an ID with another task; see
+// FixDuplicateIDsContext.
+func (r *Repository) FixDuplicateIDs(dryRun bool, branch Branch) ([]Renumbered, error) {
+	return r.FixDuplicateIDsContext(context.Background(), dryRun, branch)
+}
+
+// FixDuplicateIDsContext finds active and archived tasks that share an ID but have different
+// UIDs, as left behind when two branches created tasks from the same next_id. The task whose
+// file is named after the ID (else the oldest) keeps it; every other one gets a new ID from
+// the board's id_strategy and its file is renamed. A depends_on naming the old ID is only
+// rewritten when both the renumbered task and the dependent task were added on branch, the
+// merged branch; other dependents are reported as ambiguous and left alone. With dryRun set
+// nothing is written. It honors ctx cancellation.
+func (r *Repository) FixDuplicateIDsContext(ctx context.Context, dryRun bool, branch Branch) ([]Renumbered, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := ensureDirExists(r.tasksDir); err != nil {
+		return nil, err
+	}
+	tasks, err := r.readTasksFromDirContext(ctx, r.tasksDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := shared.Files().Stat(r.archiveTasks); err == nil {
+		archived, err := r.readTasksFromDirContext(ctx, r.archiveTasks)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, archived...)
+	}
+
+	groups := map[string][]int{}
+	ids := make([]string, 0)
+	for i, task := range tasks {
+		if _, ok := groups[task.ID]; !ok {
+			ids = append(ids, task.ID)
+		}
+		groups[task.ID] = append(groups[task.ID], i)
+	}
+	sort.Strings(ids)
+	taken := func(id string) bool {
+		_, used := groups[id]
+		return used || r.taskFileExists(id)
+	}
+
+	config, err := r.loadConfigContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Renumbered, 0)
+	moved := map[int]Renumbered{}
+	for _, id := range ids {
+		members := groups[id]
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(a, b int) bool {
+			left, right := tasks[members[a]], tasks[members[b]]
+			if keepsID(left, r.tasksDir) != keepsID(right, r.tasksDir) {
+				return keepsID(left, r.tasksDir)
+			}
+			if !left.Created.Equal(right.Created.Time) {
+				return left.Created.Before(right.Created.Time)
+			}
+			return left.UID < right.UID
+		})
+		keeper := tasks[members[0]]
+		for _, index := range members[1:] {
+			task := tasks[index]
+			if task.UID != "" && task.UID == keeper.UID {
+				// The same task twice is a copy, not a duplicate ID.
+				continue
+			}
+			newID, err := allocateID(&config, task.UID, r.now(), taken)
+			if err != nil {
+				return nil, err
+			}
+			groups[newID] = []int{index}
+			moved[index] = Renumbered{
+				OldID: id,
+				NewID: newID,
+				UID:   task.UID,
+				Title: task.Title,
+				Path:  filepath.Join(filepath.Dir(task.FilePath), newID+".md"),
+			}
+		}
+	}
+	if len(moved) == 0 {
+		return results, nil
+	}
+
+	// Only the renumbered task added on branch owns the references branch made to its old ID.
+	renamed := map[string]bool{}
+	owned := map[string]string{}
+	for index, change := range moved {
+		renamed[change.OldID] = true
+		if branch.hasTask(tasks[index]) {
+			owned[change.OldID] = change.NewID
+		}
+	}
+	dependents := map[string][]string{}
+	ambiguous := map[string][]string{}
+	updatedDeps := map[int][]string{}
+	for index, task := range tasks {
+		deps := make([]string, 0, len(task.DependsOn))
+		for _, dep := range task.DependsOn {
+			if !renamed[dep] || dep == task.ID {
+				deps = append(deps, dep)
+				continue
+			}
+			if newID, ok := owned[dep]; ok && branch.hasTask(task) {
+				dependents[dep] = append(dependents[dep], task.ID)
+				dep = newID
+			} else {
+				ambiguous[dep] = append(ambiguous[dep], task.ID)
+			}
+			deps = append(deps, dep)
+		}
+		if !slices.Equal(deps, task.DependsOn) {
+			updatedDeps[index] = deps
+		}
+	}
+	for _, change := range moved {
+		if owned[change.OldID] == change.NewID {
+			change.Dependents = dependents[change.OldID]
+		}
+		change.Ambiguous = ambiguous[change.OldID]
+		results = append(results, change)
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].OldID != results[b].OldID {
+			return results[a].OldID < results[b].OldID
+		}
+		return results[a].NewID < results[b].NewID
+	})
+	if dryRun {
+		return results, nil
+	}
+
+	if err := r.saveConfigContext(ctx, config); err != nil {
+		return nil, fmt.Errorf("board: failed to update config: %w", err)
+	}
+	for index, task := range tasks {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		change, isMoved := moved[index]
+		deps, isUpdated := updatedDeps[index]
+		if !isMoved && !isUpdated {
+			continue
+		}
+		if isUpdated {
+			task.DependsOn = deps
+		}
+		path := task.FilePath
+		if isMoved {
+			task.ID = change.NewID
+			path = change.Path
+		}
+		if err := r.writeTaskFile(path, task); err != nil {
+			return nil, err
+		}
+		if path != task.FilePath {
+			if err := shared.Files().Remove(task.FilePath); err != nil {
+				return nil, fmt.Errorf("board: failed to remove task file %s: %w", task.FilePath, err)
+			}
+		}
+	}
+	return results, nil
+}
+
+// keepsID reports whether task is the preferred holder of its ID: an active task whose file is
+// named after the ID.
+func keepsID(task Task, tasksDir string) bool {
+	return filepath.Dir(task.FilePath) == tasksDir && strings.TrimSuffix(filepath.Base(task.FilePath), ".md") == task.ID
+}
+
+func (r *Repository) writeTaskFile(path string, task Task) error {
+	content, err := r.parser.Render(task)
+	if err != nil {
+		return fmt.Errorf("board: failed to render task %s: %w", task.ID, err)
+	}
+	if err := shared.Files().WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("board: failed to write task file %s: %w", path, err)
+	}
+	return nil
+}
+
+// RewriteTaskReferences finds whole-word mentions of the old IDs in results in every Markdown
+// file under dirs (such as the wiki and ADR roots). Mentions in files added on branch are
+// rewritten to the new ID of the renumbered task added on branch; every other mention is left
+// alone and reported as ambiguous. Both maps go from file path to the old IDs it mentioned.
+// Missing dirs are skipped. With dryRun set nothing is written.
+func RewriteTaskReferences(dirs []string, results []Renumbered, branch Branch, dryRun bool) (rewritten, ambiguous map[string][]string, err error) {
+	rewritten = map[string][]string{}
+	ambiguous = map[string][]string{}
+	if len(results) == 0 {
+		return rewritten, ambiguous, nil
+	}
+	owned := map[string]string{}
+	oldIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.UID != "" && slices.Contains(branch.TaskUIDs, result.UID) {
+			owned[result.OldID] = result.NewID
+		}
+		oldIDs = append(oldIDs, regexp.QuoteMeta(result.OldID))
+	}
+	sort.Strings(oldIDs)
+	pattern := regexp.MustCompile(`\b(` + strings.Join(slices.Compact(oldIDs), "|") + `)\b`)
+	for _, dir := range dirs {
+		if _, err := shared.Files().Stat(dir); err != nil {
+			continue
+		}
+		err := shared.WalkDir(shared.Files(), dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || filepath.Ext(path) != ".md" {
+				return nil
+			}
+			data, err := shared.Files().ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("board: failed to read %s: %w", path, err)
+			}
+			found := pattern.FindAllString(string(data), -1)
+			if len(found) == 0 {
+				return nil
+			}
+			slices.Sort(found)
+			found = slices.Compact(found)
+			onBranch := branch.hasFile(path)
+			for _, oldID := range found {
+				if _, ok := owned[oldID]; ok && onBranch {
+					rewritten[path] = append(rewritten[path], oldID)
+				} else {
+					ambiguous[path] = append(ambiguous[path], oldID)
+				}
+			}
+			if dryRun || len(rewritten[path]) == 0 {
+				return nil
+			}
+			updated := pattern.ReplaceAllStringFunc(string(data), func(oldID string) string {
+				if newID, ok := owned[oldID]; ok {
+					return newID
+				}
+				return oldID
+			})
+			if err := shared.Files().WriteFile(path, []byte(updated), 0o644); err != nil {
+				return fmt.Errorf("board: failed to write %s: %w", path, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return rewritten, ambiguous, nil
+}
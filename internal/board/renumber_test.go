@@ -0,0 +1,232 @@
+package board
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"mochi-sticky/internal/shared"
+)
+
+func TestCreateTaskUsesIDStrategy(t *testing.T) {
+	tests := []struct {
+		strategy string
+		pattern  string
+	}{
+		{strategy: IDStrategySequential, pattern: `^T-000001$`},
+		{strategy: IDStrategyHash, pattern: `^T-[0-9A-F]{7}$`},
+		{strategy: IDStrategyULID, pattern: `^T-[0-9A-HJKMNP-TV-Z]{26}$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			// Arrange
+			repo, _, _ := setupRepo(t)
+			config, err := repo.LoadConfig()
+			if err != nil {
+				t.Fatalf("load config: %v", err)
+			}
+			config.IDStrategy = tt.strategy
+			if err := repo.SaveConfig(config); err != nil {
+				t.Fatalf("save config: %v", err)
+			}
+
+			// Act
+			created, err := repo.CreateTask(Task{Title: "Strategy", Status: "todo"})
+			if err != nil {
+				t.Fatalf("create task: %v", err)
+			}
+
+			// Assert
+			if !regexp.MustCompile(tt.pattern).MatchString(created.ID) {
+				t.Fatalf("expected id matching %s, got %s", tt.pattern, created.ID)
+			}
+			if _, err := os.Stat(created.FilePath); err != nil || filepath.Base(created.FilePath) != created.ID+".md" {
+				t.Fatalf("expected task file named after the id, got %s (%v)", created.FilePath, err)
+			}
+		})
+	}
+}
+
+func TestAllocateIDSkipsTakenIDs(t *testing.T) {
+	// Arrange
+	config := Config{NextID: 1}
+	taken := func(id string) bool { return id == "T-000001" || id == "T-000002" }
+
+	// Act
+	id, err := allocateID(&config, "uid", time.Time{}, taken)
+	if err != nil {
+		t.Fatalf("allocate: %v", err)
+	}
+
+	// Assert
+	if id != "T-000003" || config.NextID != 4 {
+		t.Fatalf("expected T-000003 and next_id 4, got %s and %d", id, config.NextID)
+	}
+}
+
+// reservedFS reports a task file that only exists in storage, such as a git revision.
+type reservedFS struct {
+	shared.OSFS
+	path string
+}
+
+func (f reservedFS) Stat(name string) (fs.FileInfo, error) {
+	if name == f.path {
+		return fstest.MapFS{filepath.Base(name): {}}.Stat(filepath.Base(name))
+	}
+	return f.OSFS.Stat(name)
+}
+
+func TestCreateTaskSkipsIDsTakenInStorage(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	restore := shared.UseFiles(reservedFS{path: filepath.Join(repo.archiveTasks, "T-000001.md")})
+	defer restore()
+
+	// Act
+	created, err := repo.CreateTask(Task{Title: "Skip archived", Status: "todo"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if created.ID != "T-000002" {
+		t.Fatalf("expected the archived id to be skipped, got %s", created.ID)
+	}
+}
+
+func TestNormalizeIDStrategyRejectsUnknown(t *testing.T) {
+	if _, err := NormalizeIDStrategy("author"); !errors.Is(err, ErrInvalidIDStrategy) {
+		t.Fatalf("expected ErrInvalidIDStrategy, got %v", err)
+	}
+}
+
+func TestFixDuplicateIDsRenumbersAndRewritesDependencies(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	keeper, err := repo.CreateTask(Task{Title: "Ours", Status: "todo"})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	dependent, err := repo.CreateTask(Task{Title: "Dependent", Status: "todo", DependsOn: []string{keeper.ID}})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	// A task from another branch that was handed the same ID, and a task from that branch
+	// depending on it.
+	duplicate := Task{ID: keeper.ID, UID: "0f0f0f0f-theirs", Title: "Theirs", Status: "todo", Created: keeper.Created}
+	asidePath := filepath.Join(repo.tasksDir, keeper.ID+"-0f0f0f0f.md")
+	if err := repo.writeTaskFile(asidePath, duplicate); err != nil {
+		t.Fatalf("write duplicate: %v", err)
+	}
+	theirsDependent := Task{ID: "T-000010", UID: "1e1e1e1e-theirs", Title: "Theirs dependent", Status: "todo", DependsOn: []string{keeper.ID}, Created: keeper.Created}
+	if err := repo.writeTaskFile(filepath.Join(repo.tasksDir, "T-000010.md"), theirsDependent); err != nil {
+		t.Fatalf("write dependent: %v", err)
+	}
+	branch := Branch{TaskUIDs: []string{duplicate.UID, theirsDependent.UID}}
+
+	// Act
+	unknown, err := repo.FixDuplicateIDs(true, Branch{})
+	if err != nil {
+		t.Fatalf("dry run without branch: %v", err)
+	}
+	preview, err := repo.FixDuplicateIDs(true, branch)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	_, statErr := os.Stat(asidePath)
+	results, err := repo.FixDuplicateIDs(false, branch)
+	if err != nil {
+		t.Fatalf("fix duplicates: %v", err)
+	}
+	again, err := repo.FixDuplicateIDs(false, branch)
+	if err != nil {
+		t.Fatalf("fix duplicates again: %v", err)
+	}
+
+	// Assert
+	if statErr != nil {
+		t.Fatalf("expected dry run to leave files alone: %v", statErr)
+	}
+	if len(unknown) != 1 || len(unknown[0].Dependents) != 0 || !slices.Equal(unknown[0].Ambiguous, []string{dependent.ID, theirsDependent.ID}) {
+		t.Fatalf("expected every dependent to be ambiguous without a branch, got %+v", unknown)
+	}
+	if len(preview) != 1 || len(results) != 1 || len(again) != 0 {
+		t.Fatalf("expected one renumbered task then none, got %v, %v, %v", preview, results, again)
+	}
+	result := results[0]
+	if result.OldID != keeper.ID || result.NewID != "T-000003" || result.UID != duplicate.UID {
+		t.Fatalf("expected theirs moved to T-000003, got %+v", result)
+	}
+	if !slices.Equal(result.Dependents, []string{theirsDependent.ID}) || !slices.Equal(result.Ambiguous, []string{dependent.ID}) {
+		t.Fatalf("expected %s rewritten and %s ambiguous, got %+v", theirsDependent.ID, dependent.ID, result)
+	}
+	if _, err := os.Stat(asidePath); !os.IsNotExist(err) {
+		t.Fatalf("expected the duplicate file to be renamed, got %v", err)
+	}
+	moved, err := repo.GetTaskByID(result.NewID)
+	if err != nil || moved.Title != "Theirs" {
+		t.Fatalf("expected Theirs at %s, got %+v (%v)", result.NewID, moved, err)
+	}
+	kept, err := repo.GetTaskByID(keeper.ID)
+	if err != nil || kept.Title != "Ours" {
+		t.Fatalf("expected Ours to keep %s, got %+v (%v)", keeper.ID, kept, err)
+	}
+	ours, err := repo.GetTaskByID(dependent.ID)
+	if err != nil || !slices.Equal(ours.DependsOn, []string{keeper.ID}) {
+		t.Fatalf("expected the keeper's dependent left on %s, got %v (%v)", keeper.ID, ours.DependsOn, err)
+	}
+	theirs, err := repo.GetTaskByID(theirsDependent.ID)
+	if err != nil || !slices.Equal(theirs.DependsOn, []string{result.NewID}) {
+		t.Fatalf("expected depends_on rewritten to %s, got %v (%v)", result.NewID, theirs.DependsOn, err)
+	}
+}
+
+func TestRewriteTaskReferencesOnlyRewritesBranchFiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	page := filepath.Join(dir, "notes.md")
+	other := filepath.Join(dir, "other.md")
+	if err := os.WriteFile(page, []byte("See T-000001, not T-0000012.\n"), 0o644); err != nil {
+		t.Fatalf("write page: %v", err)
+	}
+	if err := os.WriteFile(other, []byte("Ours: T-000001.\n"), 0o644); err != nil {
+		t.Fatalf("write page: %v", err)
+	}
+	results := []Renumbered{{OldID: "T-000001", NewID: "T-000009", UID: "theirs"}}
+	branch := Branch{TaskUIDs: []string{"theirs"}, Added: func(path string) bool { return path == page }}
+
+	// Act
+	rewritten, ambiguous, err := RewriteTaskReferences([]string{dir, filepath.Join(dir, "missing")}, results, branch, false)
+	if err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+	data, err := os.ReadFile(page)
+	if err != nil {
+		t.Fatalf("read page: %v", err)
+	}
+	otherData, err := os.ReadFile(other)
+	if err != nil {
+		t.Fatalf("read page: %v", err)
+	}
+
+	// Assert
+	if len(rewritten) != 1 || !slices.Equal(rewritten[page], []string{"T-000001"}) {
+		t.Fatalf("expected %s rewritten, got %v", page, rewritten)
+	}
+	if len(ambiguous) != 1 || !slices.Equal(ambiguous[other], []string{"T-000001"}) {
+		t.Fatalf("expected %s ambiguous, got %v", other, ambiguous)
+	}
+	if string(data) != "See T-000009, not T-0000012.\n" {
+		t.Fatalf("unexpected rewrite:\n%s", data)
+	}
+	if string(otherData) != "Ours: T-000001.\n" {
+		t.Fatalf("expected the other page left alone:\n%s", otherData)
+	}
+}
@@ -96,8 +96,8 @@ func NewRepositoryForBoardWithStorage(baseDir, boardID, storageRoot string) (*Re
 	registryPath := paths.Boards
 	if strings.TrimSpace(cfg.Paths.Boards) == "" {
 		legacyRegistry := filepath.Join(stickyDir, "boards.yaml")
-		if _, err := os.Stat(registryPath); err != nil && os.IsNotExist(err) {
-			if _, legacyErr := os.Stat(legacyRegistry); legacyErr == nil {
+		if _, err := shared.Files().Stat(registryPath); err != nil && os.IsNotExist(err) {
+			if _, legacyErr := shared.Files().Stat(legacyRegistry); legacyErr == nil {
 				registryPath = legacyRegistry
 			}
 		}
@@ -118,7 +118,7 @@ func NewRepositoryForBoardWithStorage(baseDir, boardID, storageRoot string) (*Re
 
 func (r *Repository) selectBoard(boardID string) error {
 	registryPath := r.registryPath
-	if _, err := os.Stat(registryPath); err == nil {
+	if _, err := shared.Files().Stat(registryPath); err == nil {
 		registry, err := r.loadBoardRegistry()
 		if err != nil {
 			return err
@@ -201,7 +201,7 @@ func (r *Repository) GetAllTasksContext(ctx context.Context) ([]Task, error) {
 		return nil, err
 	}
 
-	entries, err := os.ReadDir(r.tasksDir)
+	entries, err := shared.Files().ReadDir(r.tasksDir)
 	if err != nil {
 		return nil, fmt.Errorf("board: failed to read tasks directory: %w", err)
 	}
@@ -223,7 +223,7 @@ func (r *Repository) GetAllTasksContext(ctx context.Context) ([]Task, error) {
 		if err := shared.EnsureInDir(r.tasksDir, path); err != nil {
 			return nil, err
 		}
-		data, err := os.ReadFile(path)
+		data, err := shared.Files().ReadFile(path)
 		if err != nil {
 			return nil, fmt.Errorf("board: failed to read task file %s: %w", path, err)
 		}
@@ -291,29 +291,37 @@ func (r *Repository) CreateTaskContext(ctx context.Context, task Task) (Task, er
 		return Task{}, ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(r.tasksDir, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(r.tasksDir, 0o755); err != nil {
 		return Task{}, fmt.Errorf("board: failed to create tasks directory: %w", err)
 	}
+	task.Sprint = strings.TrimSpace(task.Sprint)
+	if task.Sprint != "" {
+		if err := r.ensureOpenSprintLockedContext(ctx, task.Sprint); err != nil {
+			return Task{}, err
+		}
+	}
 
+	if task.UID == "" {
+		uid, err := newID()
+		if err != nil {
+			return Task{}, fmt.Errorf("board: failed to generate task uid: %w", err)
+		}
+		task.UID = uid
+	}
 	if task.ID == "" {
 		config, err := r.loadConfig()
 		if err != nil {
 			return Task{}, err
 		}
-		id := formatSequentialID(config.NextID)
-		config.NextID++
+		id, err := allocateID(&config, task.UID, r.now(), r.taskFileExists)
+		if err != nil {
+			return Task{}, err
+		}
 		if err := r.saveConfig(config); err != nil {
 			return Task{}, fmt.Errorf("board: failed to update config: %w", err)
 		}
 		task.ID = id
 	}
-	if task.UID == "" {
-		uid, err := newID()
-		if err != nil {
-			return Task{}, fmt.Errorf("board: failed to generate task uid: %w", err)
-		}
-		task.UID = uid
-	}
 	if err := validateID(task.ID); err != nil {
 		return Task{}, err
 	}
@@ -325,6 +333,17 @@ func (r *Repository) CreateTaskContext(ctx context.Context, task Task) (Task, er
 		return Task{}, err
 	}
 	task.Priority = priority
+	estimate, err := normalizeEstimate(task.Estimate)
+	if err != nil {
+		return Task{}, err
+	}
+	task.Estimate = estimate
+	if err := ValidateRank(task.Rank); err != nil {
+		return Task{}, err
+	}
+	if IsDoneStatus(task.Status) && task.Completed.IsZero() {
+		task.Completed = Date{Time: r.now()}
+	}
 
 	select {
 	case <-ctx.Done():
@@ -345,7 +364,7 @@ func (r *Repository) CreateTaskContext(ctx context.Context, task Task) (Task, er
 		return Task{}, ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(filePath, content, 0o644); err != nil {
+	if err := shared.Files().WriteFile(filePath, content, 0o644); err != nil {
 		return Task{}, fmt.Errorf("board: failed to write task file %s: %w", filePath, err)
 	}
 
@@ -357,6 +376,16 @@ func formatSequentialID(value int) string {
 	return fmt.Sprintf("T-%06d", value)
 }
 
+// taskFileExists reports whether an active or archived task file is named after id.
+func (r *Repository) taskFileExists(id string) bool {
+	for _, dir := range []string{r.tasksDir, r.archiveTasks} {
+		if _, err := shared.Files().Stat(filepath.Join(dir, id+".md")); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateTaskStatus updates the status of a task by ID.
 // UpdateTaskStatus changes the status of the task with the provided ID.
 func (r *Repository) UpdateTaskStatus(id string, status string) error {
@@ -380,7 +409,7 @@ func (r *Repository) UpdateTaskStatusContext(ctx context.Context, id string, sta
 		return err
 	}
 
-	entries, err := os.ReadDir(r.tasksDir)
+	entries, err := shared.Files().ReadDir(r.tasksDir)
 	if err != nil {
 		return fmt.Errorf("board: failed to read tasks directory: %w", err)
 	}
@@ -398,7 +427,7 @@ func (r *Repository) UpdateTaskStatusContext(ctx context.Context, id string, sta
 		if err := shared.EnsureInDir(r.tasksDir, path); err != nil {
 			return err
 		}
-		data, err := os.ReadFile(path)
+		data, err := shared.Files().ReadFile(path)
 		if err != nil {
 			return fmt.Errorf("board: failed to read task file %s: %w", path, err)
 		}
@@ -409,7 +438,7 @@ func (r *Repository) UpdateTaskStatusContext(ctx context.Context, id string, sta
 		if task.ID != id {
 			continue
 		}
-		task.Status = status
+		applyStatusChange(&task, status, r.now())
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -424,7 +453,7 @@ func (r *Repository) UpdateTaskStatusContext(ctx context.Context, id string, sta
 			return ctx.Err()
 		default:
 		}
-		if err := os.WriteFile(path, content, 0o644); err != nil {
+		if err := shared.Files().WriteFile(path, content, 0o644); err != nil {
 			return fmt.Errorf("board: failed to write task file %s: %w", path, err)
 		}
 		return nil
@@ -452,10 +481,8 @@ func (r *Repository) UpdateTaskDependenciesContext(ctx context.Context, id strin
 	if err := validateID(id); err != nil {
 		return err
 	}
-	for _, dep := range deps {
-		if err := validateID(dep); err != nil {
-			return fmt.Errorf("board: dependency %q is invalid: %w", dep, ErrInvalidDependency)
-		}
+	if err := validateDependencyIDs(deps); err != nil {
+		return err
 	}
 	if err := ensureDirExists(r.tasksDir); err != nil {
 		return err
@@ -508,7 +535,7 @@ func (r *Repository) UpdateTaskDependenciesContext(ctx context.Context, id strin
 			return ctx.Err()
 		default:
 		}
-		if err := os.WriteFile(task.FilePath, content, 0o644); err != nil {
+		if err := shared.Files().WriteFile(task.FilePath, content, 0o644); err != nil {
 			return fmt.Errorf("board: failed to write task file %s: %w", task.FilePath, err)
 		}
 		return nil
@@ -530,7 +557,7 @@ func (r *Repository) GetTaskByID(id string) (Task, error) {
 		return Task{}, err
 	}
 
-	entries, err := os.ReadDir(r.tasksDir)
+	entries, err := shared.Files().ReadDir(r.tasksDir)
 	if err != nil {
 		return Task{}, fmt.Errorf("board: failed to read tasks directory: %w", err)
 	}
@@ -543,7 +570,7 @@ func (r *Repository) GetTaskByID(id string) (Task, error) {
 		if err := shared.EnsureInDir(r.tasksDir, path); err != nil {
 			return Task{}, err
 		}
-		data, err := os.ReadFile(path)
+		data, err := shared.Files().ReadFile(path)
 		if err != nil {
 			return Task{}, fmt.Errorf("board: failed to read task file %s: %w", path, err)
 		}
@@ -563,7 +590,7 @@ func (r *Repository) GetTaskByID(id string) (Task, error) {
 }
 
 func ensureDirExists(dir string) error {
-	info, err := os.Stat(dir)
+	info, err := shared.Files().Stat(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("board: %w", ErrStoreNotInitialized)
@@ -608,11 +635,11 @@ func newID() (string, error) {
 
 func legacyLayoutExists(stickyDir string) bool {
 	tasksPath := filepath.Join(stickyDir, "tasks")
-	if info, err := os.Stat(tasksPath); err == nil && info.IsDir() {
+	if info, err := shared.Files().Stat(tasksPath); err == nil && info.IsDir() {
 		return true
 	}
 	configPath := filepath.Join(stickyDir, "config.yaml")
-	if _, err := os.Stat(configPath); err == nil {
+	if _, err := shared.Files().Stat(configPath); err == nil {
 		return true
 	}
 	return false
@@ -2,6 +2,8 @@ package board
 
 import (
 	"errors"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -104,3 +106,57 @@ func TestUpdateTaskPriorityInvalid(t *testing.T) {
 		t.Fatalf("expected ErrInvalidPriority, got %v", err)
 	}
 }
+
+func TestValidateTaskEdit(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	task, _ := NewTask("Editable")
+	created, _ := repo.CreateTask(task)
+	dependent, _ := NewTask("Dependent")
+	dependent.DependsOn = []string{created.ID}
+	dependent, _ = repo.CreateTask(dependent)
+	loaded, err := repo.GetTaskByID(created.ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	original, err := os.ReadFile(loaded.FilePath)
+	if err != nil {
+		t.Fatalf("read task: %v", err)
+	}
+	edit := func(old, new string) []byte {
+		return []byte(strings.Replace(string(original), old, new, 1))
+	}
+	cases := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "valid", data: edit("status: todo", "status: doing")},
+		{name: "broken yaml", data: edit("title: Editable", "title: [Editable"), want: ErrInvalidYAML},
+		{name: "missing frontmatter", data: []byte("just text\n"), want: ErrInvalidFrontmatter},
+		{name: "changed id", data: edit("id: "+created.ID, "id: T-999999"), want: ErrInvalidID},
+		{name: "empty title", data: edit("title: Editable", "title: \"\""), want: ErrInvalidTitle},
+		{name: "unknown status", data: edit("status: todo", "status: someday"), want: ErrInvalidStatus},
+		{name: "bad priority", data: edit("priority: 2", "priority: 7"), want: ErrInvalidPriority},
+		{name: "self dependency", data: edit("depends_on: []", "depends_on: ["+created.ID+"]"), want: ErrInvalidDependency},
+		{name: "unknown dependency", data: edit("depends_on: []", "depends_on: [T-999999]"), want: ErrInvalidDependency},
+		{name: "dependency cycle", data: edit("depends_on: []", "depends_on: ["+dependent.ID+"]"), want: ErrInvalidDependency},
+		{name: "bad rank", data: edit("status: todo", "status: todo\nrank: \"10\""), want: ErrInvalidRank},
+	}
+
+	for _, tc := range cases {
+		// Act
+		got, err := repo.ValidateTaskEdit(loaded, tc.data)
+
+		// Assert
+		if tc.want == nil {
+			if err != nil || got.Status != "doing" {
+				t.Fatalf("%s: expected valid edit, got %+v, %v", tc.name, got, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
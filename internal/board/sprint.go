@@ -0,0 +1,516 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/shared"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SprintStatePlanned marks a sprint that has not started yet.
+	SprintStatePlanned = "planned"
+	// SprintStateActive marks the sprint currently in progress (at most one per board).
+	SprintStateActive = "active"
+	// SprintStateClosed marks a finished sprint with a recorded summary.
+	SprintStateClosed = "closed"
+
+	sprintsFileName = "sprints.yaml"
+)
+
+// Sprint is a time-boxed iteration stored in the board's sprints.yaml registry.
+type Sprint struct {
+	ID      string         `yaml:"id"`
+	Name    string         `yaml:"name"`
+	Goal    string         `yaml:"goal,omitempty"`
+	Start   Date           `yaml:"start,omitempty"`
+	End     Date           `yaml:"end,omitempty"`
+	State   string         `yaml:"state"`
+	Summary *SprintSummary `yaml:"summary,omitempty"`
+}
+
+// SprintSummary captures the outcome of a closed sprint. Velocity is the summed estimate of
+// the tasks completed during the sprint.
+type SprintSummary struct {
+	Closed            Date     `yaml:"closed"`
+	Committed         int      `yaml:"committed"`
+	Completed         int      `yaml:"completed"`
+	CommittedEstimate float64  `yaml:"committed_estimate,omitempty"`
+	Velocity          float64  `yaml:"velocity"`
+	CompletedTasks    []string `yaml:"completed_tasks,omitempty"`
+	RolledOver        []string `yaml:"rolled_over,omitempty"`
+	RolledTo          string   `yaml:"rolled_to,omitempty"`
+}
+
+// SprintRegistry stores all sprints for a board in creation order.
+type SprintRegistry struct {
+	Sprints []Sprint `yaml:"sprints"`
+}
+
+// ListSprints returns all sprints for the board in registry order.
+func (r *Repository) ListSprints() ([]Sprint, error) {
+	return r.ListSprintsContext(context.Background())
+}
+
+// ListSprintsContext returns all sprints for the board, honoring ctx cancellation.
+func (r *Repository) ListSprintsContext(ctx context.Context) ([]Sprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registry, err := r.loadSprintRegistryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return registry.Sprints, nil
+}
+
+// GetSprint returns the sprint with the provided ID.
+func (r *Repository) GetSprint(id string) (Sprint, error) {
+	return r.GetSprintContext(context.Background(), id)
+}
+
+// GetSprintContext returns the sprint with the provided ID, honoring ctx cancellation.
+func (r *Repository) GetSprintContext(ctx context.Context, id string) (Sprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registry, err := r.loadSprintRegistryContext(ctx)
+	if err != nil {
+		return Sprint{}, err
+	}
+	idx, err := findSprint(registry, id)
+	if err != nil {
+		return Sprint{}, err
+	}
+	return registry.Sprints[idx], nil
+}
+
+// ActiveSprint returns the active sprint, reporting false when none is active.
+func (r *Repository) ActiveSprint() (Sprint, bool, error) {
+	return r.ActiveSprintContext(context.Background())
+}
+
+// ActiveSprintContext returns the active sprint, honoring ctx cancellation.
+func (r *Repository) ActiveSprintContext(ctx context.Context) (Sprint, bool, error) {
+	sprints, err := r.ListSprintsContext(ctx)
+	if err != nil {
+		return Sprint{}, false, err
+	}
+	for _, sprint := range sprints {
+		if sprint.State == SprintStateActive {
+			return sprint, true, nil
+		}
+	}
+	return Sprint{}, false, nil
+}
+
+// CreateSprint adds a planned sprint to the registry, deriving the ID from the name when empty.
+func (r *Repository) CreateSprint(sprint Sprint) (Sprint, error) {
+	return r.CreateSprintContext(context.Background(), sprint)
+}
+
+// CreateSprintContext adds a planned sprint to the registry, honoring ctx cancellation.
+func (r *Repository) CreateSprintContext(ctx context.Context, sprint Sprint) (Sprint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sprint.Name = strings.TrimSpace(sprint.Name)
+	sprint.Goal = strings.TrimSpace(sprint.Goal)
+	sprint.ID = strings.TrimSpace(sprint.ID)
+	if sprint.Name == "" {
+		return Sprint{}, fmt.Errorf("board: sprint name is required: %w", ErrInvalidSprint)
+	}
+	if !sprint.Start.IsZero() && !sprint.End.IsZero() && sprint.End.Before(sprint.Start.Time) {
+		return Sprint{}, fmt.Errorf("board: sprint end is before start: %w", ErrInvalidSprint)
+	}
+	registry, err := r.loadSprintRegistryContext(ctx)
+	if err != nil {
+		return Sprint{}, err
+	}
+	if sprint.ID == "" {
+		sprint.ID = generateSprintID(sprint.Name, registry.Sprints)
+	} else {
+		if err := validateSprintID(sprint.ID); err != nil {
+			return Sprint{}, err
+		}
+		if _, err := findSprint(registry, sprint.ID); err == nil {
+			return Sprint{}, fmt.Errorf("board: sprint %s already exists: %w", sprint.ID, ErrInvalidSprint)
+		}
+	}
+	sprint.State = SprintStatePlanned
+	sprint.Summary = nil
+	registry.Sprints = append(registry.Sprints, sprint)
+	if err := r.saveSprintRegistryContext(ctx, registry); err != nil {
+		return Sprint{}, err
+	}
+	return sprint, nil
+}
+
+// StartSprint activates a planned sprint. Only one sprint may be active per board; the start
+// date defaults to today when unset.
+func (r *Repository) StartSprint(id string) (Sprint, error) {
+	return r.StartSprintContext(context.Background(), id)
+}
+
+// StartSprintContext activates a planned sprint, honoring ctx cancellation.
+func (r *Repository) StartSprintContext(ctx context.Context, id string) (Sprint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registry, err := r.loadSprintRegistryContext(ctx)
+	if err != nil {
+		return Sprint{}, err
+	}
+	idx, err := findSprint(registry, id)
+	if err != nil {
+		return Sprint{}, err
+	}
+	if registry.Sprints[idx].State != SprintStatePlanned {
+		return Sprint{}, fmt.Errorf("board: sprint %s is %s: %w", registry.Sprints[idx].ID, registry.Sprints[idx].State, ErrSprintState)
+	}
+	for _, other := range registry.Sprints {
+		if other.State == SprintStateActive {
+			return Sprint{}, fmt.Errorf("board: sprint %s is already active: %w", other.ID, ErrSprintState)
+		}
+	}
+	sprint := &registry.Sprints[idx]
+	sprint.State = SprintStateActive
+	if sprint.Start.IsZero() {
+		sprint.Start = Date{Time: truncateDay(r.now())}
+	}
+	if err := r.saveSprintRegistryContext(ctx, registry); err != nil {
+		return Sprint{}, err
+	}
+	return *sprint, nil
+}
+
+// CloseSprint closes the active sprint, records a summary and rolls unfinished tasks into
+// nextID (or the earliest planned sprint when nextID is empty). When no sprint is available
+// the unfinished tasks are left without a sprint. Archived tasks of the sprint count towards
+// the summary but are never rolled over.
+func (r *Repository) CloseSprint(id, nextID string) (Sprint, error) {
+	return r.CloseSprintContext(context.Background(), id, nextID)
+}
+
+// CloseSprintContext closes the active sprint and rolls unfinished tasks, honoring ctx cancellation.
+// If a task write or the registry update fails, the task files already rolled over are restored.
+func (r *Repository) CloseSprintContext(ctx context.Context, id, nextID string) (Sprint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registry, err := r.loadSprintRegistryContext(ctx)
+	if err != nil {
+		return Sprint{}, err
+	}
+	idx, err := findSprint(registry, id)
+	if err != nil {
+		return Sprint{}, err
+	}
+	if registry.Sprints[idx].State != SprintStateActive {
+		return Sprint{}, fmt.Errorf("board: sprint %s is %s: %w", registry.Sprints[idx].ID, registry.Sprints[idx].State, ErrSprintState)
+	}
+	next, err := nextSprintID(registry, idx, nextID)
+	if err != nil {
+		return Sprint{}, err
+	}
+	if err := ensureDirExists(r.tasksDir); err != nil {
+		return Sprint{}, err
+	}
+	tasks, err := r.readTasksFromDirContext(ctx, r.tasksDir)
+	if err != nil {
+		return Sprint{}, err
+	}
+	archived := make(map[string]bool)
+	if _, err := shared.Files().Stat(r.archiveTasks); err == nil {
+		archivedTasks, err := r.readTasksFromDirContext(ctx, r.archiveTasks)
+		if err != nil {
+			return Sprint{}, err
+		}
+		for _, task := range archivedTasks {
+			archived[task.FilePath] = true
+		}
+		tasks = append(tasks, archivedTasks...)
+	}
+
+	sprint := &registry.Sprints[idx]
+	summary := &SprintSummary{Closed: Date{Time: truncateDay(r.now())}, RolledTo: next}
+	var rolled []Task
+	for _, task := range tasks {
+		if task.Sprint != sprint.ID {
+			continue
+		}
+		summary.Committed++
+		summary.CommittedEstimate += task.Estimate
+		if IsDoneStatus(task.Status) {
+			summary.Completed++
+			summary.Velocity += task.Estimate
+			summary.CompletedTasks = append(summary.CompletedTasks, task.ID)
+			continue
+		}
+		if archived[task.FilePath] {
+			continue
+		}
+		summary.RolledOver = append(summary.RolledOver, task.ID)
+		rolled = append(rolled, task)
+	}
+	sort.Strings(summary.CompletedTasks)
+	sort.Strings(summary.RolledOver)
+
+	updated := make([]Task, 0, len(rolled))
+	dirty := make([]bool, 0, len(rolled))
+	for _, task := range rolled {
+		task.Sprint = next
+		updated = append(updated, task)
+		dirty = append(dirty, true)
+	}
+	applied, err := r.applyBulkLockedContext(ctx, rolled, updated, dirty, false)
+	if err != nil {
+		return Sprint{}, err
+	}
+
+	sprint.State = SprintStateClosed
+	if sprint.End.IsZero() {
+		sprint.End = summary.Closed
+	}
+	sprint.Summary = summary
+	if err := r.saveSprintRegistryContext(ctx, registry); err != nil {
+		restoreBulkWrites(applied)
+		return Sprint{}, err
+	}
+	return *sprint, nil
+}
+
+// ResolveSprintRef maps a sprint reference to a sprint ID. The reference "active" resolves to
+// the active sprint; any other value must name an existing sprint.
+func (r *Repository) ResolveSprintRef(ref string) (string, error) {
+	return r.ResolveSprintRefContext(context.Background(), ref)
+}
+
+// ResolveSprintRefContext maps a sprint reference to a sprint ID, honoring ctx cancellation.
+func (r *Repository) ResolveSprintRefContext(ctx context.Context, ref string) (string, error) {
+	trimmed := strings.TrimSpace(ref)
+	if trimmed == "" {
+		return "", nil
+	}
+	if strings.EqualFold(trimmed, SprintStateActive) {
+		active, ok, err := r.ActiveSprintContext(ctx)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", fmt.Errorf("board: no active sprint: %w", ErrSprintNotFound)
+		}
+		return active.ID, nil
+	}
+	sprint, err := r.GetSprintContext(ctx, trimmed)
+	if err != nil {
+		return "", err
+	}
+	return sprint.ID, nil
+}
+
+// UpdateTaskSprint assigns a task to a sprint by ID (empty sprintID clears the assignment).
+func (r *Repository) UpdateTaskSprint(id, sprintID string) error {
+	return r.UpdateTaskSprintContext(context.Background(), id, sprintID)
+}
+
+// UpdateTaskSprintContext assigns a task to a sprint, honoring ctx cancellation. Closed sprints
+// cannot receive new tasks.
+func (r *Repository) UpdateTaskSprintContext(ctx context.Context, id, sprintID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateID(id); err != nil {
+		return err
+	}
+	sprintID = strings.TrimSpace(sprintID)
+	if sprintID != "" {
+		if err := r.ensureOpenSprintLockedContext(ctx, sprintID); err != nil {
+			return err
+		}
+	}
+	return r.updateTaskLockedContext(ctx, id, func(task *Task) error {
+		task.Sprint = sprintID
+		return nil
+	})
+}
+
+// SprintTasks filters tasks assigned to the given sprint.
+func SprintTasks(tasks []Task, sprintID string) []Task {
+	var result []Task
+	for _, task := range tasks {
+		if task.Sprint == sprintID {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+func (r *Repository) ensureOpenSprintLockedContext(ctx context.Context, sprintID string) error {
+	registry, err := r.loadSprintRegistryContext(ctx)
+	if err != nil {
+		return err
+	}
+	idx, err := findSprint(registry, sprintID)
+	if err != nil {
+		return err
+	}
+	if registry.Sprints[idx].State == SprintStateClosed {
+		return fmt.Errorf("board: sprint %s is closed: %w", sprintID, ErrSprintState)
+	}
+	return nil
+}
+
+func (r *Repository) sprintsPath() (string, error) {
+	if strings.TrimSpace(r.boardDir) == "" {
+		return "", fmt.Errorf("board: %w", shared.ErrInvalidPath)
+	}
+	path := filepath.Join(r.boardDir, sprintsFileName)
+	if err := shared.EnsureInDir(r.boardDir, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (r *Repository) loadSprintRegistryContext(ctx context.Context) (SprintRegistry, error) {
+	select {
+	case <-ctx.Done():
+		return SprintRegistry{}, ctx.Err()
+	default:
+	}
+	path, err := r.sprintsPath()
+	if err != nil {
+		return SprintRegistry{}, err
+	}
+	data, err := shared.Files().ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SprintRegistry{}, nil
+		}
+		return SprintRegistry{}, fmt.Errorf("board: failed to read sprints file: %w", err)
+	}
+	var registry SprintRegistry
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return SprintRegistry{}, fmt.Errorf("board: failed to parse sprints file: %w", err)
+	}
+	for i := range registry.Sprints {
+		registry.Sprints[i].State = normalizeSprintState(registry.Sprints[i].State)
+	}
+	return registry, nil
+}
+
+func (r *Repository) saveSprintRegistryContext(ctx context.Context, registry SprintRegistry) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	path, err := r.sprintsPath()
+	if err != nil {
+		return err
+	}
+	if err := ensureDirExists(r.boardDir); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(registry)
+	if err != nil {
+		return fmt.Errorf("board: failed to marshal sprints: %w", err)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if err := shared.Files().WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("board: failed to write sprints file: %w", err)
+	}
+	return nil
+}
+
+// findSprint returns the registry index for the sprint ID.
+func findSprint(registry SprintRegistry, id string) (int, error) {
+	target := strings.TrimSpace(id)
+	for i, sprint := range registry.Sprints {
+		if sprint.ID == target {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("board: %s: %w", target, ErrSprintNotFound)
+}
+
+// nextSprintID resolves the sprint that receives rolled-over tasks from the sprint at idx.
+func nextSprintID(registry SprintRegistry, idx int, requested string) (string, error) {
+	if strings.TrimSpace(requested) != "" {
+		target, err := findSprint(registry, requested)
+		if err != nil {
+			return "", err
+		}
+		if target == idx || registry.Sprints[target].State == SprintStateClosed {
+			return "", fmt.Errorf("board: cannot roll tasks into sprint %s: %w", registry.Sprints[target].ID, ErrSprintState)
+		}
+		return registry.Sprints[target].ID, nil
+	}
+	best := -1
+	for i, sprint := range registry.Sprints {
+		if i == idx || sprint.State != SprintStatePlanned {
+			continue
+		}
+		if best == -1 {
+			best = i
+			continue
+		}
+		current := registry.Sprints[best]
+		if !sprint.Start.IsZero() && (current.Start.IsZero() || sprint.Start.Before(current.Start.Time)) {
+			best = i
+		}
+	}
+	if best == -1 {
+		return "", nil
+	}
+	return registry.Sprints[best].ID, nil
+}
+
+func normalizeSprintState(state string) string {
+	switch strings.ToLower(strings.TrimSpace(state)) {
+	case SprintStateActive:
+		return SprintStateActive
+	case SprintStateClosed:
+		return SprintStateClosed
+	default:
+		return SprintStatePlanned
+	}
+}
+
+func validateSprintID(id string) error {
+	if strings.TrimSpace(id) == "" || strings.ContainsAny(id, `/\`) || strings.ContainsAny(id, " \t\n") {
+		return fmt.Errorf("board: invalid sprint id %q: %w", id, ErrInvalidSprint)
+	}
+	return nil
+}
+
+// generateSprintID slugifies the sprint name and avoids collisions with existing IDs.
+func generateSprintID(name string, sprints []Sprint) string {
+	base := slugify(name)
+	if base == "" {
+		base = "sprint"
+	}
+	exists := func(id string) bool {
+		for _, sprint := range sprints {
+			if sprint.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+	id := base
+	for i := 1; exists(id); i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	return id
+}
@@ -0,0 +1,236 @@
+package board
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"mochi-sticky/internal/shared"
+)
+
+func TestCreateSprintGeneratesIDs(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+
+	// Act
+	first, err1 := repo.CreateSprint(Sprint{Name: "Sprint 1", Goal: "Ship login"})
+	second, err2 := repo.CreateSprint(Sprint{Name: "Sprint 1"})
+	_, dupErr := repo.CreateSprint(Sprint{ID: "sprint-1", Name: "Other"})
+	_, datesErr := repo.CreateSprint(Sprint{
+		Name:  "Backwards",
+		Start: Date{Time: day("2026-10-10")},
+		End:   Date{Time: day("2026-10-01")},
+	})
+	sprints, listErr := repo.ListSprints()
+
+	// Assert
+	if err1 != nil || err2 != nil || listErr != nil {
+		t.Fatalf("unexpected errors: %v %v %v", err1, err2, listErr)
+	}
+	if first.ID != "sprint-1" || second.ID != "sprint-1-1" {
+		t.Fatalf("unexpected ids %q %q", first.ID, second.ID)
+	}
+	if first.State != SprintStatePlanned {
+		t.Fatalf("expected planned state, got %q", first.State)
+	}
+	if !errors.Is(dupErr, ErrInvalidSprint) || !errors.Is(datesErr, ErrInvalidSprint) {
+		t.Fatalf("expected ErrInvalidSprint, got %v / %v", dupErr, datesErr)
+	}
+	if len(sprints) != 2 || sprints[0].Goal != "Ship login" {
+		t.Fatalf("unexpected sprints %+v", sprints)
+	}
+}
+
+func TestStartSprintAllowsSingleActive(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	repo.now = func() time.Time { return day("2026-10-05") }
+	first, _ := repo.CreateSprint(Sprint{Name: "One"})
+	second, _ := repo.CreateSprint(Sprint{Name: "Two"})
+
+	// Act
+	started, startErr := repo.StartSprint(first.ID)
+	_, conflictErr := repo.StartSprint(second.ID)
+	active, ok, activeErr := repo.ActiveSprint()
+
+	// Assert
+	if startErr != nil || activeErr != nil {
+		t.Fatalf("unexpected errors: %v %v", startErr, activeErr)
+	}
+	if !started.Start.Equal(day("2026-10-05")) {
+		t.Fatalf("expected start date stamped, got %v", started.Start)
+	}
+	if !errors.Is(conflictErr, ErrSprintState) {
+		t.Fatalf("expected ErrSprintState, got %v", conflictErr)
+	}
+	if !ok || active.ID != first.ID {
+		t.Fatalf("expected active sprint %s, got %+v (ok=%v)", first.ID, active, ok)
+	}
+}
+
+func TestCloseSprintRollsUnfinishedTasks(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	repo.now = func() time.Time { return day("2026-10-14") }
+	current, _ := repo.CreateSprint(Sprint{Name: "Current"})
+	if _, err := repo.CreateSprint(Sprint{Name: "Later", Start: Date{Time: day("2026-11-01")}}); err != nil {
+		t.Fatalf("create sprint: %v", err)
+	}
+	next, _ := repo.CreateSprint(Sprint{Name: "Next", Start: Date{Time: day("2026-10-15")}})
+	if _, err := repo.StartSprint(current.ID); err != nil {
+		t.Fatalf("start sprint: %v", err)
+	}
+	done := Task{Title: "Done work", Status: "done", Estimate: 3, Sprint: current.ID}
+	open := Task{Title: "Open work", Status: "doing", Estimate: 5, Sprint: current.ID}
+	other := Task{Title: "Unplanned", Status: "todo", Estimate: 8}
+	createdDone, _ := repo.CreateTask(done)
+	createdOpen, _ := repo.CreateTask(open)
+	createdOther, _ := repo.CreateTask(other)
+
+	// Act
+	closed, err := repo.CloseSprint(current.ID, "")
+	reloadedOpen, _ := repo.GetTaskByID(createdOpen.ID)
+	reloadedDone, _ := repo.GetTaskByID(createdDone.ID)
+	reloadedOther, _ := repo.GetTaskByID(createdOther.ID)
+	_, reassignErr := repo.CreateTask(Task{Title: "Late", Sprint: current.ID})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("close sprint: %v", err)
+	}
+	if closed.State != SprintStateClosed || closed.Summary == nil {
+		t.Fatalf("expected closed sprint with summary, got %+v", closed)
+	}
+	want := &SprintSummary{
+		Closed:            Date{Time: day("2026-10-14")},
+		Committed:         2,
+		Completed:         1,
+		CommittedEstimate: 8,
+		Velocity:          3,
+		CompletedTasks:    []string{createdDone.ID},
+		RolledOver:        []string{createdOpen.ID},
+		RolledTo:          next.ID,
+	}
+	if !reflect.DeepEqual(closed.Summary, want) {
+		t.Fatalf("unexpected summary:\n got %+v\nwant %+v", closed.Summary, want)
+	}
+	if reloadedOpen.Sprint != next.ID {
+		t.Fatalf("expected open task rolled to %s, got %q", next.ID, reloadedOpen.Sprint)
+	}
+	if reloadedDone.Sprint != current.ID || reloadedOther.Sprint != "" {
+		t.Fatalf("unexpected sprint assignments %q %q", reloadedDone.Sprint, reloadedOther.Sprint)
+	}
+	if !errors.Is(reassignErr, ErrSprintState) {
+		t.Fatalf("expected ErrSprintState for closed sprint, got %v", reassignErr)
+	}
+}
+
+func TestCloseSprintCountsArchivedTasks(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	current, _ := repo.CreateSprint(Sprint{Name: "Current"})
+	if _, err := repo.StartSprint(current.ID); err != nil {
+		t.Fatalf("start sprint: %v", err)
+	}
+	shipped, _ := repo.CreateTask(Task{Title: "Shipped", Status: "done", Estimate: 3, Sprint: current.ID})
+	open, _ := repo.CreateTask(Task{Title: "Open", Status: "todo", Estimate: 2, Sprint: current.ID})
+	if _, err := repo.ArchiveTask(shipped.ID); err != nil {
+		t.Fatalf("archive task: %v", err)
+	}
+
+	// Act
+	closed, err := repo.CloseSprint(current.ID, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("close sprint: %v", err)
+	}
+	summary := closed.Summary
+	if summary.Committed != 2 || summary.Completed != 1 || summary.Velocity != 3 || summary.CommittedEstimate != 5 {
+		t.Fatalf("expected the archived task counted, got %+v", summary)
+	}
+	if !reflect.DeepEqual(summary.CompletedTasks, []string{shipped.ID}) || !reflect.DeepEqual(summary.RolledOver, []string{open.ID}) {
+		t.Fatalf("unexpected task lists %v %v", summary.CompletedTasks, summary.RolledOver)
+	}
+}
+
+func TestCloseSprintRestoresTasksWhenRolloverFails(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	current, _ := repo.CreateSprint(Sprint{Name: "Current"})
+	if _, err := repo.StartSprint(current.ID); err != nil {
+		t.Fatalf("start sprint: %v", err)
+	}
+	first, _ := repo.CreateTask(Task{Title: "First", Status: "todo", Sprint: current.ID})
+	second, _ := repo.CreateTask(Task{Title: "Second", Status: "todo", Sprint: current.ID})
+	restore := shared.UseFiles(&truncatingFS{failPath: second.FilePath})
+	defer restore()
+
+	// Act
+	_, closeErr := repo.CloseSprint(current.ID, "")
+
+	// Assert
+	if closeErr == nil {
+		t.Fatalf("expected close to fail")
+	}
+	for _, id := range []string{first.ID, second.ID} {
+		task, err := repo.GetTaskByID(id)
+		if err != nil || task.Sprint != current.ID {
+			t.Fatalf("expected %s to stay in %s, got %q (%v)", id, current.ID, task.Sprint, err)
+		}
+	}
+	sprint, err := repo.GetSprint(current.ID)
+	if err != nil || sprint.State != SprintStateActive {
+		t.Fatalf("expected the sprint to stay active, got %+v (%v)", sprint, err)
+	}
+}
+
+func TestCloseSprintRequiresActive(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	planned, _ := repo.CreateSprint(Sprint{Name: "Planned"})
+
+	// Act
+	_, err := repo.CloseSprint(planned.ID, "")
+
+	// Assert
+	if !errors.Is(err, ErrSprintState) {
+		t.Fatalf("expected ErrSprintState, got %v", err)
+	}
+}
+
+func TestResolveSprintRefAndAssign(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	sprint, _ := repo.CreateSprint(Sprint{Name: "Active"})
+	if _, err := repo.StartSprint(sprint.ID); err != nil {
+		t.Fatalf("start sprint: %v", err)
+	}
+	task, _ := NewTask("Assign me")
+	created, _ := repo.CreateTask(task)
+
+	// Act
+	resolved, resolveErr := repo.ResolveSprintRef("active")
+	_, missingErr := repo.ResolveSprintRef("nope")
+	assignErr := repo.UpdateTaskSprint(created.ID, resolved)
+	loaded, _ := repo.GetTaskByID(created.ID)
+	filtered := FilterAndSortTasks([]Task{loaded, {ID: "T-x"}}, ListOptions{Sprint: sprint.ID})
+
+	// Assert
+	if resolveErr != nil || assignErr != nil {
+		t.Fatalf("unexpected errors: %v %v", resolveErr, assignErr)
+	}
+	if resolved != sprint.ID {
+		t.Fatalf("expected %s, got %s", sprint.ID, resolved)
+	}
+	if !errors.Is(missingErr, ErrSprintNotFound) {
+		t.Fatalf("expected ErrSprintNotFound, got %v", missingErr)
+	}
+	if loaded.Sprint != sprint.ID {
+		t.Fatalf("expected task sprint %s, got %q", sprint.ID, loaded.Sprint)
+	}
+	if len(filtered) != 1 || filtered[0].ID != created.ID {
+		t.Fatalf("expected sprint filter to keep only assigned task, got %+v", filtered)
+	}
+}
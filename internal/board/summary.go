@@ -0,0 +1,119 @@
+package board
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ColumnCount is the number of tasks in one board column.
+type ColumnCount struct {
+	Key   string `json:"key"`
+	Title string `json:"title"`
+	Count int    `json:"count"`
+}
+
+// SummaryTask identifies a task highlighted by a board summary. AgeDays counts from the
+// creation date for in-progress tasks and from the due date for overdue ones.
+type SummaryTask struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Created  string `json:"created,omitempty"`
+	Due      string `json:"due,omitempty"`
+	AgeDays  int    `json:"age_days"`
+}
+
+// Summary holds the health counts of a board. Open tasks are those not in a done status;
+// WIP counts open tasks past the first column, Ready counts open tasks in the first column
+// whose dependencies are done, and Blocked counts open tasks with unmet dependencies.
+type Summary struct {
+	Total            int           `json:"total"`
+	Open             int           `json:"open"`
+	Columns          []ColumnCount `json:"columns"`
+	WIP              int           `json:"wip"`
+	Ready            int           `json:"ready"`
+	Blocked          int           `json:"blocked"`
+	BlockedP1        int           `json:"blocked_p1"`
+	Overdue          []SummaryTask `json:"overdue"`
+	OldestInProgress *SummaryTask  `json:"oldest_in_progress,omitempty"`
+}
+
+// Summarize computes the health summary of tasks laid out in columns, as of now. Tasks whose
+// status is not a column are counted in the totals only.
+func Summarize(columns []Column, tasks []Task, now time.Time) Summary {
+	today := dateOnly(now)
+	summary := Summary{Total: len(tasks), Columns: make([]ColumnCount, 0, len(columns)), Overdue: []SummaryTask{}}
+	counts := make(map[string]int, len(columns))
+	index := make(map[string]Task, len(tasks))
+	for _, task := range tasks {
+		counts[task.Status]++
+		index[task.ID] = task
+	}
+	for _, column := range columns {
+		summary.Columns = append(summary.Columns, ColumnCount{Key: column.Key, Title: column.Title, Count: counts[column.Key]})
+	}
+	first := ""
+	if len(columns) > 0 {
+		first = columns[0].Key
+	}
+
+	var oldest *Task
+	for i, task := range tasks {
+		if IsDoneStatus(task.Status) {
+			continue
+		}
+		summary.Open++
+		ready, _ := IsReady(task, index)
+		if !ready {
+			summary.Blocked++
+			if task.Priority == 1 {
+				summary.BlockedP1++
+			}
+		}
+		if strings.EqualFold(task.Status, first) {
+			if ready {
+				summary.Ready++
+			}
+		} else {
+			summary.WIP++
+			if !task.Created.IsZero() && (oldest == nil || task.Created.Before(oldest.Created.Time)) {
+				oldest = &tasks[i]
+			}
+		}
+		if !task.Due.IsZero() && dateOnly(task.Due.Time).Before(today) {
+			item := summaryTask(task)
+			item.AgeDays = daysBetween(task.Due.Time, today)
+			summary.Overdue = append(summary.Overdue, item)
+		}
+	}
+	sort.SliceStable(summary.Overdue, func(i, j int) bool {
+		return summary.Overdue[i].AgeDays > summary.Overdue[j].AgeDays
+	})
+	if oldest != nil {
+		item := summaryTask(*oldest)
+		item.AgeDays = daysBetween(oldest.Created.Time, today)
+		summary.OldestInProgress = &item
+	}
+	return summary
+}
+
+func summaryTask(task Task) SummaryTask {
+	item := SummaryTask{ID: task.ID, Title: task.Title, Status: task.Status, Priority: task.Priority}
+	if !task.Created.IsZero() {
+		item.Created = task.Created.Format("2006-01-02")
+	}
+	if !task.Due.IsZero() {
+		item.Due = task.Due.Format("2006-01-02")
+	}
+	return item
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(dateOnly(to).Sub(dateOnly(from)).Hours() / 24)
+}
@@ -0,0 +1,46 @@
+package board
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeCountsBoardHealth(t *testing.T) {
+	// Arrange
+	day := func(value string) Date {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatalf("parse %s: %v", value, err)
+		}
+		return Date{Time: parsed}
+	}
+	columns := []Column{{Key: "todo", Title: "Todo"}, {Key: "doing", Title: "Doing"}, {Key: "done", Title: "Done"}}
+	tasks := []Task{
+		{ID: "T-1", Status: "done", Priority: 1, Created: day("2026-09-01")},
+		{ID: "T-2", Status: "todo", Priority: 2, Created: day("2026-10-01"), DependsOn: []string{"T-1"}},
+		{ID: "T-3", Status: "todo", Priority: 1, Created: day("2026-10-02"), DependsOn: []string{"T-4"}},
+		{ID: "T-4", Status: "doing", Priority: 2, Created: day("2026-10-05"), Due: day("2026-10-10")},
+		{ID: "T-5", Status: "doing", Priority: 3, Created: day("2026-09-20"), Due: day("2026-10-31")},
+		{ID: "T-6", Status: "done", Priority: 2, Created: day("2026-09-01"), Due: day("2026-09-02")},
+	}
+
+	// Act
+	summary := Summarize(columns, tasks, time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC))
+
+	// Assert
+	if summary.Total != 6 || summary.Open != 4 || summary.WIP != 2 || summary.Ready != 1 {
+		t.Fatalf("unexpected counts: %+v", summary)
+	}
+	if summary.Blocked != 1 || summary.BlockedP1 != 1 {
+		t.Fatalf("expected T-3 blocked as P1, got blocked=%d p1=%d", summary.Blocked, summary.BlockedP1)
+	}
+	if got := []int{summary.Columns[0].Count, summary.Columns[1].Count, summary.Columns[2].Count}; got[0] != 2 || got[1] != 2 || got[2] != 2 {
+		t.Fatalf("unexpected column counts: %v", got)
+	}
+	if len(summary.Overdue) != 1 || summary.Overdue[0].ID != "T-4" || summary.Overdue[0].AgeDays != 8 {
+		t.Fatalf("expected T-4 overdue by 8 days, got %+v", summary.Overdue)
+	}
+	if summary.OldestInProgress == nil || summary.OldestInProgress.ID != "T-5" || summary.OldestInProgress.AgeDays != 28 {
+		t.Fatalf("expected T-5 as oldest in progress, got %+v", summary.OldestInProgress)
+	}
+}
@@ -10,7 +10,31 @@ import (
 
 // FormatTasksTable renders tasks into a styled ASCII table.
 func FormatTasksTable(tasks []Task) string {
+	return formatTasksTable(tasks, false)
+}
+
+// FormatBoardTasksTable renders tasks gathered from several boards, with a leading Board column.
+func FormatBoardTasksTable(tasks []Task) string {
+	return formatTasksTable(tasks, true)
+}
+
+// formatTasksTable leaves out the Estimate column unless some task has an estimate, so boards
+// that do not estimate keep their original table.
+func formatTasksTable(tasks []Task, withBoard bool) string {
+	withEstimate := false
+	for _, task := range tasks {
+		if task.Estimate > 0 {
+			withEstimate = true
+			break
+		}
+	}
 	headers := []string{"ID", "Title", "Status", "Priority", "Tags", "Created"}
+	if withEstimate {
+		headers = []string{"ID", "Title", "Status", "Priority", "Estimate", "Tags", "Created"}
+	}
+	if withBoard {
+		headers = append([]string{"Board"}, headers...)
+	}
 	rows := make([][]string, 0, len(tasks))
 	for _, task := range tasks {
 		created := ""
@@ -19,9 +43,45 @@ func FormatTasksTable(tasks []Task) string {
 		}
 		tags := strings.Join(task.Tags, ", ")
 		priority := fmt.Sprintf("%d", effectivePriority(task.Priority))
-		rows = append(rows, []string{task.ID, task.Title, task.Status, priority, tags, created})
+		row := []string{task.ID, task.Title, task.Status, priority, tags, created}
+		if withEstimate {
+			estimate := ""
+			if task.Estimate > 0 {
+				estimate = FormatEstimate(task.Estimate)
+			}
+			row = []string{task.ID, task.Title, task.Status, priority, estimate, tags, created}
+		}
+		if withBoard {
+			row = append([]string{task.BoardID}, row...)
+		}
+		rows = append(rows, row)
 	}
+	return formatTable(headers, rows)
+}
+
+// FormatSprintsTable renders sprints with their dates, state and velocity (closed sprints only).
+func FormatSprintsTable(sprints []Sprint) string {
+	headers := []string{"ID", "Name", "State", "Start", "End", "Velocity", "Goal"}
+	rows := make([][]string, 0, len(sprints))
+	for _, sprint := range sprints {
+		start := ""
+		if !sprint.Start.IsZero() {
+			start = sprint.Start.Format("2006-01-02")
+		}
+		end := ""
+		if !sprint.End.IsZero() {
+			end = sprint.End.Format("2006-01-02")
+		}
+		velocity := ""
+		if sprint.Summary != nil {
+			velocity = FormatEstimate(sprint.Summary.Velocity)
+		}
+		rows = append(rows, []string{sprint.ID, sprint.Name, sprint.State, start, end, velocity, sprint.Goal})
+	}
+	return formatTable(headers, rows)
+}
 
+func formatTable(headers []string, rows [][]string) string {
 	widths := columnWidths(headers, rows)
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("242"))
 	cellStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
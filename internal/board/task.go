@@ -47,21 +47,33 @@ func (d Date) IsZero() bool {
 }
 
 // Task represents a sticky note task persisted under .sticky/boards/<board>/tasks.
-// The struct mirrors the YAML frontmatter (ID, Title, Status, Priority, Tags, Created, DependsOn)
-// while Content holds the Markdown body and FilePath/Board* are metadata injected by repositories.
+// The struct mirrors the YAML frontmatter (ID, Title, Status, StatusChanged, Priority, Estimate, Sprint,
+// Assignee, Branch, Rank, Tags, Created, Completed, DependsOn and free-form Fields) while Content holds the
+// Markdown body and FilePath/Board* are metadata injected by repositories.
 type Task struct {
-	ID        string   `yaml:"id"`
-	UID       string   `yaml:"uid,omitempty"`
-	Title     string   `yaml:"title"`
-	Status    string   `yaml:"status"`
-	Priority  int      `yaml:"priority"`
-	Tags      []string `yaml:"tags"`
-	Created   Date     `yaml:"created"`
-	DependsOn []string `yaml:"depends_on"`
-	Content   string   `yaml:"-"`
-	FilePath  string   `yaml:"-"`
-	BoardID   string   `yaml:"-"`
-	BoardName string   `yaml:"-"`
+	ID     string `yaml:"id"`
+	UID    string `yaml:"uid,omitempty"`
+	Title  string `yaml:"title"`
+	Status string `yaml:"status"`
+	// StatusChanged is when Status was last changed; the merge driver uses it to pick the newer
+	// status when two branches move the same task.
+	StatusChanged time.Time         `yaml:"status_changed,omitempty"`
+	Priority      int               `yaml:"priority"`
+	Estimate      float64           `yaml:"estimate,omitempty"`
+	Sprint        string            `yaml:"sprint,omitempty"`
+	Assignee      string            `yaml:"assignee,omitempty"`
+	Branch        string            `yaml:"branch,omitempty"`
+	Rank          string            `yaml:"rank,omitempty"`
+	Tags          []string          `yaml:"tags"`
+	Created       Date              `yaml:"created"`
+	Completed     Date              `yaml:"completed,omitempty"`
+	Due           Date              `yaml:"due,omitempty"`
+	DependsOn     []string          `yaml:"depends_on"`
+	Fields        map[string]string `yaml:"fields,omitempty"`
+	Content       string            `yaml:"-"`
+	FilePath      string            `yaml:"-"`
+	BoardID       string            `yaml:"-"`
+	BoardName     string            `yaml:"-"`
 }
 
 // NewTask creates a new task with default values (todo/status and default priority) and trims the title.
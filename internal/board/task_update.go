@@ -2,9 +2,12 @@ package board
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"math"
 	"strings"
+
+	"mochi-sticky/internal/shared"
 )
 
 // UpdateTaskTitle updates a task title by ID.
@@ -112,16 +115,192 @@ func (r *Repository) UpdateTaskContentContext(ctx context.Context, id, content s
 	})
 }
 
-func (r *Repository) updateTaskLockedContext(ctx context.Context, id string, update func(*Task) error) error {
+// EditTask applies edit to a task by ID and writes it once.
+func (r *Repository) EditTask(id string, edit func(*Task)) error {
+	return r.EditTaskContext(context.Background(), id, edit)
+}
+
+// EditTaskContext applies edit to the task with the given ID and writes the result in a single
+// render and write, honoring ctx cancellation. The edited title must not be empty; tags are
+// normalized.
+func (r *Repository) EditTaskContext(ctx context.Context, id string, edit func(*Task)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if err := validateID(id); err != nil {
+		return err
+	}
+	return r.updateTaskLockedContext(ctx, id, func(task *Task) error {
+		edit(task)
+		task.Title = strings.TrimSpace(task.Title)
+		if task.Title == "" {
+			return fmt.Errorf("board: %w", ErrInvalidTitle)
+		}
+		task.Tags = NormalizeTags(task.Tags)
+		return nil
+	})
+}
+
+// SetTaskField sets a custom field on an active or archived task by ID.
+func (r *Repository) SetTaskField(id, key, value string) error {
+	return r.SetTaskFieldContext(context.Background(), id, key, value)
+}
+
+// SetTaskFieldContext sets a custom field on an active or archived task by ID, honoring ctx
+// cancellation. An empty value removes the field.
+func (r *Repository) SetTaskFieldContext(ctx context.Context, id, key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
 	}
+	if err := validateID(id); err != nil {
+		return err
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("board: field name is required")
+	}
+	update := func(task *Task) error {
+		value := strings.TrimSpace(value)
+		if value == "" {
+			delete(task.Fields, key)
+			if len(task.Fields) == 0 {
+				task.Fields = nil
+			}
+			return nil
+		}
+		if task.Fields == nil {
+			task.Fields = make(map[string]string)
+		}
+		task.Fields[key] = value
+		return nil
+	}
+	err := r.updateTaskLockedContext(ctx, id, update)
+	if !errors.Is(err, ErrTaskNotFound) {
+		return err
+	}
+	if _, statErr := shared.Files().Stat(r.archiveTasks); statErr != nil {
+		return err
+	}
+	return r.updateTaskInDirLockedContext(ctx, r.archiveTasks, id, update)
+}
+
+// ValidateTaskEdit checks a task file rewritten outside the repository (e.g. in an editor)
+// against the task it replaces and returns the parsed task.
+func (r *Repository) ValidateTaskEdit(original Task, data []byte) (Task, error) {
+	return r.ValidateTaskEditContext(context.Background(), original, data)
+}
+
+// ValidateTaskEditContext parses data and rejects a changed ID, an empty title, a status
+// that is not a board column, an out-of-range priority or estimate, a malformed rank and
+// dependencies that are unknown, on the task itself or form a cycle, honoring ctx cancellation.
+func (r *Repository) ValidateTaskEditContext(ctx context.Context, original Task, data []byte) (Task, error) {
+	select {
+	case <-ctx.Done():
+		return Task{}, ctx.Err()
+	default:
+	}
+	task, err := r.parser.Parse(data)
+	if err != nil {
+		return Task{}, err
+	}
+	if task.ID != original.ID {
+		return Task{}, fmt.Errorf("board: id cannot change from %s to %q: %w", original.ID, task.ID, ErrInvalidID)
+	}
+	if strings.TrimSpace(task.Title) == "" {
+		return Task{}, fmt.Errorf("board: %w", ErrInvalidTitle)
+	}
+	if task.Priority != 0 {
+		if _, err := normalizePriority(task.Priority); err != nil {
+			return Task{}, err
+		}
+	}
+	if task.Estimate < 0 || math.IsNaN(task.Estimate) || math.IsInf(task.Estimate, 0) {
+		return Task{}, fmt.Errorf("board: %w", ErrInvalidEstimate)
+	}
+	if err := ValidateRank(task.Rank); err != nil {
+		return Task{}, err
+	}
+	config, err := r.LoadConfigContext(ctx)
+	if err != nil {
+		return Task{}, err
+	}
+	if err := config.ValidateStatus(task.Status); err != nil {
+		return Task{}, err
+	}
+	if err := r.validateEditedDependenciesContext(ctx, task); err != nil {
+		return Task{}, err
+	}
+	return task, nil
+}
+
+// validateEditedDependenciesContext checks the dependencies of an edited task the way
+// UpdateTaskDependencies does (valid IDs, no cycles) and also requires every dependency to be an
+// existing active or archived task other than the task itself.
+func (r *Repository) validateEditedDependenciesContext(ctx context.Context, task Task) error {
+	if len(task.DependsOn) == 0 {
+		return nil
+	}
+	if err := validateDependencyIDs(task.DependsOn); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if err := ensureDirExists(r.tasksDir); err != nil {
 		return err
 	}
-	path, task, err := r.findTaskFileLockedContext(ctx, r.tasksDir, id)
+	tasks, err := r.readTasksFromDirContext(ctx, r.tasksDir)
+	if err != nil {
+		return err
+	}
+	if _, err := shared.Files().Stat(r.archiveTasks); err == nil {
+		archived, err := r.readTasksFromDirContext(ctx, r.archiveTasks)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, archived...)
+	}
+	known := make(map[string]bool, len(tasks))
+	for i := range tasks {
+		known[tasks[i].ID] = true
+		if tasks[i].ID == task.ID {
+			tasks[i].DependsOn = task.DependsOn
+		}
+	}
+	for _, dep := range task.DependsOn {
+		if dep == task.ID {
+			return fmt.Errorf("board: %s cannot depend on itself: %w", task.ID, ErrInvalidDependency)
+		}
+		if !known[dep] {
+			return fmt.Errorf("board: dependency %s does not exist: %w", dep, ErrInvalidDependency)
+		}
+	}
+	return ValidateNoCycles(tasks)
+}
+
+func (r *Repository) updateTaskLockedContext(ctx context.Context, id string, update func(*Task) error) error {
+	return r.updateTaskInDirLockedContext(ctx, r.tasksDir, id, update)
+}
+
+func (r *Repository) updateTaskInDirLockedContext(ctx context.Context, dir, id string, update func(*Task) error) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if err := ensureDirExists(dir); err != nil {
+		return err
+	}
+	path, task, err := r.findTaskFileLockedContext(ctx, dir, id)
 	if err != nil {
 		return err
 	}
@@ -142,7 +321,7 @@ func (r *Repository) updateTaskLockedContext(ctx context.Context, id string, upd
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(path, content, 0o644); err != nil {
+	if err := shared.Files().WriteFile(path, content, 0o644); err != nil {
 		return fmt.Errorf("board: failed to write task file %s: %w", path, err)
 	}
 	return nil
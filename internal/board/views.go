@@ -0,0 +1,267 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// View is a named slice of a board saved in config.yaml: a query (filter), a sort spec, the
+// visible columns and a swimlane grouping. Empty fields leave the board's defaults in place.
+type View struct {
+	Name    string   `yaml:"name" json:"name"`
+	Query   string   `yaml:"query,omitempty" json:"query,omitempty"`
+	Sort    string   `yaml:"sort,omitempty" json:"sort,omitempty"`
+	Columns []string `yaml:"columns,omitempty" json:"columns,omitempty"`
+	GroupBy string   `yaml:"group_by,omitempty" json:"group_by,omitempty"`
+}
+
+// ParsedQuery combines the view's query and sort spec into a single parsed Query.
+func (v View) ParsedQuery() (Query, error) {
+	raw := strings.TrimSpace(v.Query)
+	if sortSpec := strings.TrimSpace(v.Sort); sortSpec != "" {
+		raw = strings.TrimSpace(raw + " sort:" + sortSpec)
+	}
+	return ParseQuery(raw)
+}
+
+// LaneGroup returns the view's swimlane grouping (zero when the view does not group).
+func (v View) LaneGroup() (LaneGroup, error) {
+	return ParseLaneGroup(v.GroupBy)
+}
+
+// ShowsStatus reports whether tasks with status appear in the view's visible columns.
+func (v View) ShowsStatus(status string) bool {
+	if len(v.Columns) == 0 {
+		return true
+	}
+	return containsFold(v.Columns, status)
+}
+
+// VisibleColumns returns the board columns shown by the view, in board order.
+func (v View) VisibleColumns(columns []Column) []Column {
+	if len(v.Columns) == 0 {
+		return columns
+	}
+	visible := make([]Column, 0, len(v.Columns))
+	for _, column := range columns {
+		if v.ShowsStatus(column.Key) {
+			visible = append(visible, column)
+		}
+	}
+	return visible
+}
+
+// Apply returns the tasks in the view's visible columns that match its query, ordered by its sort.
+func (v View) Apply(tasks []Task) ([]Task, error) {
+	query, err := v.ParsedQuery()
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		if v.ShowsStatus(task.Status) {
+			visible = append(visible, task)
+		}
+	}
+	return query.Apply(visible), nil
+}
+
+// ListViews returns the board's saved views in config order.
+func (r *Repository) ListViews() ([]View, error) {
+	return r.ListViewsContext(context.Background())
+}
+
+// ListViewsContext returns the board's saved views, honoring ctx cancellation.
+func (r *Repository) ListViewsContext(ctx context.Context) ([]View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cfg, err := r.loadConfigContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Views, nil
+}
+
+// GetView returns the saved view with the provided name (case-insensitive).
+func (r *Repository) GetView(name string) (View, error) {
+	return r.GetViewContext(context.Background(), name)
+}
+
+// GetViewContext returns the saved view with the provided name, honoring ctx cancellation.
+func (r *Repository) GetViewContext(ctx context.Context, name string) (View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cfg, err := r.loadConfigContext(ctx)
+	if err != nil {
+		return View{}, err
+	}
+	idx := viewIndex(cfg.Views, name)
+	if idx < 0 {
+		return View{}, fmt.Errorf("board: view %q: %w", strings.TrimSpace(name), ErrViewNotFound)
+	}
+	return cfg.Views[idx], nil
+}
+
+// SaveView validates view and stores it in the board config, replacing a view with the same
+// name. It reports whether an existing view was replaced.
+func (r *Repository) SaveView(view View) (bool, error) {
+	return r.SaveViewContext(context.Background(), view)
+}
+
+// SaveViewContext validates and stores view, honoring ctx cancellation.
+func (r *Repository) SaveViewContext(ctx context.Context, view View) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := r.loadConfigContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	view, err = normalizeView(view, normalizeConfig(cfg).Columns)
+	if err != nil {
+		return false, err
+	}
+	replaced := false
+	if idx := viewIndex(cfg.Views, view.Name); idx >= 0 {
+		cfg.Views[idx] = view
+		replaced = true
+	} else {
+		cfg.Views = append(cfg.Views, view)
+	}
+	if err := r.saveConfigContext(ctx, cfg); err != nil {
+		return false, err
+	}
+	return replaced, nil
+}
+
+// DeleteView removes the saved view with the provided name.
+func (r *Repository) DeleteView(name string) error {
+	return r.DeleteViewContext(context.Background(), name)
+}
+
+// DeleteViewContext removes the saved view with the provided name, honoring ctx cancellation.
+func (r *Repository) DeleteViewContext(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := r.loadConfigContext(ctx)
+	if err != nil {
+		return err
+	}
+	idx := viewIndex(cfg.Views, name)
+	if idx < 0 {
+		return fmt.Errorf("board: view %q: %w", strings.TrimSpace(name), ErrViewNotFound)
+	}
+	cfg.Views = append(cfg.Views[:idx], cfg.Views[idx+1:]...)
+	return r.saveConfigContext(ctx, cfg)
+}
+
+// normalizeView trims the view's fields, maps its columns onto the board's column keys and
+// rejects views whose query, sort or grouping does not parse.
+func normalizeView(view View, columns []Column) (View, error) {
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return View{}, fmt.Errorf("board: view name is required: %w", ErrInvalidView)
+	}
+	if strings.ContainsAny(view.Name, "\n\r\t") {
+		return View{}, fmt.Errorf("board: view name %q must be a single line: %w", view.Name, ErrInvalidView)
+	}
+	view.Query = strings.TrimSpace(view.Query)
+	view.Sort = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(view.Sort), "sort:"))
+	view.GroupBy = strings.TrimSpace(view.GroupBy)
+	if _, err := view.ParsedQuery(); err != nil {
+		return View{}, fmt.Errorf("board: view %q: %w: %w", view.Name, ErrInvalidView, err)
+	}
+	if _, err := view.LaneGroup(); err != nil {
+		return View{}, fmt.Errorf("board: view %q: %w: %w", view.Name, ErrInvalidView, err)
+	}
+	keys := make([]string, 0, len(view.Columns))
+	for _, name := range view.Columns {
+		for _, part := range strings.Split(name, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			key := ""
+			for _, column := range columns {
+				if strings.EqualFold(column.Key, part) {
+					key = column.Key
+					break
+				}
+			}
+			if key == "" {
+				return View{}, fmt.Errorf("board: view %q: unknown column %q: %w", view.Name, part, ErrInvalidView)
+			}
+			if !containsFold(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	view.Columns = nil
+	if len(keys) > 0 {
+		view.Columns = keys
+	}
+	return view, nil
+}
+
+func viewIndex(views []View, name string) int {
+	trimmed := strings.TrimSpace(name)
+	for i, view := range views {
+		if strings.EqualFold(view.Name, trimmed) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatViewsTable renders saved views as a table.
+func FormatViewsTable(views []View) string {
+	headers := []string{"Name", "Query", "Sort", "Columns", "Group By"}
+	rows := make([][]string, 0, len(views))
+	for _, view := range views {
+		rows = append(rows, []string{view.Name, view.Query, view.Sort, strings.Join(view.Columns, ","), view.GroupBy})
+	}
+	return formatTable(headers, rows)
+}
+
+// FormatViewDetail renders a saved view's definition.
+func FormatViewDetail(view View) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "View: %s\n", view.Name)
+	fields := []struct{ label, value string }{
+		{"Query", view.Query},
+		{"Sort", view.Sort},
+		{"Columns", strings.Join(view.Columns, ", ")},
+		{"Group By", view.GroupBy},
+	}
+	for _, field := range fields {
+		value := field.value
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", field.label, value)
+	}
+	return b.String()
+}
+
+// FormatViewTasks renders tasks already selected by the view: grouped into swimlanes across the
+// view's visible columns when it groups, otherwise as a task table.
+func FormatViewTasks(view View, columns []Column, tasks []Task, laneOrder []string) string {
+	group, err := view.LaneGroup()
+	if err != nil || group.IsZero() {
+		return FormatTasksTable(tasks)
+	}
+	return FormatLanes(view.VisibleColumns(columns), tasks, group, laneOrder)
+}
@@ -0,0 +1,79 @@
+package board
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSaveViewNormalizesAndReplaces(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+
+	// Act
+	replaced, err := repo.SaveView(View{
+		Name:    " p1-review ",
+		Query:   "priority:1",
+		Sort:    "sort:-created",
+		Columns: []string{"DOING,done", "doing"},
+		GroupBy: "assignee",
+	})
+	if err != nil {
+		t.Fatalf("save view: %v", err)
+	}
+	again, err := repo.SaveView(View{Name: "P1-Review", Query: "priority:1 status:doing"})
+	if err != nil {
+		t.Fatalf("replace view: %v", err)
+	}
+
+	// Assert
+	if replaced || !again {
+		t.Fatalf("expected first save to create and second to replace, got %v/%v", replaced, again)
+	}
+	views, err := repo.ListViews()
+	if err != nil {
+		t.Fatalf("list views: %v", err)
+	}
+	want := []View{{Name: "P1-Review", Query: "priority:1 status:doing"}}
+	if !reflect.DeepEqual(views, want) {
+		t.Fatalf("expected %+v, got %+v", want, views)
+	}
+	if _, err := repo.SaveView(View{Name: "bad", Columns: []string{"review"}}); !errors.Is(err, ErrInvalidView) {
+		t.Fatalf("expected ErrInvalidView for unknown column, got %v", err)
+	}
+	if _, err := repo.SaveView(View{Name: "bad", Query: "priority<"}); !errors.Is(err, ErrInvalidQuery) || !errors.Is(err, ErrInvalidView) {
+		t.Fatalf("expected query error wrapped in ErrInvalidView, got %v", err)
+	}
+	if err := repo.DeleteView("p1-review"); err != nil {
+		t.Fatalf("delete view: %v", err)
+	}
+	if _, err := repo.GetView("p1-review"); !errors.Is(err, ErrViewNotFound) {
+		t.Fatalf("expected ErrViewNotFound, got %v", err)
+	}
+}
+
+func TestViewApply(t *testing.T) {
+	// Arrange
+	view := View{Name: "mine", Query: "tag:api", Sort: "-priority", Columns: []string{"todo", "doing"}}
+	tasks := []Task{
+		{ID: "T-1", Status: "todo", Priority: 1, Tags: []string{"api"}},
+		{ID: "T-2", Status: "done", Priority: 3, Tags: []string{"api"}},
+		{ID: "T-3", Status: "doing", Priority: 3, Tags: []string{"api"}},
+		{ID: "T-4", Status: "doing", Priority: 3, Tags: []string{"ui"}},
+	}
+
+	// Act
+	got, err := view.Apply(tasks)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("apply view: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "T-3" || got[1].ID != "T-1" {
+		t.Fatalf("unexpected view result: %+v", got)
+	}
+	columns := view.VisibleColumns(DefaultConfig().Columns)
+	if len(columns) != 2 || columns[1].Key != "doing" {
+		t.Fatalf("unexpected visible columns: %+v", columns)
+	}
+}
@@ -0,0 +1,92 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DefaultBranchPattern names task branches after the task ID and title.
+const DefaultBranchPattern = "{id}-{slug}"
+
+// maxBranchSlug caps the title part of a branch name.
+const maxBranchSlug = 40
+
+// StartColumn returns the first active column: the first column after the backlog column
+// that is not a done status.
+func (cfg Config) StartColumn() (string, error) {
+	for i, column := range cfg.Columns {
+		if i > 0 && !IsDoneStatus(column.Key) {
+			return column.Key, nil
+		}
+	}
+	return "", fmt.Errorf("board: no active column after %q: %w", firstColumnKey(cfg), ErrInvalidStatus)
+}
+
+// FinishColumn returns the review column (a column whose key contains "review") when the
+// board has one, otherwise its first done column.
+func (cfg Config) FinishColumn() (string, error) {
+	for _, column := range cfg.Columns {
+		if strings.Contains(normalizeStatus(column.Key), "review") {
+			return column.Key, nil
+		}
+	}
+	for _, column := range cfg.Columns {
+		if IsDoneStatus(column.Key) {
+			return column.Key, nil
+		}
+	}
+	return "", fmt.Errorf("board: no review or done column: %w", ErrInvalidStatus)
+}
+
+func firstColumnKey(cfg Config) string {
+	if len(cfg.Columns) == 0 {
+		return ""
+	}
+	return cfg.Columns[0].Key
+}
+
+// BranchName renders a branch pattern for task. Placeholders: {id}, {slug} (the title in
+// lower-case words joined by dashes, at most 40 characters) and {board}. An empty pattern
+// uses DefaultBranchPattern.
+func BranchName(pattern string, task Task) string {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = DefaultBranchPattern
+	}
+	slug := slugify(task.Title)
+	if len(slug) > maxBranchSlug {
+		slug = slug[:maxBranchSlug]
+		if cut := strings.LastIndex(slug, "-"); cut > 0 {
+			slug = slug[:cut]
+		}
+	}
+	return strings.NewReplacer(
+		"{id}", task.ID,
+		"{slug}", slug,
+		"{board}", task.BoardID,
+	).Replace(pattern)
+}
+
+// StartTask moves a task to status and records its branch.
+func (r *Repository) StartTask(id, status, branch string) error {
+	return r.StartTaskContext(context.Background(), id, status, branch)
+}
+
+// StartTaskContext moves a task to status and records branch in its frontmatter, honoring
+// ctx cancellation.
+func (r *Repository) StartTaskContext(ctx context.Context, id, status, branch string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateID(id); err != nil {
+		return err
+	}
+	if strings.TrimSpace(status) == "" {
+		return fmt.Errorf("board: %w", ErrInvalidStatus)
+	}
+	return r.updateTaskLockedContext(ctx, id, func(task *Task) error {
+		applyStatusChange(task, status, r.now())
+		task.Branch = strings.TrimSpace(branch)
+		return nil
+	})
+}
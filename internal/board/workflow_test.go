@@ -0,0 +1,98 @@
+package board
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkflowColumns(t *testing.T) {
+	tests := []struct {
+		name      string
+		columns   []string
+		start     string
+		finish    string
+		finishErr bool
+	}{
+		{name: "default board", columns: []string{"todo", "doing", "done"}, start: "doing", finish: "done"},
+		{name: "review column", columns: []string{"backlog", "in-progress", "code-review", "done"}, start: "in-progress", finish: "code-review"},
+		{name: "no done column", columns: []string{"todo", "doing"}, start: "doing", finishErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := Config{}
+			for _, key := range tt.columns {
+				cfg.Columns = append(cfg.Columns, Column{Key: key, Title: key})
+			}
+
+			// Act
+			start, startErr := cfg.StartColumn()
+			finish, finishErr := cfg.FinishColumn()
+
+			// Assert
+			if startErr != nil || start != tt.start {
+				t.Fatalf("expected start column %q, got %q (%v)", tt.start, start, startErr)
+			}
+			if tt.finishErr {
+				if !errors.Is(finishErr, ErrInvalidStatus) {
+					t.Fatalf("expected ErrInvalidStatus, got %q (%v)", finish, finishErr)
+				}
+				return
+			}
+			if finishErr != nil || finish != tt.finish {
+				t.Fatalf("expected finish column %q, got %q (%v)", tt.finish, finish, finishErr)
+			}
+		})
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		task    Task
+		want    string
+	}{
+		{name: "default pattern", task: Task{ID: "T-000012", Title: "Add login page!"}, want: "T-000012-add-login-page"},
+		{name: "custom pattern", pattern: "feature/{board}/{id}", task: Task{ID: "T-000012", BoardID: "web"}, want: "feature/web/T-000012"},
+		{
+			name: "long title cut at word",
+			task: Task{ID: "T-1", Title: "Support exporting every board to a single printable report"},
+			want: "T-1-support-exporting-every-board-to-a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := BranchName(tt.pattern, tt.task)
+
+			// Assert
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStartTaskRecordsBranch(t *testing.T) {
+	// Arrange
+	repo, _, _ := setupRepo(t)
+	created, err := repo.CreateTask(Task{Title: "Add login", Status: "todo"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Act
+	if err := repo.StartTask(created.ID, "doing", "T-1-add-login"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	started, err := repo.GetTaskByID(created.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	// Assert
+	if started.Status != "doing" || started.Branch != "T-1-add-login" {
+		t.Fatalf("unexpected started task: %+v", started)
+	}
+}
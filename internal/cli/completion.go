@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/adr"
+	boardpkg "mochi-sticky/internal/board"
+	"mochi-sticky/internal/storage"
+	"mochi-sticky/internal/wiki"
+
+	"github.com/spf13/cobra"
+)
+
+// Template kinds accepted by CompleteTemplates.
+const (
+	TemplateTask  = "task"
+	TemplateBoard = "board"
+	TemplateWiki  = "wiki"
+	TemplateADR   = "adr"
+)
+
+// CompleteArgs completes each positional argument with the function at its index; later
+// arguments get no completions.
+func CompleteArgs(funcs ...cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) >= len(funcs) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return funcs[len(args)](cmd, args, toComplete)
+	}
+}
+
+// CompleteTaskIDs completes task IDs of the active board, or of --board when the command has
+// that flag, with the task titles as descriptions.
+func CompleteTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	repo, err := completionRepo(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tasks, err := repo.GetAllTasksContext(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]cobra.Completion, 0, len(tasks))
+	for _, task := range tasks {
+		if strings.HasPrefix(task.ID, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(task.ID, task.Title))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteArchivedTaskIDs completes IDs of archived tasks of the active board.
+func CompleteArchivedTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	repo, err := completionRepo(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tasks, err := repo.ListArchivedTasksContext(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]cobra.Completion, 0, len(tasks))
+	for _, task := range tasks {
+		if strings.HasPrefix(task.ID, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(task.ID, task.Title))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteBoardIDs completes board IDs with the board names as descriptions. A
+// comma-separated value (--boards a,b) completes its last element.
+func CompleteBoardIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	boardRepo, err := BoardRepoFromCwd()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	boards, _, err := boardRepo.ListBoardsContext(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	listPrefix, current := splitListValue(toComplete)
+	completions := make([]cobra.Completion, 0, len(boards))
+	for _, item := range boards {
+		if strings.HasPrefix(item.ID, current) {
+			completions = append(completions, cobra.CompletionWithDesc(listPrefix+item.ID, item.Name))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteStatuses completes the column keys of the active board, or of --board when the
+// command has that flag, with the column titles as descriptions.
+func CompleteStatuses(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	repo, err := completionRepo(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	config, err := repo.LoadConfigContext(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	listPrefix, current := splitListValue(toComplete)
+	completions := make([]cobra.Completion, 0, len(config.Columns))
+	for _, column := range config.Columns {
+		if strings.HasPrefix(column.Key, current) {
+			completions = append(completions, cobra.CompletionWithDesc(listPrefix+column.Key, column.Title))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteADRIDs completes ADR IDs in the ADR-0001 form with the ADR titles as descriptions.
+func CompleteADRIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	repo, err := completionADRRepo()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	records, err := repo.ListADRsContext(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]cobra.Completion, 0, len(records))
+	for _, record := range records {
+		id := "ADR-" + adr.FormatID(record.ID)
+		if strings.HasPrefix(id, strings.ToUpper(toComplete)) || strings.HasPrefix(adr.FormatID(record.ID), toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(id, record.Title))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteADRStatuses completes the configured ADR status keys.
+func CompleteADRStatuses(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	repo, err := completionADRRepo()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	config, err := repo.LoadConfigContext(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]cobra.Completion, 0, len(config.Columns))
+	for _, column := range config.Columns {
+		if strings.HasPrefix(column.Key, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(column.Key, column.Title))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteWikiSlugs completes wiki page slugs with the page titles as descriptions.
+func CompleteWikiSlugs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	root, pages, err := completionWikiPages(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]cobra.Completion, 0, len(pages))
+	for _, page := range pages {
+		slug := strings.TrimSpace(page.Slug)
+		if slug == "" {
+			rel, err := filepath.Rel(root, page.FilePath)
+			if err != nil {
+				continue
+			}
+			slug = filepath.ToSlash(strings.TrimSuffix(rel, ".md"))
+		}
+		if strings.HasPrefix(slug, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(slug, page.Title))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteWikiSections completes the section names used by wiki pages.
+func CompleteWikiSections(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	_, pages, err := completionWikiPages(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	seen := map[string]bool{}
+	completions := make([]cobra.Completion, 0)
+	for _, page := range pages {
+		section := strings.TrimSpace(page.Section)
+		if section == "" || seen[section] || !strings.HasPrefix(strings.ToLower(section), strings.ToLower(toComplete)) {
+			continue
+		}
+		seen[section] = true
+		completions = append(completions, section)
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteTemplates returns a completion function for template names of the given kind
+// (TemplateTask, TemplateBoard, TemplateWiki or TemplateADR).
+func CompleteTemplates(kind string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		storageRoot, err := ResolveStorageRoot(workingDir, false)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		paths, err := ResolveTemplatePaths(workingDir, storageRoot)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		dir, ext := templateDir(kind, paths)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		completions := make([]cobra.Completion, 0, len(entries))
+		for _, entry := range entries {
+			name, ok := strings.CutSuffix(entry.Name(), ext)
+			if entry.IsDir() || !ok || !strings.HasPrefix(name, toComplete) {
+				continue
+			}
+			completions = append(completions, name)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func templateDir(kind string, paths storage.TemplatePaths) (string, string) {
+	switch kind {
+	case TemplateBoard:
+		return paths.Board, ".yaml"
+	case TemplateWiki:
+		return paths.Wiki, ".md"
+	case TemplateADR:
+		return paths.ADR, ".md"
+	default:
+		return paths.Task, ".md"
+	}
+}
+
+// completionRepo opens the board named by the command's --board flag, or the active board.
+func completionRepo(cmd *cobra.Command) (*boardpkg.Repository, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	storageRoot, err := ResolveStorageRoot(workingDir, false)
+	if err != nil {
+		return nil, err
+	}
+	if flag := cmd.Flags().Lookup("board"); flag != nil && strings.TrimSpace(flag.Value.String()) != "" {
+		return boardpkg.NewRepositoryForBoardWithStorage(workingDir, flag.Value.String(), storageRoot)
+	}
+	return boardpkg.NewRepositoryWithStorage(workingDir, storageRoot)
+}
+
+func completionADRRepo() (*adr.Repository, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	storageRoot, err := ResolveStorageRoot(workingDir, false)
+	if err != nil {
+		return nil, err
+	}
+	return adr.NewRepository(filepath.Join(storageRoot, "adrs"))
+}
+
+func completionWikiPages(cmd *cobra.Command) (string, []wiki.Page, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return "", nil, err
+	}
+	storageRoot, err := ResolveStorageRoot(workingDir, false)
+	if err != nil {
+		return "", nil, err
+	}
+	root := filepath.Join(storageRoot, "wiki")
+	pages, err := wiki.ListPagesContext(cmd.Context(), root)
+	if err != nil {
+		return "", nil, err
+	}
+	return root, pages, nil
+}
+
+// splitListValue splits a comma-separated flag value into the completed elements (with
+// their trailing comma) and the element being typed.
+func splitListValue(value string) (string, string) {
+	index := strings.LastIndex(value, ",")
+	if index < 0 {
+		return "", value
+	}
+	return value[:index+1], value[index+1:]
+}
+
+// CompleteFlag registers fn as the completion function of the named flag. The flag must be
+// defined on cmd (locally or as a persistent flag).
+func CompleteFlag(cmd *cobra.Command, name string, fn cobra.CompletionFunc) {
+	if err := cmd.RegisterFlagCompletionFunc(name, fn); err != nil {
+		panic(err)
+	}
+}
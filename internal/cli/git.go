@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/git"
+)
+
+// TaskCommits maps task IDs to the commits referencing them in the git history of the
+// working tree that holds the storage root, using the storage config's git.task_pattern.
+// Outside a git repository the map is empty.
+func TaskCommits(ctx context.Context, workingDir, storageRoot string) (map[string][]git.Commit, error) {
+	config, err := LoadStorageConfig(workingDir)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.Open(ctx, storageRoot)
+	if errors.Is(err, git.ErrNotRepository) {
+		return map[string][]git.Commit{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return repo.TaskCommits(ctx, git.CommitCachePath(storageRoot), config.Git.TaskPattern)
+}
+
+// MergedBranch describes the branch merged into the working tree that holds the storage root
+// (see git.Repo.MergedBranch): the UIDs of the tasks it added and the files it added. Outside
+// a git repository or a merge it is the zero Branch.
+func MergedBranch(ctx context.Context, storageRoot string) (board.Branch, error) {
+	repo, err := git.Open(ctx, storageRoot)
+	if errors.Is(err, git.ErrNotRepository) {
+		return board.Branch{}, nil
+	}
+	if err != nil {
+		return board.Branch{}, err
+	}
+	merged, ok, err := repo.MergedBranch(ctx)
+	if err != nil || !ok {
+		return board.Branch{}, err
+	}
+	branch := board.Branch{
+		TaskUIDs: make([]string, 0),
+		Added: func(name string) bool {
+			rel, ok := repo.Relative(name)
+			_, added := merged.Added[rel]
+			return ok && added
+		},
+	}
+	parser := &board.Parser{}
+	for name, data := range merged.Added {
+		if path.Ext(name) != ".md" {
+			continue
+		}
+		if task, err := parser.Parse(data); err == nil && task.UID != "" {
+			branch.TaskUIDs = append(branch.TaskUIDs, task.UID)
+		}
+	}
+	sort.Strings(branch.TaskUIDs)
+	return branch, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	boardpkg "mochi-sticky/internal/board"
+	"mochi-sticky/internal/shared"
 	"mochi-sticky/internal/storage"
 	"mochi-sticky/internal/wiki"
 
@@ -44,6 +45,14 @@ func ResolveTemplatePaths(workingDir, storageRoot string) (storage.TemplatePaths
 	return storage.ResolveTemplates(workingDir, storageRoot, cfg)
 }
 
+func ResolveConfigPaths(workingDir, storageRoot string) (storage.ResolvedConfigPaths, error) {
+	cfg, err := storage.LoadConfigWithOverride(workingDir, storageFlagValue())
+	if err != nil {
+		return storage.ResolvedConfigPaths{}, err
+	}
+	return storage.ResolveConfigPaths(workingDir, storageRoot, cfg)
+}
+
 func RepoFromCwd() (*boardpkg.Repository, error) {
 	workingDir, err := os.Getwd()
 	if err != nil {
@@ -173,7 +182,7 @@ func ResolvePathForConfig(workingDir, value string) string {
 }
 
 func EnsureReadableDir(path string) error {
-	info, err := os.Stat(path)
+	info, err := shared.Files().Stat(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("missing directory: %s", path)
@@ -187,7 +196,7 @@ func EnsureReadableDir(path string) error {
 }
 
 func EnsureReadableFile(path string) error {
-	info, err := os.Stat(path)
+	info, err := shared.Files().Stat(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("missing file: %s", path)
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -212,3 +213,19 @@ func TestResolveStorageRootUsesOverrideFlag(t *testing.T) {
 		t.Fatalf("expected override path, got %q", resolved)
 	}
 }
+
+func TestCheckRevisionRejectsCommandsThatWrite(t *testing.T) {
+	// Arrange
+	rev := "HEAD"
+	SetAtFlagRef(&rev)
+	t.Cleanup(func() { SetAtFlagRef(nil) })
+	cmd := &cobra.Command{Use: "move"}
+
+	// Act
+	err := CheckRevision(cmd)
+
+	// Assert
+	if !errors.Is(err, ErrRevisionUnsupported) {
+		t.Fatalf("expected ErrRevisionUnsupported, got %v", err)
+	}
+}
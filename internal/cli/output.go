@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+
+	"mochi-sticky/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// OutputAnnotation marks commands that honor the global --output flag.
+const OutputAnnotation = "mochi-sticky/output"
+
+var outputFlagRef *string
+
+func SetOutputFlagRef(ref *string) {
+	outputFlagRef = ref
+}
+
+// SupportOutput marks cmd as honoring --output json|yaml|csv.
+func SupportOutput(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[OutputAnnotation] = "true"
+}
+
+// OutputFormat returns the format selected with the global --output flag.
+func OutputFormat() (output.Format, error) {
+	if outputFlagRef == nil {
+		return output.FormatTable, nil
+	}
+	return output.ParseFormat(*outputFlagRef)
+}
+
+// CheckOutput validates --output for cmd: commands that only print tables reject the
+// machine-readable formats, and structured output silences cobra's plain-text error and
+// usage messages so errors can be reported in the same format.
+func CheckOutput(cmd *cobra.Command) error {
+	format, err := OutputFormat()
+	if err != nil {
+		return err
+	}
+	if format == output.FormatTable {
+		return nil
+	}
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	if cmd.Annotations[OutputAnnotation] != "true" {
+		return fmt.Errorf("%s: --output %s: %w", cmd.CommandPath(), format, output.ErrUnsupportedFormat)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/shared"
+
+	"github.com/spf13/cobra"
+)
+
+// RevisionAnnotation marks commands that can read storage at a git revision with --at.
+const RevisionAnnotation = "mochi-sticky/revision"
+
+// ErrRevisionUnsupported indicates --at on a command that changes storage.
+var ErrRevisionUnsupported = errors.New("--at is only supported by read-only commands")
+
+var atFlagRef *string
+
+func SetAtFlagRef(ref *string) {
+	atFlagRef = ref
+}
+
+// Revision returns the git revision selected with --at, or an empty string for the working
+// tree.
+func Revision() string {
+	if atFlagRef == nil {
+		return ""
+	}
+	return strings.TrimSpace(*atFlagRef)
+}
+
+// SupportRevision marks cmd as able to read storage at the revision given by --at.
+func SupportRevision(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[RevisionAnnotation] = "true"
+}
+
+// CheckRevision applies --at for cmd: commands not marked with SupportRevision reject it, and
+// the others read boards, wiki pages and ADRs from the git objects of that revision instead
+// of the working tree. Writes to storage then fail with shared.ErrReadOnly.
+func CheckRevision(cmd *cobra.Command) error {
+	rev := Revision()
+	if rev == "" {
+		return nil
+	}
+	cmd.SilenceUsage = true
+	if cmd.Annotations[RevisionAnnotation] != "true" {
+		return fmt.Errorf("%s: %w", cmd.CommandPath(), ErrRevisionUnsupported)
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	storageRoot, err := ResolveStorageRoot(workingDir, true)
+	if err != nil {
+		return err
+	}
+	repoDir := storageRoot
+	if _, err := os.Stat(repoDir); err != nil {
+		repoDir = workingDir
+	}
+	repo, err := git.Open(cmd.Context(), repoDir)
+	if err != nil {
+		return err
+	}
+	snapshot, err := repo.Snapshot(cmd.Context(), rev)
+	if err != nil {
+		return err
+	}
+	shared.UseFiles(snapshot)
+	return nil
+}
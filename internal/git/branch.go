@@ -0,0 +1,83 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrDirtyWorktree indicates uncommitted changes that switching branches could carry over
+	// or lose.
+	ErrDirtyWorktree = errors.New("working tree has uncommitted changes")
+	// ErrInvalidBranch indicates a name git does not accept as a branch.
+	ErrInvalidBranch = errors.New("invalid branch name")
+)
+
+// IsDirty reports whether the working tree has staged, unstaged or untracked changes.
+func (r *Repo) IsDirty(ctx context.Context) (bool, error) {
+	out, err := r.run(ctx, "status", "--porcelain")
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, fmt.Errorf("git: failed to read working tree status: %w", err)
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// CurrentBranch returns the checked out branch, or an empty string on a detached HEAD.
+func (r *Repo) CurrentBranch(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "branch", "--show-current")
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("git: failed to read current branch: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// BranchExists reports whether the local branch name exists.
+func (r *Repo) BranchExists(ctx context.Context, name string) (bool, error) {
+	_, err := r.run(ctx, "show-ref", "--verify", "--quiet", "refs/heads/"+name)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// CheckoutBranch switches to the branch name, creating it from HEAD when create is set. The
+// name is checked with git check-ref-format first.
+func (r *Repo) CheckoutBranch(ctx context.Context, name string, create bool) error {
+	if _, err := r.run(ctx, "check-ref-format", "--branch", name); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("git: %q: %w", name, ErrInvalidBranch)
+	}
+	args := []string{"checkout", name}
+	if create {
+		args = []string{"checkout", "-b", name}
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("git: failed to check out branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// UserName returns the configured user.name, or an empty string when it is not set.
+func (r *Repo) UserName(ctx context.Context) string {
+	out, err := r.run(ctx, "config", "user.name")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
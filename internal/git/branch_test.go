@@ -0,0 +1,59 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckoutBranchRequiresCleanCheck(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	commitFile(t, dir, "README.md", "Initial commit")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	ctx := context.Background()
+
+	// Act
+	clean, err := repo.IsDirty(ctx)
+	if err != nil {
+		t.Fatalf("is dirty: %v", err)
+	}
+	if err := repo.CheckoutBranch(ctx, "T-000001-add-login", true); err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	current, err := repo.CurrentBranch(ctx)
+	if err != nil {
+		t.Fatalf("current branch: %v", err)
+	}
+	exists, err := repo.BranchExists(ctx, "T-000001-add-login")
+	if err != nil {
+		t.Fatalf("branch exists: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	dirty, err := repo.IsDirty(ctx)
+	if err != nil {
+		t.Fatalf("is dirty: %v", err)
+	}
+	invalid := repo.CheckoutBranch(ctx, "bad..name", true)
+
+	// Assert
+	if clean {
+		t.Fatalf("expected a clean working tree after commit")
+	}
+	if current != "T-000001-add-login" || !exists {
+		t.Fatalf("expected to be on the new branch, got %q (exists %v)", current, exists)
+	}
+	if !dirty {
+		t.Fatalf("expected untracked file to make the tree dirty")
+	}
+	if !errors.Is(invalid, ErrInvalidBranch) {
+		t.Fatalf("expected ErrInvalidBranch, got %v", invalid)
+	}
+}
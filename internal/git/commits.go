@@ -0,0 +1,247 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultTaskPattern matches task IDs such as T-000012, hash IDs such as T-465F0BD and ULIDs
+// in commit messages.
+const DefaultTaskPattern = `\b[A-Z][A-Z0-9]*-[0-9A-Z]+\b`
+
+const (
+	commitCacheVersion = 1
+
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+	logFormat       = "--format=%H%x1f%an%x1f%ae%x1f%aI%x1f%s%x1f%b%x1e"
+)
+
+// Commit is a commit that references a task.
+type Commit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// CommitCachePath returns the location of the task commit cache within the storage root.
+func CommitCachePath(storageRoot string) string {
+	return filepath.Join(storageRoot, ".cache", "git-commits.json")
+}
+
+// CompileTaskPattern compiles a task reference pattern; an empty pattern uses
+// DefaultTaskPattern. When the pattern has a capture group, the first non-empty group is the
+// task ID, otherwise the whole match is.
+func CompileTaskPattern(pattern string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = DefaultTaskPattern
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("git: %q: %v: %w", pattern, err, ErrInvalidPattern)
+	}
+	return compiled, nil
+}
+
+// TaskRefs returns the task IDs referenced by message, in order of first appearance.
+func TaskRefs(pattern *regexp.Regexp, message string) []string {
+	seen := map[string]bool{}
+	refs := make([]string, 0)
+	for _, match := range pattern.FindAllStringSubmatch(message, -1) {
+		id := match[0]
+		for _, group := range match[1:] {
+			if group != "" {
+				id = group
+				break
+			}
+		}
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		refs = append(refs, id)
+	}
+	return refs
+}
+
+// commitCache is the on-disk task commit index, valid for one HEAD and pattern.
+type commitCache struct {
+	Version int                 `json:"version"`
+	Head    string              `json:"head"`
+	Pattern string              `json:"pattern"`
+	Tasks   map[string][]Commit `json:"tasks"`
+}
+
+// TaskCommits maps every task ID referenced in the history of HEAD to its commits, newest
+// first. pattern selects task references (see CompileTaskPattern). The result is cached at
+// cachePath for the current HEAD; when HEAD moved forward only the new commits are read.
+// An empty cachePath disables the cache.
+func (r *Repo) TaskCommits(ctx context.Context, cachePath, pattern string) (map[string][]Commit, error) {
+	compiled, err := CompileTaskPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	head, err := r.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head == "" {
+		return map[string][]Commit{}, nil
+	}
+	cache := loadCommitCache(cachePath)
+	if cache.Head == head && cache.Pattern == compiled.String() {
+		return cache.Tasks, nil
+	}
+
+	revision := head
+	tasks := map[string][]Commit{}
+	if cache.Head != "" && cache.Pattern == compiled.String() && r.isAncestor(ctx, cache.Head, head) {
+		revision = cache.Head + ".." + head
+		tasks = cache.Tasks
+	}
+	scanned, err := r.scanTaskCommits(ctx, compiled, revision)
+	if err != nil {
+		return nil, err
+	}
+	for id, commits := range scanned {
+		tasks[id] = append(commits, tasks[id]...)
+	}
+
+	if strings.TrimSpace(cachePath) != "" {
+		updated := commitCache{Version: commitCacheVersion, Head: head, Pattern: compiled.String(), Tasks: tasks}
+		if err := updated.save(cachePath); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+// CommitsForTask returns the commits referencing the task ID, newest first.
+func (r *Repo) CommitsForTask(ctx context.Context, cachePath, pattern, id string) ([]Commit, error) {
+	tasks, err := r.TaskCommits(ctx, cachePath, pattern)
+	if err != nil {
+		return nil, err
+	}
+	commits := tasks[id]
+	if commits == nil {
+		commits = []Commit{}
+	}
+	return commits, nil
+}
+
+func (r *Repo) scanTaskCommits(ctx context.Context, pattern *regexp.Regexp, revision string) (map[string][]Commit, error) {
+	out, err := r.run(ctx, "log", logFormat, revision, "--")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("git: failed to read history: %w", err)
+	}
+	tasks := map[string][]Commit{}
+	for _, record := range strings.Split(out, recordSeparator) {
+		commit, message, ok, err := parseCommitRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, id := range TaskRefs(pattern, message) {
+			tasks[id] = append(tasks[id], commit)
+		}
+	}
+	return tasks, nil
+}
+
+// parseCommitRecord parses one logFormat record into the commit and its full message. ok is
+// false for blank records.
+func parseCommitRecord(record string) (Commit, string, bool, error) {
+	fields := strings.SplitN(strings.TrimLeft(record, "\n"), fieldSeparator, 6)
+	if len(fields) < 6 {
+		return Commit{}, "", false, nil
+	}
+	date, err := time.Parse(time.RFC3339, fields[3])
+	if err != nil {
+		return Commit{}, "", false, fmt.Errorf("git: commit %s: invalid date %q: %w", fields[0], fields[3], err)
+	}
+	commit := Commit{Hash: fields[0], Author: fields[1], Email: fields[2], Date: date, Subject: fields[4]}
+	return commit, strings.TrimSpace(fields[4] + "\n\n" + fields[5]), true, nil
+}
+
+// loadCommitCache reads the cache at path. A missing, unreadable or outdated cache is
+// replaced by an empty one.
+func loadCommitCache(path string) commitCache {
+	empty := commitCache{Version: commitCacheVersion, Tasks: map[string][]Commit{}}
+	if strings.TrimSpace(path) == "" {
+		return empty
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return empty
+	}
+	var cache commitCache
+	if err := json.Unmarshal(data, &cache); err != nil || cache.Version != commitCacheVersion || cache.Tasks == nil {
+		return empty
+	}
+	return cache
+}
+
+// save writes the cache atomically and keeps the cache directory out of version control.
+func (c commitCache) save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("git: failed to create cache dir %s: %w", dir, err)
+	}
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); errors.Is(err, fs.ErrNotExist) {
+		if err := os.WriteFile(ignore, []byte("*\n"), 0o644); err != nil {
+			return fmt.Errorf("git: failed to write %s: %w", ignore, err)
+		}
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("git: failed to encode commit cache: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".git-commits-*.json")
+	if err != nil {
+		return fmt.Errorf("git: failed to write commit cache: %w", err)
+	}
+	// CreateTemp uses 0600; the cache is as readable as the other files under .cache.
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("git: failed to write commit cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("git: failed to write commit cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("git: failed to write commit cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("git: failed to write commit cache: %w", err)
+	}
+	return nil
+}
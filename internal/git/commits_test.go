@@ -0,0 +1,146 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTaskRefs(t *testing.T) {
+	cases := []struct {
+		name    string
+		pattern string
+		message string
+		want    []string
+	}{
+		{name: "default", message: "T-000012: fix parser\n\nAlso touches T-000003 and T-000012.", want: []string{"T-000012", "T-000003"}},
+		{name: "hash and ulid ids", message: "T-465F0BD: fix parser (refs T-01JB8Z6Q4X7T3M9K2V5W0N1R8C)", want: []string{"T-465F0BD", "T-01JB8Z6Q4X7T3M9K2V5W0N1R8C"}},
+		{name: "no refs", message: "Bump dependencies", want: []string{}},
+		{name: "capture group", pattern: `\[task (T-\d+)\]`, message: "Fix parser [task T-7] for T-8", want: []string{"T-7"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			pattern, err := CompileTaskPattern(tc.pattern)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+
+			// Act
+			got := TaskRefs(pattern, tc.message)
+
+			// Assert
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCompileTaskPatternRejectsInvalidRegexp(t *testing.T) {
+	// Act
+	_, err := CompileTaskPattern("T-(")
+
+	// Assert
+	if !errors.Is(err, ErrInvalidPattern) {
+		t.Fatalf("expected ErrInvalidPattern, got %v", err)
+	}
+}
+
+func TestOpenOutsideRepository(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	// Act
+	_, err := Open(context.Background(), dir)
+
+	// Assert
+	if !errors.Is(err, ErrNotRepository) {
+		t.Fatalf("expected ErrNotRepository, got %v", err)
+	}
+}
+
+func TestTaskCommitsCachesByHead(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	commitFile(t, dir, "a.txt", "T-000001: add parser")
+	commitFile(t, dir, "b.txt", "Refactor lexer\n\nRefs T-000002")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	cachePath := filepath.Join(t.TempDir(), ".cache", "git-commits.json")
+
+	// Act
+	first, err := repo.TaskCommits(context.Background(), cachePath, "")
+	if err != nil {
+		t.Fatalf("task commits: %v", err)
+	}
+	commitFile(t, dir, "c.txt", "T-000001: fix parser")
+	second, err := repo.TaskCommits(context.Background(), cachePath, "")
+	if err != nil {
+		t.Fatalf("task commits: %v", err)
+	}
+
+	// Assert
+	if len(first["T-000001"]) != 1 || len(first["T-000002"]) != 1 {
+		t.Fatalf("unexpected links: %+v", first)
+	}
+	if first["T-000002"][0].Subject != "Refactor lexer" || first["T-000002"][0].Author != "Test Author" {
+		t.Fatalf("unexpected commit: %+v", first["T-000002"][0])
+	}
+	linked := second["T-000001"]
+	if len(linked) != 2 || linked[0].Subject != "T-000001: fix parser" || linked[1].Subject != "T-000001: add parser" {
+		t.Fatalf("expected the new commit first, got %+v", linked)
+	}
+	cache := loadCommitCache(cachePath)
+	head, err := repo.Head(context.Background())
+	if err != nil {
+		t.Fatalf("head: %v", err)
+	}
+	if cache.Head != head || len(cache.Tasks["T-000001"]) != 2 {
+		t.Fatalf("expected the cache to follow HEAD, got %+v", cache)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(cachePath), ".gitignore")); err != nil {
+		t.Fatalf("expected cache .gitignore: %v", err)
+	}
+	if info, err := os.Stat(cachePath); err != nil || info.Mode().Perm() != 0o644 {
+		t.Fatalf("expected a 0644 cache file, got %v %v", info, err)
+	}
+}
+
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	return dir
+}
+
+func commitFile(t *testing.T, dir, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(message+"\n"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-q", "-m", message)
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test Author", "GIT_AUTHOR_EMAIL=author@example.com",
+		"GIT_COMMITTER_NAME=Test Author", "GIT_COMMITTER_EMAIL=author@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
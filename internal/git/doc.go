@@ -0,0 +1,5 @@
+// Package git works with the git working tree that holds the storage root. It links commits
+// to tasks by scanning commit messages for task IDs (`T-000012: fix parser`) and caches the
+// links by HEAD so repeated lookups do not re-read the log. It also manages the hooks, task
+// branches and merge driver the CLI installs.
+package git
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ListFiles returns the files below paths that git tracks or would track: tracked files plus
+// untracked files not excluded by .gitignore, .git/info/exclude or the global excludes. Paths
+// are OS paths inside the working tree (none lists the whole tree); the result holds OS paths
+// in the order git reports them. Tracked files deleted from the working tree are still listed.
+func (r *Repo) ListFiles(ctx context.Context, paths []string) ([]string, error) {
+	args := []string{"ls-files", "-z", "--cached", "--others", "--exclude-standard", "--"}
+	for _, path := range paths {
+		rel, ok := r.Relative(path)
+		if !ok {
+			return nil, fmt.Errorf("git: %s is outside the working tree %s", path, r.root)
+		}
+		args = append(args, rel)
+	}
+	out, err := r.run(ctx, args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	files := make([]string, 0)
+	for _, name := range strings.Split(out, "\x00") {
+		// Unmerged files are listed once per conflict stage.
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		files = append(files, filepath.Join(r.root, filepath.FromSlash(name)))
+	}
+	return files, nil
+}
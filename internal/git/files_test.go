@@ -0,0 +1,56 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListFilesRespectsGitignore(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	commitFile(t, dir, ".gitignore", "build/")
+	if err := os.MkdirAll(filepath.Join(dir, "build"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	commitFile(t, dir, "src/main.go", "package main")
+	for _, name := range []string{"src/new.go", "build/out.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package main\n"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Act
+	all, err := repo.ListFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	src, err := repo.ListFiles(context.Background(), []string{filepath.Join(dir, "src")})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("list src: %v", err)
+	}
+	listed := map[string]bool{}
+	for _, path := range all {
+		rel, _ := repo.Relative(path)
+		listed[rel] = true
+	}
+	if !listed["src/main.go"] || !listed["src/new.go"] || !listed[".gitignore"] {
+		t.Fatalf("expected tracked and untracked files, got %v", all)
+	}
+	if listed["build/out.go"] {
+		t.Fatalf("expected ignored files to be skipped, got %v", all)
+	}
+	if len(src) != 2 {
+		t.Fatalf("expected the files below src, got %v", src)
+	}
+}
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrNotRepository indicates the directory is not inside a git working tree, or git is
+	// not installed.
+	ErrNotRepository = errors.New("not a git repository")
+	// ErrInvalidPattern indicates a task reference pattern that is not a valid regular expression.
+	ErrInvalidPattern = errors.New("invalid task pattern")
+)
+
+// Repo is a git working tree.
+type Repo struct {
+	root string
+}
+
+// Open returns the working tree containing dir.
+func Open(ctx context.Context, dir string) (*Repo, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, fmt.Errorf("git: directory is required")
+	}
+	out, err := run(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("git: %s: %w", dir, ErrNotRepository)
+	}
+	return &Repo{root: filepath.Clean(strings.TrimSpace(out))}, nil
+}
+
+// Root returns the top-level directory of the working tree.
+func (r *Repo) Root() string {
+	return r.root
+}
+
+// Head returns the commit hash of HEAD, or an empty string when the repository has no
+// commits yet.
+func (r *Repo) Head(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "rev-parse", "--verify", "--quiet", "HEAD")
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", nil
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Relative maps an OS path to its slash-separated path in the working tree ("." for the top
+// level). It reports false for paths outside the working tree.
+func (r *Repo) Relative(name string) (string, bool) {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return "", false
+	}
+	for _, candidate := range []string{abs, resolveSymlinks(abs)} {
+		rel, err := filepath.Rel(r.root, candidate)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel), true
+		}
+	}
+	return "", false
+}
+
+// resolveSymlinks resolves the symlinks in the longest existing prefix of path, so paths
+// through a symlinked directory (such as /tmp on macOS) still map into the working tree,
+// whose root git reports without symlinks.
+func resolveSymlinks(path string) string {
+	rest := ""
+	for dir := path; ; dir = filepath.Dir(dir) {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(resolved, rest)
+		}
+		if filepath.Dir(dir) == dir {
+			return path
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+	}
+}
+
+// isAncestor reports whether ancestor is reachable from rev.
+func (r *Repo) isAncestor(ctx context.Context, ancestor, rev string) bool {
+	_, err := r.run(ctx, "merge-base", "--is-ancestor", ancestor, rev)
+	return err == nil
+}
+
+func (r *Repo) run(ctx context.Context, args ...string) (string, error) {
+	return run(ctx, r.root, args...)
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s: %w", args[0], message, err)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,298 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historyFormat starts every commit of `git log --name-status` with a record separator so
+// the file status lines that follow stay with their commit.
+const historyFormat = "--format=%x1e%H%x1f%an%x1f%ae%x1f%aI%x1f%s"
+
+// FileRevision is a file as of a commit that changed it.
+type FileRevision struct {
+	Commit Commit
+	// Path is the file's path in that commit, relative to the working tree root.
+	Path string
+	// Data is the file's content after the commit; nil when the commit deleted it.
+	Data []byte
+}
+
+// FileHistory returns the committed revisions of the file at path, oldest first, following
+// renames such as a task moving into archive/tasks. Untracked files have no history.
+func (r *Repo) FileHistory(ctx context.Context, path string) ([]FileRevision, error) {
+	rel, ok := r.Relative(path)
+	if !ok {
+		return nil, fmt.Errorf("git: %s is outside the working tree %s", path, r.root)
+	}
+	out, err := r.run(ctx, "log", "--follow", "--name-status", historyFormat, "--", rel)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if head, headErr := r.Head(ctx); headErr == nil && head == "" {
+			return []FileRevision{}, nil
+		}
+		return nil, fmt.Errorf("git: failed to read history of %s: %w", rel, err)
+	}
+	revisions := make([]FileRevision, 0)
+	for _, record := range strings.Split(out, recordSeparator) {
+		commit, files, ok, err := parseLogRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || len(files) == 0 {
+			continue
+		}
+		// With --follow each commit lists the one file it changed.
+		last := files[len(files)-1]
+		revision := FileRevision{Commit: commit, Path: last.path}
+		if !last.deleted {
+			revision.Data = []byte{}
+		}
+		revisions = append(revisions, revision)
+	}
+	// git log lists the newest commit first.
+	for i, j := 0, len(revisions)-1; i < j; i, j = i+1, j-1 {
+		revisions[i], revisions[j] = revisions[j], revisions[i]
+	}
+
+	specs := make([]string, 0, len(revisions))
+	for _, revision := range revisions {
+		if revision.Data != nil {
+			specs = append(specs, revision.Commit.Hash+":"+revision.Path)
+		}
+	}
+	contents, err := r.catFiles(ctx, specs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range revisions {
+		if revisions[i].Data != nil {
+			revisions[i].Data = contents[revisions[i].Commit.Hash+":"+revisions[i].Path]
+		}
+	}
+	return revisions, nil
+}
+
+// FileAt returns the content of the file at path as of rev. A file missing from that commit
+// fails with fs.ErrNotExist and an unknown revision with ErrUnknownRevision.
+func (r *Repo) FileAt(ctx context.Context, rev, path string) ([]byte, error) {
+	rel, ok := r.Relative(path)
+	if !ok {
+		return nil, fmt.Errorf("git: %s is outside the working tree %s", path, r.root)
+	}
+	commit, err := r.resolveCommit(ctx, rev)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := r.catFiles(ctx, []string{commit + ":" + rel})
+	if err != nil {
+		return nil, err
+	}
+	data, found := contents[commit+":"+rel]
+	if !found {
+		return nil, &fs.PathError{Op: "open", Path: rev + ":" + rel, Err: fs.ErrNotExist}
+	}
+	return data, nil
+}
+
+// CommitOf returns the commit rev names. It fails with ErrUnknownRevision for revisions git does
+// not know.
+func (r *Repo) CommitOf(ctx context.Context, rev string) (Commit, error) {
+	hash, err := r.resolveCommit(ctx, rev)
+	if err != nil {
+		return Commit{}, err
+	}
+	out, err := r.run(ctx, "show", "-s", historyFormat, hash, "--")
+	if err != nil {
+		return Commit{}, err
+	}
+	commit, _, ok, err := parseLogRecord(strings.TrimPrefix(strings.TrimSpace(out), recordSeparator))
+	if err != nil {
+		return Commit{}, err
+	}
+	if !ok {
+		return Commit{}, fmt.Errorf("git: unexpected output for commit %s", hash)
+	}
+	return commit, nil
+}
+
+// LastChange returns the committer date of the latest commit that changed the file at path,
+// following renames. A non-empty pickaxe keeps only commits whose diff adds or removes a line
+// matching that regular expression (git log -G). It reports false when no commit matches, such
+// as for untracked files or a repository without commits.
+func (r *Repo) LastChange(ctx context.Context, path, pickaxe string) (time.Time, bool, error) {
+	rel, ok := r.Relative(path)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("git: %s is outside the working tree %s", path, r.root)
+	}
+	args := []string{"log", "-1", "--follow", "--format=%cI"}
+	if pickaxe != "" {
+		args = append(args, "-G", pickaxe)
+	}
+	out, err := r.run(ctx, append(args, "--", rel)...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return time.Time{}, false, ctx.Err()
+		}
+		return time.Time{}, false, nil
+	}
+	value := strings.TrimSpace(out)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("git: %s: invalid date %q: %w", rel, value, err)
+	}
+	return date, true, nil
+}
+
+// ChangeSet is a commit and the files it changed below a path.
+type ChangeSet struct {
+	Commit Commit `json:"commit"`
+	// Paths are relative to the working tree root; renamed files are listed by their new path.
+	Paths []string `json:"paths"`
+}
+
+// Changes returns the latest commits that changed files below path, newest first. A positive
+// limit caps the number of commits.
+func (r *Repo) Changes(ctx context.Context, path string, limit int) ([]ChangeSet, error) {
+	rel, ok := r.Relative(path)
+	if !ok {
+		return nil, fmt.Errorf("git: %s is outside the working tree %s", path, r.root)
+	}
+	args := []string{"log", "--name-status", "-M", historyFormat}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	out, err := r.run(ctx, append(args, "--", rel)...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if head, headErr := r.Head(ctx); headErr == nil && head == "" {
+			return []ChangeSet{}, nil
+		}
+		return nil, fmt.Errorf("git: failed to read history of %s: %w", rel, err)
+	}
+	changes := make([]ChangeSet, 0)
+	for _, record := range strings.Split(out, recordSeparator) {
+		commit, files, ok, err := parseLogRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		change := ChangeSet{Commit: commit, Paths: make([]string, 0, len(files))}
+		for _, file := range files {
+			change.Paths = append(change.Paths, file.path)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// loggedFile is a file status line from `git log --name-status`.
+type loggedFile struct {
+	path    string
+	deleted bool
+}
+
+// parseLogRecord parses one historyFormat record and the status lines that follow it; it
+// reports false for the empty record before the first separator.
+func parseLogRecord(record string) (Commit, []loggedFile, bool, error) {
+	header, status, _ := strings.Cut(strings.TrimLeft(record, "\n"), "\n")
+	fields := strings.SplitN(header, fieldSeparator, 5)
+	if len(fields) < 5 {
+		return Commit{}, nil, false, nil
+	}
+	date, err := time.Parse(time.RFC3339, fields[3])
+	if err != nil {
+		return Commit{}, nil, false, fmt.Errorf("git: commit %s: invalid date %q: %w", fields[0], fields[3], err)
+	}
+	commit := Commit{Hash: fields[0], Author: fields[1], Email: fields[2], Date: date, Subject: fields[4]}
+	files := make([]loggedFile, 0)
+	for _, line := range strings.Split(strings.TrimSpace(status), "\n") {
+		// A<TAB>path, M<TAB>path, D<TAB>path or R086<TAB>old<TAB>new.
+		parts := strings.Split(line, "\t")
+		if len(parts) < 2 {
+			continue
+		}
+		files = append(files, loggedFile{path: parts[len(parts)-1], deleted: strings.HasPrefix(parts[0], "D")})
+	}
+	return commit, files, true, nil
+}
+
+// resolveCommit returns the commit hash rev names, rejecting anything git could read as an
+// option.
+func (r *Repo) resolveCommit(ctx context.Context, rev string) (string, error) {
+	rev = strings.TrimSpace(rev)
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", fmt.Errorf("git: %q: %w", rev, ErrUnknownRevision)
+	}
+	out, err := r.run(ctx, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("git: %q: %w", rev, ErrUnknownRevision)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// catFiles reads the objects named by specs (such as "<commit>:<path>") with a single
+// `git cat-file --batch`. Missing objects are left out of the result.
+func (r *Repo) catFiles(ctx context.Context, specs []string) (map[string][]byte, error) {
+	contents := make(map[string][]byte, len(specs))
+	if len(specs) == 0 {
+		return contents, nil
+	}
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	cmd.Dir = r.root
+	cmd.Stdin = strings.NewReader(strings.Join(specs, "\n") + "\n")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("git cat-file: %s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	reader := bufio.NewReader(&stdout)
+	for _, spec := range specs {
+		// <object> <type> <size>\n<content>\n, or <spec> missing\n.
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("git cat-file: unexpected end of output: %w", err)
+		}
+		if strings.HasSuffix(header, " missing\n") {
+			continue
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("git cat-file: unexpected header %q", strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("git cat-file: unexpected header %q", strings.TrimSpace(header))
+		}
+		data := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, fmt.Errorf("git cat-file: unexpected end of output: %w", err)
+		}
+		contents[spec] = data[:size]
+	}
+	return contents, nil
+}
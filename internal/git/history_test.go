@@ -0,0 +1,163 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileHistoryFollowsRenames(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "archive", "tasks"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "tasks"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	commitFile(t, dir, "tasks/T-000001.md", "status: todo")
+	commitFile(t, dir, "tasks/T-000001.md", "status: done")
+	commitFile(t, dir, "tasks/T-000002.md", "unrelated")
+	runGit(t, dir, "mv", "tasks/T-000001.md", "archive/tasks/T-000001.md")
+	runGit(t, dir, "commit", "-q", "-m", "archive")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Act
+	revisions, err := repo.FileHistory(context.Background(), filepath.Join(dir, "archive", "tasks", "T-000001.md"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %+v", revisions)
+	}
+	if revisions[0].Path != "tasks/T-000001.md" || string(revisions[0].Data) != "status: todo\n" {
+		t.Fatalf("expected the first revision oldest first, got %s %q", revisions[0].Path, revisions[0].Data)
+	}
+	if revisions[1].Commit.Subject != "status: done" || revisions[1].Commit.Author != "Test Author" {
+		t.Fatalf("expected commit metadata, got %+v", revisions[1].Commit)
+	}
+	if revisions[2].Path != "archive/tasks/T-000001.md" || string(revisions[2].Data) != "status: done\n" {
+		t.Fatalf("expected the rename to be followed, got %s %q", revisions[2].Path, revisions[2].Data)
+	}
+}
+
+func TestFileAtReadsRevisions(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	commitFile(t, dir, "page.md", "first")
+	commitFile(t, dir, "page.md", "second")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	path := filepath.Join(dir, "page.md")
+
+	// Act
+	previous, err := repo.FileAt(context.Background(), "HEAD~1", path)
+	_, missingErr := repo.FileAt(context.Background(), "HEAD", filepath.Join(dir, "other.md"))
+	_, unknownErr := repo.FileAt(context.Background(), "nope", path)
+
+	// Assert
+	if err != nil || string(previous) != "first\n" {
+		t.Fatalf("expected the previous revision, got %q (%v)", previous, err)
+	}
+	if !errors.Is(missingErr, fs.ErrNotExist) {
+		t.Fatalf("expected fs.ErrNotExist, got %v", missingErr)
+	}
+	if !errors.Is(unknownErr, ErrUnknownRevision) {
+		t.Fatalf("expected ErrUnknownRevision, got %v", unknownErr)
+	}
+}
+
+func TestChangesListsPathsBelowDirectory(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "wiki"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	commitFile(t, dir, "wiki/a.md", "add a")
+	commitFile(t, dir, "notes.txt", "unrelated")
+	commitFile(t, dir, "wiki/b.md", "add b")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Act
+	changes, err := repo.Changes(context.Background(), filepath.Join(dir, "wiki"), 0)
+	limited, limitErr := repo.Changes(context.Background(), filepath.Join(dir, "wiki"), 1)
+
+	// Assert
+	if err != nil || limitErr != nil {
+		t.Fatalf("changes: %v, %v", err, limitErr)
+	}
+	if len(changes) != 2 || changes[0].Commit.Subject != "add b" || len(changes[0].Paths) != 1 || changes[0].Paths[0] != "wiki/b.md" {
+		t.Fatalf("expected wiki changes newest first, got %+v", changes)
+	}
+	if len(limited) != 1 {
+		t.Fatalf("expected the limit to apply, got %+v", limited)
+	}
+}
+
+func TestCommitOfResolvesRevisions(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	commitFile(t, dir, "a.txt", "first")
+	runGit(t, dir, "tag", "v1")
+	commitFile(t, dir, "a.txt", "second")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Act
+	commit, err := repo.CommitOf(context.Background(), "v1")
+	_, unknownErr := repo.CommitOf(context.Background(), "v2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("commit of: %v", err)
+	}
+	if commit.Subject != "first" || commit.Author != "Test Author" || len(commit.Hash) != 40 || commit.Date.IsZero() {
+		t.Fatalf("unexpected commit: %+v", commit)
+	}
+	if !errors.Is(unknownErr, ErrUnknownRevision) {
+		t.Fatalf("expected ErrUnknownRevision, got %v", unknownErr)
+	}
+}
+
+func TestLastChangeFiltersByPickaxe(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	commitFile(t, dir, "T-000001.md", "status: done")
+	commitFile(t, dir, "T-000001.md", "status: done\ntitle: renamed")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	path := filepath.Join(dir, "T-000001.md")
+
+	// Act
+	last, lastOK, lastErr := repo.LastChange(context.Background(), path, "")
+	done, doneOK, doneErr := repo.LastChange(context.Background(), path, "^status: done$")
+	_, untrackedOK, untrackedErr := repo.LastChange(context.Background(), filepath.Join(dir, "T-000002.md"), "")
+
+	// Assert
+	if lastErr != nil || doneErr != nil || untrackedErr != nil {
+		t.Fatalf("last change: %v / %v / %v", lastErr, doneErr, untrackedErr)
+	}
+	if !lastOK || !doneOK || untrackedOK {
+		t.Fatalf("expected tracked matches only, got %v %v %v", lastOK, doneOK, untrackedOK)
+	}
+	if last.Before(done) {
+		t.Fatalf("expected the pickaxe to select the earlier commit, got %s and %s", done, last)
+	}
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HookMarker identifies hook scripts written by mochi-sticky; only those are replaced or
+// removed.
+const HookMarker = "# managed by mochi-sticky"
+
+// ErrHookConflict indicates a hook script that mochi-sticky did not write is in the way.
+var ErrHookConflict = errors.New("hook exists and is not managed by mochi-sticky")
+
+// Hook install results.
+const (
+	HookInstalled = "installed"
+	HookUpdated   = "updated"
+	HookUnchanged = "unchanged"
+	HookRemoved   = "removed"
+	HookAbsent    = "not installed"
+)
+
+// HooksDir returns the directory git runs hooks from, honoring core.hooksPath.
+func (r *Repo) HooksDir(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("git: failed to locate hooks directory: %w", err)
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.root, dir)
+	}
+	return dir, nil
+}
+
+// InstallHook writes the named hook script. Installing the same script again is a no-op; a
+// script mochi-sticky did not write is only replaced with force. It returns HookInstalled,
+// HookUpdated or HookUnchanged.
+func (r *Repo) InstallHook(ctx context.Context, name, script string, force bool) (string, error) {
+	dir, err := r.HooksDir(ctx)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	result := HookInstalled
+	existing, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return "", fmt.Errorf("git: failed to read hook %s: %w", path, err)
+	case string(existing) == script:
+		return HookUnchanged, nil
+	case !strings.Contains(string(existing), HookMarker) && !force:
+		return "", fmt.Errorf("git: %s: %w (use --force to replace it)", path, ErrHookConflict)
+	default:
+		result = HookUpdated
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("git: failed to create hooks directory %s: %w", dir, err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return "", fmt.Errorf("git: failed to write hook %s: %w", path, err)
+	}
+	// WriteFile keeps the mode of an existing file.
+	if err := os.Chmod(path, 0o755); err != nil {
+		return "", fmt.Errorf("git: failed to make hook %s executable: %w", path, err)
+	}
+	return result, nil
+}
+
+// UninstallHook removes the named hook when mochi-sticky wrote it, returning HookRemoved or
+// HookAbsent. Other scripts are left alone.
+func (r *Repo) UninstallHook(ctx context.Context, name string) (string, error) {
+	dir, err := r.HooksDir(ctx)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return HookAbsent, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("git: failed to read hook %s: %w", path, err)
+	}
+	if !strings.Contains(string(existing), HookMarker) {
+		return HookAbsent, nil
+	}
+	if err := os.Remove(path); err != nil {
+		return "", fmt.Errorf("git: failed to remove hook %s: %w", path, err)
+	}
+	return HookRemoved, nil
+}
+
+// ReadCommit returns the commit rev resolves to and its full message.
+func (r *Repo) ReadCommit(ctx context.Context, rev string) (Commit, string, error) {
+	out, err := r.run(ctx, "log", "-1", logFormat, rev, "--")
+	if err != nil {
+		return Commit{}, "", fmt.Errorf("git: failed to read commit %s: %w", rev, err)
+	}
+	commit, message, ok, err := parseCommitRecord(strings.TrimSuffix(strings.TrimSpace(out), recordSeparator))
+	if err != nil {
+		return Commit{}, "", err
+	}
+	if !ok {
+		return Commit{}, "", fmt.Errorf("git: failed to read commit %s: unexpected log output", rev)
+	}
+	return commit, message, nil
+}
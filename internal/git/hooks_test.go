@@ -0,0 +1,78 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstallHookIsIdempotent(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	script := "#!/bin/sh\n" + HookMarker + "\nexit 0\n"
+	ctx := context.Background()
+
+	// Act
+	first, err := repo.InstallHook(ctx, "post-commit", script, false)
+	if err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	second, err := repo.InstallHook(ctx, "post-commit", script, false)
+	if err != nil {
+		t.Fatalf("reinstall: %v", err)
+	}
+	removed, err := repo.UninstallHook(ctx, "post-commit")
+	if err != nil {
+		t.Fatalf("uninstall: %v", err)
+	}
+	absent, err := repo.UninstallHook(ctx, "post-commit")
+	if err != nil {
+		t.Fatalf("uninstall again: %v", err)
+	}
+
+	// Assert
+	if first != HookInstalled || second != HookUnchanged || removed != HookRemoved || absent != HookAbsent {
+		t.Fatalf("unexpected results: %s, %s, %s, %s", first, second, removed, absent)
+	}
+}
+
+func TestInstallHookKeepsForeignHooks(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	ctx := context.Background()
+	hooksDir, err := repo.HooksDir(ctx)
+	if err != nil {
+		t.Fatalf("hooks dir: %v", err)
+	}
+	path := filepath.Join(hooksDir, "commit-msg")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nlint-message \"$1\"\n"), 0o755); err != nil {
+		t.Fatalf("write hook: %v", err)
+	}
+	script := "#!/bin/sh\n" + HookMarker + "\n"
+
+	// Act
+	_, installErr := repo.InstallHook(ctx, "commit-msg", script, false)
+	uninstalled, uninstallErr := repo.UninstallHook(ctx, "commit-msg")
+	forced, forceErr := repo.InstallHook(ctx, "commit-msg", script, true)
+
+	// Assert
+	if !errors.Is(installErr, ErrHookConflict) {
+		t.Fatalf("expected ErrHookConflict, got %v", installErr)
+	}
+	if uninstallErr != nil || uninstalled != HookAbsent {
+		t.Fatalf("expected the foreign hook to be kept, got %s, %v", uninstalled, uninstallErr)
+	}
+	if forceErr != nil || forced != HookUpdated {
+		t.Fatalf("expected --force to replace the hook, got %s, %v", forced, forceErr)
+	}
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// KeywordAction is what a commit message keyword does to the tasks it names: move them to
+// Status (when set) and/or append a reference to the commit to their body.
+type KeywordAction struct {
+	Status string
+	Ref    bool
+}
+
+// Trigger is one task action requested by a commit message, e.g. "closes T-000012".
+type Trigger struct {
+	Keyword string
+	TaskID  string
+	KeywordAction
+}
+
+// DefaultKeywords returns the keywords used when the storage config defines none: closes,
+// fixes and resolves move the task to done and reference the commit; refs only references it.
+func DefaultKeywords() map[string]KeywordAction {
+	return map[string]KeywordAction{
+		"closes":   {Status: "done", Ref: true},
+		"fixes":    {Status: "done", Ref: true},
+		"resolves": {Status: "done", Ref: true},
+		"refs":     {Ref: true},
+	}
+}
+
+// ParseTriggers returns the actions a commit message requests. A keyword (matched without
+// case, optionally followed by a colon) applies to the task IDs right after it, separated
+// by commas, spaces or "and": "Closes T-000012, T-000013 and refs T-000020". IDs are
+// matched with pattern (see CompileTaskPattern). Lines starting with # are git comments and
+// are skipped. When several keywords name the same task, their actions are merged.
+func ParseTriggers(message string, keywords map[string]KeywordAction, pattern *regexp.Regexp) []Trigger {
+	if len(keywords) == 0 {
+		return []Trigger{}
+	}
+	names := make([]string, 0, len(keywords))
+	lookup := make(map[string]KeywordAction, len(keywords))
+	for name, action := range keywords {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		names = append(names, regexp.QuoteMeta(name))
+		lookup[name] = action
+	}
+	// Longest first so "close" does not shadow "closes".
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	keywordPattern := regexp.MustCompile(`(?i)\b(` + strings.Join(names, "|") + `)\b:?`)
+
+	triggers := make([]Trigger, 0)
+	index := map[string]int{}
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, match := range keywordPattern.FindAllStringSubmatchIndex(line, -1) {
+			keyword := strings.ToLower(line[match[2]:match[3]])
+			action := lookup[keyword]
+			for _, id := range LeadingTaskRefs(line[match[1]:], pattern) {
+				if i, ok := index[id]; ok {
+					if action.Status != "" {
+						triggers[i].Status = action.Status
+					}
+					triggers[i].Ref = triggers[i].Ref || action.Ref
+					continue
+				}
+				index[id] = len(triggers)
+				triggers = append(triggers, Trigger{Keyword: keyword, TaskID: id, KeywordAction: action})
+			}
+		}
+	}
+	return triggers
+}
+
+// LeadingTaskRefs returns the task IDs at the start of text, stopping at the first word that
+// is neither an ID nor a separator, so "T-000012, T-000013: retry" names both tasks and a
+// later "SHA-256" in the sentence is not read as a reference.
+func LeadingTaskRefs(text string, pattern *regexp.Regexp) []string {
+	ids := make([]string, 0)
+	for _, word := range strings.Fields(strings.ReplaceAll(text, ",", " ")) {
+		word = strings.Trim(word, ".;:()[]")
+		if word == "" || strings.EqualFold(word, "and") || word == "&" {
+			continue
+		}
+		refs := TaskRefs(pattern, word)
+		if len(refs) == 0 {
+			break
+		}
+		ids = append(ids, refs...)
+	}
+	return ids
+}
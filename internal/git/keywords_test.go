@@ -0,0 +1,74 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTriggers(t *testing.T) {
+	pattern, err := CompileTaskPattern("")
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	done := KeywordAction{Status: "done", Ref: true}
+	ref := KeywordAction{Ref: true}
+	cases := []struct {
+		name     string
+		message  string
+		keywords map[string]KeywordAction
+		want     []Trigger
+	}{
+		{
+			name:    "list after keyword",
+			message: "Fix parser\n\nCloses T-000012, T-000013 and refs T-000020.",
+			want: []Trigger{
+				{Keyword: "closes", TaskID: "T-000012", KeywordAction: done},
+				{Keyword: "closes", TaskID: "T-000013", KeywordAction: done},
+				{Keyword: "refs", TaskID: "T-000020", KeywordAction: ref},
+			},
+		},
+		{
+			name:    "ids stop at the first other word",
+			message: "fixes: T-000001 see T-000002",
+			want:    []Trigger{{Keyword: "fixes", TaskID: "T-000001", KeywordAction: done}},
+		},
+		{
+			name:    "merged actions and skipped comments",
+			message: "refs T-000003\n# closes T-000004\nresolves T-000003",
+			want:    []Trigger{{Keyword: "refs", TaskID: "T-000003", KeywordAction: done}},
+		},
+		{
+			name:    "hash and ulid ids",
+			message: "Closes T-465F0BD, refs T-01JB8Z6Q4X7T3M9K2V5W0N1R8C",
+			want: []Trigger{
+				{Keyword: "closes", TaskID: "T-465F0BD", KeywordAction: done},
+				{Keyword: "refs", TaskID: "T-01JB8Z6Q4X7T3M9K2V5W0N1R8C", KeywordAction: ref},
+			},
+		},
+		{name: "plain mention", message: "T-000005: tidy up", want: []Trigger{}},
+		{
+			name:     "custom keywords",
+			message:  "starts WIKI-3, closes T-000001",
+			keywords: map[string]KeywordAction{"Starts": {Status: "doing"}},
+			want:     []Trigger{{Keyword: "starts", TaskID: "WIKI-3", KeywordAction: KeywordAction{Status: "doing"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			keywords := tc.keywords
+			if keywords == nil {
+				keywords = DefaultKeywords()
+			}
+
+			// Act
+			got := ParseTriggers(tc.message, keywords, pattern)
+
+			// Assert
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
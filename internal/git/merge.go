@@ -0,0 +1,234 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MergeDriverName is the merge driver `hooks install --merge-driver` registers.
+const MergeDriverName = "mochi-sticky"
+
+// ErrMergeConflict indicates a merge left conflict markers in the result.
+var ErrMergeConflict = errors.New("merge conflict")
+
+// gitattributesBegin and gitattributesEnd delimit the managed block of .gitattributes.
+const (
+	gitattributesBegin = HookMarker + " (begin)"
+	gitattributesEnd   = HookMarker + " (end)"
+)
+
+// MergeLabels names the three versions in conflict markers.
+type MergeLabels struct {
+	Ours   string
+	Base   string
+	Theirs string
+}
+
+// MergeFile runs a line-based three-way merge of ours and theirs against base with
+// git merge-file. The result contains standard conflict markers where both sides changed the
+// same lines; conflicts is their number.
+func MergeFile(ctx context.Context, base, ours, theirs []byte, labels MergeLabels) ([]byte, int, error) {
+	dir, err := os.MkdirTemp("", "mochi-sticky-merge-")
+	if err != nil {
+		return nil, 0, fmt.Errorf("git: failed to create merge dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	paths := make([]string, 0, 3)
+	for _, version := range []struct {
+		name string
+		data []byte
+	}{{"ours", ours}, {"base", base}, {"theirs", theirs}} {
+		path := filepath.Join(dir, version.name)
+		if err := os.WriteFile(path, version.data, 0o644); err != nil {
+			return nil, 0, fmt.Errorf("git: failed to write merge input: %w", err)
+		}
+		paths = append(paths, path)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "merge-file", "-p",
+		"-L", labels.Ours, "-L", labels.Base, "-L", labels.Theirs,
+		paths[0], paths[1], paths[2])
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return stdout.Bytes(), 0, nil
+	case ctx.Err() != nil:
+		return nil, 0, ctx.Err()
+	case errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128:
+		// A positive exit status is the number of conflicts.
+		return stdout.Bytes(), exitErr.ExitCode(), nil
+	}
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return nil, 0, fmt.Errorf("git merge-file: %s: %w", message, err)
+	}
+	return nil, 0, fmt.Errorf("git merge-file: %w", err)
+}
+
+// InstallMergeDriver registers command as the merge driver name in the repository's
+// .git/config and assigns it to patterns (paths relative to the repository root) in a managed
+// block of .gitattributes. It returns HookInstalled, HookUpdated or HookUnchanged.
+func (r *Repo) InstallMergeDriver(ctx context.Context, name, description, command string, patterns []string) (string, error) {
+	current, _ := r.run(ctx, "config", "--local", "--get", "merge."+name+".driver")
+	current = strings.TrimSpace(current)
+	result := HookInstalled
+	if current != "" {
+		result = HookUnchanged
+		if current != command {
+			result = HookUpdated
+		}
+	}
+	if current != command {
+		if _, err := r.run(ctx, "config", "--local", "merge."+name+".name", description); err != nil {
+			return "", fmt.Errorf("git: failed to register merge driver: %w", err)
+		}
+		if _, err := r.run(ctx, "config", "--local", "merge."+name+".driver", command); err != nil {
+			return "", fmt.Errorf("git: failed to register merge driver: %w", err)
+		}
+	}
+
+	lines := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		lines = append(lines, pattern+" merge="+name)
+	}
+	changed, err := r.writeAttributesBlock(lines)
+	if err != nil {
+		return "", err
+	}
+	if changed && result == HookUnchanged {
+		result = HookUpdated
+	}
+	return result, nil
+}
+
+// UninstallMergeDriver removes the merge driver name from .git/config and the managed block
+// from .gitattributes, returning HookRemoved or HookAbsent.
+func (r *Repo) UninstallMergeDriver(ctx context.Context, name string) (string, error) {
+	result := HookAbsent
+	if _, err := r.run(ctx, "config", "--local", "--get", "merge."+name+".driver"); err == nil {
+		if _, err := r.run(ctx, "config", "--local", "--remove-section", "merge."+name); err != nil {
+			return "", fmt.Errorf("git: failed to remove merge driver: %w", err)
+		}
+		result = HookRemoved
+	}
+	changed, err := r.writeAttributesBlock(nil)
+	if err != nil {
+		return "", err
+	}
+	if changed {
+		result = HookRemoved
+	}
+	return result, nil
+}
+
+// writeAttributesBlock replaces the managed block of .gitattributes with lines, removing it
+// when lines is empty. It reports whether the file changed.
+func (r *Repo) writeAttributesBlock(lines []string) (bool, error) {
+	path := filepath.Join(r.root, ".gitattributes")
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("git: failed to read %s: %w", path, err)
+	}
+	existing := string(data)
+
+	kept := make([]string, 0)
+	inBlock := false
+	for _, line := range strings.Split(strings.TrimRight(existing, "\n"), "\n") {
+		switch {
+		case line == gitattributesBegin:
+			inBlock = true
+		case line == gitattributesEnd:
+			inBlock = false
+		case !inBlock && (line != "" || len(kept) > 0):
+			kept = append(kept, line)
+		}
+	}
+	for len(kept) > 0 && kept[len(kept)-1] == "" {
+		kept = kept[:len(kept)-1]
+	}
+	if len(lines) > 0 {
+		if len(kept) > 0 {
+			kept = append(kept, "")
+		}
+		kept = append(kept, gitattributesBegin)
+		kept = append(kept, lines...)
+		kept = append(kept, gitattributesEnd)
+	}
+
+	updated := ""
+	if len(kept) > 0 {
+		updated = strings.Join(kept, "\n") + "\n"
+	}
+	if updated == existing {
+		return false, nil
+	}
+	if updated == "" {
+		if err := os.Remove(path); err != nil {
+			return false, fmt.Errorf("git: failed to remove %s: %w", path, err)
+		}
+		return true, nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return false, fmt.Errorf("git: failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// MergedBranch is the branch brought in by a merge.
+type MergedBranch struct {
+	// Tip is the commit hash at the tip of the branch.
+	Tip string
+	// Added maps the slash-separated working tree paths of the files the branch added since it
+	// forked to their content at Tip.
+	Added map[string][]byte
+}
+
+// MergedBranch returns the branch being merged: MERGE_HEAD while a merge is in progress, else
+// the second parent when HEAD is a merge commit. It reports false when there is neither.
+func (r *Repo) MergedBranch(ctx context.Context) (MergedBranch, bool, error) {
+	base, tip := "HEAD", "MERGE_HEAD"
+	hash, err := r.resolveCommit(ctx, tip)
+	if errors.Is(err, ErrUnknownRevision) {
+		base, tip = "HEAD^1", "HEAD^2"
+		hash, err = r.resolveCommit(ctx, tip)
+	}
+	if errors.Is(err, ErrUnknownRevision) {
+		return MergedBranch{}, false, nil
+	}
+	if err != nil {
+		return MergedBranch{}, false, err
+	}
+	out, err := r.run(ctx, "diff", "--name-only", "-z", "--no-renames", "--diff-filter=A", base+"..."+hash, "--")
+	if err != nil {
+		if ctx.Err() != nil {
+			return MergedBranch{}, false, ctx.Err()
+		}
+		return MergedBranch{}, false, err
+	}
+	names := make([]string, 0)
+	specs := make([]string, 0)
+	for _, name := range strings.Split(out, "\x00") {
+		if name != "" {
+			names = append(names, name)
+			specs = append(specs, hash+":"+name)
+		}
+	}
+	contents, err := r.catFiles(ctx, specs)
+	if err != nil {
+		return MergedBranch{}, false, err
+	}
+	branch := MergedBranch{Tip: hash, Added: make(map[string][]byte, len(names))}
+	for _, name := range names {
+		branch.Added[name] = contents[hash+":"+name]
+	}
+	return branch, true, nil
+}
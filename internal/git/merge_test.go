@@ -0,0 +1,118 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeFileMarksConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts int
+	}{
+		{name: "separate lines", ours: "a1\nb\nc\n", theirs: "a\nb\nc1\n", want: "a1\nb\nc1\n"},
+		{name: "same line", ours: "a1\nb\nc\n", theirs: "a2\nb\nc\n", want: "<<<<<<< ours\na1\n=======\na2\n>>>>>>> theirs\n", conflicts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, conflicts, err := MergeFile(context.Background(), []byte("a\nb\nc\n"), []byte(tt.ours), []byte(tt.theirs), MergeLabels{Ours: "ours", Base: "base", Theirs: "theirs"})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("merge: %v", err)
+			}
+			if conflicts != tt.conflicts || !strings.Contains(string(result), tt.want) {
+				t.Fatalf("expected %d conflicts and %q, got %d:\n%s", tt.conflicts, tt.want, conflicts, result)
+			}
+		})
+	}
+}
+
+func TestInstallMergeDriverIsIdempotent(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.png binary\n"), 0o644); err != nil {
+		t.Fatalf("write attributes: %v", err)
+	}
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	ctx := context.Background()
+	patterns := []string{".sticky/boards/*/tasks/*.md"}
+
+	// Act
+	first, err := repo.InstallMergeDriver(ctx, MergeDriverName, "test driver", "driver %O %A %B", patterns)
+	if err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	second, err := repo.InstallMergeDriver(ctx, MergeDriverName, "test driver", "driver %O %A %B", patterns)
+	if err != nil {
+		t.Fatalf("reinstall: %v", err)
+	}
+	installed, err := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+	if err != nil {
+		t.Fatalf("read attributes: %v", err)
+	}
+	removed, err := repo.UninstallMergeDriver(ctx, MergeDriverName)
+	if err != nil {
+		t.Fatalf("uninstall: %v", err)
+	}
+	restored, err := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+	if err != nil {
+		t.Fatalf("read attributes: %v", err)
+	}
+
+	// Assert
+	if first != HookInstalled || second != HookUnchanged || removed != HookRemoved {
+		t.Fatalf("unexpected results: %s, %s, %s", first, second, removed)
+	}
+	if !strings.Contains(string(installed), "*.png binary\n") || !strings.Contains(string(installed), ".sticky/boards/*/tasks/*.md merge=mochi-sticky\n") {
+		t.Fatalf("unexpected .gitattributes:\n%s", installed)
+	}
+	if string(restored) != "*.png binary\n" {
+		t.Fatalf("expected uninstall to restore .gitattributes, got:\n%s", restored)
+	}
+}
+
+func TestMergedBranchListsAddedFiles(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	commitFile(t, dir, "base.md", "base")
+	runGit(t, dir, "checkout", "-q", "-b", "theirs")
+	commitFile(t, dir, "theirs.md", "theirs")
+	runGit(t, dir, "checkout", "-q", "-")
+	commitFile(t, dir, "ours.md", "ours")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Act
+	_, before, beforeErr := repo.MergedBranch(context.Background())
+	runGit(t, dir, "merge", "-q", "--no-ff", "--no-commit", "theirs")
+	merging, during, duringErr := repo.MergedBranch(context.Background())
+	runGit(t, dir, "commit", "-q", "-m", "merge")
+	merged, after, afterErr := repo.MergedBranch(context.Background())
+
+	// Assert
+	if beforeErr != nil || before {
+		t.Fatalf("expected no merged branch before the merge, got %v %v", before, beforeErr)
+	}
+	for _, got := range []struct {
+		branch MergedBranch
+		ok     bool
+		err    error
+	}{{merging, during, duringErr}, {merged, after, afterErr}} {
+		if got.err != nil || !got.ok || len(got.branch.Added) != 1 || string(got.branch.Added["theirs.md"]) != "theirs\n" {
+			t.Fatalf("expected theirs.md added on the merged branch, got %+v %v %v", got.branch, got.ok, got.err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mochi-sticky/internal/shared"
+)
+
+// ErrUnknownRevision indicates a revision that does not name a commit.
+var ErrUnknownRevision = errors.New("unknown revision")
+
+// Snapshot is the working tree as of a commit, read from git objects instead of the files on
+// disk. It implements shared.FS for the paths inside the working tree; paths outside it are
+// read from disk. Every write fails with shared.ErrReadOnly.
+type Snapshot struct {
+	repo     *Repo
+	commit   string
+	modTime  time.Time
+	entries  map[string]treeEntry
+	children map[string][]string
+
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+// treeEntry is a file or directory in the commit's tree.
+type treeEntry struct {
+	object string
+	size   int64
+	dir    bool
+}
+
+// Snapshot lists the tree of rev, which may be any revision git understands (a branch, tag,
+// hash or an expression such as HEAD~3). File contents are read on first use.
+func (r *Repo) Snapshot(ctx context.Context, rev string) (*Snapshot, error) {
+	rev = strings.TrimSpace(rev)
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("git: %q: %w", rev, ErrUnknownRevision)
+	}
+	out, err := r.run(ctx, "show", "-s", "--format=%H %ct", rev+"^{commit}", "--")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("git: %q: %w", rev, ErrUnknownRevision)
+	}
+	commit, stamp, _ := strings.Cut(strings.TrimSpace(out), " ")
+	seconds, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("git: unexpected commit time %q", stamp)
+	}
+	tree, err := r.run(ctx, "ls-tree", "-r", "-t", "-l", "-z", "--full-tree", commit)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{
+		repo:     r,
+		commit:   commit,
+		modTime:  time.Unix(seconds, 0),
+		entries:  map[string]treeEntry{".": {dir: true}},
+		children: map[string][]string{},
+		blobs:    map[string][]byte{},
+	}
+	for _, record := range strings.Split(tree, "\x00") {
+		// <mode> <type> <object> <size>\t<path>
+		meta, name, ok := strings.Cut(record, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 4 || (fields[1] != "blob" && fields[1] != "tree") {
+			continue
+		}
+		entry := treeEntry{object: fields[2], dir: fields[1] == "tree"}
+		if !entry.dir {
+			entry.size, _ = strconv.ParseInt(fields[3], 10, 64)
+		}
+		snapshot.entries[name] = entry
+		parent := path.Dir(name)
+		snapshot.children[parent] = append(snapshot.children[parent], path.Base(name))
+	}
+	for _, names := range snapshot.children {
+		sort.Strings(names)
+	}
+	return snapshot, nil
+}
+
+// Commit returns the hash of the snapshot's commit.
+func (s *Snapshot) Commit() string {
+	return s.commit
+}
+
+// ReadFile returns the content of the file at name as of the commit.
+func (s *Snapshot) ReadFile(name string) ([]byte, error) {
+	rel, ok := s.rel(name)
+	if !ok {
+		return os.ReadFile(name)
+	}
+	entry, found := s.entries[rel]
+	if !found {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if entry.dir {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if data, cached := s.blobs[entry.object]; cached {
+		return data, nil
+	}
+	out, err := s.repo.run(context.Background(), "cat-file", "blob", entry.object)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	data := []byte(out)
+	s.blobs[entry.object] = data
+	return data, nil
+}
+
+// ReadDir lists the directory at name as of the commit, sorted by file name.
+func (s *Snapshot) ReadDir(name string) ([]fs.DirEntry, error) {
+	rel, ok := s.rel(name)
+	if !ok {
+		return os.ReadDir(name)
+	}
+	entry, found := s.entries[rel]
+	if !found {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if !entry.dir {
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: errors.New("not a directory")}
+	}
+	names := s.children[rel]
+	entries := make([]fs.DirEntry, 0, len(names))
+	for _, child := range names {
+		entries = append(entries, fs.FileInfoToDirEntry(s.info(path.Join(rel, child))))
+	}
+	return entries, nil
+}
+
+// Stat describes the file or directory at name as of the commit. Every entry has the commit
+// time as its modification time.
+func (s *Snapshot) Stat(name string) (fs.FileInfo, error) {
+	rel, ok := s.rel(name)
+	if !ok {
+		return os.Stat(name)
+	}
+	if _, found := s.entries[rel]; !found {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return s.info(rel), nil
+}
+
+func (s *Snapshot) WriteFile(name string, _ []byte, _ fs.FileMode) error {
+	return readOnly("write", name)
+}
+
+func (s *Snapshot) WriteFileAtomic(name string, _ []byte, _ fs.FileMode) error {
+	return readOnly("write", name)
+}
+
+func (s *Snapshot) MkdirAll(name string, _ fs.FileMode) error {
+	return readOnly("mkdir", name)
+}
+
+func (s *Snapshot) Remove(name string) error {
+	return readOnly("remove", name)
+}
+
+func (s *Snapshot) RemoveAll(name string) error {
+	return readOnly("remove", name)
+}
+
+func (s *Snapshot) Rename(oldName, _ string) error {
+	return readOnly("rename", oldName)
+}
+
+func readOnly(op, name string) error {
+	return &fs.PathError{Op: op, Path: name, Err: shared.ErrReadOnly}
+}
+
+// rel maps an OS path to its path in the tree; it reports false for paths outside the
+// working tree.
+func (s *Snapshot) rel(name string) (string, bool) {
+	return s.repo.Relative(name)
+}
+
+func (s *Snapshot) info(rel string) fs.FileInfo {
+	entry := s.entries[rel]
+	return snapshotInfo{name: path.Base(rel), size: entry.size, dir: entry.dir, modTime: s.modTime}
+}
+
+// snapshotInfo implements fs.FileInfo for a tree entry.
+type snapshotInfo struct {
+	name    string
+	size    int64
+	dir     bool
+	modTime time.Time
+}
+
+func (i snapshotInfo) Name() string       { return i.name }
+func (i snapshotInfo) Size() int64        { return i.size }
+func (i snapshotInfo) ModTime() time.Time { return i.modTime }
+func (i snapshotInfo) IsDir() bool        { return i.dir }
+func (i snapshotInfo) Sys() any           { return nil }
+
+func (i snapshotInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mochi-sticky/internal/shared"
+)
+
+func TestSnapshotReadsFilesAtRevision(t *testing.T) {
+	// Arrange
+	dir := initTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "tasks"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	commitFile(t, dir, "tasks/T-000001.md", "first")
+	runGit(t, dir, "tag", "v1")
+	commitFile(t, dir, "tasks/T-000001.md", "changed")
+	commitFile(t, dir, "tasks/T-000002.md", "second")
+	repo, err := Open(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Act
+	snapshot, err := repo.Snapshot(context.Background(), "v1")
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	data, readErr := snapshot.ReadFile(filepath.Join(dir, "tasks", "T-000001.md"))
+	entries, dirErr := snapshot.ReadDir(filepath.Join(dir, "tasks"))
+	_, missingErr := snapshot.Stat(filepath.Join(dir, "tasks", "T-000002.md"))
+	writeErr := snapshot.WriteFile(filepath.Join(dir, "tasks", "T-000003.md"), []byte("new"), 0o644)
+	_, unknownErr := repo.Snapshot(context.Background(), "no-such-tag")
+
+	// Assert
+	if readErr != nil || string(data) != "first\n" {
+		t.Fatalf("expected the file as of v1, got %q (%v)", data, readErr)
+	}
+	if dirErr != nil || len(entries) != 1 || entries[0].Name() != "T-000001.md" || entries[0].IsDir() {
+		t.Fatalf("expected only T-000001.md at v1, got %v (%v)", entries, dirErr)
+	}
+	if !os.IsNotExist(missingErr) {
+		t.Fatalf("expected a file added later to be missing, got %v", missingErr)
+	}
+	if !errors.Is(writeErr, shared.ErrReadOnly) {
+		t.Fatalf("expected writes to fail with ErrReadOnly, got %v", writeErr)
+	}
+	if !errors.Is(unknownErr, ErrUnknownRevision) {
+		t.Fatalf("expected ErrUnknownRevision, got %v", unknownErr)
+	}
+}
@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mochi-sticky/internal/board"
+)
+
+type bulkUpdateParams struct {
+	BoardID      string   `json:"board_id"`
+	Filter       string   `json:"filter"`
+	SetStatus    string   `json:"set_status"`
+	AddTags      []string `json:"add_tags"`
+	RemoveTags   []string `json:"remove_tags"`
+	SetPriority  int      `json:"set_priority"`
+	Archive      bool     `json:"archive"`
+	DryRun       *bool    `json:"dry_run"`
+	PreviewToken string   `json:"preview_token"`
+}
+
+type bulkUpdateResult struct {
+	BoardID      string             `json:"board_id"`
+	DryRun       bool               `json:"dry_run"`
+	Matched      int                `json:"matched"`
+	Tasks        []board.BulkResult `json:"tasks"`
+	PreviewToken string             `json:"preview_token,omitempty"`
+}
+
+// bulkUpdateTasks always previews first: a dry run returns a preview token, and applying the
+// change requires that token to match a fresh preview of the same operation.
+func (s *Server) bulkUpdateTasks(ctx context.Context, params bulkUpdateParams) (any, *rpcError) {
+	query, err := board.ParseBulkFilter(params.Filter)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	change := board.BulkChange{
+		Status:     params.SetStatus,
+		AddTags:    params.AddTags,
+		RemoveTags: params.RemoveTags,
+		Priority:   params.SetPriority,
+		Archive:    params.Archive,
+	}
+	preview, err := repo.BulkUpdateContext(ctx, query, change, true)
+	if err != nil {
+		return nil, bulkError(err)
+	}
+	token, err := bulkPreviewToken(boardID, params, preview)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	dryRun := params.DryRun == nil || *params.DryRun
+	if dryRun {
+		return bulkUpdateResult{BoardID: boardID, DryRun: true, Matched: len(preview), Tasks: preview, PreviewToken: token}, nil
+	}
+	if strings.TrimSpace(params.PreviewToken) == "" {
+		return nil, &rpcError{Code: codeDenied, Message: "bulk_update_tasks requires a dry_run preview first; pass its preview_token to apply"}
+	}
+	if params.PreviewToken != token {
+		return nil, &rpcError{Code: codeDenied, Message: "bulk_update_tasks preview is stale; run dry_run again"}
+	}
+	applied, err := repo.BulkUpdateContext(ctx, query, change, false)
+	if err != nil {
+		return nil, bulkError(err)
+	}
+	return bulkUpdateResult{BoardID: boardID, Matched: len(applied), Tasks: applied}, nil
+}
+
+func bulkPreviewToken(boardID string, params bulkUpdateParams, preview []board.BulkResult) (string, error) {
+	params.DryRun = nil
+	params.PreviewToken = ""
+	params.BoardID = boardID
+	data, err := json.Marshal(struct {
+		Params  bulkUpdateParams   `json:"params"`
+		Preview []board.BulkResult `json:"preview"`
+	}{params, preview})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func bulkError(err error) *rpcError {
+	if errors.Is(err, board.ErrInvalidBulk) || errors.Is(err, board.ErrInvalidFilter) || errors.Is(err, board.ErrInvalidQuery) || errors.Is(err, board.ErrInvalidPriority) {
+		return invalidParams(err)
+	}
+	return internalError(fmt.Errorf("bulk update: %w", err))
+}
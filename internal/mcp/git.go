@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/output"
+	"mochi-sticky/internal/storage"
+	"mochi-sticky/internal/wiki"
+)
+
+func (s *Server) getTaskCommits(ctx context.Context, params getTaskParams) (any, *rpcError) {
+	if strings.TrimSpace(params.ID) == "" {
+		return nil, invalidParams(fmt.Errorf("id is required"))
+	}
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	task, err := repo.GetTaskByID(params.ID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	cfg, err := storage.LoadConfigFromRoot(s.storageRoot)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	commits := []git.Commit{}
+	gitRepo, err := git.Open(ctx, s.storageRoot)
+	switch {
+	case errors.Is(err, git.ErrNotRepository):
+	case err != nil:
+		return nil, internalError(err)
+	default:
+		commits, err = gitRepo.CommitsForTask(ctx, git.CommitCachePath(s.storageRoot), cfg.Git.TaskPattern, task.ID)
+		if err != nil {
+			if errors.Is(err, git.ErrInvalidPattern) {
+				return nil, invalidParams(err)
+			}
+			return nil, internalError(err)
+		}
+	}
+	return map[string]any{"id": task.ID, "board_id": boardID, "commits": output.FromCommits(commits)}, nil
+}
+
+type wikiHistoryParams struct {
+	Slug              string `json:"slug"`
+	Limit             int    `json:"limit"`
+	Patch             bool   `json:"patch"`
+	IgnoreFrontmatter bool   `json:"ignore_frontmatter"`
+}
+
+type wikiDiffParams struct {
+	Slug              string `json:"slug"`
+	From              string `json:"from"`
+	To                string `json:"to"`
+	IgnoreFrontmatter bool   `json:"ignore_frontmatter"`
+}
+
+type wikiRevisionRecord struct {
+	wiki.Revision
+	Diff string `json:"diff,omitempty"`
+}
+
+func (s *Server) wikiHistory(ctx context.Context, params wikiHistoryParams) (any, *rpcError) {
+	if params.Limit < 0 {
+		return nil, invalidParams(fmt.Errorf("limit must be zero or greater"))
+	}
+	if strings.TrimSpace(params.Slug) == "" {
+		changes, err := wiki.RecentChanges(ctx, s.wikiRoot(), params.Limit)
+		if errors.Is(err, git.ErrNotRepository) {
+			changes = []wiki.Change{}
+		} else if err != nil {
+			return nil, wikiGitError(err)
+		}
+		return map[string]any{"changes": changes}, nil
+	}
+	slug, err := wiki.NormalizeSlug(params.Slug)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	revisions, err := wiki.History(ctx, s.wikiRoot(), slug)
+	if errors.Is(err, git.ErrNotRepository) {
+		revisions = nil
+	} else if err != nil {
+		return nil, wikiGitError(err)
+	}
+	shown := len(revisions)
+	if params.Limit > 0 && shown > params.Limit {
+		shown = params.Limit
+	}
+	records := make([]wikiRevisionRecord, 0, shown)
+	for i, revision := range revisions[:shown] {
+		record := wikiRevisionRecord{Revision: revision}
+		if params.Patch {
+			record.Diff = wiki.RevisionDiff(slug, revisions, i, params.IgnoreFrontmatter)
+		}
+		records = append(records, record)
+	}
+	return map[string]any{"slug": slug, "revisions": records}, nil
+}
+
+func (s *Server) wikiDiff(ctx context.Context, params wikiDiffParams) (any, *rpcError) {
+	slug, err := wiki.NormalizeSlug(params.Slug)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	diff, err := wiki.Diff(ctx, s.wikiRoot(), slug, wiki.DiffOptions{
+		From:              params.From,
+		To:                params.To,
+		IgnoreFrontmatter: params.IgnoreFrontmatter,
+	})
+	if err != nil {
+		return nil, wikiGitError(err)
+	}
+	return map[string]any{"slug": slug, "diff": diff, "changed": diff != ""}, nil
+}
+
+// wikiGitError reports unknown revisions and pages as invalid params.
+func wikiGitError(err error) *rpcError {
+	if errors.Is(err, git.ErrUnknownRevision) || errors.Is(err, wiki.ErrPageNotFound) {
+		return invalidParams(err)
+	}
+	return internalError(err)
+}
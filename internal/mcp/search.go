@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"mochi-sticky/internal/search"
+)
+
+type searchParams struct {
+	Query   string   `json:"query"`
+	Types   []string `json:"types"`
+	Status  string   `json:"status"`
+	BoardID string   `json:"board_id"`
+	Limit   int      `json:"limit"`
+}
+
+func (s *Server) search(ctx context.Context, params searchParams) (any, *rpcError) {
+	types, err := search.ParseTypes(params.Types)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	if params.Limit < 0 {
+		return nil, invalidParams(errors.New("limit must be zero or greater"))
+	}
+	sources := search.Sources{
+		WikiRoot: s.wikiRoot(),
+		ADRRoot:  filepath.Join(s.storageRoot, "adrs"),
+	}
+	if len(types) == 0 || containsType(types, search.TypeTask) {
+		_, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		sources.Board = repo
+	}
+	hits, err := search.SearchContext(ctx, sources, search.Options{
+		Query:  params.Query,
+		Types:  types,
+		Status: params.Status,
+		Limit:  params.Limit,
+	})
+	if err != nil {
+		if errors.Is(err, search.ErrEmptyQuery) {
+			return nil, invalidParams(err)
+		}
+		return nil, internalError(err)
+	}
+	for i := range hits {
+		if rel, err := filepath.Rel(s.baseDir, hits[i].Path); err == nil && !strings.HasPrefix(rel, "..") {
+			hits[i].Path = filepath.ToSlash(rel)
+		}
+	}
+	return hits, nil
+}
+
+func containsType(types []string, kind string) bool {
+	for _, candidate := range types {
+		if candidate == kind {
+			return true
+		}
+	}
+	return false
+}
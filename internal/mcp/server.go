@@ -180,15 +180,19 @@ type rpcError struct {
 }
 
 type listTasksParams struct {
-	BoardID string   `json:"board_id"`
-	Status  string   `json:"status"`
-	Title   string   `json:"title"`
-	Tags    []string `json:"tags"`
-	TagMode string   `json:"tag_mode"`
-	From    string   `json:"from"`
-	To      string   `json:"to"`
-	Sort    string   `json:"sort"`
-	Desc    bool     `json:"desc"`
+	BoardID   string   `json:"board_id"`
+	Status    string   `json:"status"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags"`
+	TagMode   string   `json:"tag_mode"`
+	Sprint    string   `json:"sprint"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Sort      string   `json:"sort"`
+	Desc      bool     `json:"desc"`
+	Query     string   `json:"query"`
+	AllBoards bool     `json:"all_boards"`
+	Boards    []string `json:"boards"`
 }
 
 type getTaskParams struct {
@@ -202,6 +206,8 @@ type createTaskParams struct {
 	Status   string   `json:"status"`
 	Tags     []string `json:"tags"`
 	Priority int      `json:"priority"`
+	Estimate float64  `json:"estimate"`
+	Sprint   string   `json:"sprint"`
 }
 
 type listWikiParams struct {
@@ -266,6 +272,7 @@ type searchWikiParams struct {
 	Status           string `json:"status"`
 	IncludeTemplates bool   `json:"include_templates"`
 	CaseInsensitive  *bool  `json:"case_insensitive"`
+	Limit            int    `json:"limit"`
 }
 
 type listWikiTemplatesParams struct{}
@@ -327,6 +334,12 @@ type updatePriorityParams struct {
 	Priority int    `json:"priority"`
 }
 
+type updateEstimateParams struct {
+	BoardID  string  `json:"board_id"`
+	ID       string  `json:"id"`
+	Estimate float64 `json:"estimate"`
+}
+
 type updateTitleParams struct {
 	BoardID string `json:"board_id"`
 	ID      string `json:"id"`
@@ -393,8 +406,12 @@ type taskSummary struct {
 	Title     string   `json:"title"`
 	Status    string   `json:"status"`
 	Priority  int      `json:"priority"`
+	Estimate  float64  `json:"estimate,omitempty"`
+	Sprint    string   `json:"sprint,omitempty"`
+	Rank      string   `json:"rank,omitempty"`
 	Tags      []string `json:"tags,omitempty"`
 	Created   string   `json:"created,omitempty"`
+	Completed string   `json:"completed,omitempty"`
 	DependsOn []string `json:"depends_on,omitempty"`
 }
 
@@ -520,6 +537,66 @@ func (s *Server) dispatch(ctx context.Context, req rpcRequest) (any, *rpcError)
 			return nil, invalidParams(err)
 		}
 		return s.updateTaskPriority(ctx, params)
+	case "update_task_estimate":
+		var params updateEstimateParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.updateTaskEstimate(ctx, params)
+	case "list_views":
+		var params listViewsParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.listViews(ctx, params)
+	case "apply_view":
+		var params applyViewParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.applyView(ctx, params)
+	case "list_sprints":
+		var params listSprintsParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.listSprints(ctx, params)
+	case "get_sprint":
+		var params sprintParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.getSprint(ctx, params)
+	case "create_sprint":
+		var params createSprintParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.createSprint(ctx, params)
+	case "start_sprint":
+		var params sprintParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.startSprint(ctx, params)
+	case "close_sprint":
+		var params closeSprintParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.closeSprint(ctx, params)
+	case "assign_task_sprint":
+		var params assignSprintParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.assignTaskSprint(ctx, params)
+	case "bulk_update_tasks":
+		var params bulkUpdateParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.bulkUpdateTasks(ctx, params)
 	case "update_task_title":
 		var params updateTitleParams
 		if err := decodeParams(req.Params, &params); err != nil {
@@ -550,6 +627,12 @@ func (s *Server) dispatch(ctx context.Context, req rpcRequest) (any, *rpcError)
 			return nil, invalidParams(err)
 		}
 		return s.getTaskDependencies(params)
+	case "get_task_commits":
+		var params getTaskParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.getTaskCommits(ctx, params)
 	case "list_ready_tasks":
 		var params listTasksParams
 		if err := decodeParams(req.Params, &params); err != nil {
@@ -660,6 +743,18 @@ func (s *Server) dispatch(ctx context.Context, req rpcRequest) (any, *rpcError)
 			return nil, invalidParams(err)
 		}
 		return s.readWikiPage(params)
+	case "wiki_history":
+		var params wikiHistoryParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.wikiHistory(ctx, params)
+	case "wiki_diff":
+		var params wikiDiffParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.wikiDiff(ctx, params)
 	case "write_wiki_page":
 		var params writeWikiParams
 		if err := decodeParams(req.Params, &params); err != nil {
@@ -672,6 +767,12 @@ func (s *Server) dispatch(ctx context.Context, req rpcRequest) (any, *rpcError)
 			return nil, invalidParams(err)
 		}
 		return s.updateWikiSection(ctx, params)
+	case "search":
+		var params searchParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.search(ctx, params)
 	case "search_wiki":
 		var params searchWikiParams
 		if err := decodeParams(req.Params, &params); err != nil {
@@ -766,9 +867,13 @@ func toolList() []toolDescriptor {
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
-					"board_id": map[string]any{"type": "string", "description": "Board ID (optional)"},
-					"status":   map[string]any{"type": "string", "description": "Filter by status"},
-					"sort":     map[string]any{"type": "string", "description": "Sort field"},
+					"board_id":   map[string]any{"type": "string", "description": "Board ID (optional)"},
+					"status":     map[string]any{"type": "string", "description": "Filter by status"},
+					"sprint":     map[string]any{"type": "string", "description": "Filter by sprint ID (or \"active\")"},
+					"sort":       map[string]any{"type": "string", "description": "Sort field (status, created, title, priority, rank)"},
+					"query":      map[string]any{"type": "string", "description": "Query, e.g. status:doing,review tag:backend -tag:wontfix priority<=2 \"login bug\" sort:-priority"},
+					"all_boards": map[string]any{"type": "boolean", "description": "List tasks from every non-archived board"},
+					"boards":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "List tasks from these board IDs"},
 				},
 			},
 		},
@@ -782,18 +887,69 @@ func toolList() []toolDescriptor {
 		{Name: "create_task", Description: "Create a new task", InputSchema: map[string]any{
 			"type": "object",
 			"properties": map[string]any{
-				"title":  map[string]any{"type": "string", "description": "Task title"},
-				"status": map[string]any{"type": "string", "description": "Task status"},
+				"title":    map[string]any{"type": "string", "description": "Task title"},
+				"status":   map[string]any{"type": "string", "description": "Task status"},
+				"estimate": map[string]any{"type": "number", "description": "Estimate in the board unit (points or hours)"},
+				"sprint":   map[string]any{"type": "string", "description": "Sprint ID (or \"active\")"},
 			},
 			"required": []string{"title"},
 		}},
 		{Name: "update_task_status", Description: "Update a task status"},
 		{Name: "update_task_priority", Description: "Update a task priority"},
+		{Name: "update_task_estimate", Description: "Update a task estimate (0 clears it)"},
 		{Name: "update_task_title", Description: "Update a task title"},
+		{Name: "list_views", Description: "List saved views (named query, sort, columns and grouping) for a board"},
+		{Name: "apply_view", Description: "Apply a saved view and return its definition with the tasks it selects", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"board_id": map[string]any{"type": "string", "description": "Board ID (defaults to active)"},
+				"name":     map[string]any{"type": "string", "description": "View name"},
+			},
+			"required": []string{"name"},
+		}},
+		{Name: "list_sprints", Description: "List sprints for a board"},
+		{Name: "get_sprint", Description: "Get sprint details, summary and assigned tasks"},
+		{Name: "create_sprint", Description: "Create a planned sprint", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":  map[string]any{"type": "string", "description": "Sprint name"},
+				"id":    map[string]any{"type": "string", "description": "Sprint ID (optional, defaults to slug of name)"},
+				"goal":  map[string]any{"type": "string", "description": "Sprint goal"},
+				"start": map[string]any{"type": "string", "description": "Start date YYYY-MM-DD"},
+				"end":   map[string]any{"type": "string", "description": "End date YYYY-MM-DD"},
+			},
+			"required": []string{"name"},
+		}},
+		{Name: "start_sprint", Description: "Start a planned sprint"},
+		{Name: "close_sprint", Description: "Close the active sprint and roll unfinished tasks into the next sprint"},
+		{Name: "assign_task_sprint", Description: "Assign a task to a sprint (empty sprint clears it)"},
+		{Name: "bulk_update_tasks", Description: "Change status, tags, priority or archive every task matching a filter (dry run first, then apply with the preview token)", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"board_id":      map[string]any{"type": "string", "description": "Board ID (defaults to active)"},
+				"filter":        map[string]any{"type": "string", "description": "Task query, as in list_tasks query (e.g. \"status:todo tag:backend\"), or \"all\""},
+				"set_status":    map[string]any{"type": "string", "description": "New status"},
+				"add_tags":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				"remove_tags":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				"set_priority":  map[string]any{"type": "integer", "description": "New priority (1-3)"},
+				"archive":       map[string]any{"type": "boolean", "description": "Archive matching tasks"},
+				"dry_run":       map[string]any{"type": "boolean", "description": "Preview only (default true)"},
+				"preview_token": map[string]any{"type": "string", "description": "Token from the dry run, required to apply"},
+			},
+			"required": []string{"filter"},
+		}},
 		{Name: "update_task_tags", Description: "Update task tags"},
 		{Name: "update_task_content", Description: "Update task content"},
 		{Name: "update_task_dependencies", Description: "Set task dependencies"},
 		{Name: "get_task_dependencies", Description: "Get task dependency list"},
+		{Name: "get_task_commits", Description: "List the git commits whose message references a task ID (hash, author, date, subject)", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":       map[string]any{"type": "string", "description": "Task ID"},
+				"board_id": map[string]any{"type": "string", "description": "Board ID (defaults to active)"},
+			},
+			"required": []string{"id"},
+		}},
 		{Name: "list_ready_tasks", Description: "List tasks whose dependencies are satisfied"},
 		{Name: "archive_task", Description: "Archive a task (requires force)"},
 		{Name: "restore_task", Description: "Restore an archived task"},
@@ -811,9 +967,48 @@ func toolList() []toolDescriptor {
 		{Name: "list_wiki_pages", Description: "List wiki pages"},
 		{Name: "list_wiki_sections", Description: "List wiki sections"},
 		{Name: "read_wiki_page", Description: "Read a wiki page"},
+		{Name: "wiki_history", Description: "List the commits that changed a wiki page (author, date, subject, optional diffs), or without a slug the latest commits that changed any page", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"slug":               map[string]any{"type": "string", "description": "Page slug (omit for recent changes across the wiki)"},
+				"limit":              map[string]any{"type": "integer", "description": "Maximum number of commits (0 for all)"},
+				"patch":              map[string]any{"type": "boolean", "description": "Include the diff each commit made to the page"},
+				"ignore_frontmatter": map[string]any{"type": "boolean", "description": "Leave frontmatter out of diffs"},
+			},
+		}},
+		{Name: "wiki_diff", Description: "Unified diff of a wiki page between two git revisions, or a revision and the working tree", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"slug":               map[string]any{"type": "string", "description": "Page slug"},
+				"from":               map[string]any{"type": "string", "description": "Base revision (default HEAD)"},
+				"to":                 map[string]any{"type": "string", "description": "Target revision (default: working tree)"},
+				"ignore_frontmatter": map[string]any{"type": "boolean", "description": "Compare page bodies only"},
+			},
+			"required": []string{"slug"},
+		}},
 		{Name: "write_wiki_page", Description: "Create or update a wiki page"},
 		{Name: "update_wiki_section", Description: "Update wiki section metadata"},
-		{Name: "search_wiki", Description: "Search wiki pages"},
+		{Name: "search", Description: "Search tasks, wiki pages and ADRs and return ranked hits with snippets and line numbers", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query":    map[string]any{"type": "string", "description": "Words to match (all must match); quote phrases"},
+				"types":    map[string]any{"type": "array", "items": map[string]any{"type": "string", "enum": []string{"task", "wiki", "adr"}}},
+				"status":   map[string]any{"type": "string", "description": "Only include items with these statuses (comma-separated)"},
+				"board_id": map[string]any{"type": "string", "description": "Board whose tasks are searched (defaults to active)"},
+				"limit":    map[string]any{"type": "integer", "description": "Maximum number of hits (0 = all)"},
+			},
+			"required": []string{"query"},
+		}},
+		{Name: "search_wiki", Description: "Search wiki pages with ranked (BM25) results, scores and highlighted snippets", InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query":             map[string]any{"type": "string", "description": "Words are ANDed; supports \"phrases\", OR, NOT/-term, parentheses, title:/tag:/section: scopes and prefix*"},
+				"status":            map[string]any{"type": "string", "description": "Only include pages with this status"},
+				"include_templates": map[string]any{"type": "boolean", "description": "Include template pages"},
+				"limit":             map[string]any{"type": "integer", "description": "Maximum number of results (0 = all)"},
+			},
+			"required": []string{"query"},
+		}},
 		{Name: "list_wiki_templates", Description: "List wiki templates"},
 		{Name: "create_wiki_from_template", Description: "Create a wiki page from a template"},
 		{Name: "lint_wiki", Description: "Lint wiki pages"},
@@ -834,6 +1029,9 @@ func resourceList() []resourceDescriptor {
 }
 
 func (s *Server) listTasks(ctx context.Context, params listTasksParams) (any, *rpcError) {
+	if params.AllBoards || len(params.Boards) > 0 {
+		return s.listBoardsTasks(ctx, params)
+	}
 	boardID, err := s.resolveBoardIDContext(ctx, params.BoardID)
 	if err != nil {
 		return nil, internalError(err)
@@ -854,16 +1052,26 @@ func (s *Server) listTasks(ctx context.Context, params listTasksParams) (any, *r
 	if err != nil {
 		return nil, invalidParams(err)
 	}
+	sprintID, err := repo.ResolveSprintRefContext(ctx, params.Sprint)
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	query, err := board.ParseQuery(params.Query)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
 	filtered := board.FilterAndSortTasks(tasks, board.ListOptions{
 		Status:  params.Status,
 		Title:   params.Title,
 		Tags:    board.NormalizeTags(params.Tags),
 		TagMode: params.TagMode,
+		Sprint:  sprintID,
 		From:    fromDate,
 		To:      toDate,
 		SortBy:  params.Sort,
 		Desc:    params.Desc,
 	})
+	filtered = query.Apply(filtered)
 
 	result := make([]taskSummary, 0, len(filtered))
 	for _, task := range filtered {
@@ -872,6 +1080,67 @@ func (s *Server) listTasks(ctx context.Context, params listTasksParams) (any, *r
 	return result, nil
 }
 
+// listBoardsTasks fans list_tasks out across boards concurrently. Results are merged in board
+// order before sorting, so the response is deterministic.
+func (s *Server) listBoardsTasks(ctx context.Context, params listTasksParams) (any, *rpcError) {
+	boardRepo, err := s.boardRepo()
+	if err != nil {
+		return nil, internalError(err)
+	}
+	boards, err := boardRepo.SelectBoardsContext(ctx, params.Boards, params.AllBoards)
+	if err != nil {
+		if errors.Is(err, board.ErrBoardNotFound) {
+			return nil, invalidParams(err)
+		}
+		return nil, internalError(err)
+	}
+	fromDate, err := parseDate(params.From)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	toDate, err := parseDate(params.To)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	query, err := board.ParseQuery(params.Query)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	opts := board.ListOptions{
+		Status:  params.Status,
+		Title:   params.Title,
+		Tags:    board.NormalizeTags(params.Tags),
+		TagMode: params.TagMode,
+		From:    fromDate,
+		To:      toDate,
+	}
+	tasks, err := board.CollectTasksContext(ctx, s.baseDir, s.storageRoot, boards, func(ctx context.Context, repo *board.Repository) ([]board.Task, error) {
+		sprintID, err := repo.ResolveSprintRefContext(ctx, params.Sprint)
+		if errors.Is(err, board.ErrSprintNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		boardOpts := opts
+		boardOpts.Sprint = sprintID
+		return board.FilterAndSortTasks(tasks, boardOpts), nil
+	})
+	if err != nil {
+		return nil, internalError(err)
+	}
+	tasks = query.Apply(board.FilterAndSortTasks(tasks, board.ListOptions{SortBy: params.Sort, Desc: params.Desc}))
+	result := make([]taskSummary, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, toTaskSummary(task, task.BoardID))
+	}
+	return result, nil
+}
+
 func (s *Server) listArchivedTasks(ctx context.Context, params listTasksParams) (any, *rpcError) {
 	boardID, err := s.resolveBoardIDContext(ctx, params.BoardID)
 	if err != nil {
@@ -954,9 +1223,14 @@ func (s *Server) createTask(ctx context.Context, params createTaskParams) (any,
 	if len(params.Tags) > 0 {
 		task.Tags = params.Tags
 	}
+	task.Estimate = params.Estimate
+	task.Sprint, err = repo.ResolveSprintRefContext(ctx, params.Sprint)
+	if err != nil {
+		return nil, sprintError(err)
+	}
 	created, err := repo.CreateTaskContext(ctx, task)
 	if err != nil {
-		return nil, internalError(err)
+		return nil, sprintError(err)
 	}
 	return toTaskSummary(created, boardID), nil
 }
@@ -997,6 +1271,27 @@ func (s *Server) updateTaskPriority(ctx context.Context, params updatePriorityPa
 	return s.getTask(getTaskParams{BoardID: boardID, ID: params.ID})
 }
 
+func (s *Server) updateTaskEstimate(ctx context.Context, params updateEstimateParams) (any, *rpcError) {
+	if strings.TrimSpace(params.ID) == "" {
+		return nil, invalidParams(fmt.Errorf("id is required"))
+	}
+	boardID, err := s.resolveBoardIDContext(ctx, params.BoardID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	repo, err := s.repoForBoard(boardID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	if err := repo.UpdateTaskEstimateContext(ctx, params.ID, params.Estimate); err != nil {
+		if errors.Is(err, board.ErrInvalidEstimate) {
+			return nil, invalidParams(err)
+		}
+		return nil, internalError(err)
+	}
+	return s.getTask(getTaskParams{BoardID: boardID, ID: params.ID})
+}
+
 func (s *Server) updateTaskTitle(ctx context.Context, params updateTitleParams) (any, *rpcError) {
 	if strings.TrimSpace(params.ID) == "" || strings.TrimSpace(params.Title) == "" {
 		return nil, invalidParams(fmt.Errorf("id and title are required"))
@@ -1680,9 +1975,8 @@ func (s *Server) searchWiki(ctx context.Context, params searchWikiParams) (any,
 	if strings.TrimSpace(params.Query) == "" {
 		return nil, invalidParams(fmt.Errorf("query is required"))
 	}
-	caseInsensitive := true
-	if params.CaseInsensitive != nil {
-		caseInsensitive = *params.CaseInsensitive
+	if params.Limit < 0 {
+		return nil, invalidParams(fmt.Errorf("limit must be zero or greater"))
 	}
 	templatePaths, err := s.templatePaths()
 	if err != nil {
@@ -1692,12 +1986,19 @@ func (s *Server) searchWiki(ctx context.Context, params searchWikiParams) (any,
 		Query:            params.Query,
 		Status:           params.Status,
 		IncludeTemplates: params.IncludeTemplates,
-		CaseInsensitive:  caseInsensitive,
 		TemplatesRoot:    templatePaths.Wiki,
+		IndexPath:        wiki.SearchIndexPath(s.storageRoot),
+		Limit:            params.Limit,
 	})
 	if err != nil {
+		if errors.Is(err, wiki.ErrInvalidSearchQuery) {
+			return nil, invalidParams(err)
+		}
 		return nil, internalError(err)
 	}
+	if results == nil {
+		results = []wiki.SearchResult{}
+	}
 	return results, nil
 }
 
@@ -2238,6 +2539,10 @@ func toTaskSummary(task board.Task, boardID string) taskSummary {
 	if !task.Created.IsZero() {
 		created = task.Created.Format("2006-01-02")
 	}
+	completed := ""
+	if !task.Completed.IsZero() {
+		completed = task.Completed.Format("2006-01-02")
+	}
 	priority := task.Priority
 	if priority == 0 {
 		priority = board.DefaultPriority
@@ -2250,8 +2555,12 @@ func toTaskSummary(task board.Task, boardID string) taskSummary {
 		Title:     task.Title,
 		Status:    task.Status,
 		Priority:  priority,
+		Estimate:  task.Estimate,
+		Sprint:    task.Sprint,
+		Rank:      task.Rank,
 		Tags:      task.Tags,
 		Created:   created,
+		Completed: completed,
 		DependsOn: task.DependsOn,
 	}
 }
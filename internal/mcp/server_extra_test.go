@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -518,6 +519,54 @@ func TestServerUpdateTaskFields(t *testing.T) {
 	}
 }
 
+func TestServerUpdateTaskEstimate(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+
+	createOut := runServerWithStorage(t, baseDir, storageRoot, `{"jsonrpc":"2.0","method":"create_task","params":{"title":"Sized","estimate":3},"id":96}`)
+	createResp := decodeResponses(t, createOut)
+	if len(createResp) != 1 || createResp[0].Error != nil {
+		t.Fatalf("unexpected create response: %+v", createResp)
+	}
+	created := createResp[0].Result.(map[string]any)
+	taskID := created["id"].(string)
+	if created["estimate"] != float64(3) {
+		t.Fatalf("expected estimate 3, got %v", created["estimate"])
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"update_task_estimate","params":{"id":"` + taskID + `","estimate":1.5},"id":97}`,
+		`{"jsonrpc":"2.0","method":"update_task_estimate","params":{"id":"` + taskID + `","estimate":-1},"id":98}`,
+		`{"jsonrpc":"2.0","method":"read_task","params":{"id":"` + taskID + `"},"id":99}`,
+	}, "\n")
+	output := runServerWithStorage(t, baseDir, storageRoot, input)
+	responses := decodeResponses(t, output)
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	if responses[0].Error != nil {
+		t.Fatalf("unexpected error: %+v", responses[0].Error)
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != -32602 {
+		t.Fatalf("expected invalid params for negative estimate, got %+v", responses[1].Error)
+	}
+	updated := responses[0].Result.(map[string]any)
+	if updated["estimate"] != 1.5 {
+		t.Fatalf("expected estimate 1.5, got %v", updated["estimate"])
+	}
+	read := responses[2].Result.(map[string]any)
+	if !strings.Contains(read["content"].(string), "estimate: 1.5") {
+		t.Fatalf("expected estimate in frontmatter, got %v", read["content"])
+	}
+}
+
 func runServerWithStorage(t *testing.T, baseDir, storageRoot, input string) string {
 	t.Helper()
 	server, err := NewServer(baseDir, storageRoot)
@@ -530,3 +579,459 @@ func runServerWithStorage(t *testing.T, baseDir, storageRoot, input string) stri
 	}
 	return out.String()
 }
+
+func TestServerSprintLifecycle(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"create_sprint","params":{"name":"Sprint 1","goal":"Ship"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"create_sprint","params":{"name":"Sprint 2"},"id":2}`,
+		`{"jsonrpc":"2.0","method":"start_sprint","params":{"id":"sprint-1"},"id":3}`,
+		`{"jsonrpc":"2.0","method":"create_task","params":{"title":"Done","status":"done","estimate":2,"sprint":"active"},"id":4}`,
+		`{"jsonrpc":"2.0","method":"create_task","params":{"title":"Open","estimate":3},"id":5}`,
+		`{"jsonrpc":"2.0","method":"assign_task_sprint","params":{"id":"T-000002","sprint_id":"sprint-1"},"id":6}`,
+		`{"jsonrpc":"2.0","method":"list_tasks","params":{"sprint":"active"},"id":7}`,
+		`{"jsonrpc":"2.0","method":"close_sprint","params":{"id":"active"},"id":8}`,
+		`{"jsonrpc":"2.0","method":"get_sprint","params":{"id":"sprint-2"},"id":9}`,
+		`{"jsonrpc":"2.0","method":"start_sprint","params":{"id":"missing"},"id":10}`,
+	}, "\n")
+	output := runServerWithStorage(t, baseDir, storageRoot, input)
+	responses := decodeResponses(t, output)
+	if len(responses) != 10 {
+		t.Fatalf("expected 10 responses, got %d", len(responses))
+	}
+	for i, resp := range responses[:9] {
+		if resp.Error != nil {
+			t.Fatalf("response %d: unexpected error %+v", i, resp.Error)
+		}
+	}
+	listed := responses[6].Result.([]any)
+	if len(listed) != 2 {
+		t.Fatalf("expected 2 sprint tasks, got %d", len(listed))
+	}
+	closed := responses[7].Result.(map[string]any)
+	summary := closed["summary"].(map[string]any)
+	if closed["state"] != "closed" || summary["velocity"] != float64(2) || summary["rolled_to"] != "sprint-2" {
+		t.Fatalf("unexpected close result: %+v", closed)
+	}
+	next := responses[8].Result.(map[string]any)
+	tasks := next["tasks"].([]any)
+	if len(tasks) != 1 || tasks[0].(map[string]any)["id"] != "T-000002" {
+		t.Fatalf("expected rolled task in next sprint, got %+v", tasks)
+	}
+	if responses[9].Error == nil || responses[9].Error.Code != codeInvalidParams {
+		t.Fatalf("expected invalid params for missing sprint, got %+v", responses[9].Error)
+	}
+}
+
+func TestServerBulkUpdateRequiresPreview(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+	if _, err := repo.CreateTask(board.Task{Title: "One", Tags: []string{"api"}}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if _, err := repo.CreateTask(board.Task{Title: "Two"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	preview := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"bulk_update_tasks","params":{"filter":"tag:api","set_status":"doing"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"bulk_update_tasks","params":{"filter":"tag:api","set_status":"doing","dry_run":false},"id":2}`,
+		`{"jsonrpc":"2.0","method":"bulk_update_tasks","params":{"filter":"owner:me","set_status":"doing"},"id":3}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, preview))
+	if len(responses) != 3 || responses[0].Error != nil {
+		t.Fatalf("unexpected preview responses: %+v", responses)
+	}
+	result := responses[0].Result.(map[string]any)
+	token, _ := result["preview_token"].(string)
+	if result["dry_run"] != true || result["matched"] != float64(1) || token == "" {
+		t.Fatalf("unexpected preview result: %+v", result)
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != codeDenied {
+		t.Fatalf("expected apply without preview token to be denied, got %+v", responses[1].Error)
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != codeInvalidParams {
+		t.Fatalf("expected invalid filter error, got %+v", responses[2].Error)
+	}
+
+	apply := `{"jsonrpc":"2.0","method":"bulk_update_tasks","params":{"filter":"tag:api","set_status":"doing","dry_run":false,"preview_token":"` + token + `"},"id":4}` + "\n" +
+		`{"jsonrpc":"2.0","method":"bulk_update_tasks","params":{"filter":"tag:api","set_status":"doing","dry_run":false,"preview_token":"` + token + `"},"id":5}`
+	responses = decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, apply))
+	if len(responses) != 2 || responses[0].Error != nil {
+		t.Fatalf("unexpected apply responses: %+v", responses)
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != codeDenied {
+		t.Fatalf("expected stale token to be denied, got %+v", responses[1].Error)
+	}
+	updated, err := repo.GetTaskByID("T-000001")
+	if err != nil || updated.Status != "doing" {
+		t.Fatalf("expected task moved to doing, got %+v (err=%v)", updated, err)
+	}
+}
+
+func TestServerListTasksQuery(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+	for _, task := range []board.Task{
+		{Title: "Login bug", Priority: 3, Tags: []string{"backend"}},
+		{Title: "Signup", Priority: 1, Tags: []string{"backend"}, Content: "Mentions the login bug"},
+		{Title: "Styles", Priority: 1, Tags: []string{"ui"}},
+	} {
+		if _, err := repo.CreateTask(task); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"list_tasks","params":{"query":"tag:backend \"login bug\" sort:priority"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"list_tasks","params":{"query":"priority<=low"},"id":2}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, input))
+	if len(responses) != 2 || responses[0].Error != nil {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	listed := responses[0].Result.([]any)
+	if len(listed) != 2 || listed[0].(map[string]any)["title"] != "Signup" {
+		t.Fatalf("unexpected query result: %+v", listed)
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != codeInvalidParams || !strings.Contains(responses[1].Error.Message, "column 11") {
+		t.Fatalf("expected invalid params pointing at column 11, got %+v", responses[1].Error)
+	}
+}
+
+func TestServerListAndApplyViews(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+	for _, task := range []board.Task{
+		{Title: "Urgent fix", Priority: 1, Status: "doing"},
+		{Title: "Later", Priority: 3, Status: "doing"},
+		{Title: "Shipped", Priority: 1, Status: "done"},
+	} {
+		if _, err := repo.CreateTask(task); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+	if _, err := repo.SaveView(board.View{Name: "p1-active", Query: "priority:1", Columns: []string{"todo", "doing"}}); err != nil {
+		t.Fatalf("save view: %v", err)
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"list_views","id":1}`,
+		`{"jsonrpc":"2.0","method":"apply_view","params":{"name":"P1-ACTIVE"},"id":2}`,
+		`{"jsonrpc":"2.0","method":"apply_view","params":{"name":"missing"},"id":3}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, input))
+	if len(responses) != 3 || responses[0].Error != nil || responses[1].Error != nil {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	views := responses[0].Result.([]any)
+	if len(views) != 1 || views[0].(map[string]any)["name"] != "p1-active" {
+		t.Fatalf("unexpected views: %+v", views)
+	}
+	applied := responses[1].Result.(map[string]any)
+	tasks := applied["tasks"].([]any)
+	if len(tasks) != 1 || tasks[0].(map[string]any)["title"] != "Urgent fix" {
+		t.Fatalf("unexpected view tasks: %+v", applied)
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != codeInvalidParams {
+		t.Fatalf("expected invalid params for missing view, got %+v", responses[2].Error)
+	}
+}
+
+func TestServerListTasksAcrossBoards(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+	boardRepo, err := board.NewBoardRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new board repo: %v", err)
+	}
+	work, err := boardRepo.CreateBoard("Work")
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	workRepo, err := board.NewRepositoryForBoardWithStorage(baseDir, work.ID, storageRoot)
+	if err != nil {
+		t.Fatalf("work repo: %v", err)
+	}
+	if _, err := repo.CreateTask(board.Task{Title: "Default low", Priority: 3}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if _, err := workRepo.CreateTask(board.Task{Title: "Work high", Priority: 1}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"list_tasks","params":{"all_boards":true,"sort":"priority"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"list_tasks","params":{"boards":["missing"]},"id":2}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, input))
+	if len(responses) != 2 || responses[0].Error != nil {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	listed := responses[0].Result.([]any)
+	if len(listed) != 2 {
+		t.Fatalf("expected tasks from both boards, got %+v", listed)
+	}
+	first := listed[0].(map[string]any)
+	if first["title"] != "Work high" || first["board_id"] != work.ID {
+		t.Fatalf("expected work task first with its board id, got %+v", first)
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != codeInvalidParams {
+		t.Fatalf("expected invalid params for unknown board, got %+v", responses[1].Error)
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+	if _, err := repo.CreateTask(board.Task{Title: "Tune retries", Content: "Back off on timeout errors."}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	page := wiki.Page{Title: "Timeouts", Slug: "timeouts", Status: "published", Content: "Every timeout is logged.\n"}
+	if err := wiki.SavePage(filepath.Join(storageRoot, "wiki", "timeouts.md"), page); err != nil {
+		t.Fatalf("save page: %v", err)
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"search","params":{"query":"timeout"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"search","params":{"query":"timeout","types":["task"]},"id":2}`,
+		`{"jsonrpc":"2.0","method":"search","params":{"query":""},"id":3}`,
+		`{"jsonrpc":"2.0","method":"search","params":{"query":"timeout","types":["page"]},"id":4}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, input))
+	if len(responses) != 4 || responses[0].Error != nil || responses[1].Error != nil {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	hits := responses[0].Result.([]any)
+	if len(hits) != 2 || hits[0].(map[string]any)["type"] != "wiki" {
+		t.Fatalf("expected the title match first, got %+v", hits)
+	}
+	if path := hits[0].(map[string]any)["path"]; path != "storage/wiki/timeouts.md" {
+		t.Fatalf("expected path relative to the base dir, got %v", path)
+	}
+	taskHits := responses[1].Result.([]any)
+	if len(taskHits) != 1 || taskHits[0].(map[string]any)["snippet"] != "Back off on timeout errors." {
+		t.Fatalf("unexpected task hits: %+v", taskHits)
+	}
+	for _, idx := range []int{2, 3} {
+		if responses[idx].Error == nil || responses[idx].Error.Code != codeInvalidParams {
+			t.Fatalf("expected invalid params for response %d, got %+v", idx, responses[idx].Error)
+		}
+	}
+}
+
+func TestServerSearchWikiRanksWithHighlights(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	for _, page := range []wiki.Page{
+		{Title: "Timeouts", Slug: "timeouts", Status: "published", Content: "Every timeout is logged.\n"},
+		{Title: "Retries", Slug: "retries", Status: "published", Content: "Retries back off after a timeout.\n"},
+	} {
+		if err := wiki.SavePage(filepath.Join(storageRoot, "wiki", page.Slug+".md"), page); err != nil {
+			t.Fatalf("save page: %v", err)
+		}
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"search_wiki","params":{"query":"timeout*"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"search_wiki","params":{"query":"timeout -title:timeouts","limit":1},"id":2}`,
+		`{"jsonrpc":"2.0","method":"search_wiki","params":{"query":"(timeout"},"id":3}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, input))
+	if len(responses) != 3 || responses[0].Error != nil || responses[1].Error != nil {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	results := responses[0].Result.([]any)
+	if len(results) != 2 {
+		t.Fatalf("expected both pages, got %+v", results)
+	}
+	first := results[0].(map[string]any)
+	if first["slug"] != "timeouts" || first["score"].(float64) <= results[1].(map[string]any)["score"].(float64) {
+		t.Fatalf("expected the title match to rank first, got %+v", results)
+	}
+	if first["highlight"] != "Every **timeout** is logged." {
+		t.Fatalf("unexpected highlight: %v", first["highlight"])
+	}
+	scoped := responses[1].Result.([]any)
+	if len(scoped) != 1 || scoped[0].(map[string]any)["slug"] != "retries" {
+		t.Fatalf("unexpected scoped results: %+v", scoped)
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != codeInvalidParams {
+		t.Fatalf("expected invalid params, got %+v", responses[2].Error)
+	}
+}
+
+func TestServerGetTaskCommits(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+	task, err := repo.CreateTask(board.Task{Title: "Fix parser"})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"commit", "-q", "-m", "Fix parser", "-m", "Closes " + task.ID},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = baseDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com",
+			"GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"get_task_commits","params":{"id":"` + task.ID + `"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"get_task_commits","params":{},"id":2}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, input))
+	if len(responses) != 2 || responses[0].Error != nil {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	commits := responses[0].Result.(map[string]any)["commits"].([]any)
+	if len(commits) != 1 {
+		t.Fatalf("expected one linked commit, got %+v", commits)
+	}
+	commit := commits[0].(map[string]any)
+	if commit["subject"] != "Fix parser" || commit["author"] != "Dev" || len(commit["hash"].(string)) != 40 {
+		t.Fatalf("unexpected commit: %+v", commit)
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != codeInvalidParams {
+		t.Fatalf("expected invalid params, got %+v", responses[1].Error)
+	}
+}
+
+func TestServerWikiHistoryAndDiff(t *testing.T) {
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, "storage")
+	pagePath := filepath.Join(storageRoot, "wiki", "guide.md")
+	if err := os.MkdirAll(filepath.Dir(pagePath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	gitEnv := append(os.Environ(),
+		"GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com",
+		"GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+	)
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = baseDir
+		cmd.Env = gitEnv
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	runGit("init", "-q")
+	for i, body := range []string{"# Guide\n\nFirst draft.\n", "# Guide\n\nSecond draft.\n"} {
+		if err := os.WriteFile(pagePath, []byte(body), 0o644); err != nil {
+			t.Fatalf("write page: %v", err)
+		}
+		runGit("add", ".")
+		runGit("commit", "-q", "-m", []string{"Add guide", "Revise guide"}[i])
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"wiki_history","params":{"slug":"guide","patch":true},"id":1}`,
+		`{"jsonrpc":"2.0","method":"wiki_history","params":{},"id":2}`,
+		`{"jsonrpc":"2.0","method":"wiki_diff","params":{"slug":"guide","from":"HEAD~1","to":"HEAD"},"id":3}`,
+		`{"jsonrpc":"2.0","method":"wiki_diff","params":{"slug":"guide","from":"nope"},"id":4}`,
+		`{"jsonrpc":"2.0","method":"wiki_history","params":{"slug":"missing"},"id":5}`,
+	}, "\n")
+	responses := decodeResponses(t, runServerWithStorage(t, baseDir, storageRoot, input))
+	if len(responses) != 5 {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	for _, response := range responses[:3] {
+		if response.Error != nil {
+			t.Fatalf("unexpected error: %+v", response.Error)
+		}
+	}
+
+	revisions := responses[0].Result.(map[string]any)["revisions"].([]any)
+	if len(revisions) != 2 {
+		t.Fatalf("expected two revisions, got %+v", revisions)
+	}
+	latest := revisions[0].(map[string]any)
+	commit := latest["commit"].(map[string]any)
+	if commit["subject"] != "Revise guide" || commit["author"] != "Dev" {
+		t.Fatalf("unexpected latest commit: %+v", commit)
+	}
+	if diff := latest["diff"].(string); !strings.Contains(diff, "-First draft.") || !strings.Contains(diff, "+Second draft.") {
+		t.Fatalf("unexpected revision diff: %q", diff)
+	}
+
+	changes := responses[1].Result.(map[string]any)["changes"].([]any)
+	if len(changes) != 2 {
+		t.Fatalf("expected two recent changes, got %+v", changes)
+	}
+	if slugs := changes[0].(map[string]any)["slugs"].([]any); len(slugs) != 1 || slugs[0] != "guide" {
+		t.Fatalf("unexpected changed slugs: %+v", slugs)
+	}
+
+	diff := responses[2].Result.(map[string]any)
+	if diff["changed"] != true || !strings.Contains(diff["diff"].(string), "+Second draft.") {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+	for _, response := range responses[3:] {
+		if response.Error == nil || response.Error.Code != codeInvalidParams {
+			t.Fatalf("expected invalid params, got %+v", response.Error)
+		}
+	}
+}
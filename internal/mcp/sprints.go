@@ -0,0 +1,262 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mochi-sticky/internal/board"
+)
+
+type listSprintsParams struct {
+	BoardID string `json:"board_id"`
+	State   string `json:"state"`
+}
+
+type sprintParams struct {
+	BoardID string `json:"board_id"`
+	ID      string `json:"id"`
+}
+
+type createSprintParams struct {
+	BoardID string `json:"board_id"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Goal    string `json:"goal"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+type closeSprintParams struct {
+	BoardID string `json:"board_id"`
+	ID      string `json:"id"`
+	Next    string `json:"next"`
+}
+
+type assignSprintParams struct {
+	BoardID  string `json:"board_id"`
+	ID       string `json:"id"`
+	SprintID string `json:"sprint_id"`
+}
+
+type sprintSummary struct {
+	BoardID string             `json:"board_id,omitempty"`
+	ID      string             `json:"id"`
+	Name    string             `json:"name"`
+	Goal    string             `json:"goal,omitempty"`
+	Start   string             `json:"start,omitempty"`
+	End     string             `json:"end,omitempty"`
+	State   string             `json:"state"`
+	Summary *sprintCloseReport `json:"summary,omitempty"`
+}
+
+type sprintCloseReport struct {
+	Closed            string   `json:"closed"`
+	Committed         int      `json:"committed"`
+	Completed         int      `json:"completed"`
+	CommittedEstimate float64  `json:"committed_estimate"`
+	Velocity          float64  `json:"velocity"`
+	CompletedTasks    []string `json:"completed_tasks,omitempty"`
+	RolledOver        []string `json:"rolled_over,omitempty"`
+	RolledTo          string   `json:"rolled_to,omitempty"`
+}
+
+type sprintDetail struct {
+	sprintSummary
+	Unit  string        `json:"unit"`
+	Tasks []taskSummary `json:"tasks"`
+}
+
+func (s *Server) listSprints(ctx context.Context, params listSprintsParams) (any, *rpcError) {
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	sprints, err := repo.ListSprintsContext(ctx)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	state := strings.ToLower(strings.TrimSpace(params.State))
+	result := make([]sprintSummary, 0, len(sprints))
+	for _, sprint := range sprints {
+		if state != "" && sprint.State != state {
+			continue
+		}
+		result = append(result, toSprintSummary(sprint, boardID))
+	}
+	return result, nil
+}
+
+func (s *Server) getSprint(ctx context.Context, params sprintParams) (any, *rpcError) {
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	sprintID, err := repo.ResolveSprintRefContext(ctx, params.ID)
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	if sprintID == "" {
+		return nil, invalidParams(fmt.Errorf("id is required"))
+	}
+	return s.sprintDetail(ctx, repo, boardID, sprintID)
+}
+
+func (s *Server) createSprint(ctx context.Context, params createSprintParams) (any, *rpcError) {
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, invalidParams(fmt.Errorf("name is required"))
+	}
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	start, err := parseDate(params.Start)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	end, err := parseDate(params.End)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	created, err := repo.CreateSprintContext(ctx, board.Sprint{
+		ID:    params.ID,
+		Name:  params.Name,
+		Goal:  params.Goal,
+		Start: board.Date{Time: start},
+		End:   board.Date{Time: end},
+	})
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	return toSprintSummary(created, boardID), nil
+}
+
+func (s *Server) startSprint(ctx context.Context, params sprintParams) (any, *rpcError) {
+	if strings.TrimSpace(params.ID) == "" {
+		return nil, invalidParams(fmt.Errorf("id is required"))
+	}
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	started, err := repo.StartSprintContext(ctx, params.ID)
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	return toSprintSummary(started, boardID), nil
+}
+
+func (s *Server) closeSprint(ctx context.Context, params closeSprintParams) (any, *rpcError) {
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	sprintID, err := repo.ResolveSprintRefContext(ctx, params.ID)
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	if sprintID == "" {
+		return nil, invalidParams(fmt.Errorf("id is required"))
+	}
+	closed, err := repo.CloseSprintContext(ctx, sprintID, params.Next)
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	return toSprintSummary(closed, boardID), nil
+}
+
+func (s *Server) assignTaskSprint(ctx context.Context, params assignSprintParams) (any, *rpcError) {
+	if strings.TrimSpace(params.ID) == "" {
+		return nil, invalidParams(fmt.Errorf("id is required"))
+	}
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	sprintID, err := repo.ResolveSprintRefContext(ctx, params.SprintID)
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	if err := repo.UpdateTaskSprintContext(ctx, params.ID, sprintID); err != nil {
+		return nil, sprintError(err)
+	}
+	return s.getTask(getTaskParams{BoardID: boardID, ID: params.ID})
+}
+
+func (s *Server) resolveRepo(ctx context.Context, requested string) (string, *board.Repository, *rpcError) {
+	boardID, err := s.resolveBoardIDContext(ctx, requested)
+	if err != nil {
+		return "", nil, internalError(err)
+	}
+	repo, err := s.repoForBoard(boardID)
+	if err != nil {
+		return "", nil, internalError(err)
+	}
+	return boardID, repo, nil
+}
+
+func (s *Server) sprintDetail(ctx context.Context, repo *board.Repository, boardID, sprintID string) (any, *rpcError) {
+	sprint, err := repo.GetSprintContext(ctx, sprintID)
+	if err != nil {
+		return nil, sprintError(err)
+	}
+	config, err := repo.LoadConfigContext(ctx)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	unit, err := board.NormalizeEstimateUnit(config.EstimateUnit)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	tasks, err := repo.GetAllTasksContext(ctx)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	assigned := board.SprintTasks(tasks, sprint.ID)
+	detail := sprintDetail{
+		sprintSummary: toSprintSummary(sprint, boardID),
+		Unit:          unit,
+		Tasks:         make([]taskSummary, 0, len(assigned)),
+	}
+	for _, task := range assigned {
+		detail.Tasks = append(detail.Tasks, toTaskSummary(task, boardID))
+	}
+	return detail, nil
+}
+
+func sprintError(err error) *rpcError {
+	if errors.Is(err, board.ErrSprintNotFound) || errors.Is(err, board.ErrInvalidSprint) || errors.Is(err, board.ErrSprintState) {
+		return invalidParams(err)
+	}
+	return internalError(err)
+}
+
+func toSprintSummary(sprint board.Sprint, boardID string) sprintSummary {
+	summary := sprintSummary{
+		BoardID: boardID,
+		ID:      sprint.ID,
+		Name:    sprint.Name,
+		Goal:    sprint.Goal,
+		State:   sprint.State,
+	}
+	if !sprint.Start.IsZero() {
+		summary.Start = sprint.Start.Format("2006-01-02")
+	}
+	if !sprint.End.IsZero() {
+		summary.End = sprint.End.Format("2006-01-02")
+	}
+	if report := sprint.Summary; report != nil {
+		summary.Summary = &sprintCloseReport{
+			Closed:            report.Closed.Format("2006-01-02"),
+			Committed:         report.Committed,
+			Completed:         report.Completed,
+			CommittedEstimate: report.CommittedEstimate,
+			Velocity:          report.Velocity,
+			CompletedTasks:    report.CompletedTasks,
+			RolledOver:        report.RolledOver,
+			RolledTo:          report.RolledTo,
+		}
+	}
+	return summary
+}
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+
+	"mochi-sticky/internal/board"
+)
+
+type listViewsParams struct {
+	BoardID string `json:"board_id"`
+}
+
+type applyViewParams struct {
+	BoardID string `json:"board_id"`
+	Name    string `json:"name"`
+}
+
+type viewResult struct {
+	BoardID string        `json:"board_id"`
+	View    board.View    `json:"view"`
+	Tasks   []taskSummary `json:"tasks"`
+}
+
+func (s *Server) listViews(ctx context.Context, params listViewsParams) (any, *rpcError) {
+	_, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	views, err := repo.ListViewsContext(ctx)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	if views == nil {
+		views = []board.View{}
+	}
+	return views, nil
+}
+
+func (s *Server) applyView(ctx context.Context, params applyViewParams) (any, *rpcError) {
+	boardID, repo, rpcErr := s.resolveRepo(ctx, params.BoardID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	view, err := repo.GetViewContext(ctx, params.Name)
+	if err != nil {
+		return nil, viewError(err)
+	}
+	tasks, err := repo.GetAllTasksContext(ctx)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	tasks, err = view.Apply(tasks)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	result := viewResult{BoardID: boardID, View: view, Tasks: make([]taskSummary, 0, len(tasks))}
+	for _, task := range tasks {
+		result.Tasks = append(result.Tasks, toTaskSummary(task, boardID))
+	}
+	return result, nil
+}
+
+func viewError(err error) *rpcError {
+	if errors.Is(err, board.ErrViewNotFound) || errors.Is(err, board.ErrInvalidView) {
+		return invalidParams(err)
+	}
+	return internalError(err)
+}
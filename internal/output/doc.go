@@ -0,0 +1,5 @@
+// Package output renders tasks, boards, ADRs and wiki pages in the machine-readable formats
+// selected with the global --output flag. JSON and YAML wrap the records in a versioned
+// envelope; CSV writes a header row followed by one row per record. The record types define
+// the stable field names scripts can rely on.
+package output
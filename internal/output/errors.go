@@ -0,0 +1,10 @@
+package output
+
+import "errors"
+
+var (
+	// ErrInvalidFormat indicates an --output value other than table, json, yaml or csv.
+	ErrInvalidFormat = errors.New("invalid output format")
+	// ErrUnsupportedFormat indicates a command that only prints tables was asked for another format.
+	ErrUnsupportedFormat = errors.New("output format not supported by this command")
+)
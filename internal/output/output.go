@@ -0,0 +1,228 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/wiki"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the version of the JSON/YAML envelope and record fields. It is bumped
+// whenever a field is renamed or removed; new fields may be added within a version.
+const SchemaVersion = 1
+
+// Format selects how read commands print their results.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatCSV   Format = "csv"
+)
+
+// Document kinds identify the payload of the envelope.
+const (
+	KindTask          = "task"
+	KindTasks         = "task_list"
+	KindBoard         = "board"
+	KindBoards        = "board_list"
+	KindADR           = "adr"
+	KindADRs          = "adr_list"
+	KindWikiPage      = "wiki_page"
+	KindWikiPages     = "wiki_page_list"
+	KindBurndown      = "burndown"
+	KindSearchHits    = "search_hit_list"
+	KindStatusReport  = "status_report"
+	KindReleaseNotes  = "release_notes"
+	KindTodoReport    = "todo_report"
+	KindTaskHistory   = "task_history"
+	KindTaskHistories = "task_history_list"
+	KindStatuses      = "status_list"
+	KindWikiSections  = "wiki_section_list"
+	KindWikiRevisions = "wiki_revision_list"
+	KindWikiChanges   = "wiki_change_list"
+	KindSprint        = "sprint"
+	KindSprints       = "sprint_list"
+	KindView          = "view"
+	KindViews         = "view_list"
+	KindError         = "error"
+)
+
+// Error codes reported in error documents.
+const (
+	CodeNotFound          = "not_found"
+	CodeUnsupportedOutput = "unsupported_output"
+	CodeError             = "error"
+)
+
+// ParseFormat validates an --output value; an empty value selects the table format.
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return FormatTable, nil
+	case FormatTable, FormatJSON, FormatYAML, FormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("output: %q (expected table, json, yaml or csv): %w", value, ErrInvalidFormat)
+	}
+}
+
+// Document is the envelope written for JSON and YAML output.
+type Document struct {
+	SchemaVersion int    `json:"schema_version" yaml:"schema_version"`
+	Kind          string `json:"kind" yaml:"kind"`
+	Data          any    `json:"data" yaml:"data"`
+}
+
+// ErrorInfo is the payload of an error document.
+type ErrorInfo struct {
+	Code    string `json:"code" yaml:"code"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// Record is a value that can also be written as a CSV row.
+type Record interface {
+	CSVHeader() []string
+	CSVRow() []string
+}
+
+// WriteList writes records as a document of the given kind, or as CSV rows. An empty list
+// is written as an empty array (or a header-only CSV), never as null.
+func WriteList[T Record](w io.Writer, format Format, kind string, records []T) error {
+	if records == nil {
+		records = []T{}
+	}
+	if format == FormatCSV {
+		return writeRecords(w, records)
+	}
+	return writeDocument(w, format, Document{SchemaVersion: SchemaVersion, Kind: kind, Data: records})
+}
+
+// WriteReport writes a nested report as a document of the given kind, or its flattened rows
+// as CSV. YAML uses the report's JSON field names, so report types only need json tags.
+func WriteReport[T Record](w io.Writer, format Format, kind string, report any, rows []T) error {
+	if format == FormatCSV {
+		return writeRecords(w, rows)
+	}
+	if format == FormatYAML {
+		node, err := yamlNode(report)
+		if err != nil {
+			return err
+		}
+		report = node
+	}
+	return writeDocument(w, format, Document{SchemaVersion: SchemaVersion, Kind: kind, Data: report})
+}
+
+// WriteRecord writes a single record as a document of the given kind, or as one CSV row.
+func WriteRecord[T Record](w io.Writer, format Format, kind string, record T) error {
+	if format == FormatCSV {
+		return writeCSV(w, record.CSVHeader(), [][]string{record.CSVRow()})
+	}
+	return writeDocument(w, format, Document{SchemaVersion: SchemaVersion, Kind: kind, Data: record})
+}
+
+// WriteError writes err as an error document for JSON and YAML, and as a plain line otherwise.
+func WriteError(w io.Writer, format Format, err error) error {
+	if format != FormatJSON && format != FormatYAML {
+		_, writeErr := fmt.Fprintln(w, err)
+		return writeErr
+	}
+	return writeDocument(w, format, Document{
+		SchemaVersion: SchemaVersion,
+		Kind:          KindError,
+		Data:          ErrorInfo{Code: ErrorCode(err), Message: err.Error()},
+	})
+}
+
+// ErrorCode classifies err for error documents.
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, board.ErrTaskNotFound),
+		errors.Is(err, board.ErrBoardNotFound),
+		errors.Is(err, board.ErrSprintNotFound),
+		errors.Is(err, board.ErrViewNotFound),
+		errors.Is(err, adr.ErrADRNotFound),
+		errors.Is(err, wiki.ErrPageNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrInvalidFormat), errors.Is(err, ErrUnsupportedFormat):
+		return CodeUnsupportedOutput
+	default:
+		return CodeError
+	}
+}
+
+func writeDocument(w io.Writer, format Format, doc Document) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("output: %s: %w", format, ErrUnsupportedFormat)
+	}
+}
+
+func writeRecords[T Record](w io.Writer, records []T) error {
+	var zero T
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, record.CSVRow())
+	}
+	return writeCSV(w, zero.CSVHeader(), rows)
+}
+
+// yamlNode converts value to a YAML node through its JSON encoding, keeping the JSON field
+// names and order.
+func yamlNode(value any) (*yaml.Node, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	node := doc.Content[0]
+	resetStyle(node)
+	return node, nil
+}
+
+// resetStyle drops the flow and quoting styles taken over from JSON.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,162 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/status"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"": FormatTable, "JSON": FormatJSON, " yaml ": FormatYAML, "csv": FormatCSV} {
+		got, err := ParseFormat(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected ErrInvalidFormat, got %v", err)
+	}
+}
+
+func TestWriteListWrapsRecordsInVersionedEnvelope(t *testing.T) {
+	// Arrange
+	baseDir := t.TempDir()
+	task := board.Task{
+		ID:       "T-000001",
+		Title:    "Fix login",
+		Status:   "todo",
+		Priority: 1,
+		Tags:     []string{"auth", "bug"},
+		Content:  "Body",
+		FilePath: filepath.Join(baseDir, "boards", "default", "tasks", "T-000001.md"),
+	}
+	records := FromTasks([]board.Task{task}, "default", baseDir)
+
+	// Act
+	var jsonOut, yamlOut, emptyOut bytes.Buffer
+	jsonErr := WriteList(&jsonOut, FormatJSON, KindTasks, records)
+	yamlErr := WriteList(&yamlOut, FormatYAML, KindTasks, records)
+	emptyErr := WriteList[Task](&emptyOut, FormatJSON, KindTasks, nil)
+
+	// Assert
+	if jsonErr != nil || yamlErr != nil || emptyErr != nil {
+		t.Fatalf("write: %v / %v / %v", jsonErr, yamlErr, emptyErr)
+	}
+	var doc struct {
+		SchemaVersion int              `json:"schema_version"`
+		Kind          string           `json:"kind"`
+		Data          []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &doc); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if doc.SchemaVersion != SchemaVersion || doc.Kind != KindTasks || len(doc.Data) != 1 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	got := doc.Data[0]
+	if got["board"] != "default" || got["id"] != "T-000001" || got["path"] != "boards/default/tasks/T-000001.md" {
+		t.Fatalf("unexpected task record: %+v", got)
+	}
+	if _, ok := got["content"]; ok {
+		t.Fatalf("expected list records without content: %+v", got)
+	}
+	if deps, ok := got["depends_on"].([]any); !ok || len(deps) != 0 {
+		t.Fatalf("expected empty depends_on array, got %#v", got["depends_on"])
+	}
+	var yamlDoc Document
+	if err := yaml.Unmarshal(yamlOut.Bytes(), &yamlDoc); err != nil || yamlDoc.Kind != KindTasks || yamlDoc.SchemaVersion != SchemaVersion {
+		t.Fatalf("unexpected yaml document %+v: %v", yamlDoc, err)
+	}
+	if !strings.Contains(emptyOut.String(), `"data": []`) {
+		t.Fatalf("expected an empty array, got %s", emptyOut.String())
+	}
+}
+
+func TestWriteRecordCSV(t *testing.T) {
+	// Arrange
+	record := FromTask(board.Task{
+		ID:        "T-000002",
+		Title:     "Ship, then test",
+		Tags:      []string{"a", "b"},
+		DependsOn: []string{"T-000001"},
+		Fields:    map[string]string{"team": "core", "area": "api"},
+		Content:   "line one\nline two",
+	}, "default", "")
+
+	// Act
+	var out bytes.Buffer
+	err := WriteRecord(&out, FormatCSV, KindTask, record)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := strings.Join(Task{}.CSVHeader(), ",") + "\n" +
+		"default,T-000002,,\"Ship, then test\",,0,0,,,,,a;b,,,,T-000001,area=api;team=core,,\"line one\nline two\"\n"
+	if out.String() != want {
+		t.Fatalf("unexpected csv:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWriteReportUsesJSONFieldNames(t *testing.T) {
+	// Arrange
+	report := status.Report{
+		Date:   "2026-10-18",
+		Boards: []status.BoardReport{{ID: "default", Name: "Default", Summary: board.Summary{Total: 3, Open: 2, BlockedP1: 1}}},
+		Totals: status.Totals{Tasks: 3, BlockedP1: 1},
+	}
+
+	// Act
+	var yamlOut, csvOut bytes.Buffer
+	yamlErr := WriteReport(&yamlOut, FormatYAML, KindStatusReport, report, FromStatusReport(report))
+	csvErr := WriteReport(&csvOut, FormatCSV, KindStatusReport, report, FromStatusReport(report))
+
+	// Assert
+	if yamlErr != nil || csvErr != nil {
+		t.Fatalf("write: %v / %v", yamlErr, csvErr)
+	}
+	if !strings.Contains(yamlOut.String(), "kind: status_report") || !strings.Contains(yamlOut.String(), "blocked_p1: 1") {
+		t.Fatalf("expected yaml with json field names, got:\n%s", yamlOut.String())
+	}
+	want := "board,name,tasks,open,wip,ready,blocked,blocked_p1,overdue\ndefault,Default,3,2,0,0,0,1,0\n"
+	if csvOut.String() != want {
+		t.Fatalf("unexpected csv:\n%s\nwant:\n%s", csvOut.String(), want)
+	}
+}
+
+func TestWriteErrorClassifiesErrors(t *testing.T) {
+	// Arrange
+	notFound := fmt.Errorf("board: T-9: %w", board.ErrTaskNotFound)
+
+	// Act
+	var jsonOut, plainOut bytes.Buffer
+	jsonErr := WriteError(&jsonOut, FormatJSON, notFound)
+	plainErr := WriteError(&plainOut, FormatCSV, errors.New("boom"))
+
+	// Assert
+	if jsonErr != nil || plainErr != nil {
+		t.Fatalf("write: %v / %v", jsonErr, plainErr)
+	}
+	var doc struct {
+		Kind string    `json:"kind"`
+		Data ErrorInfo `json:"data"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc.Kind != KindError || doc.Data.Code != CodeNotFound || doc.Data.Message != notFound.Error() {
+		t.Fatalf("unexpected error document: %+v", doc)
+	}
+	if plainOut.String() != "boom\n" {
+		t.Fatalf("expected plain error for csv, got %q", plainOut.String())
+	}
+}
@@ -0,0 +1,846 @@
+package output
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/release"
+	"mochi-sticky/internal/scan"
+	"mochi-sticky/internal/search"
+	"mochi-sticky/internal/status"
+	"mochi-sticky/internal/wiki"
+)
+
+// listSeparator joins list values (tags, dependencies, ...) inside a single CSV cell.
+const listSeparator = ";"
+
+// Task is the stable representation of a board task. Content and Commits are only set by show
+// commands; Commits is left out of CSV.
+type Task struct {
+	Board     string            `json:"board" yaml:"board"`
+	ID        string            `json:"id" yaml:"id"`
+	UID       string            `json:"uid" yaml:"uid"`
+	Title     string            `json:"title" yaml:"title"`
+	Status    string            `json:"status" yaml:"status"`
+	Priority  int               `json:"priority" yaml:"priority"`
+	Estimate  float64           `json:"estimate" yaml:"estimate"`
+	Sprint    string            `json:"sprint" yaml:"sprint"`
+	Assignee  string            `json:"assignee" yaml:"assignee"`
+	Branch    string            `json:"branch" yaml:"branch"`
+	Rank      string            `json:"rank" yaml:"rank"`
+	Tags      []string          `json:"tags" yaml:"tags"`
+	Created   string            `json:"created" yaml:"created"`
+	Completed string            `json:"completed" yaml:"completed"`
+	Due       string            `json:"due" yaml:"due"`
+	DependsOn []string          `json:"depends_on" yaml:"depends_on"`
+	Fields    map[string]string `json:"fields" yaml:"fields"`
+	Path      string            `json:"path" yaml:"path"`
+	Content   string            `json:"content,omitempty" yaml:"content,omitempty"`
+	Commits   []Commit          `json:"commits,omitempty" yaml:"commits,omitempty"`
+}
+
+// Commit is the stable representation of a git commit linked to a task.
+type Commit struct {
+	Hash    string `json:"hash" yaml:"hash"`
+	Author  string `json:"author" yaml:"author"`
+	Date    string `json:"date" yaml:"date"`
+	Subject string `json:"subject" yaml:"subject"`
+}
+
+// FromCommits converts linked commits; dates keep their time and offset (RFC 3339).
+func FromCommits(commits []git.Commit) []Commit {
+	records := make([]Commit, 0, len(commits))
+	for _, commit := range commits {
+		records = append(records, Commit{
+			Hash:    commit.Hash,
+			Author:  commit.Author,
+			Date:    commit.Date.Format(time.RFC3339),
+			Subject: commit.Subject,
+		})
+	}
+	return records
+}
+
+// FromTask converts a task, making its path relative to baseDir. boardID is used when the
+// task does not carry its own board (single-board listings).
+func FromTask(task board.Task, boardID, baseDir string) Task {
+	if strings.TrimSpace(task.BoardID) != "" {
+		boardID = task.BoardID
+	}
+	fields := task.Fields
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	return Task{
+		Board:     boardID,
+		ID:        task.ID,
+		UID:       task.UID,
+		Title:     task.Title,
+		Status:    task.Status,
+		Priority:  task.Priority,
+		Estimate:  task.Estimate,
+		Sprint:    task.Sprint,
+		Assignee:  task.Assignee,
+		Branch:    task.Branch,
+		Rank:      task.Rank,
+		Tags:      nonNil(task.Tags),
+		Created:   formatDate(task.Created.Time.IsZero(), task.Created.Format),
+		Completed: formatDate(task.Completed.Time.IsZero(), task.Completed.Format),
+		Due:       formatDate(task.Due.Time.IsZero(), task.Due.Format),
+		DependsOn: nonNil(task.DependsOn),
+		Fields:    fields,
+		Path:      relativePath(baseDir, task.FilePath),
+		Content:   task.Content,
+	}
+}
+
+// FromTasks converts tasks for list output, leaving out their content.
+func FromTasks(tasks []board.Task, boardID, baseDir string) []Task {
+	records := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		record := FromTask(task, boardID, baseDir)
+		record.Content = ""
+		records = append(records, record)
+	}
+	return records
+}
+
+// CSVHeader implements Record.
+func (Task) CSVHeader() []string {
+	return []string{
+		"board", "id", "uid", "title", "status", "priority", "estimate", "sprint", "assignee",
+		"branch", "rank", "tags", "created", "completed", "due", "depends_on", "fields", "path", "content",
+	}
+}
+
+// CSVRow implements Record.
+func (t Task) CSVRow() []string {
+	return []string{
+		t.Board, t.ID, t.UID, t.Title, t.Status, strconv.Itoa(t.Priority),
+		formatFloat(t.Estimate), t.Sprint, t.Assignee, t.Branch, t.Rank,
+		strings.Join(t.Tags, listSeparator), t.Created, t.Completed, t.Due,
+		strings.Join(t.DependsOn, listSeparator), joinFields(t.Fields), t.Path, t.Content,
+	}
+}
+
+// Board is the stable representation of a board. Description and Context are only set by
+// `board show`.
+type Board struct {
+	ID          string              `json:"id" yaml:"id"`
+	Name        string              `json:"name" yaml:"name"`
+	Path        string              `json:"path" yaml:"path"`
+	Archived    bool                `json:"archived" yaml:"archived"`
+	Active      bool                `json:"active" yaml:"active"`
+	Created     string              `json:"created" yaml:"created"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Context     *board.BoardContext `json:"context,omitempty" yaml:"context,omitempty"`
+}
+
+// FromBoard converts a board entry of the registry.
+func FromBoard(item board.Board, activeID string) Board {
+	return Board{
+		ID:       item.ID,
+		Name:     item.Name,
+		Path:     filepath.ToSlash(item.Path),
+		Archived: item.Archived,
+		Active:   item.ID == activeID,
+		Created:  formatDate(item.Created.Time.IsZero(), item.Created.Format),
+	}
+}
+
+// CSVHeader implements Record.
+func (Board) CSVHeader() []string {
+	return []string{
+		"id", "name", "path", "archived", "active", "created", "description",
+		"scope", "release", "target", "owners", "notes",
+	}
+}
+
+// CSVRow implements Record.
+func (b Board) CSVRow() []string {
+	var ctx board.BoardContext
+	if b.Context != nil {
+		ctx = *b.Context
+	}
+	return []string{
+		b.ID, b.Name, b.Path, strconv.FormatBool(b.Archived), strconv.FormatBool(b.Active),
+		b.Created, b.Description, ctx.Scope, ctx.Release, ctx.Target,
+		strings.Join(ctx.Owners, listSeparator), ctx.Notes,
+	}
+}
+
+// ADR is the stable representation of an architecture decision record. IDs use the
+// ADR-0001 form; Content is only set by `adr view`.
+type ADR struct {
+	ID           string   `json:"id" yaml:"id"`
+	Number       int      `json:"number" yaml:"number"`
+	UID          string   `json:"uid" yaml:"uid"`
+	Title        string   `json:"title" yaml:"title"`
+	Status       string   `json:"status" yaml:"status"`
+	Date         string   `json:"date" yaml:"date"`
+	Tags         []string `json:"tags" yaml:"tags"`
+	Supersedes   []string `json:"supersedes" yaml:"supersedes"`
+	SupersededBy string   `json:"superseded_by" yaml:"superseded_by"`
+	Links        []string `json:"links" yaml:"links"`
+	Path         string   `json:"path" yaml:"path"`
+	Content      string   `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// FromADR converts an ADR, making its path relative to baseDir.
+func FromADR(record adr.ADR, baseDir string) ADR {
+	supersedes := make([]string, 0, len(record.Supersedes))
+	for _, id := range record.Supersedes {
+		supersedes = append(supersedes, adrID(id))
+	}
+	supersededBy := ""
+	if record.SupersededBy > 0 {
+		supersededBy = adrID(record.SupersededBy)
+	}
+	return ADR{
+		ID:           adrID(record.ID),
+		Number:       record.ID,
+		UID:          record.UID,
+		Title:        record.Title,
+		Status:       record.Status,
+		Date:         formatDate(record.Date.IsZero(), record.Date.Format),
+		Tags:         nonNil(record.Tags),
+		Supersedes:   supersedes,
+		SupersededBy: supersededBy,
+		Links:        nonNil(record.Links),
+		Path:         relativePath(baseDir, record.FilePath),
+		Content:      record.Content,
+	}
+}
+
+// FromADRs converts ADRs for list output, leaving out their content.
+func FromADRs(records []adr.ADR, baseDir string) []ADR {
+	out := make([]ADR, 0, len(records))
+	for _, record := range records {
+		converted := FromADR(record, baseDir)
+		converted.Content = ""
+		out = append(out, converted)
+	}
+	return out
+}
+
+// CSVHeader implements Record.
+func (ADR) CSVHeader() []string {
+	return []string{
+		"id", "number", "uid", "title", "status", "date", "tags", "supersedes",
+		"superseded_by", "links", "path", "content",
+	}
+}
+
+// CSVRow implements Record.
+func (a ADR) CSVRow() []string {
+	return []string{
+		a.ID, strconv.Itoa(a.Number), a.UID, a.Title, a.Status, a.Date,
+		strings.Join(a.Tags, listSeparator), strings.Join(a.Supersedes, listSeparator),
+		a.SupersededBy, strings.Join(a.Links, listSeparator), a.Path, a.Content,
+	}
+}
+
+// WikiPage is the stable representation of a wiki page. Content is only set by `wiki view`.
+type WikiPage struct {
+	Slug    string   `json:"slug" yaml:"slug"`
+	Title   string   `json:"title" yaml:"title"`
+	Section string   `json:"section" yaml:"section"`
+	Order   int      `json:"order" yaml:"order"`
+	Tags    []string `json:"tags" yaml:"tags"`
+	Status  string   `json:"status" yaml:"status"`
+	Path    string   `json:"path" yaml:"path"`
+	Content string   `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// FromWikiPage converts a page whose slug has already been resolved, making its path
+// relative to baseDir.
+func FromWikiPage(page wiki.Page, slug, baseDir string) WikiPage {
+	return WikiPage{
+		Slug:    slug,
+		Title:   page.Title,
+		Section: page.Section,
+		Order:   page.Order,
+		Tags:    nonNil(page.Tags),
+		Status:  page.Status,
+		Path:    relativePath(baseDir, page.FilePath),
+		Content: page.Content,
+	}
+}
+
+// CSVHeader implements Record.
+func (WikiPage) CSVHeader() []string {
+	return []string{"slug", "title", "section", "order", "tags", "status", "path", "content"}
+}
+
+// CSVRow implements Record.
+func (p WikiPage) CSVRow() []string {
+	return []string{
+		p.Slug, p.Title, p.Section, strconv.Itoa(p.Order),
+		strings.Join(p.Tags, listSeparator), p.Status, p.Path, p.Content,
+	}
+}
+
+// BurndownPoint is one day of a board burndown, in the board's estimate unit.
+type BurndownPoint struct {
+	Board     string  `json:"board" yaml:"board"`
+	Unit      string  `json:"unit" yaml:"unit"`
+	Date      string  `json:"date" yaml:"date"`
+	Scope     float64 `json:"scope" yaml:"scope"`
+	Done      float64 `json:"done" yaml:"done"`
+	Remaining float64 `json:"remaining" yaml:"remaining"`
+}
+
+// FromBurndown converts the points of a board burndown.
+func FromBurndown(points []board.BurndownPoint, boardID, unit string) []BurndownPoint {
+	records := make([]BurndownPoint, 0, len(points))
+	for _, point := range points {
+		records = append(records, BurndownPoint{
+			Board:     boardID,
+			Unit:      unit,
+			Date:      point.Date.Format("2006-01-02"),
+			Scope:     point.Scope,
+			Done:      point.Done,
+			Remaining: point.Remaining,
+		})
+	}
+	return records
+}
+
+// CSVHeader implements Record.
+func (BurndownPoint) CSVHeader() []string {
+	return []string{"board", "unit", "date", "scope", "done", "remaining"}
+}
+
+// CSVRow implements Record.
+func (p BurndownPoint) CSVRow() []string {
+	return []string{p.Board, p.Unit, p.Date, formatFloat(p.Scope), formatFloat(p.Done), formatFloat(p.Remaining)}
+}
+
+// SearchHit is one result of `search`.
+type SearchHit struct {
+	Type    string `json:"type" yaml:"type"`
+	ID      string `json:"id" yaml:"id"`
+	Title   string `json:"title" yaml:"title"`
+	Status  string `json:"status" yaml:"status"`
+	Board   string `json:"board" yaml:"board"`
+	Path    string `json:"path" yaml:"path"`
+	Line    int    `json:"line" yaml:"line"`
+	Snippet string `json:"snippet" yaml:"snippet"`
+	Score   int    `json:"score" yaml:"score"`
+}
+
+// FromSearchHits converts search hits, making their paths relative to baseDir.
+func FromSearchHits(hits []search.Hit, baseDir string) []SearchHit {
+	records := make([]SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		records = append(records, SearchHit{
+			Type:    hit.Type,
+			ID:      hit.ID,
+			Title:   hit.Title,
+			Status:  hit.Status,
+			Board:   hit.Board,
+			Path:    relativePath(baseDir, hit.Path),
+			Line:    hit.Line,
+			Snippet: hit.Snippet,
+			Score:   hit.Score,
+		})
+	}
+	return records
+}
+
+// CSVHeader implements Record.
+func (SearchHit) CSVHeader() []string {
+	return []string{"type", "id", "title", "status", "board", "path", "line", "snippet", "score"}
+}
+
+// CSVRow implements Record.
+func (h SearchHit) CSVRow() []string {
+	return []string{
+		h.Type, h.ID, h.Title, h.Status, h.Board, h.Path, strconv.Itoa(h.Line), h.Snippet,
+		strconv.Itoa(h.Score),
+	}
+}
+
+// Status is a status key of a board or of the ADRs, with its column title.
+type Status struct {
+	Key   string `json:"key" yaml:"key"`
+	Title string `json:"title" yaml:"title"`
+}
+
+// CSVHeader implements Record.
+func (Status) CSVHeader() []string {
+	return []string{"key", "title"}
+}
+
+// CSVRow implements Record.
+func (s Status) CSVRow() []string {
+	return []string{s.Key, s.Title}
+}
+
+// WikiSection is the stable representation of a wiki index section. The root section has
+// an empty slug.
+type WikiSection struct {
+	Slug      string   `json:"slug" yaml:"slug"`
+	Title     string   `json:"title" yaml:"title"`
+	Order     int      `json:"order" yaml:"order"`
+	Tags      []string `json:"tags" yaml:"tags"`
+	DependsOn []string `json:"depends_on" yaml:"depends_on"`
+	RelatedTo []string `json:"related_to" yaml:"related_to"`
+	Pages     []string `json:"pages" yaml:"pages"`
+}
+
+// FromWikiSection converts a section of the wiki index; pages are fully qualified slugs.
+func FromWikiSection(section wiki.IndexSection) WikiSection {
+	return WikiSection{
+		Slug:      strings.TrimSpace(section.Slug),
+		Title:     section.Title,
+		Order:     section.Order,
+		Tags:      nonNil(section.Tags),
+		DependsOn: nonNil(section.Links.DependsOn),
+		RelatedTo: nonNil(section.Links.RelatedTo),
+		Pages:     nonNil(section.ListPages()),
+	}
+}
+
+// CSVHeader implements Record.
+func (WikiSection) CSVHeader() []string {
+	return []string{"slug", "title", "order", "tags", "depends_on", "related_to", "pages"}
+}
+
+// CSVRow implements Record.
+func (s WikiSection) CSVRow() []string {
+	return []string{
+		s.Slug, s.Title, strconv.Itoa(s.Order), strings.Join(s.Tags, listSeparator),
+		strings.Join(s.DependsOn, listSeparator), strings.Join(s.RelatedTo, listSeparator),
+		strings.Join(s.Pages, listSeparator),
+	}
+}
+
+// WikiRevision is the stable representation of a commit that changed a wiki page. Path is
+// relative to the git working tree root; Diff is only set with --patch.
+type WikiRevision struct {
+	Slug    string `json:"slug" yaml:"slug"`
+	Commit  string `json:"commit" yaml:"commit"`
+	Author  string `json:"author" yaml:"author"`
+	Date    string `json:"date" yaml:"date"`
+	Subject string `json:"subject" yaml:"subject"`
+	Path    string `json:"path" yaml:"path"`
+	Deleted bool   `json:"deleted" yaml:"deleted"`
+	Diff    string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// FromWikiRevision converts a revision of the page slug.
+func FromWikiRevision(slug string, revision wiki.Revision) WikiRevision {
+	return WikiRevision{
+		Slug:    slug,
+		Commit:  revision.Commit.Hash,
+		Author:  revision.Commit.Author,
+		Date:    revision.Commit.Date.Format(time.RFC3339),
+		Subject: revision.Commit.Subject,
+		Path:    revision.Path,
+		Deleted: revision.Deleted,
+	}
+}
+
+// CSVHeader implements Record.
+func (WikiRevision) CSVHeader() []string {
+	return []string{"slug", "commit", "author", "date", "subject", "path", "deleted", "diff"}
+}
+
+// CSVRow implements Record.
+func (r WikiRevision) CSVRow() []string {
+	return []string{r.Slug, r.Commit, r.Author, r.Date, r.Subject, r.Path, strconv.FormatBool(r.Deleted), r.Diff}
+}
+
+// WikiChange is the stable representation of a commit that changed wiki pages.
+type WikiChange struct {
+	Commit  string   `json:"commit" yaml:"commit"`
+	Author  string   `json:"author" yaml:"author"`
+	Date    string   `json:"date" yaml:"date"`
+	Subject string   `json:"subject" yaml:"subject"`
+	Pages   []string `json:"pages" yaml:"pages"`
+}
+
+// FromWikiChanges converts the recent changes to the wiki.
+func FromWikiChanges(changes []wiki.Change) []WikiChange {
+	records := make([]WikiChange, 0, len(changes))
+	for _, change := range changes {
+		records = append(records, WikiChange{
+			Commit:  change.Commit.Hash,
+			Author:  change.Commit.Author,
+			Date:    change.Commit.Date.Format(time.RFC3339),
+			Subject: change.Commit.Subject,
+			Pages:   nonNil(change.Slugs),
+		})
+	}
+	return records
+}
+
+// CSVHeader implements Record.
+func (WikiChange) CSVHeader() []string {
+	return []string{"commit", "author", "date", "subject", "pages"}
+}
+
+// CSVRow implements Record.
+func (c WikiChange) CSVRow() []string {
+	return []string{c.Commit, c.Author, c.Date, c.Subject, strings.Join(c.Pages, listSeparator)}
+}
+
+// Sprint is the stable representation of a sprint. The summary fields stay empty until the
+// sprint is closed; Tasks is only set by `sprint show` and is left out of CSV.
+type Sprint struct {
+	Board             string   `json:"board" yaml:"board"`
+	ID                string   `json:"id" yaml:"id"`
+	Name              string   `json:"name" yaml:"name"`
+	Goal              string   `json:"goal" yaml:"goal"`
+	State             string   `json:"state" yaml:"state"`
+	Start             string   `json:"start" yaml:"start"`
+	End               string   `json:"end" yaml:"end"`
+	Closed            string   `json:"closed" yaml:"closed"`
+	Committed         int      `json:"committed" yaml:"committed"`
+	Completed         int      `json:"completed" yaml:"completed"`
+	CommittedEstimate float64  `json:"committed_estimate" yaml:"committed_estimate"`
+	Velocity          float64  `json:"velocity" yaml:"velocity"`
+	CompletedTasks    []string `json:"completed_tasks" yaml:"completed_tasks"`
+	RolledOver        []string `json:"rolled_over" yaml:"rolled_over"`
+	RolledTo          string   `json:"rolled_to" yaml:"rolled_to"`
+	Tasks             []Task   `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+}
+
+// FromSprint converts a sprint of the given board.
+func FromSprint(sprint board.Sprint, boardID string) Sprint {
+	record := Sprint{
+		Board:          boardID,
+		ID:             sprint.ID,
+		Name:           sprint.Name,
+		Goal:           sprint.Goal,
+		State:          sprint.State,
+		Start:          formatDate(sprint.Start.Time.IsZero(), sprint.Start.Format),
+		End:            formatDate(sprint.End.Time.IsZero(), sprint.End.Format),
+		CompletedTasks: []string{},
+		RolledOver:     []string{},
+	}
+	if summary := sprint.Summary; summary != nil {
+		record.Closed = formatDate(summary.Closed.Time.IsZero(), summary.Closed.Format)
+		record.Committed = summary.Committed
+		record.Completed = summary.Completed
+		record.CommittedEstimate = summary.CommittedEstimate
+		record.Velocity = summary.Velocity
+		record.CompletedTasks = nonNil(summary.CompletedTasks)
+		record.RolledOver = nonNil(summary.RolledOver)
+		record.RolledTo = summary.RolledTo
+	}
+	return record
+}
+
+// FromSprints converts the sprints of a board.
+func FromSprints(sprints []board.Sprint, boardID string) []Sprint {
+	records := make([]Sprint, 0, len(sprints))
+	for _, sprint := range sprints {
+		records = append(records, FromSprint(sprint, boardID))
+	}
+	return records
+}
+
+// CSVHeader implements Record.
+func (Sprint) CSVHeader() []string {
+	return []string{
+		"board", "id", "name", "goal", "state", "start", "end", "closed", "committed", "completed",
+		"committed_estimate", "velocity", "completed_tasks", "rolled_over", "rolled_to",
+	}
+}
+
+// CSVRow implements Record.
+func (s Sprint) CSVRow() []string {
+	return []string{
+		s.Board, s.ID, s.Name, s.Goal, s.State, s.Start, s.End, s.Closed,
+		strconv.Itoa(s.Committed), strconv.Itoa(s.Completed), formatFloat(s.CommittedEstimate),
+		formatFloat(s.Velocity), strings.Join(s.CompletedTasks, listSeparator),
+		strings.Join(s.RolledOver, listSeparator), s.RolledTo,
+	}
+}
+
+// View is the stable representation of a saved view. Tasks is only set by `view show` and is
+// left out of CSV.
+type View struct {
+	Board   string   `json:"board" yaml:"board"`
+	Name    string   `json:"name" yaml:"name"`
+	Query   string   `json:"query" yaml:"query"`
+	Sort    string   `json:"sort" yaml:"sort"`
+	Columns []string `json:"columns" yaml:"columns"`
+	GroupBy string   `json:"group_by" yaml:"group_by"`
+	Tasks   []Task   `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+}
+
+// FromView converts a saved view of the given board.
+func FromView(view board.View, boardID string) View {
+	return View{
+		Board:   boardID,
+		Name:    view.Name,
+		Query:   view.Query,
+		Sort:    view.Sort,
+		Columns: nonNil(view.Columns),
+		GroupBy: view.GroupBy,
+	}
+}
+
+// FromViews converts the saved views of a board.
+func FromViews(views []board.View, boardID string) []View {
+	records := make([]View, 0, len(views))
+	for _, view := range views {
+		records = append(records, FromView(view, boardID))
+	}
+	return records
+}
+
+// CSVHeader implements Record.
+func (View) CSVHeader() []string {
+	return []string{"board", "name", "query", "sort", "columns", "group_by"}
+}
+
+// CSVRow implements Record.
+func (v View) CSVRow() []string {
+	return []string{v.Board, v.Name, v.Query, v.Sort, strings.Join(v.Columns, listSeparator), v.GroupBy}
+}
+
+// BoardHealth is the CSV row of one board in a `status` report; JSON and YAML carry the
+// whole report instead.
+type BoardHealth struct {
+	Board     string
+	Name      string
+	Tasks     int
+	Open      int
+	WIP       int
+	Ready     int
+	Blocked   int
+	BlockedP1 int
+	Overdue   int
+}
+
+// FromStatusReport flattens a status report into one row per board.
+func FromStatusReport(report status.Report) []BoardHealth {
+	rows := make([]BoardHealth, 0, len(report.Boards))
+	for _, item := range report.Boards {
+		rows = append(rows, BoardHealth{
+			Board:     item.ID,
+			Name:      item.Name,
+			Tasks:     item.Total,
+			Open:      item.Open,
+			WIP:       item.WIP,
+			Ready:     item.Ready,
+			Blocked:   item.Blocked,
+			BlockedP1: item.BlockedP1,
+			Overdue:   len(item.Overdue),
+		})
+	}
+	return rows
+}
+
+// CSVHeader implements Record.
+func (BoardHealth) CSVHeader() []string {
+	return []string{"board", "name", "tasks", "open", "wip", "ready", "blocked", "blocked_p1", "overdue"}
+}
+
+// CSVRow implements Record.
+func (h BoardHealth) CSVRow() []string {
+	return []string{
+		h.Board, h.Name, strconv.Itoa(h.Tasks), strconv.Itoa(h.Open), strconv.Itoa(h.WIP),
+		strconv.Itoa(h.Ready), strconv.Itoa(h.Blocked), strconv.Itoa(h.BlockedP1), strconv.Itoa(h.Overdue),
+	}
+}
+
+// ReleaseItem is the CSV row of one task or ADR in `release notes`; JSON and YAML carry the
+// whole report instead. ADRs are listed under the "Decisions" group.
+type ReleaseItem struct {
+	Group string
+	Type  string
+	ID    string
+	Title string
+	Board string
+	Tags  []string
+	Date  string
+}
+
+// FromReleaseNotes flattens grouped release tasks and accepted ADRs into one row each.
+func FromReleaseNotes(groups []release.Group, adrs []release.ADR) []ReleaseItem {
+	rows := make([]ReleaseItem, 0, len(adrs))
+	for _, group := range groups {
+		for _, task := range group.Tasks {
+			rows = append(rows, ReleaseItem{
+				Group: group.Title,
+				Type:  "task",
+				ID:    task.ID,
+				Title: task.Title,
+				Board: task.BoardID,
+				Tags:  task.Tags,
+				Date:  task.Released.Format(time.RFC3339),
+			})
+		}
+	}
+	for _, record := range adrs {
+		rows = append(rows, ReleaseItem{
+			Group: "Decisions",
+			Type:  "adr",
+			ID:    record.ID,
+			Title: record.Title,
+			Tags:  record.Tags,
+			Date:  record.Accepted.Format(time.RFC3339),
+		})
+	}
+	return rows
+}
+
+// CSVHeader implements Record.
+func (ReleaseItem) CSVHeader() []string {
+	return []string{"group", "type", "id", "title", "board", "tags", "date"}
+}
+
+// CSVRow implements Record.
+func (i ReleaseItem) CSVRow() []string {
+	return []string{i.Group, i.Type, i.ID, i.Title, i.Board, strings.Join(i.Tags, listSeparator), i.Date}
+}
+
+// TodoReference is the CSV row of one task reference in `scan todos`; JSON and YAML carry the
+// whole report, untracked comments included.
+type TodoReference struct {
+	Path       string
+	Line       int
+	Marker     string
+	Task       string
+	Status     string
+	TaskStatus string
+	Board      string
+	Archived   bool
+	Text       string
+}
+
+// FromTodoReferences converts the task references of a scan.
+func FromTodoReferences(references []scan.Reference) []TodoReference {
+	rows := make([]TodoReference, 0, len(references))
+	for _, reference := range references {
+		rows = append(rows, TodoReference{
+			Path:       reference.Path,
+			Line:       reference.Line,
+			Marker:     reference.Marker,
+			Task:       reference.TaskID,
+			Status:     reference.Status,
+			TaskStatus: reference.TaskStatus,
+			Board:      reference.BoardID,
+			Archived:   reference.Archived,
+			Text:       reference.Text,
+		})
+	}
+	return rows
+}
+
+// CSVHeader implements Record.
+func (TodoReference) CSVHeader() []string {
+	return []string{"path", "line", "marker", "task", "status", "task_status", "board", "archived", "text"}
+}
+
+// CSVRow implements Record.
+func (r TodoReference) CSVRow() []string {
+	return []string{
+		r.Path, strconv.Itoa(r.Line), r.Marker, r.Task, r.Status, r.TaskStatus, r.Board,
+		strconv.FormatBool(r.Archived), r.Text,
+	}
+}
+
+// TaskChange is the CSV row of one field change in `task history`; JSON and YAML carry the
+// whole timeline instead.
+type TaskChange struct {
+	Task    string
+	Date    string
+	Commit  string
+	Author  string
+	Created bool
+	Field   string
+	From    string
+	To      string
+}
+
+// FromTaskHistories flattens task timelines into one row per changed field.
+func FromTaskHistories(histories []board.TaskHistory) []TaskChange {
+	rows := make([]TaskChange, 0)
+	for _, history := range histories {
+		for _, change := range history.Changes {
+			for _, field := range change.Changes {
+				rows = append(rows, TaskChange{
+					Task:    history.ID,
+					Date:    change.Commit.Date.Format(time.RFC3339),
+					Commit:  change.Commit.Hash,
+					Author:  change.Commit.Author,
+					Created: change.Created,
+					Field:   field.Field,
+					From:    field.From,
+					To:      field.To,
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// CSVHeader implements Record.
+func (TaskChange) CSVHeader() []string {
+	return []string{"task", "date", "commit", "author", "created", "field", "from", "to"}
+}
+
+// CSVRow implements Record.
+func (c TaskChange) CSVRow() []string {
+	return []string{c.Task, c.Date, c.Commit, c.Author, strconv.FormatBool(c.Created), c.Field, c.From, c.To}
+}
+
+func adrID(id int) string {
+	return "ADR-" + adr.FormatID(id)
+}
+
+func formatDate(zero bool, format func(string) string) string {
+	if zero {
+		return ""
+	}
+	return format("2006-01-02")
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func joinFields(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+fields[key])
+	}
+	return strings.Join(pairs, listSeparator)
+}
+
+// relativePath reports path relative to baseDir with forward slashes, or unchanged when it
+// lies outside baseDir.
+func relativePath(baseDir, path string) string {
+	if path == "" || baseDir == "" {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,4 @@
+// Package release collects what shipped between two points in time, tasks that reached done
+// or were archived and ADRs that were accepted, and renders them as release notes from the
+// release-notes wiki template. It backs the `release notes` command.
+package release
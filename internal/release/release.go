@@ -0,0 +1,252 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/git"
+)
+
+// FieldName is the custom task field Stamp records the release name in.
+const FieldName = "release"
+
+// acceptedStatus is the ADR status that counts as a decision taken.
+const acceptedStatus = "accepted"
+
+var datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// Sources points the release notes at the stores to read. Empty Boards selects every
+// non-archived board; an empty ADRRoot skips ADRs.
+type Sources struct {
+	BaseDir     string
+	StorageRoot string
+	ADRRoot     string
+	Boards      []string
+}
+
+// Options bounds the release window: Since is inclusive and Until exclusive. NoGit skips the
+// git history and relies on frontmatter dates only.
+type Options struct {
+	Since time.Time
+	Until time.Time
+	NoGit bool
+}
+
+// Task is a task that reached done or was archived within the window.
+type Task struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	BoardID   string    `json:"board_id"`
+	BoardName string    `json:"board_name"`
+	Tags      []string  `json:"tags"`
+	Released  time.Time `json:"released"`
+	Archived  bool      `json:"archived,omitempty"`
+}
+
+// ADR is a decision accepted within the window.
+type ADR struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Tags     []string  `json:"tags"`
+	Accepted time.Time `json:"accepted"`
+}
+
+// Notes is everything that shipped within a release window.
+type Notes struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	Tasks []Task    `json:"tasks"`
+	ADRs  []ADR     `json:"adrs"`
+}
+
+// ResolveTime turns a release bound into a point in time. A YYYY-MM-DD date names the
+// midnight (UTC) that starts it, or the one that ends it when end is set; anything else is a
+// git revision of the working tree containing dir (such as the storage root) and names the
+// second after its commit, so the commit itself falls before the bound.
+func ResolveTime(ctx context.Context, dir, value string, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if datePattern.MatchString(value) {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("release: invalid date %q: %w", value, err)
+		}
+		if end {
+			date = date.AddDate(0, 0, 1)
+		}
+		return date, nil
+	}
+	repo, err := git.Open(ctx, dir)
+	if err != nil {
+		return time.Time{}, err
+	}
+	commit, err := repo.CommitOf(ctx, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return commit.Date.Add(time.Second), nil
+}
+
+// Collect gathers the tasks of the selected boards that entered a done status or moved into
+// archive/tasks within the window, and the ADRs accepted within it. Status changes come from
+// the git history of the task and ADR files; outside a git working tree (or with NoGit) tasks
+// fall back to their completed and status_changed dates and ADRs to their date.
+func Collect(ctx context.Context, sources Sources, opts Options) (Notes, error) {
+	if opts.Since.IsZero() || opts.Until.IsZero() {
+		return Notes{}, fmt.Errorf("release: since and until are required")
+	}
+	if !opts.Until.After(opts.Since) {
+		return Notes{}, fmt.Errorf("release: until %s is not after since %s",
+			opts.Until.Format(time.RFC3339), opts.Since.Format(time.RFC3339))
+	}
+	notes := Notes{Since: opts.Since, Until: opts.Until, Tasks: []Task{}, ADRs: []ADR{}}
+	tasks, err := collectTasks(ctx, sources, opts)
+	if err != nil {
+		return Notes{}, err
+	}
+	notes.Tasks = tasks
+	if strings.TrimSpace(sources.ADRRoot) != "" {
+		if notes.ADRs, err = collectADRs(ctx, sources.ADRRoot, opts); err != nil {
+			return Notes{}, err
+		}
+	}
+	return notes, nil
+}
+
+func collectTasks(ctx context.Context, sources Sources, opts Options) ([]Task, error) {
+	boardRepo, err := board.NewBoardRepositoryWithStorage(sources.BaseDir, sources.StorageRoot)
+	if err != nil {
+		return nil, err
+	}
+	boards, err := boardRepo.SelectBoardsContext(ctx, sources.Boards, len(sources.Boards) == 0)
+	if err != nil {
+		return nil, err
+	}
+	released := make([]Task, 0)
+	for _, entry := range boards {
+		repo, err := board.NewRepositoryForBoardWithStorage(sources.BaseDir, entry.ID, sources.StorageRoot)
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		archived, err := repo.ListArchivedTasksContext(ctx)
+		if err != nil && !errors.Is(err, board.ErrStoreNotInitialized) {
+			return nil, err
+		}
+		histories := map[string][]board.TaskChange{}
+		if !opts.NoGit {
+			all, err := repo.TaskHistoriesContext(ctx)
+			if err != nil && !errors.Is(err, git.ErrNotRepository) {
+				return nil, err
+			}
+			for _, history := range all {
+				histories[history.ID] = history.Changes
+			}
+		}
+		for _, item := range board.ReleasedTasks(tasks, board.ReleaseWindowOptions{
+			From:      opts.Since,
+			To:        opts.Until,
+			Archived:  archived,
+			Histories: histories,
+		}) {
+			released = append(released, Task{
+				ID:        item.Task.ID,
+				Title:     item.Task.Title,
+				BoardID:   entry.ID,
+				BoardName: entry.Name,
+				Tags:      append([]string{}, item.Task.Tags...),
+				Released:  item.At,
+				Archived:  item.Archived,
+			})
+		}
+	}
+	sort.SliceStable(released, func(i, j int) bool { return released[i].Released.Before(released[j].Released) })
+	return released, nil
+}
+
+func collectADRs(ctx context.Context, root string, opts Options) ([]ADR, error) {
+	repo, err := adr.NewRepository(root)
+	if err != nil {
+		return nil, err
+	}
+	records, err := repo.ListADRsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	histories := map[int][]adr.StatusChange{}
+	if !opts.NoGit {
+		histories, err = repo.StatusHistoriesContext(ctx)
+		if err != nil && !errors.Is(err, git.ErrNotRepository) {
+			return nil, err
+		}
+	}
+	accepted := make([]ADR, 0)
+	for _, record := range records {
+		at := acceptedAt(record, histories[record.ID], opts)
+		if at.IsZero() {
+			continue
+		}
+		accepted = append(accepted, ADR{
+			ID:       "ADR-" + adr.FormatID(record.ID),
+			Title:    record.Title,
+			Tags:     append([]string{}, record.Tags...),
+			Accepted: at,
+		})
+	}
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].Accepted.Before(accepted[j].Accepted) })
+	return accepted, nil
+}
+
+// acceptedAt returns the last time within the window the ADR moved into the accepted status,
+// or the zero time. Without history an accepted ADR counts as accepted on its date.
+func acceptedAt(record adr.ADR, history []adr.StatusChange, opts Options) time.Time {
+	inWindow := func(at time.Time) bool {
+		return !at.IsZero() && !at.Before(opts.Since) && at.Before(opts.Until)
+	}
+	var at time.Time
+	if len(history) == 0 {
+		if strings.EqualFold(record.Status, acceptedStatus) && inWindow(record.Date.Time) {
+			at = record.Date.Time
+		}
+		return at
+	}
+	for _, change := range history {
+		if strings.EqualFold(change.Status, acceptedStatus) && inWindow(change.Commit.Date) {
+			at = change.Commit.Date
+		}
+	}
+	return at
+}
+
+// Stamp records name in the release field of every task in notes.
+func Stamp(ctx context.Context, sources Sources, notes Notes, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("release: a release name is required to stamp tasks")
+	}
+	repos := make(map[string]*board.Repository)
+	for _, task := range notes.Tasks {
+		repo, ok := repos[task.BoardID]
+		if !ok {
+			var err error
+			repo, err = board.NewRepositoryForBoardWithStorage(sources.BaseDir, task.BoardID, sources.StorageRoot)
+			if err != nil {
+				return err
+			}
+			repos[task.BoardID] = repo
+		}
+		if err := repo.SetTaskFieldContext(ctx, task.ID, FieldName, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package release
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/templates"
+	"mochi-sticky/internal/testutil"
+	"mochi-sticky/internal/wiki"
+)
+
+func day(value string) time.Time {
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func setupSources(t *testing.T) Sources {
+	t.Helper()
+
+	storage := testutil.NewStorage(t)
+	storage.AddTasks(t,
+		board.Task{Title: "Fix login", Status: "done", Tags: []string{"bug", "auth"}, Completed: board.Date{Time: day("2026-10-05")}},
+		board.Task{Title: "Add SSO", Status: "done", Tags: []string{"feature"}, Completed: board.Date{Time: day("2026-10-06")}},
+		board.Task{Title: "Old fix", Status: "done", Tags: []string{"bug"}, Completed: board.Date{Time: day("2026-09-01")}},
+		board.Task{Title: "Write docs", Status: "todo"},
+	)
+	storage.AddADR(t, "Adopt Redis", adr.CreateOptions{Status: "accepted", Date: day("2026-10-02")})
+	storage.AddADR(t, "Drop MySQL", adr.CreateOptions{Status: "proposed", Date: day("2026-10-02")})
+	return Sources{BaseDir: storage.BaseDir, StorageRoot: storage.StorageRoot, ADRRoot: storage.ADRRoot}
+}
+
+func TestCollectFindsReleasedTasksAndAcceptedADRs(t *testing.T) {
+	// Arrange
+	sources := setupSources(t)
+
+	// Act
+	notes, err := Collect(context.Background(), sources, Options{Since: day("2026-10-01"), Until: day("2026-10-08"), NoGit: true})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(notes.Tasks) != 2 || notes.Tasks[0].Title != "Fix login" || notes.Tasks[1].Title != "Add SSO" {
+		t.Fatalf("expected the two tasks completed in the window, got %+v", notes.Tasks)
+	}
+	if notes.Tasks[0].BoardID == "" {
+		t.Fatalf("expected tasks to carry their board, got %+v", notes.Tasks[0])
+	}
+	if len(notes.ADRs) != 1 || notes.ADRs[0].Title != "Adopt Redis" || !strings.HasPrefix(notes.ADRs[0].ID, "ADR-") {
+		t.Fatalf("expected the accepted ADR, got %+v", notes.ADRs)
+	}
+}
+
+func TestRenderFillsReleaseNotesTemplate(t *testing.T) {
+	// Arrange
+	data, err := templates.Bundled("wiki/release-notes.md")
+	if err != nil {
+		t.Fatalf("bundled template: %v", err)
+	}
+	template, err := wiki.ParsePage(data)
+	if err != nil {
+		t.Fatalf("parse template: %v", err)
+	}
+	notes := Notes{
+		Tasks: []Task{
+			{ID: "T-000001", Title: "Fix login", BoardID: "main", Tags: []string{"bug", "auth"}},
+			{ID: "T-000002", Title: "Add SSO", BoardID: "main", Tags: []string{"feature"}},
+			{ID: "T-000003", Title: "Tidy", BoardID: "main"},
+		},
+		ADRs: []ADR{{ID: "ADR-0001", Title: "Adopt Redis"}},
+	}
+
+	// Act
+	groups := Groups(notes, GroupByTag, []string{"feature"})
+	page := Render(template, "v1.2.0", notes, groups)
+
+	// Assert
+	if page.Title != "Release Notes: v1.2.0" || !strings.HasPrefix(page.Slug, "releases/") {
+		t.Fatalf("unexpected title or slug: %q %q", page.Title, page.Slug)
+	}
+	expected := "## Changes\n\n### feature\n- Add SSO (T-000002)\n\n### bug\n- Fix login (T-000001)\n\n### (untagged)\n- Tidy (T-000003)\n\n" +
+		"## Decisions\n\n- ADR-0001 Adopt Redis\n\n## Migration Notes\n"
+	if !strings.Contains(page.Content, expected) {
+		t.Fatalf("expected grouped changes and decisions, got:\n%s", page.Content)
+	}
+	if !strings.Contains(page.Content, "# Release Notes: v1.2.0") || !strings.Contains(page.Content, "## Highlights") {
+		t.Fatalf("expected the rest of the template to be kept, got:\n%s", page.Content)
+	}
+}
+
+func TestGroupsByBoard(t *testing.T) {
+	// Arrange
+	notes := Notes{Tasks: []Task{
+		{ID: "T-1", BoardID: "web", BoardName: "Web"},
+		{ID: "T-2", BoardID: "api", BoardName: "API"},
+		{ID: "T-3", BoardID: "web", BoardName: "Web"},
+	}}
+
+	// Act
+	groups := Groups(notes, GroupByBoard, nil)
+
+	// Assert
+	if len(groups) != 2 || groups[0].Title != "Web" || len(groups[0].Tasks) != 2 || groups[1].Title != "API" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
@@ -0,0 +1,189 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/wiki"
+)
+
+const (
+	// GroupByTag groups tasks under their first tag.
+	GroupByTag = "tag"
+	// GroupByBoard groups tasks under their board.
+	GroupByBoard = "board"
+)
+
+// DefaultName stands in for the release name when none is given.
+const DefaultName = "Unreleased"
+
+// Group is a heading of the release notes and the tasks listed under it.
+type Group struct {
+	Title string `json:"title"`
+	Tasks []Task `json:"tasks"`
+}
+
+// ParseGroupBy validates a --group-by value; empty means GroupByTag.
+func ParseGroupBy(value string) (string, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(value)); normalized {
+	case "", GroupByTag:
+		return GroupByTag, nil
+	case GroupByBoard:
+		return GroupByBoard, nil
+	default:
+		return "", fmt.Errorf("release: invalid group %q (expected tag or board)", value)
+	}
+}
+
+// Groups splits the tasks of notes into headings. By tag, a task with several tags lands in
+// the first tag listed in order, falling back to its first tag, the way board lanes do; groups
+// follow order, then the remaining tags sorted, with untagged tasks last. By board, groups
+// follow the order boards were collected in.
+func Groups(notes Notes, groupBy string, order []string) []Group {
+	if groupBy == GroupByBoard {
+		groups := make([]Group, 0)
+		index := make(map[string]int)
+		for _, task := range notes.Tasks {
+			i, ok := index[task.BoardID]
+			if !ok {
+				title := task.BoardName
+				if strings.TrimSpace(title) == "" {
+					title = task.BoardID
+				}
+				i = len(groups)
+				index[task.BoardID] = i
+				groups = append(groups, Group{Title: title})
+			}
+			groups[i].Tasks = append(groups[i].Tasks, task)
+		}
+		return groups
+	}
+
+	lane := board.LaneGroup{Kind: board.LaneByTag}
+	keyed := make(map[string][]Task)
+	tasks := make([]board.Task, 0, len(notes.Tasks))
+	for _, task := range notes.Tasks {
+		item := board.Task{ID: task.ID, Tags: task.Tags}
+		tasks = append(tasks, item)
+		key := board.LaneKey(item, lane, order)
+		keyed[key] = append(keyed[key], task)
+	}
+	groups := make([]Group, 0, len(keyed))
+	for _, key := range board.LaneKeys(tasks, lane, order) {
+		if len(keyed[key]) == 0 {
+			continue
+		}
+		groups = append(groups, Group{Title: board.LaneTitle(key, lane), Tasks: keyed[key]})
+	}
+	return groups
+}
+
+// Render fills the release-notes template for the release called name. The <Version> and
+// <version> placeholders of the title, slug and body become the name and its slug; the body of
+// the "Changes" section is replaced by the grouped tasks and a "Decisions" section lists the
+// accepted ADRs. Other sections, such as highlights and migration notes, are kept for editing.
+// A template without a "Changes" section gets both sections appended.
+func Render(template wiki.Page, name string, notes Notes, groups []Group) wiki.Page {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultName
+	}
+	replacer := strings.NewReplacer("<Version>", name, "<version>", wiki.Slugify(name))
+	page := template
+	page.Title = replacer.Replace(template.Title)
+	page.Slug = replacer.Replace(template.Slug)
+	page.Tags = append([]string(nil), template.Tags...)
+
+	changes := changesSection(groups)
+	decisions := decisionsSection(notes.ADRs)
+	content := replacer.Replace(template.Content)
+	var replaced bool
+	content, replaced = replaceSection(content, "Changes", changes)
+	if !replaced {
+		content = strings.TrimRight(content, "\n") + "\n\n## Changes\n\n" + changes
+	}
+	if decisions != "" {
+		if content, replaced = replaceSection(content, "Decisions", decisions); !replaced {
+			content = insertAfterSection(content, "Changes", "## Decisions\n\n"+decisions)
+		}
+	}
+	page.Content = strings.TrimLeft(content, "\n")
+	return page
+}
+
+func changesSection(groups []Group) string {
+	if len(groups) == 0 {
+		return "- No tasks were completed in this release.\n"
+	}
+	var b strings.Builder
+	for i, group := range groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "### %s\n", group.Title)
+		for _, task := range group.Tasks {
+			fmt.Fprintf(&b, "- %s (%s)\n", task.Title, task.ID)
+		}
+	}
+	return b.String()
+}
+
+func decisionsSection(adrs []ADR) string {
+	if len(adrs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, record := range adrs {
+		fmt.Fprintf(&b, "- %s %s\n", record.ID, record.Title)
+	}
+	return b.String()
+}
+
+// sectionBounds returns the line range of the body of the level-two section called title:
+// from the line after its heading to the next level-one or level-two heading.
+func sectionBounds(lines []string, title string) (int, int, bool) {
+	start := -1
+	for i, line := range lines {
+		heading, ok := strings.CutPrefix(strings.TrimSpace(line), "## ")
+		if start < 0 {
+			if ok && strings.EqualFold(strings.TrimSpace(heading), title) {
+				start = i + 1
+			}
+			continue
+		}
+		if ok || strings.HasPrefix(strings.TrimSpace(line), "# ") {
+			return start, i, true
+		}
+	}
+	return start, len(lines), start >= 0
+}
+
+// replaceSection swaps the body of the section called title for body.
+func replaceSection(content, title, body string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	start, end, ok := sectionBounds(lines, title)
+	if !ok {
+		return content, false
+	}
+	replacement := append([]string{""}, strings.Split(strings.TrimRight(body, "\n"), "\n")...)
+	if end == len(lines) {
+		out := append(append([]string{}, lines[:start]...), replacement...)
+		return strings.Join(out, "\n") + "\n", true
+	}
+	replacement = append(replacement, "")
+	out := append(append(append([]string{}, lines[:start]...), replacement...), lines[end:]...)
+	return strings.Join(out, "\n"), true
+}
+
+// insertAfterSection adds section (a heading and its body) after the section called title.
+func insertAfterSection(content, title, section string) string {
+	lines := strings.Split(content, "\n")
+	_, end, ok := sectionBounds(lines, title)
+	if !ok || end == len(lines) {
+		return strings.TrimRight(content, "\n") + "\n\n" + section
+	}
+	inserted := append(strings.Split(strings.TrimRight(section, "\n"), "\n"), "")
+	out := append(append(append([]string{}, lines[:end]...), inserted...), lines[end:]...)
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,147 @@
+package scan
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strings"
+
+	"mochi-sticky/internal/git"
+)
+
+// Markers are the words that start a TODO-style comment; they match in upper case only.
+var Markers = []string{"TODO", "FIXME", "HACK", "XXX"}
+
+// commentOpeners start a line or trailing comment in the languages the scan recognizes. Lines
+// continuing a block comment start with "*".
+var commentOpeners = []string{"//", "/*", "#", "<!--", "--"}
+
+// Comment is a TODO-style comment in a source file.
+type Comment struct {
+	// Path is the file's slash-separated path relative to the scanned base directory.
+	Path   string `json:"path"`
+	Line   int    `json:"line"`
+	Marker string `json:"marker"`
+	// TaskIDs are the tasks named right after the marker, e.g. TODO(T-000012).
+	TaskIDs []string `json:"task_ids,omitempty"`
+	Text    string   `json:"text"`
+
+	// file is the OS path of the file and column the byte offset just after the marker.
+	file   string
+	column int
+}
+
+// ParseComments returns the TODO-style comments of data, one per line at most. A marker counts
+// when it is the first word of a comment, after a comment opener (//, /*, #, <!--, --) outside
+// a string literal or at the start of a line continuing a block comment with "*", and is
+// followed by ":", "(", white space or the end of the line, so "TODO-style" in prose does not
+// count. Task IDs are matched with pattern (see git.CompileTaskPattern) and only where they
+// directly follow the marker, as in "TODO(T-000012):" or "TODO T-000012 -", so that words such
+// as SHA-256 later in the comment are not read as references.
+func ParseComments(data []byte, pattern *regexp.Regexp) []Comment {
+	comments := make([]Comment, 0)
+	for i, raw := range bytes.Split(data, []byte("\n")) {
+		line := string(raw)
+		for _, start := range commentStarts(line) {
+			marker, end, ok := leadingMarker(line, start)
+			if !ok {
+				continue
+			}
+			rest := line[end:]
+			ids := git.LeadingTaskRefs(rest, pattern)
+			comments = append(comments, Comment{
+				Line:    i + 1,
+				Marker:  marker,
+				TaskIDs: ids,
+				Text:    commentText(rest, ids),
+				column:  end,
+			})
+			break
+		}
+	}
+	return comments
+}
+
+// commentStarts returns the byte offsets just after each comment opener of line that is not
+// inside a string literal, or the offset after the "*" of a block comment continuation. A
+// quote without a closing quote on the same line, such as an apostrophe in prose, does not
+// start a string.
+func commentStarts(line string) []int {
+	trimmed := strings.TrimLeft(line, " \t")
+	if strings.HasPrefix(trimmed, "*") && !strings.HasPrefix(trimmed, "*/") {
+		return []int{len(line) - len(trimmed) + 1}
+	}
+	starts := make([]int, 0)
+	for i := 0; i < len(line); i++ {
+		switch quote := line[i]; quote {
+		case '"', '\'', '`':
+			if end := closingQuote(line, i+1, quote); end >= 0 {
+				i = end
+			}
+			continue
+		}
+		for _, opener := range commentOpeners {
+			if strings.HasPrefix(line[i:], opener) {
+				starts = append(starts, i+len(opener))
+				i += len(opener) - 1
+				break
+			}
+		}
+	}
+	return starts
+}
+
+// closingQuote returns the index of the quote closing a string literal that starts at from, or
+// -1 when the line has none. Backslashes escape quotes except in raw (backquoted) strings.
+func closingQuote(line string, from int, quote byte) int {
+	for i := from; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && quote != '`':
+			i++
+		case line[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// leadingMarker reports the marker that is the first word of the comment starting at start in
+// line, and the byte offset just after it. Repeated opener characters, as in "///" or "##",
+// are skipped.
+func leadingMarker(line string, start int) (string, int, bool) {
+	text := strings.TrimLeft(line[start:], "/*#!- \t")
+	offset := len(line) - len(text)
+	for _, marker := range Markers {
+		after, ok := strings.CutPrefix(text, marker)
+		if !ok {
+			continue
+		}
+		if after == "" || strings.ContainsRune(":( \t", rune(after[0])) {
+			return marker, offset + len(marker), true
+		}
+	}
+	return "", 0, false
+}
+
+// commentText returns the description after a marker: without a parenthesized owner or task
+// list, the leading task IDs, the separating colon or dash and a closing */ or -->.
+func commentText(rest string, ids []string) string {
+	text := strings.TrimSpace(rest)
+	for _, closer := range []string{"*/", "-->"} {
+		text = strings.TrimSpace(strings.TrimSuffix(text, closer))
+	}
+	if strings.HasPrefix(text, "(") {
+		if end := strings.Index(text, ")"); end >= 0 {
+			text = text[end+1:]
+		}
+	}
+	for {
+		text = strings.TrimLeft(text, ":-, \t")
+		word, after, _ := strings.Cut(text, " ")
+		if !slices.Contains(ids, strings.Trim(word, ".,;:()[]")) {
+			break
+		}
+		text = after
+	}
+	return strings.TrimSpace(strings.TrimLeft(text, ":- \t"))
+}
@@ -0,0 +1,5 @@
+// Package scan finds TODO-style comments in source files and the tasks they reference, such
+// as `// TODO(T-000012): handle retries`. It reports references to tasks that are missing or
+// already done, records code locations on the referenced tasks and turns untracked TODOs into
+// tasks. It backs the `scan todos` command.
+package scan
@@ -0,0 +1,329 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/shared"
+)
+
+// ErrStaleReferences indicates that at least one comment references a missing or done task.
+var ErrStaleReferences = errors.New("stale task references")
+
+const (
+	// StatusOpen marks a reference to a task that is still open.
+	StatusOpen = "open"
+	// StatusDone marks a reference to a task that is done or archived.
+	StatusDone = "done"
+	// StatusMissing marks a reference to a task that exists on none of the scanned boards.
+	StatusMissing = "missing"
+)
+
+// maxFileSize skips generated bundles and data files that are unlikely to hold comments.
+const maxFileSize = 1 << 20
+
+// binarySniffLength is how much of a file is checked for NUL bytes to detect binaries.
+const binarySniffLength = 8000
+
+// Sources points the scan at the tasks that references resolve against. Empty Boards selects
+// every non-archived board.
+type Sources struct {
+	BaseDir     string
+	StorageRoot string
+	Boards      []string
+}
+
+// Options selects what to scan. Paths are files or directories, relative to the base
+// directory; none scans the base directory. Pattern matches task IDs (see
+// git.CompileTaskPattern).
+type Options struct {
+	Paths   []string
+	Pattern *regexp.Regexp
+}
+
+// Reference is a TODO-style comment naming a task, with the state of that task.
+type Reference struct {
+	Path   string `json:"path"`
+	Line   int    `json:"line"`
+	Marker string `json:"marker"`
+	TaskID string `json:"task_id"`
+	Text   string `json:"text"`
+	// Status is StatusOpen, StatusDone or StatusMissing.
+	Status     string `json:"status"`
+	TaskStatus string `json:"task_status,omitempty"`
+	BoardID    string `json:"board_id,omitempty"`
+	Archived   bool   `json:"archived,omitempty"`
+}
+
+// Stale reports whether the reference names a missing or done task.
+func (r Reference) Stale() bool {
+	return r.Status != StatusOpen
+}
+
+// Location returns the reference as path:line.
+func (r Reference) Location() string {
+	return fmt.Sprintf("%s:%d", r.Path, r.Line)
+}
+
+// Report is the result of a scan: the task references found, the TODO-style comments that
+// name no task, and how many references are stale.
+type Report struct {
+	Files      int         `json:"files"`
+	References []Reference `json:"references"`
+	Untracked  []Comment   `json:"untracked"`
+	Stale      int         `json:"stale"`
+}
+
+type taskState struct {
+	boardID  string
+	status   string
+	archived bool
+}
+
+// Collect scans the files below opts.Paths for TODO-style comments and resolves the tasks they
+// reference on the selected boards. Inside a git working tree the files are those git tracks
+// or would track, so .gitignore is respected; elsewhere directories are walked, skipping
+// hidden ones. The storage root, binary files and files over 1 MiB are skipped.
+func Collect(ctx context.Context, sources Sources, opts Options) (Report, error) {
+	if opts.Pattern == nil {
+		return Report{}, fmt.Errorf("scan: task pattern is required")
+	}
+	files, err := listFiles(ctx, sources, opts.Paths)
+	if err != nil {
+		return Report{}, err
+	}
+	tasks, err := loadTasks(ctx, sources)
+	if err != nil {
+		return Report{}, err
+	}
+	report := Report{References: []Reference{}, Untracked: []Comment{}}
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return Report{}, err
+		}
+		data, ok, err := readSource(file)
+		if err != nil {
+			return Report{}, err
+		}
+		if !ok {
+			continue
+		}
+		report.Files++
+		path := displayPath(sources.BaseDir, file)
+		for _, comment := range ParseComments(data, opts.Pattern) {
+			comment.Path = path
+			comment.file = file
+			if len(comment.TaskIDs) == 0 {
+				report.Untracked = append(report.Untracked, comment)
+				continue
+			}
+			for _, id := range comment.TaskIDs {
+				reference := Reference{
+					Path:   path,
+					Line:   comment.Line,
+					Marker: comment.Marker,
+					TaskID: id,
+					Text:   comment.Text,
+					Status: StatusMissing,
+				}
+				if state, ok := tasks[id]; ok {
+					reference.Status = StatusOpen
+					if state.archived || board.IsDoneStatus(state.status) {
+						reference.Status = StatusDone
+					}
+					reference.TaskStatus = state.status
+					reference.BoardID = state.boardID
+					reference.Archived = state.archived
+				}
+				if reference.Stale() {
+					report.Stale++
+				}
+				report.References = append(report.References, reference)
+			}
+		}
+	}
+	return report, nil
+}
+
+// listFiles returns the OS paths of the files to scan, outside the storage root.
+func listFiles(ctx context.Context, sources Sources, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	roots := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(sources.BaseDir, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("scan: %s: %w", path, err)
+		}
+		roots = append(roots, filepath.Clean(path))
+	}
+
+	files := make([]string, 0)
+	for _, root := range roots {
+		listed, err := listRoot(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, listed...)
+	}
+
+	excluded := make([]string, 0, 2)
+	if storageRoot := strings.TrimSpace(sources.StorageRoot); storageRoot != "" {
+		excluded = append(excluded, storageRoot)
+		if resolved, err := filepath.EvalSymlinks(storageRoot); err == nil {
+			excluded = append(excluded, resolved)
+		}
+	}
+	seen := make(map[string]bool, len(files))
+	kept := make([]string, 0, len(files))
+	for _, file := range files {
+		// Overlapping paths list the same file twice.
+		if seen[file] {
+			continue
+		}
+		seen[file] = true
+		if !slices.ContainsFunc(excluded, func(dir string) bool { return shared.IsSubpath(dir, file) }) {
+			kept = append(kept, file)
+		}
+	}
+	return kept, nil
+}
+
+// listRoot lists the files below root through the git working tree containing it, or by
+// walking root when there is none.
+func listRoot(ctx context.Context, root string) ([]string, error) {
+	dir := root
+	if info, err := os.Stat(root); err == nil && !info.IsDir() {
+		dir = filepath.Dir(root)
+	}
+	repo, err := git.Open(ctx, dir)
+	if err == nil {
+		return repo.ListFiles(ctx, []string{root})
+	}
+	if !errors.Is(err, git.ErrNotRepository) {
+		return nil, err
+	}
+	return walkFiles(ctx, root)
+}
+
+// walkFiles lists the files below root, skipping hidden directories below it.
+func walkFiles(ctx context.Context, root string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	return files, nil
+}
+
+// readSource reads a file to scan from the working tree, even when storage is read at a git
+// revision. It reports false for files that are gone (tracked but deleted), are not regular
+// files (such as submodules), are too large or look binary.
+func readSource(path string) ([]byte, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("scan: %w", err)
+	}
+	if !info.Mode().IsRegular() || info.Size() > maxFileSize {
+		return nil, false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("scan: %w", err)
+	}
+	if bytes.IndexByte(data[:min(len(data), binarySniffLength)], 0) >= 0 {
+		return nil, false, nil
+	}
+	return data, true, nil
+}
+
+// displayPath returns file relative to baseDir with forward slashes, or as is when it lies
+// outside. git reports paths without symlinks, so the resolved baseDir is tried as well.
+func displayPath(baseDir, file string) string {
+	dirs := []string{baseDir}
+	if resolved, err := filepath.EvalSymlinks(baseDir); err == nil {
+		dirs = append(dirs, resolved)
+	}
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(dir, file)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+// loadTasks indexes the active and archived tasks of the selected boards by ID. When boards
+// share an ID, an open task wins over a done one.
+func loadTasks(ctx context.Context, sources Sources) (map[string]taskState, error) {
+	boardRepo, err := board.NewBoardRepositoryWithStorage(sources.BaseDir, sources.StorageRoot)
+	if err != nil {
+		return nil, err
+	}
+	boards, err := boardRepo.SelectBoardsContext(ctx, sources.Boards, len(sources.Boards) == 0)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]taskState)
+	add := func(state taskState, id string) {
+		current, ok := states[id]
+		if !ok || ((current.archived || board.IsDoneStatus(current.status)) &&
+			!state.archived && !board.IsDoneStatus(state.status)) {
+			states[id] = state
+		}
+	}
+	for _, entry := range boards {
+		repo, err := board.NewRepositoryForBoardWithStorage(sources.BaseDir, entry.ID, sources.StorageRoot)
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			add(taskState{boardID: entry.ID, status: task.Status}, task.ID)
+		}
+		archived, err := repo.ListArchivedTasksContext(ctx)
+		if err != nil && !errors.Is(err, board.ErrStoreNotInitialized) {
+			return nil, err
+		}
+		for _, task := range archived {
+			add(taskState{boardID: entry.ID, status: task.Status, archived: true}, task.ID)
+		}
+	}
+	return states, nil
+}
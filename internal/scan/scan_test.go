@@ -0,0 +1,242 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/testutil"
+)
+
+func taskPattern(t *testing.T) *regexp.Regexp {
+	t.Helper()
+	pattern, err := git.CompileTaskPattern("")
+	if err != nil {
+		t.Fatalf("compile pattern: %v", err)
+	}
+	return pattern
+}
+
+func writeSource(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func setupSources(t *testing.T) (Sources, *board.Repository) {
+	t.Helper()
+
+	storage := testutil.NewStorage(t)
+	storage.AddTasks(t,
+		board.Task{Title: "Handle retries", Status: "todo", Priority: 2},
+		board.Task{Title: "Drop legacy API", Status: "done", Priority: 2},
+	)
+	return Sources{BaseDir: storage.BaseDir, StorageRoot: storage.StorageRoot}, storage.Board
+}
+
+func TestParseComments(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []Comment
+	}{
+		{
+			name: "parenthesized task",
+			line: "\t// TODO(T-000012): handle retries",
+			want: []Comment{{Line: 1, Marker: "TODO", TaskIDs: []string{"T-000012"}, Text: "handle retries"}},
+		},
+		{
+			name: "task list after marker",
+			line: "# FIXME T-000001, T-000002 - flaky",
+			want: []Comment{{Line: 1, Marker: "FIXME", TaskIDs: []string{"T-000001", "T-000002"}, Text: "flaky"}},
+		},
+		{
+			name: "hash and ulid tasks",
+			line: "// TODO(T-465F0BD, T-01JB8Z6Q4X7T3M9K2V5W0N1R8C): merge caches",
+			want: []Comment{{Line: 1, Marker: "TODO", TaskIDs: []string{"T-465F0BD", "T-01JB8Z6Q4X7T3M9K2V5W0N1R8C"}, Text: "merge caches"}},
+		},
+		{
+			name: "owner without task",
+			line: "/* TODO(alice): support SHA-256 */",
+			want: []Comment{{Line: 1, Marker: "TODO", TaskIDs: []string{}, Text: "support SHA-256"}},
+		},
+		{
+			name: "block comment continuation",
+			line: " * XXX: remove",
+			want: []Comment{{Line: 1, Marker: "XXX", TaskIDs: []string{}, Text: "remove"}},
+		},
+		{
+			name: "marker outside a comment",
+			line: `msg := "TODO(T-000001): not a comment"`,
+			want: []Comment{},
+		},
+		{
+			name: "lower-case marker",
+			line: "// todo: later",
+			want: []Comment{},
+		},
+		{
+			name: "marker inside a word",
+			line: "// Parse handles TODO-style comments.",
+			want: []Comment{},
+		},
+		{
+			name: "marker after the first word",
+			line: "// see TODO: later",
+			want: []Comment{},
+		},
+		{
+			name: "opener inside a string literal",
+			line: `x := "# TODO: not a comment"`,
+			want: []Comment{},
+		},
+		{
+			name: "comment after a string literal",
+			line: `x := "// not yet" // TODO: real`,
+			want: []Comment{{Line: 1, Marker: "TODO", TaskIDs: []string{}, Text: "real"}},
+		},
+		{
+			name: "comment after a decrement",
+			line: "i-- // FIXME: off by one",
+			want: []Comment{{Line: 1, Marker: "FIXME", TaskIDs: []string{}, Text: "off by one"}},
+		},
+		{
+			name: "apostrophe before the comment",
+			line: "It's fine <!-- HACK: trim -->",
+			want: []Comment{{Line: 1, Marker: "HACK", TaskIDs: []string{}, Text: "trim"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got := ParseComments([]byte(tc.line), taskPattern(t))
+
+			// Assert
+			for i := range got {
+				got[i].column = 0
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCollectResolvesReferences(t *testing.T) {
+	// Arrange
+	sources, _ := setupSources(t)
+	writeSource(t, sources.BaseDir, "src/main.go", "package main\n\n"+
+		"// TODO(T-000001): handle retries\n"+
+		"// TODO(T-000002): drop the fallback\n"+
+		"// TODO(T-000099): ghost\n"+
+		"// TODO: add pagination\n")
+	writeSource(t, sources.BaseDir, ".cache/gen.go", "// TODO(T-000098): hidden\n")
+	writeSource(t, sources.BaseDir, "src/logo.png", "\x00// TODO(T-000097): binary\n")
+
+	// Act
+	report, err := Collect(context.Background(), sources, Options{Pattern: taskPattern(t)})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if report.Files != 1 {
+		t.Fatalf("expected only src/main.go to be scanned, got %d files", report.Files)
+	}
+	statuses := map[string]string{}
+	for _, reference := range report.References {
+		statuses[reference.TaskID] = reference.Status
+	}
+	want := map[string]string{"T-000001": StatusOpen, "T-000002": StatusDone, "T-000099": StatusMissing}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("expected statuses %v, got %v", want, statuses)
+	}
+	if report.Stale != 2 {
+		t.Fatalf("expected 2 stale references, got %d", report.Stale)
+	}
+	if len(report.Untracked) != 1 || report.Untracked[0].Path != "src/main.go" || report.Untracked[0].Line != 6 {
+		t.Fatalf("expected one untracked TODO, got %+v", report.Untracked)
+	}
+}
+
+func TestUpdateTasksWritesCodeReferences(t *testing.T) {
+	// Arrange
+	sources, repo := setupSources(t)
+	if err := repo.UpdateTaskContent("T-000001", "Retry with backoff.\n\n## Code references\n\n- old.go:1\n\n## Notes\n\nKeep.\n"); err != nil {
+		t.Fatalf("update content: %v", err)
+	}
+	writeSource(t, sources.BaseDir, "a.go", "// TODO(T-000001): one\n\n// TODO(T-000001): two\n")
+	report, err := Collect(context.Background(), sources, Options{Pattern: taskPattern(t)})
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	// Act
+	updated, err := UpdateTasks(context.Background(), sources, report)
+	if err != nil {
+		t.Fatalf("update tasks: %v", err)
+	}
+	again, err := UpdateTasks(context.Background(), sources, report)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("update tasks again: %v", err)
+	}
+	if !reflect.DeepEqual(updated, []string{"T-000001"}) || len(again) != 0 {
+		t.Fatalf("expected one update and then none, got %v and %v", updated, again)
+	}
+	task, err := repo.GetTaskByID("T-000001")
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	want := "Retry with backoff.\n\n## Code references\n\n- a.go:1\n- a.go:3\n\n## Notes\n\nKeep."
+	if task.Content != want {
+		t.Fatalf("expected content %q, got %q", want, task.Content)
+	}
+}
+
+func TestCreateTasksTagsComments(t *testing.T) {
+	// Arrange
+	sources, repo := setupSources(t)
+	writeSource(t, sources.BaseDir, "a.py", "# TODO: add pagination\n# TODO(alice): cache results\n# TODO:\n")
+	report, err := Collect(context.Background(), sources, Options{Pattern: taskPattern(t)})
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	// Act
+	created, err := CreateTasks(context.Background(), repo, report)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("create tasks: %v", err)
+	}
+	if len(created) != 2 || created[0].Title != "add pagination" || created[1].Line != 2 {
+		t.Fatalf("expected two tasks, got %+v", created)
+	}
+	data, err := os.ReadFile(filepath.Join(sources.BaseDir, "a.py"))
+	if err != nil {
+		t.Fatalf("read source: %v", err)
+	}
+	want := "# TODO(" + created[0].ID + "): add pagination\n# TODO(" + created[1].ID + ", alice): cache results\n# TODO:\n"
+	if string(data) != want {
+		t.Fatalf("expected tagged comments %q, got %q", want, data)
+	}
+	task, err := repo.GetTaskByID(created[0].ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if task.Content != "## Code references\n\n- a.py:1" {
+		t.Fatalf("expected the comment location in the task body, got %q", task.Content)
+	}
+}
@@ -0,0 +1,188 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"mochi-sticky/internal/board"
+)
+
+// referencesHeading titles the task body section that lists code locations.
+const referencesHeading = "Code references"
+
+// CreatedTask is a task created from an untracked TODO-style comment.
+type CreatedTask struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Path  string `json:"path"`
+	Line  int    `json:"line"`
+}
+
+// UpdateTasks writes a "Code references" section listing path:line for every reference into
+// the body of each referenced task that is still on its board, replacing the section when the
+// task already has one. Missing and archived tasks are skipped. It returns the IDs of the
+// tasks whose body changed.
+func UpdateTasks(ctx context.Context, sources Sources, report Report) ([]string, error) {
+	type key struct{ boardID, taskID string }
+	order := make([]key, 0)
+	locations := make(map[key][]string)
+	for _, reference := range report.References {
+		if reference.Status == StatusMissing || reference.Archived {
+			continue
+		}
+		k := key{reference.BoardID, reference.TaskID}
+		if _, ok := locations[k]; !ok {
+			order = append(order, k)
+		}
+		location := reference.Location()
+		if !slices.Contains(locations[k], location) {
+			locations[k] = append(locations[k], location)
+		}
+	}
+
+	updated := make([]string, 0)
+	repos := make(map[string]*board.Repository)
+	for _, k := range order {
+		repo, ok := repos[k.boardID]
+		if !ok {
+			var err error
+			repo, err = board.NewRepositoryForBoardWithStorage(sources.BaseDir, k.boardID, sources.StorageRoot)
+			if err != nil {
+				return nil, err
+			}
+			repos[k.boardID] = repo
+		}
+		task, err := repo.GetTaskByID(k.taskID)
+		if err != nil {
+			return nil, err
+		}
+		content, changed := WithCodeReferences(task.Content, locations[k])
+		if !changed {
+			continue
+		}
+		if err := repo.UpdateTaskContentContext(ctx, task.ID, content); err != nil {
+			return nil, err
+		}
+		updated = append(updated, task.ID)
+	}
+	return updated, nil
+}
+
+// CreateTasks creates a task on repo for every untracked comment of report that has a
+// description, titled after it and listing its location under "Code references". The comment
+// is then rewritten to name the new task, e.g. "TODO: add retries" becomes
+// "TODO(T-000042): add retries" and "TODO(alice): ..." becomes "TODO(T-000042, alice): ...",
+// so later scans track it.
+func CreateTasks(ctx context.Context, repo *board.Repository, report Report) ([]CreatedTask, error) {
+	created := make([]CreatedTask, 0)
+	edits := make(map[string][]Comment)
+	files := make([]string, 0)
+	for _, comment := range report.Untracked {
+		if strings.TrimSpace(comment.Text) == "" {
+			continue
+		}
+		task, err := board.NewTask(comment.Text)
+		if err != nil {
+			return nil, err
+		}
+		location := fmt.Sprintf("%s:%d", comment.Path, comment.Line)
+		task.Content, _ = WithCodeReferences("", []string{location})
+		task, err = repo.CreateTaskContext(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, CreatedTask{ID: task.ID, Title: task.Title, Path: comment.Path, Line: comment.Line})
+		comment.TaskIDs = []string{task.ID}
+		if _, ok := edits[comment.file]; !ok {
+			files = append(files, comment.file)
+		}
+		edits[comment.file] = append(edits[comment.file], comment)
+	}
+	for _, file := range files {
+		if err := tagComments(file, edits[file]); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// tagComments inserts the task ID of each comment right after its marker in file.
+func tagComments(file string, comments []Comment) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("scan: %w", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("scan: %w", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	for _, comment := range comments {
+		index := comment.Line - 1
+		if index < 0 || index >= len(lines) || comment.column > len(lines[index]) {
+			return fmt.Errorf("scan: %s:%d changed during the scan", comment.Path, comment.Line)
+		}
+		line := lines[index]
+		head, tail := line[:comment.column], line[comment.column:]
+		id := strings.Join(comment.TaskIDs, ", ")
+		if rest, ok := strings.CutPrefix(tail, "("); ok {
+			lines[index] = head + "(" + id + ", " + rest
+		} else {
+			lines[index] = head + "(" + id + ")" + tail
+		}
+	}
+	if err := os.WriteFile(file, []byte(strings.Join(lines, "\n")), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("scan: %w", err)
+	}
+	return nil
+}
+
+// WithCodeReferences returns content with a "Code references" section listing locations,
+// replacing the body of an existing section up to the next heading or appending a new one. It
+// reports false when content already lists exactly those locations.
+func WithCodeReferences(content string, locations []string) (string, bool) {
+	items := make([]string, 0, len(locations))
+	for _, location := range locations {
+		items = append(items, "- "+location)
+	}
+	section := []string{"## " + referencesHeading, ""}
+	section = append(section, items...)
+
+	lines := strings.Split(content, "\n")
+	start, end := -1, len(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start < 0 {
+			if heading, ok := strings.CutPrefix(trimmed, "## "); ok &&
+				strings.EqualFold(strings.TrimSpace(heading), referencesHeading) {
+				start = i
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "# ") || strings.HasPrefix(trimmed, "## ") {
+			end = i
+			break
+		}
+	}
+
+	var updated string
+	switch {
+	case start < 0:
+		updated = strings.TrimRight(content, "\n")
+		if updated != "" {
+			updated += "\n\n"
+		}
+		updated += strings.Join(section, "\n") + "\n"
+	case end == len(lines):
+		updated = strings.Join(append(append([]string{}, lines[:start]...), section...), "\n") + "\n"
+	default:
+		out := append(append([]string{}, lines[:start]...), section...)
+		out = append(append(out, ""), lines[end:]...)
+		updated = strings.Join(out, "\n")
+	}
+	// Task bodies are read back without their final newline.
+	return updated, strings.TrimRight(updated, "\n") != strings.TrimRight(content, "\n")
+}
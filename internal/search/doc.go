@@ -0,0 +1,4 @@
+// Package search runs ranked full-text searches across board tasks, wiki pages and ADRs,
+// returning typed hits with the best matching line as a snippet. It backs the `search`
+// command, the MCP `search` tool and the TUI search screen.
+package search
@@ -0,0 +1,420 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/shared"
+	"mochi-sticky/internal/wiki"
+)
+
+const (
+	// TypeTask marks hits in board tasks.
+	TypeTask = "task"
+	// TypeWiki marks hits in wiki pages.
+	TypeWiki = "wiki"
+	// TypeADR marks hits in architecture decision records.
+	TypeADR = "adr"
+
+	snippetWidth = 120
+)
+
+// Types lists every searchable type in display order.
+var Types = []string{TypeTask, TypeWiki, TypeADR}
+
+var (
+	// ErrEmptyQuery indicates a search without any terms.
+	ErrEmptyQuery = errors.New("search query is required")
+	// ErrInvalidType indicates an unknown value in the type filter.
+	ErrInvalidType = errors.New("invalid search type")
+)
+
+// Hit is a single ranked search result. Line is the 1-based line of the snippet in Path.
+type Hit struct {
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Status  string `json:"status,omitempty"`
+	Board   string `json:"board,omitempty"`
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Snippet string `json:"snippet"`
+	Score   int    `json:"score"`
+}
+
+// Options controls a search. Empty Types searches everything; Status keeps only documents whose
+// status matches one of the comma-separated values; Limit caps the number of hits (0 = all).
+type Options struct {
+	Query  string
+	Types  []string
+	Status string
+	Limit  int
+}
+
+// Sources points the search at the stores to read. A nil Board or an empty root skips that type.
+type Sources struct {
+	Board    *board.Repository
+	WikiRoot string
+	ADRRoot  string
+}
+
+// document is a searchable file with its metadata.
+type document struct {
+	kind   string
+	id     string
+	title  string
+	status string
+	board  string
+	path   string
+	tags   []string
+}
+
+// ParseTypes parses a comma-separated type filter (task, wiki, adr; plurals accepted).
+func ParseTypes(values []string) ([]string, error) {
+	var types []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			kind := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(part)), "s")
+			if kind == "" {
+				continue
+			}
+			if kind != TypeTask && kind != TypeWiki && kind != TypeADR {
+				return nil, fmt.Errorf("search: %q (expected task, wiki or adr): %w", part, ErrInvalidType)
+			}
+			if !contains(types, kind) {
+				types = append(types, kind)
+			}
+		}
+	}
+	return types, nil
+}
+
+// Search runs opts against sources and returns hits ranked by score, then by type and ID.
+func Search(sources Sources, opts Options) ([]Hit, error) {
+	return SearchContext(context.Background(), sources, opts)
+}
+
+// SearchContext runs a search, honoring ctx cancellation. Every query term (words, or phrases
+// in double quotes) must appear in a document's title, tags, ID or body for it to match.
+func SearchContext(ctx context.Context, sources Sources, opts Options) ([]Hit, error) {
+	terms := splitTerms(opts.Query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search: %w", ErrEmptyQuery)
+	}
+	types, err := ParseTypes(opts.Types)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		types = Types
+	}
+	docs, err := collectDocuments(ctx, sources, types)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := splitStatuses(opts.Status)
+	phrase := strings.ToLower(strings.Join(terms, " "))
+	hits := make([]Hit, 0)
+	for _, doc := range docs {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		if len(statuses) > 0 && !contains(statuses, strings.ToLower(doc.status)) {
+			continue
+		}
+		hit, ok, err := scoreDocument(doc, terms, phrase)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			hits = append(hits, hit)
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Type != hits[j].Type {
+			return typeRank(hits[i].Type) < typeRank(hits[j].Type)
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+	return hits, nil
+}
+
+func collectDocuments(ctx context.Context, sources Sources, types []string) ([]document, error) {
+	var docs []document
+	if contains(types, TypeTask) && sources.Board != nil {
+		tasks, err := sources.Board.GetAllTasksContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			docs = append(docs, document{
+				kind: TypeTask, id: task.ID, title: task.Title, status: task.Status,
+				board: task.BoardID, path: task.FilePath, tags: task.Tags,
+			})
+		}
+	}
+	if contains(types, TypeWiki) && strings.TrimSpace(sources.WikiRoot) != "" {
+		pages, err := wiki.ListPagesContext(ctx, sources.WikiRoot)
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range pages {
+			slug := strings.TrimSpace(page.Slug)
+			if slug == "" {
+				slug = wiki.SlugFromPath(sources.WikiRoot, page.FilePath)
+			}
+			docs = append(docs, document{
+				kind: TypeWiki, id: slug, title: page.Title, status: page.Status,
+				path: page.FilePath, tags: page.Tags,
+			})
+		}
+	}
+	if contains(types, TypeADR) && strings.TrimSpace(sources.ADRRoot) != "" {
+		repo, err := adr.NewRepository(sources.ADRRoot)
+		if err != nil {
+			return nil, err
+		}
+		records, err := repo.ListADRsContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			docs = append(docs, document{
+				kind: TypeADR, id: "ADR-" + adr.FormatID(record.ID), title: record.Title,
+				status: record.Status, path: record.FilePath, tags: record.Tags,
+			})
+		}
+	}
+	return docs, nil
+}
+
+// scoreDocument ranks a document against the query terms. Title matches weigh most, then tags,
+// then body occurrences (capped per term so long pages do not drown short ones); the whole
+// phrase earns a bonus and an exact ID match wins outright. The snippet is the body line that
+// covers the most terms, falling back to the title line.
+func scoreDocument(doc document, terms []string, phrase string) (Hit, bool, error) {
+	lines, bodyStart, titleLine, err := readDocument(doc.path)
+	if err != nil {
+		return Hit{}, false, err
+	}
+	title := strings.ToLower(doc.title)
+	id := strings.ToLower(doc.id)
+	score := 0
+	if id == phrase {
+		score += 100
+	}
+	if len(terms) > 1 && strings.Contains(title, phrase) {
+		score += 15
+	}
+
+	lowerLines := make([]string, len(lines))
+	for i, line := range lines {
+		lowerLines[i] = strings.ToLower(line)
+	}
+	bestLine, bestCount := -1, 0
+	for i := bodyStart; i < len(lowerLines); i++ {
+		count := 0
+		for _, term := range terms {
+			if strings.Contains(lowerLines[i], term) {
+				count++
+			}
+		}
+		if len(terms) > 1 && strings.Contains(lowerLines[i], phrase) {
+			count = len(terms) + 1
+		}
+		if count > bestCount {
+			bestLine, bestCount = i, count
+		}
+	}
+	if bestCount > len(terms) {
+		score += 5
+	}
+
+	for _, term := range terms {
+		found := false
+		if strings.Contains(title, term) {
+			score += 10
+			found = true
+		}
+		for _, tag := range doc.tags {
+			if strings.EqualFold(tag, term) {
+				score += 5
+				found = true
+			}
+		}
+		if strings.Contains(id, term) {
+			score += 5
+			found = true
+		}
+		occurrences := 0
+		for i := bodyStart; i < len(lowerLines); i++ {
+			occurrences += strings.Count(lowerLines[i], term)
+		}
+		if occurrences > 0 {
+			score += min(occurrences, 5)
+			found = true
+		}
+		if !found {
+			return Hit{}, false, nil
+		}
+	}
+
+	hit := Hit{
+		Type:   doc.kind,
+		ID:     doc.id,
+		Title:  doc.title,
+		Status: doc.status,
+		Board:  doc.board,
+		Path:   doc.path,
+		Score:  score,
+	}
+	if bestLine >= 0 {
+		hit.Line = bestLine + 1
+		hit.Snippet = Snippet(lines[bestLine], firstTerm(lowerLines[bestLine], terms, phrase), snippetWidth)
+	} else {
+		hit.Line = titleLine
+		hit.Snippet = doc.title
+	}
+	return hit, true, nil
+}
+
+// readDocument returns a file's lines, the index of the first body line after the YAML
+// frontmatter and the 1-based line of the frontmatter title (1 when absent).
+func readDocument(path string) ([]string, int, int, error) {
+	data, err := shared.Files().ReadFile(path)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("search: failed to read %s: %w", path, err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, 0, fmt.Errorf("search: failed to read %s: %w", path, err)
+	}
+	bodyStart, titleLine := 0, 1
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			trimmed := strings.TrimSpace(lines[i])
+			if trimmed == "---" {
+				bodyStart = i + 1
+				break
+			}
+			if strings.HasPrefix(trimmed, "title:") {
+				titleLine = i + 1
+			}
+		}
+	}
+	return lines, bodyStart, titleLine, nil
+}
+
+// Snippet trims line to at most width runes centered on the first occurrence of term (matched
+// case-insensitively), marking cuts with an ellipsis.
+func Snippet(line, term string, width int) string {
+	line = strings.TrimSpace(line)
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return line
+	}
+	start := 0
+	if idx := strings.Index(strings.ToLower(line), term); idx >= 0 && term != "" {
+		start = max(0, utf8.RuneCountInString(line[:idx])-width/3)
+	}
+	end := min(len(runes), start+width)
+	start = max(0, end-width)
+	out := string(runes[start:end])
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(runes) {
+		out += "…"
+	}
+	return out
+}
+
+func firstTerm(line string, terms []string, phrase string) string {
+	if strings.Contains(line, phrase) {
+		return phrase
+	}
+	for _, term := range terms {
+		if strings.Contains(line, term) {
+			return term
+		}
+	}
+	return ""
+}
+
+// splitTerms lowercases the query and splits it on whitespace, keeping double-quoted phrases
+// together.
+func splitTerms(query string) []string {
+	var terms []string
+	var current strings.Builder
+	quoted := false
+	flush := func() {
+		if term := strings.TrimSpace(current.String()); term != "" {
+			terms = append(terms, strings.ToLower(term))
+		}
+		current.Reset()
+	}
+	for _, ch := range query {
+		switch {
+		case ch == '"':
+			flush()
+			quoted = !quoted
+		case !quoted && (ch == ' ' || ch == '\t' || ch == '\n'):
+			flush()
+		default:
+			current.WriteRune(ch)
+		}
+	}
+	flush()
+	return terms
+}
+
+func splitStatuses(value string) []string {
+	var statuses []string
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.ToLower(strings.TrimSpace(part)); trimmed != "" {
+			statuses = append(statuses, trimmed)
+		}
+	}
+	return statuses
+}
+
+func typeRank(kind string) int {
+	for i, candidate := range Types {
+		if candidate == kind {
+			return i
+		}
+	}
+	return len(Types)
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
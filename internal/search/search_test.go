@@ -0,0 +1,153 @@
+package search
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/testutil"
+	"mochi-sticky/internal/wiki"
+)
+
+func setupSources(t *testing.T) Sources {
+	t.Helper()
+
+	storage := testutil.NewStorage(t)
+	storage.AddTasks(t,
+		board.Task{Title: "Rotate cache keys", Status: "todo", Content: "Keys expire nightly.\nThe cache warmer must reload them."},
+		board.Task{Title: "Write release notes", Status: "done", Content: "Mention the cache invalidation fix."},
+		board.Task{Title: "Unrelated chore", Status: "todo", Content: "Nothing to see here."},
+	)
+	storage.AddWikiPages(t, wiki.Page{
+		Title:   "Caching",
+		Slug:    "guides/caching",
+		Status:  "published",
+		Content: "# Caching\n\nWe use a write-through cache.\nCache invalidation happens on deploy.\n",
+	})
+	storage.AddADR(t, "Adopt Redis", adr.CreateOptions{
+		Status: "accepted",
+		Body:   "## Decision\n\nUse Redis as the shared cache.\n",
+	})
+	return Sources{Board: storage.Board, WikiRoot: storage.WikiRoot, ADRRoot: storage.ADRRoot}
+}
+
+func TestSearchRanksHitsAcrossTypes(t *testing.T) {
+	// Arrange
+	sources := setupSources(t)
+
+	// Act
+	hits, err := Search(sources, Options{Query: "cache"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(hits) != 4 {
+		t.Fatalf("expected 4 hits, got %+v", hits)
+	}
+	if hits[0].Type != TypeTask || hits[0].Title != "Rotate cache keys" {
+		t.Fatalf("expected title match first, got %+v", hits[0])
+	}
+	types := map[string]bool{}
+	for _, hit := range hits {
+		types[hit.Type] = true
+		if hit.Line <= 0 || hit.Snippet == "" || hit.Path == "" {
+			t.Fatalf("expected line, snippet and path, got %+v", hit)
+		}
+	}
+	if !types[TypeTask] || !types[TypeWiki] || !types[TypeADR] {
+		t.Fatalf("expected hits of every type, got %+v", hits)
+	}
+	for _, hit := range hits {
+		if hit.Type == TypeADR && (hit.ID != "ADR-0001" || !strings.Contains(hit.Snippet, "shared cache")) {
+			t.Fatalf("unexpected adr hit: %+v", hit)
+		}
+	}
+}
+
+func TestSearchReportsBodyLineNumbers(t *testing.T) {
+	// Arrange
+	sources := setupSources(t)
+
+	// Act
+	hits, err := Search(sources, Options{Query: `"cache invalidation"`, Types: []string{"wiki"}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("expected one wiki hit, got %+v", hits)
+	}
+	lines, _, _, err := readDocument(hits[0].Path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got := lines[hits[0].Line-1]; got != "Cache invalidation happens on deploy." || hits[0].Snippet != got {
+		t.Fatalf("expected line %d to hold the snippet, got %q / %q", hits[0].Line, got, hits[0].Snippet)
+	}
+}
+
+func TestSearchFiltersByTypeAndStatus(t *testing.T) {
+	// Arrange
+	sources := setupSources(t)
+
+	// Act
+	hits, err := Search(sources, Options{Query: "cache", Types: []string{"tasks"}, Status: "done"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Title != "Write release notes" {
+		t.Fatalf("expected only the done task, got %+v", hits)
+	}
+}
+
+func TestSearchRequiresEveryTerm(t *testing.T) {
+	// Arrange
+	sources := setupSources(t)
+
+	// Act
+	hits, err := Search(sources, Options{Query: "cache nightly"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Title != "Rotate cache keys" {
+		t.Fatalf("expected a single task hit, got %+v", hits)
+	}
+}
+
+func TestSearchRejectsInvalidInput(t *testing.T) {
+	// Arrange
+	sources := setupSources(t)
+
+	// Act
+	_, emptyErr := Search(sources, Options{Query: "  "})
+	_, typeErr := Search(sources, Options{Query: "cache", Types: []string{"task,page"}})
+
+	// Assert
+	if !errors.Is(emptyErr, ErrEmptyQuery) {
+		t.Fatalf("expected ErrEmptyQuery, got %v", emptyErr)
+	}
+	if !errors.Is(typeErr, ErrInvalidType) {
+		t.Fatalf("expected ErrInvalidType, got %v", typeErr)
+	}
+}
+
+func TestSnippetTrimsAroundMatch(t *testing.T) {
+	// Arrange
+	line := strings.Repeat("a", 50) + " needle " + strings.Repeat("b", 50)
+
+	// Act
+	got := Snippet(line, "needle", 30)
+
+	// Assert
+	if !strings.Contains(got, "needle") || !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") {
+		t.Fatalf("unexpected snippet %q", got)
+	}
+}
@@ -1,4 +1,6 @@
 // Package shared provides reusable utilities for working safely with the
 // workspace filesystem, such as helpers that confirm paths stay within the
-// allowed directory tree and safe existence checks.
+// allowed directory tree and safe existence checks. Storage is read and
+// written through FS, which is the working tree unless a read-only snapshot
+// (such as a git revision) is selected with UseFiles.
 package shared
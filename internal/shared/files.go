@@ -0,0 +1,136 @@
+package shared
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+)
+
+// ErrReadOnly indicates a write to storage that is read-only, such as a git revision.
+var ErrReadOnly = errors.New("read-only storage")
+
+// FS is the file system the repositories read and write storage through. Names are OS paths,
+// as elsewhere in the repositories, not io/fs paths. Errors follow the os package, so
+// os.IsNotExist and errors.Is(err, fs.ErrNotExist) work on them.
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Stat(name string) (fs.FileInfo, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// WriteFileAtomic writes name like WriteFile, but readers see either the old or the new
+	// content, never a partial write.
+	WriteFileAtomic(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	Remove(name string) error
+	RemoveAll(name string) error
+	Rename(oldName, newName string) error
+}
+
+// OSFS is the working tree.
+type OSFS struct{}
+
+func (OSFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (OSFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+func (OSFS) MkdirAll(name string, perm fs.FileMode) error { return os.MkdirAll(name, perm) }
+func (OSFS) Remove(name string) error                     { return os.Remove(name) }
+func (OSFS) RemoveAll(name string) error                  { return os.RemoveAll(name) }
+func (OSFS) Rename(oldName, newName string) error         { return os.Rename(oldName, newName) }
+
+// WriteFileAtomic writes data to a temporary file next to name and renames it over name.
+func (OSFS) WriteFileAtomic(name string, data []byte, perm fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+"-*")
+	if err != nil {
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// files holds the file system selected with UseFiles; nil means OSFS. It is swapped atomically
+// so commands may read storage from goroutines while it changes.
+var files atomic.Pointer[FS]
+
+// Files returns the file system storage is read through: the working tree unless UseFiles
+// selected another one.
+func Files() FS {
+	if fsys := files.Load(); fsys != nil {
+		return *fsys
+	}
+	return OSFS{}
+}
+
+// UseFiles makes fsys the file system storage is read through, for the rest of the process,
+// and returns a func that restores the previous one.
+func UseFiles(fsys FS) (restore func()) {
+	previous := files.Swap(&fsys)
+	return func() {
+		files.Store(previous)
+	}
+}
+
+// WalkDir walks the tree rooted at root through fsys like filepath.WalkDir: in lexical
+// order, calling fn for root and every file and directory below it.
+func WalkDir(fsys FS, root string, fn fs.WalkDirFunc) error {
+	info, err := fsys.Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDir(fsys, root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if errors.Is(err, filepath.SkipDir) || errors.Is(err, filepath.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+func walkDir(fsys FS, path string, entry fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, entry, nil); err != nil || !entry.IsDir() {
+		if errors.Is(err, filepath.SkipDir) && entry.IsDir() {
+			err = nil
+		}
+		return err
+	}
+	entries, err := fsys.ReadDir(path)
+	if err != nil {
+		if err = fn(path, entry, err); err != nil {
+			if errors.Is(err, filepath.SkipDir) {
+				err = nil
+			}
+			return err
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, child := range entries {
+		if err := walkDir(fsys, filepath.Join(path, child.Name()), child, fn); err != nil {
+			if errors.Is(err, filepath.SkipDir) {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
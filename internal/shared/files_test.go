@@ -0,0 +1,101 @@
+package shared
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWalkDirVisitsInLexicalOrder(t *testing.T) {
+	// Arrange
+	base := t.TempDir()
+	for _, name := range []string{"b.md", "a/z.md", "skip/x.md", "a/c.md"} {
+		path := filepath.Join(base, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	var visited []string
+
+	// Act
+	err := WalkDir(OSFS{}, base, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == "skip" {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(base, path)
+		visited = append(visited, filepath.ToSlash(rel))
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	want := []string{".", "a", "a/c.md", "a/z.md", "b.md"}
+	if !slices.Equal(visited, want) {
+		t.Fatalf("expected %v, got %v", want, visited)
+	}
+}
+
+func TestWriteFileAtomicReplacesFile(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.json")
+	if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// Act
+	err := OSFS{}.WriteFileAtomic(path, []byte("new"), 0o644)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("write atomic: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "new" {
+		t.Fatalf("expected the new content, got %q (%v)", data, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o644 {
+		t.Fatalf("expected mode 0644, got %v (%v)", info, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected no temporary files left, got %v (%v)", entries, err)
+	}
+}
+
+func TestUseFilesRestoresPreviousFS(t *testing.T) {
+	// Arrange
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = Files()
+		}
+	}()
+
+	// Act
+	restore := UseFiles(readOnlyFS{})
+	swapped := Files()
+	restore()
+	<-done
+
+	// Assert
+	if _, ok := swapped.(readOnlyFS); !ok {
+		t.Fatalf("expected the swapped file system, got %T", swapped)
+	}
+	if _, ok := Files().(OSFS); !ok {
+		t.Fatalf("expected OSFS after restore, got %T", Files())
+	}
+}
+
+type readOnlyFS struct{ OSFS }
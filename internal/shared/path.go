@@ -21,7 +21,7 @@ func EnsureInDir(baseDir, path string) error {
 
 // PathExists reports whether a path exists on disk.
 func PathExists(path string) (bool, error) {
-	_, err := os.Stat(path)
+	_, err := Files().Stat(path)
 	if err == nil {
 		return true, nil
 	}
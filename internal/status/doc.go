@@ -0,0 +1,4 @@
+// Package status summarizes the health of a storage root: per-board task counts, ADR
+// statuses and wiki lint results. It backs the `status` command, including the thresholds
+// that make it exit non-zero in CI.
+package status
@@ -0,0 +1,220 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/wiki"
+)
+
+// Report is the health summary of a storage root.
+type Report struct {
+	Date     string        `json:"date"`
+	Boards   []BoardReport `json:"boards"`
+	ADRs     ADRReport     `json:"adrs"`
+	Wiki     WikiReport    `json:"wiki"`
+	Totals   Totals        `json:"totals"`
+	Breaches []Breach      `json:"breaches"`
+}
+
+// BoardReport is the summary of one non-archived board.
+type BoardReport struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	board.Summary
+}
+
+// StatusCount is the number of records with one status.
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// ADRItem identifies an ADR listed by the report.
+type ADRItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Date  string `json:"date,omitempty"`
+}
+
+// ADRReport counts ADRs by status and lists the pending proposals.
+type ADRReport struct {
+	Total    int           `json:"total"`
+	ByStatus []StatusCount `json:"by_status"`
+	Proposed []ADRItem     `json:"proposed"`
+}
+
+// WikiReport counts wiki pages by status and totals `wiki lint` findings. Pages without a
+// status count as published.
+type WikiReport struct {
+	Total        int `json:"total"`
+	Draft        int `json:"draft"`
+	Published    int `json:"published"`
+	Archived     int `json:"archived"`
+	LintIssues   int `json:"lint_issues"`
+	LintErrors   int `json:"lint_errors"`
+	LintWarnings int `json:"lint_warnings"`
+}
+
+// Totals aggregates the report across boards. Its fields are the metrics thresholds refer to.
+type Totals struct {
+	Tasks          int `json:"tasks"`
+	Open           int `json:"open"`
+	WIP            int `json:"wip"`
+	Ready          int `json:"ready"`
+	Blocked        int `json:"blocked"`
+	BlockedP1      int `json:"blocked_p1"`
+	Overdue        int `json:"overdue"`
+	ADRProposed    int `json:"adr_proposed"`
+	WikiDrafts     int `json:"wiki_drafts"`
+	WikiLintIssues int `json:"wiki_lint_issues"`
+}
+
+// Sources points the report at the stores to read. Empty roots skip ADRs or the wiki.
+type Sources struct {
+	BaseDir     string
+	StorageRoot string
+	WikiRoot    string
+	ADRRoot     string
+}
+
+// Collect builds the report for every non-archived board, the ADRs and the wiki as of now.
+func Collect(ctx context.Context, sources Sources, now time.Time) (Report, error) {
+	report := Report{Date: now.Format("2006-01-02"), Boards: []BoardReport{}, Breaches: []Breach{}}
+	boards, err := collectBoards(ctx, sources, now)
+	if err != nil {
+		return Report{}, err
+	}
+	report.Boards = boards
+	if strings.TrimSpace(sources.ADRRoot) != "" {
+		if report.ADRs, err = collectADRs(ctx, sources.ADRRoot); err != nil {
+			return Report{}, err
+		}
+	}
+	if strings.TrimSpace(sources.WikiRoot) != "" {
+		if report.Wiki, err = collectWiki(ctx, sources.WikiRoot); err != nil {
+			return Report{}, err
+		}
+	}
+	report.Totals = totals(report)
+	return report, nil
+}
+
+func collectBoards(ctx context.Context, sources Sources, now time.Time) ([]BoardReport, error) {
+	boardRepo, err := board.NewBoardRepositoryWithStorage(sources.BaseDir, sources.StorageRoot)
+	if err != nil {
+		return nil, err
+	}
+	boards, err := boardRepo.SelectBoardsContext(ctx, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]BoardReport, 0, len(boards))
+	for _, item := range boards {
+		repo, err := board.NewRepositoryForBoardWithStorage(sources.BaseDir, item.ID, sources.StorageRoot)
+		if err != nil {
+			return nil, err
+		}
+		config, err := repo.LoadConfigContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := repo.GetAllTasksContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, BoardReport{
+			ID:      item.ID,
+			Name:    item.Name,
+			Summary: board.Summarize(config.Columns, tasks, now),
+		})
+	}
+	return reports, nil
+}
+
+func collectADRs(ctx context.Context, root string) (ADRReport, error) {
+	repo, err := adr.NewRepository(root)
+	if err != nil {
+		return ADRReport{}, err
+	}
+	config, err := repo.LoadConfigContext(ctx)
+	if err != nil {
+		return ADRReport{}, err
+	}
+	records, err := repo.ListADRsContext(ctx)
+	if err != nil {
+		return ADRReport{}, err
+	}
+	report := ADRReport{Total: len(records), ByStatus: []StatusCount{}, Proposed: []ADRItem{}}
+	counts := make(map[string]int)
+	for _, record := range records {
+		counts[record.Status]++
+		if strings.EqualFold(record.Status, "proposed") {
+			item := ADRItem{ID: "ADR-" + adr.FormatID(record.ID), Title: record.Title}
+			if !record.Date.IsZero() {
+				item.Date = record.Date.Format("2006-01-02")
+			}
+			report.Proposed = append(report.Proposed, item)
+		}
+	}
+	seen := make(map[string]bool, len(config.Columns))
+	for _, column := range config.Columns {
+		seen[column.Key] = true
+		report.ByStatus = append(report.ByStatus, StatusCount{Status: column.Key, Count: counts[column.Key]})
+	}
+	for _, record := range records {
+		if !seen[record.Status] {
+			seen[record.Status] = true
+			report.ByStatus = append(report.ByStatus, StatusCount{Status: record.Status, Count: counts[record.Status]})
+		}
+	}
+	return report, nil
+}
+
+func collectWiki(ctx context.Context, root string) (WikiReport, error) {
+	pages, err := wiki.ListPagesContext(ctx, root)
+	if err != nil {
+		return WikiReport{}, fmt.Errorf("status: wiki: %w", err)
+	}
+	report := WikiReport{Total: len(pages)}
+	for _, page := range pages {
+		switch strings.ToLower(strings.TrimSpace(page.Status)) {
+		case "draft":
+			report.Draft++
+		case "archived":
+			report.Archived++
+		default:
+			report.Published++
+		}
+	}
+	for _, issue := range wiki.LintPages(pages) {
+		report.LintIssues++
+		if issue.Severity == "error" {
+			report.LintErrors++
+		} else {
+			report.LintWarnings++
+		}
+	}
+	return report, nil
+}
+
+func totals(report Report) Totals {
+	var out Totals
+	for _, item := range report.Boards {
+		out.Tasks += item.Total
+		out.Open += item.Open
+		out.WIP += item.WIP
+		out.Ready += item.Ready
+		out.Blocked += item.Blocked
+		out.BlockedP1 += item.BlockedP1
+		out.Overdue += len(item.Overdue)
+	}
+	out.ADRProposed = len(report.ADRs.Proposed)
+	out.WikiDrafts = report.Wiki.Draft
+	out.WikiLintIssues = report.Wiki.LintIssues
+	return out
+}
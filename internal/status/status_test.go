@@ -0,0 +1,89 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/testutil"
+	"mochi-sticky/internal/wiki"
+)
+
+func setupSources(t *testing.T) Sources {
+	t.Helper()
+
+	storage := testutil.NewStorage(t)
+	blocker := storage.AddTasks(t, board.Task{Title: "Migrate schema", Status: "doing", Priority: 2})[0]
+	due := board.Date{Time: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+	storage.AddTasks(t,
+		board.Task{Title: "Fix login", Status: "todo", Priority: 1, DependsOn: []string{blocker.ID}},
+		board.Task{Title: "Write docs", Status: "todo", Priority: 3, Due: due},
+	)
+	storage.AddWikiPages(t,
+		wiki.Page{Title: "guides/deploy", Slug: "guides/deploy", Status: "draft", Content: "# Page\n\nBody.\n"},
+		wiki.Page{Title: "guides/setup", Slug: "guides/setup", Status: "published", Content: "# Page\n\nBody.\n"},
+	)
+	storage.AddADR(t, "Adopt Redis", adr.CreateOptions{Status: "accepted", Body: "## Decision\n\nYes.\n"})
+	storage.AddADR(t, "Drop MySQL", adr.CreateOptions{Status: "proposed", Body: "## Decision\n\nYes.\n"})
+	return Sources{BaseDir: storage.BaseDir, StorageRoot: storage.StorageRoot, WikiRoot: storage.WikiRoot, ADRRoot: storage.ADRRoot}
+}
+
+func TestCollectSummarizesStorage(t *testing.T) {
+	// Arrange
+	sources := setupSources(t)
+
+	// Act
+	report, err := Collect(context.Background(), sources, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(report.Boards) != 1 || report.Boards[0].ID != "default" {
+		t.Fatalf("expected the default board, got %+v", report.Boards)
+	}
+	want := Totals{Tasks: 3, Open: 3, WIP: 1, Ready: 1, Blocked: 1, BlockedP1: 1, Overdue: 1, ADRProposed: 1, WikiDrafts: 1}
+	if report.Totals != want {
+		t.Fatalf("expected totals %+v, got %+v", want, report.Totals)
+	}
+	if report.ADRs.Total != 2 || len(report.ADRs.Proposed) != 1 || report.ADRs.Proposed[0].Title != "Drop MySQL" {
+		t.Fatalf("unexpected ADR report: %+v", report.ADRs)
+	}
+	if report.Wiki.Total != 2 || report.Wiki.Published != 1 {
+		t.Fatalf("unexpected wiki report: %+v", report.Wiki)
+	}
+}
+
+func TestReportCheckMatchesThresholds(t *testing.T) {
+	// Arrange
+	report := Report{Totals: Totals{BlockedP1: 2, WIP: 3}}
+	thresholds, err := ParseThresholds([]string{"blocked_p1 > 0", "wip>=5", "overdue=0", ""})
+	if err != nil {
+		t.Fatalf("parse thresholds: %v", err)
+	}
+
+	// Act
+	breaches := report.Check(thresholds)
+
+	// Assert
+	if len(breaches) != 2 || breaches[0].Threshold != "blocked_p1>0" || breaches[0].Actual != 2 || breaches[1].Metric != "overdue" {
+		t.Fatalf("unexpected breaches: %+v", breaches)
+	}
+}
+
+func TestParseThresholdRejectsInvalidInput(t *testing.T) {
+	for _, input := range []string{"blocked", "unknown>1", "wip>-1", "wip>x"} {
+		t.Run(input, func(t *testing.T) {
+			// Act
+			_, err := ParseThreshold(input)
+
+			// Assert
+			if !errors.Is(err, ErrInvalidThreshold) {
+				t.Fatalf("expected ErrInvalidThreshold, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidThreshold indicates a threshold that cannot be parsed.
+	ErrInvalidThreshold = errors.New("invalid status threshold")
+	// ErrThresholdExceeded indicates that at least one threshold matched the report.
+	ErrThresholdExceeded = errors.New("status threshold exceeded")
+)
+
+// thresholdOperators are checked longest first so ">=" is not read as ">".
+var thresholdOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// Threshold fails the status check when Metric compared with Value using Op holds, e.g.
+// "blocked_p1>0" fails as soon as one P1 task is blocked.
+type Threshold struct {
+	Metric string `json:"metric"`
+	Op     string `json:"op"`
+	Value  int    `json:"value"`
+}
+
+// Breach is a threshold that matched, with the metric's actual value.
+type Breach struct {
+	Threshold string `json:"threshold"`
+	Metric    string `json:"metric"`
+	Actual    int    `json:"actual"`
+}
+
+// String renders the threshold in its parseable form.
+func (t Threshold) String() string {
+	return t.Metric + t.Op + strconv.Itoa(t.Value)
+}
+
+// Metrics returns the threshold metrics of the totals by name.
+func (t Totals) Metrics() map[string]int {
+	return map[string]int{
+		"tasks":            t.Tasks,
+		"open":             t.Open,
+		"wip":              t.WIP,
+		"ready":            t.Ready,
+		"blocked":          t.Blocked,
+		"blocked_p1":       t.BlockedP1,
+		"overdue":          t.Overdue,
+		"adr_proposed":     t.ADRProposed,
+		"wiki_drafts":      t.WikiDrafts,
+		"wiki_lint_issues": t.WikiLintIssues,
+	}
+}
+
+// MetricNames lists the metrics thresholds can refer to, sorted.
+func MetricNames() []string {
+	names := make([]string, 0)
+	for name := range (Totals{}).Metrics() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseThreshold parses "<metric><op><value>" where op is one of >, >=, <, <=, = or !=.
+// Spaces are ignored.
+func ParseThreshold(input string) (Threshold, error) {
+	compact := strings.Join(strings.Fields(input), "")
+	for _, op := range thresholdOperators {
+		index := strings.Index(compact, op)
+		if index < 0 {
+			continue
+		}
+		metric := strings.ToLower(compact[:index])
+		if _, ok := (Totals{}).Metrics()[metric]; !ok {
+			return Threshold{}, fmt.Errorf("status: %q: unknown metric %q (expected one of %s): %w",
+				input, metric, strings.Join(MetricNames(), ", "), ErrInvalidThreshold)
+		}
+		value, err := strconv.Atoi(compact[index+len(op):])
+		if err != nil || value < 0 {
+			return Threshold{}, fmt.Errorf("status: %q: value must be a non-negative integer: %w", input, ErrInvalidThreshold)
+		}
+		return Threshold{Metric: metric, Op: op, Value: value}, nil
+	}
+	return Threshold{}, fmt.Errorf("status: %q: expected <metric><op><value>, e.g. blocked_p1>0: %w", input, ErrInvalidThreshold)
+}
+
+// ParseThresholds parses every threshold, skipping empty entries.
+func ParseThresholds(inputs []string) ([]Threshold, error) {
+	thresholds := make([]Threshold, 0, len(inputs))
+	for _, input := range inputs {
+		if strings.TrimSpace(input) == "" {
+			continue
+		}
+		threshold, err := ParseThreshold(input)
+		if err != nil {
+			return nil, err
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// Check returns the thresholds that match the report's totals.
+func (r Report) Check(thresholds []Threshold) []Breach {
+	metrics := r.Totals.Metrics()
+	breaches := make([]Breach, 0)
+	for _, threshold := range thresholds {
+		actual := metrics[threshold.Metric]
+		if threshold.matches(actual) {
+			breaches = append(breaches, Breach{Threshold: threshold.String(), Metric: threshold.Metric, Actual: actual})
+		}
+	}
+	return breaches
+}
+
+func (t Threshold) matches(actual int) bool {
+	switch t.Op {
+	case ">":
+		return actual > t.Value
+	case ">=":
+		return actual >= t.Value
+	case "<":
+		return actual < t.Value
+	case "<=":
+		return actual <= t.Value
+	case "!=":
+		return actual != t.Value
+	default:
+		return actual == t.Value
+	}
+}
@@ -1,6 +1,13 @@
 package storage
 
-import "testing"
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"mochi-sticky/internal/shared"
+)
 
 func TestSaveConfigToRootRoundTrip(t *testing.T) {
 	root := t.TempDir()
@@ -26,3 +33,42 @@ func TestSaveConfigToRootRoundTrip(t *testing.T) {
 		t.Fatalf("expected boards path %q, got %q", "boards/boards.yaml", loaded.Paths.Boards)
 	}
 }
+
+// configFS serves one file from memory, as storage at a git revision would.
+type configFS struct {
+	shared.OSFS
+	path string
+	data []byte
+}
+
+func (f configFS) Stat(name string) (fs.FileInfo, error) {
+	if name == f.path {
+		return fstest.MapFS{filepath.Base(name): {Data: f.data}}.Stat(filepath.Base(name))
+	}
+	return f.OSFS.Stat(name)
+}
+
+func (f configFS) ReadFile(name string) ([]byte, error) {
+	if name == f.path {
+		return f.data, nil
+	}
+	return f.OSFS.ReadFile(name)
+}
+
+func TestLoadConfigFromRootReadsThroughFiles(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	restore := shared.UseFiles(configFS{path: filepath.Join(root, ConfigFileName), data: []byte("editor: nano\n")})
+	defer restore()
+
+	// Act
+	loaded, err := LoadConfigFromRoot(root)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("LoadConfigFromRoot: %v", err)
+	}
+	if loaded.Editor != "nano" {
+		t.Fatalf("expected the config from the file system in use, got %+v", loaded)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"mochi-sticky/internal/shared"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,6 +24,41 @@ type Config struct {
 	Editor      string          `yaml:"editor"`
 	Templates   TemplatesConfig `yaml:"templates"`
 	Paths       ConfigPaths     `yaml:"config_paths"`
+	Status      StatusConfig    `yaml:"status,omitempty"`
+	Git         GitConfig       `yaml:"git,omitempty"`
+	Hooks       HooksConfig     `yaml:"hooks,omitempty"`
+}
+
+// StatusConfig configures the `status` command.
+type StatusConfig struct {
+	// FailOn lists thresholds (e.g. "blocked_p1>0") that make `status` exit non-zero.
+	FailOn []string `yaml:"fail_on,omitempty"`
+}
+
+// GitConfig configures the git integration.
+type GitConfig struct {
+	// TaskPattern is the regular expression that finds task IDs in commit messages. The first
+	// capture group, when present, is the ID. Empty uses the default `\b[A-Z][A-Z0-9]*-[0-9A-Z]+\b`.
+	TaskPattern string `yaml:"task_pattern,omitempty"`
+	// BranchPattern names the branches created by `task start`, with {id}, {slug} and
+	// {board} placeholders. Empty uses "{id}-{slug}".
+	BranchPattern string `yaml:"branch_pattern,omitempty"`
+}
+
+// HooksConfig configures the git hooks written by `hooks install`.
+type HooksConfig struct {
+	// Keywords maps commit message keywords (e.g. "closes") to the action applied to the task
+	// IDs that follow them. Empty uses closes/fixes/resolves (move to done and reference the
+	// commit) and refs (reference only).
+	Keywords map[string]HookKeyword `yaml:"keywords,omitempty"`
+}
+
+// HookKeyword is the action of a commit message keyword.
+type HookKeyword struct {
+	// Status is the column the task moves to; empty leaves the status alone.
+	Status string `yaml:"status,omitempty"`
+	// Ref appends a reference to the commit to the task body.
+	Ref bool `yaml:"ref,omitempty"`
 }
 
 // ResolveRoot determines the storage root based on override, env, config, or defaults.
@@ -39,7 +76,7 @@ func ResolveRoot(workingDir string, allowMissing bool, override string) (string,
 	}
 
 	legacyConfigPath := filepath.Join(workingDir, ConfigFileName)
-	if _, err := os.Stat(legacyConfigPath); err == nil {
+	if _, err := shared.Files().Stat(legacyConfigPath); err == nil {
 		config, err := loadConfigAtPath(legacyConfigPath)
 		if err != nil {
 			return "", err
@@ -74,7 +111,7 @@ func resolvePath(workingDir, value string, allowMissing bool) (string, error) {
 		return "", fmt.Errorf("storage: failed to resolve path: %w", err)
 	}
 
-	info, err := os.Stat(absPath)
+	info, err := shared.Files().Stat(absPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			if allowMissing {
@@ -127,7 +164,7 @@ func LoadConfigWithOverride(workingDir, override string) (Config, error) {
 	}
 
 	legacyPath := filepath.Join(workingDir, ConfigFileName)
-	if _, err := os.Stat(legacyPath); err != nil {
+	if _, err := shared.Files().Stat(legacyPath); err != nil {
 		if os.IsNotExist(err) {
 			return Config{}, nil
 		}
@@ -142,7 +179,7 @@ func LoadConfigFromRoot(storageRoot string) (Config, error) {
 		return Config{}, fmt.Errorf("storage: storage root is required")
 	}
 	configPath := filepath.Join(storageRoot, ConfigFileName)
-	if _, err := os.Stat(configPath); err != nil {
+	if _, err := shared.Files().Stat(configPath); err != nil {
 		if os.IsNotExist(err) {
 			return Config{}, nil
 		}
@@ -156,7 +193,7 @@ func SaveConfigToRoot(storageRoot string, cfg Config) error {
 	if strings.TrimSpace(storageRoot) == "" {
 		return fmt.Errorf("storage: storage root is required")
 	}
-	if err := os.MkdirAll(storageRoot, 0o755); err != nil {
+	if err := shared.Files().MkdirAll(storageRoot, 0o755); err != nil {
 		return fmt.Errorf("storage: failed to create storage root %s: %w", storageRoot, err)
 	}
 	configPath := filepath.Join(storageRoot, ConfigFileName)
@@ -164,14 +201,14 @@ func SaveConfigToRoot(storageRoot string, cfg Config) error {
 	if err != nil {
 		return fmt.Errorf("storage: failed to marshal config: %w", err)
 	}
-	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(configPath, data, 0o644); err != nil {
 		return fmt.Errorf("storage: failed to write config %s: %w", configPath, err)
 	}
 	return nil
 }
 
 func loadConfigAtPath(configPath string) (Config, error) {
-	data, err := os.ReadFile(configPath)
+	data, err := shared.Files().ReadFile(configPath)
 	if err != nil {
 		return Config{}, fmt.Errorf("storage: failed to read config %s: %w", configPath, err)
 	}
@@ -187,7 +224,11 @@ func isEmptyConfig(cfg Config) bool {
 		strings.TrimSpace(cfg.PDFTemplate) == "" &&
 		strings.TrimSpace(cfg.Editor) == "" &&
 		isEmptyTemplatesConfig(cfg.Templates) &&
-		isEmptyConfigPaths(cfg.Paths)
+		isEmptyConfigPaths(cfg.Paths) &&
+		len(cfg.Status.FailOn) == 0 &&
+		strings.TrimSpace(cfg.Git.TaskPattern) == "" &&
+		strings.TrimSpace(cfg.Git.BranchPattern) == "" &&
+		len(cfg.Hooks.Keywords) == 0
 }
 
 func isEmptyTemplatesConfig(cfg TemplatesConfig) bool {
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"mochi-sticky/internal/shared"
 )
 
 // TemplatesConfig captures template directory overrides.
@@ -105,7 +107,7 @@ func resolveTemplatePath(workingDir, value string, allowMissing, expectDir bool)
 		return "", fmt.Errorf("storage: failed to resolve template path: %w", err)
 	}
 
-	info, err := os.Stat(absPath)
+	info, err := shared.Files().Stat(absPath)
 	if err != nil {
 		if os.IsNotExist(err) && allowMissing {
 			return absPath, nil
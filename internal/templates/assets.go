@@ -4,3 +4,9 @@ import "embed"
 
 //go:embed assets/**/*
 var embeddedFS embed.FS
+
+// Bundled returns a template embedded in the binary by its path below the assets directory,
+// such as "wiki/release-notes.md".
+func Bundled(name string) ([]byte, error) {
+	return embeddedFS.ReadFile("assets/" + name)
+}
@@ -0,0 +1,85 @@
+package testutil
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/wiki"
+)
+
+// Storage is a temporary storage root at <base>/.sticky with an initialized default board,
+// for package tests that read boards, wiki pages and ADRs directly.
+type Storage struct {
+	BaseDir     string
+	StorageRoot string
+	WikiRoot    string
+	ADRRoot     string
+	Board       *board.Repository
+}
+
+// NewStorage creates a storage root in a temp dir and initializes its default board.
+func NewStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	baseDir := t.TempDir()
+	storageRoot := filepath.Join(baseDir, ".sticky")
+	repo, err := board.NewRepositoryWithStorage(baseDir, storageRoot)
+	if err != nil {
+		t.Fatalf("new repo: %v", err)
+	}
+	if err := repo.InitStoreContext(context.Background()); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+	return &Storage{
+		BaseDir:     baseDir,
+		StorageRoot: storageRoot,
+		WikiRoot:    filepath.Join(storageRoot, "wiki"),
+		ADRRoot:     filepath.Join(storageRoot, "adrs"),
+		Board:       repo,
+	}
+}
+
+// AddTasks creates tasks on the default board, in order, and returns them with their IDs.
+func (s *Storage) AddTasks(t *testing.T, tasks ...board.Task) []board.Task {
+	t.Helper()
+
+	created := make([]board.Task, 0, len(tasks))
+	for _, task := range tasks {
+		task, err := s.Board.CreateTask(task)
+		if err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+		created = append(created, task)
+	}
+	return created
+}
+
+// AddWikiPages saves pages below the wiki root, at the path given by their slug.
+func (s *Storage) AddWikiPages(t *testing.T, pages ...wiki.Page) {
+	t.Helper()
+
+	for _, page := range pages {
+		path := filepath.Join(s.WikiRoot, filepath.FromSlash(page.Slug)+".md")
+		if err := wiki.SavePage(path, page); err != nil {
+			t.Fatalf("save page: %v", err)
+		}
+	}
+}
+
+// AddADR creates an ADR and returns it.
+func (s *Storage) AddADR(t *testing.T, title string, opts adr.CreateOptions) adr.ADR {
+	t.Helper()
+
+	repo, err := adr.NewRepository(s.ADRRoot)
+	if err != nil {
+		t.Fatalf("new adr repo: %v", err)
+	}
+	record, err := repo.CreateADR(title, opts)
+	if err != nil {
+		t.Fatalf("create adr: %v", err)
+	}
+	return record
+}
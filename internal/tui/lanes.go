@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/board"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var laneHeaderStyle = lipgloss.NewStyle().Foreground(textMuted).Background(panelBg).Bold(true)
+
+// applyLaneConfig loads the board's lane settings. Lanes start enabled when the board config
+// defines a grouping; collapsed lanes are reset whenever the board changes.
+func (m *Model) applyLaneConfig(boardID string, cfg board.LaneConfig) {
+	group, err := board.ParseLaneGroup(cfg.GroupBy)
+	if err != nil {
+		group = board.LaneGroup{}
+	}
+	m.laneOrder = cfg.Order
+	if m.laneBoardID == boardID {
+		if !group.IsZero() && m.viewGroup.IsZero() {
+			m.laneGroup = group
+		}
+		return
+	}
+	m.laneBoardID = boardID
+	m.laneGroup = group
+	m.lanesEnabled = !group.IsZero()
+	m.laneCursor = 0
+	m.collapsedLanes = make(map[string]bool, len(cfg.Collapsed))
+	for _, key := range cfg.Collapsed {
+		m.collapsedLanes[strings.ToLower(strings.TrimSpace(key))] = true
+	}
+}
+
+// toggleLanes switches swimlanes on or off, defaulting to tag lanes when the board has no grouping.
+func (m Model) toggleLanes() Model {
+	m.captureSelection()
+	m.lanesEnabled = !m.lanesEnabled
+	if m.lanesEnabled && m.laneGroup.IsZero() {
+		m.laneGroup = board.LaneGroup{Kind: board.LaneByTag}
+	}
+	if m.lanesEnabled {
+		m.arrangeLanes()
+	} else {
+		taskIndex := buildTaskIndex(m.columns)
+		for i := range m.columns {
+			sortTasksByReadiness(m.columns[i].Tasks, taskIndex)
+		}
+	}
+	m.restoreSelection()
+	m.syncLaneCursor()
+	return m
+}
+
+// arrangeLanes recomputes lane keys and orders every column's tasks by lane so that the
+// column selection walks lanes top to bottom.
+func (m *Model) arrangeLanes() {
+	if !m.lanesEnabled {
+		m.laneKeys = nil
+		return
+	}
+	var all []board.Task
+	for _, column := range m.columns {
+		all = append(all, column.Tasks...)
+	}
+	m.laneKeys = board.LaneKeys(all, m.laneGroup, m.laneOrder)
+	for i := range m.columns {
+		m.sortColumnByLane(i)
+	}
+	if m.laneCursor >= len(m.laneKeys) {
+		m.laneCursor = max(0, len(m.laneKeys)-1)
+	}
+}
+
+func (m *Model) sortColumnByLane(col int) {
+	if !m.lanesEnabled || col < 0 || col >= len(m.columns) {
+		return
+	}
+	tasks := m.columns[col].Tasks
+	sort.SliceStable(tasks, func(a, b int) bool {
+		return m.laneIndexOf(tasks[a]) < m.laneIndexOf(tasks[b])
+	})
+}
+
+func (m Model) laneIndexOf(task board.Task) int {
+	key := board.LaneKey(task, m.laneGroup, m.laneOrder)
+	for i, laneKey := range m.laneKeys {
+		if strings.EqualFold(laneKey, key) {
+			return i
+		}
+	}
+	return len(m.laneKeys)
+}
+
+func (m Model) laneCollapsed(key string) bool {
+	return m.collapsedLanes[strings.ToLower(key)]
+}
+
+func (m Model) taskHidden(task board.Task) bool {
+	if !m.lanesEnabled {
+		return false
+	}
+	return m.laneCollapsed(board.LaneKey(task, m.laneGroup, m.laneOrder))
+}
+
+// moveLaneSelection moves the selection by delta within the active column, skipping tasks in
+// collapsed lanes. Selection crosses lane boundaries naturally because tasks are lane-ordered.
+func (m Model) moveLaneSelection(delta int) Model {
+	column := &m.columns[m.active]
+	for idx := column.Selected + delta; idx >= 0 && idx < len(column.Tasks); idx += delta {
+		if m.taskHidden(column.Tasks[idx]) {
+			continue
+		}
+		column.Selected = idx
+		break
+	}
+	m.syncLaneCursor()
+	return m
+}
+
+// moveLaneCursor moves the lane cursor and selects the first visible task of that lane in the
+// active column when there is one.
+func (m Model) moveLaneCursor(delta int) Model {
+	if !m.lanesEnabled || len(m.laneKeys) == 0 {
+		return m
+	}
+	m.laneCursor = clampIndex(m.laneCursor+delta, len(m.laneKeys))
+	m.selectFirstInLane()
+	return m
+}
+
+func (m *Model) selectFirstInLane() {
+	if len(m.columns) == 0 || m.laneCursor >= len(m.laneKeys) {
+		return
+	}
+	key := m.laneKeys[m.laneCursor]
+	if m.laneCollapsed(key) {
+		return
+	}
+	column := &m.columns[m.active]
+	for i, task := range column.Tasks {
+		if strings.EqualFold(board.LaneKey(task, m.laneGroup, m.laneOrder), key) {
+			column.Selected = i
+			return
+		}
+	}
+}
+
+// toggleLaneCollapse collapses or expands the lane under the cursor and moves the selection
+// off hidden tasks.
+func (m Model) toggleLaneCollapse() Model {
+	if !m.lanesEnabled || m.laneCursor >= len(m.laneKeys) {
+		return m
+	}
+	if m.collapsedLanes == nil {
+		m.collapsedLanes = make(map[string]bool)
+	}
+	key := strings.ToLower(m.laneKeys[m.laneCursor])
+	m.collapsedLanes[key] = !m.collapsedLanes[key]
+	if len(m.columns) == 0 {
+		return m
+	}
+	column := &m.columns[m.active]
+	if column.Selected < len(column.Tasks) && m.taskHidden(column.Tasks[column.Selected]) {
+		cursor := m.laneCursor
+		for i := range column.Tasks {
+			if !m.taskHidden(column.Tasks[i]) {
+				column.Selected = i
+				break
+			}
+		}
+		m.laneCursor = cursor
+	} else if !m.collapsedLanes[key] {
+		m.selectFirstInLane()
+	}
+	return m
+}
+
+func (m *Model) syncLaneCursor() {
+	if !m.lanesEnabled {
+		return
+	}
+	task, ok := m.currentTask()
+	if !ok || m.taskHidden(task) {
+		return
+	}
+	if idx := m.laneIndexOf(task); idx < len(m.laneKeys) {
+		m.laneCursor = idx
+	}
+}
+
+// renderLanes draws the Kanban as horizontal swimlanes: one header row with the column titles,
+// then for every lane a header line followed by the lane's tasks laid out under each column.
+func (m Model) renderLanes(columnWidth int, index map[string]board.Task, height int) string {
+	cellStyle := lipgloss.NewStyle().Width(columnWidth + 2).MarginRight(2).Background(panelBg)
+	cells := make([]string, 0, len(m.columns))
+	for _, column := range m.columns {
+		title := column.Title
+		if strings.TrimSpace(title) == "" {
+			title = column.Key
+		}
+		title = fmt.Sprintf("%s (%d)", title, len(column.Tasks))
+		if board.HasEstimates(column.Tasks) {
+			title = fmt.Sprintf("%s • %s %s", title, board.FormatEstimate(board.SumEstimates(column.Tasks)), board.EstimateUnitLabel(m.estimateUnit))
+		}
+		cells = append(cells, cellStyle.Render(headerStyle.Render(title)))
+	}
+	rows := []string{lipgloss.JoinHorizontal(lipgloss.Top, cells...)}
+
+	for laneIdx, key := range m.laneKeys {
+		count := 0
+		laneCells := make([]string, 0, len(m.columns))
+		for colIdx, column := range m.columns {
+			var lines []string
+			for taskIdx, task := range column.Tasks {
+				if !strings.EqualFold(board.LaneKey(task, m.laneGroup, m.laneOrder), key) {
+					continue
+				}
+				count++
+				line := taskLine(task, index)
+				if colIdx == m.active && taskIdx == column.Selected {
+					lines = append(lines, selectedTask.Render(line))
+					continue
+				}
+				lines = append(lines, taskStyle.Render(line))
+			}
+			if len(lines) == 0 {
+				lines = append(lines, taskStyle.Render("·"))
+			}
+			laneCells = append(laneCells, cellStyle.Render(strings.Join(lines, "\n")))
+		}
+		marker := "▾"
+		if m.laneCollapsed(key) {
+			marker = "▸"
+		}
+		header := fmt.Sprintf("%s %s (%d)", marker, board.LaneTitle(key, m.laneGroup), count)
+		style := laneHeaderStyle
+		if laneIdx == m.laneCursor {
+			style = style.Foreground(accentSoft)
+		}
+		rows = append(rows, style.Render(header))
+		if m.laneCollapsed(key) {
+			continue
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, laneCells...))
+	}
+
+	lines := strings.Split(strings.Join(rows, "\n"), "\n")
+	if height > 0 && len(lines) > height {
+		overflow := len(lines) - height
+		if height >= 2 {
+			lines = append(lines[:height-1], taskStyle.Render(fmt.Sprintf("… %d more lines", overflow+1)))
+		} else {
+			lines = lines[:height]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +12,8 @@ import (
 
 	"mochi-sticky/internal/adr"
 	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/search"
+	"mochi-sticky/internal/shared"
 	"mochi-sticky/internal/wiki"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -53,6 +56,8 @@ const (
 	screenADRStatusPicker
 	screenADRCreate
 	screenADRDetail
+	screenViewPicker
+	screenSearch
 )
 
 type boardFocus int
@@ -112,6 +117,33 @@ type Model struct {
 	activeBoard          string
 	boardDesc            string
 	boardContext         board.BoardContext
+	estimateUnit         string
+	activeSprint         board.Sprint
+	hasActiveSprint      bool
+	sprintFilter         bool
+	laneGroup            board.LaneGroup
+	laneOrder            []string
+	laneBoardID          string
+	lanesEnabled         bool
+	laneKeys             []string
+	laneCursor           int
+	collapsedLanes       map[string]bool
+	query                board.Query
+	queryInput           string
+	queryEditing         bool
+	queryErr             error
+	views                []board.View
+	view                 board.View
+	viewQuery            board.Query
+	viewGroup            board.LaneGroup
+	viewBoardID          string
+	viewPickIndex        int
+	searchInput          string
+	searchRan            string
+	searchHits           []search.Hit
+	searchIndex          int
+	searchErr            error
+	searchJump           search.Hit
 	selectedTaskID       string
 	boardIndex           int
 	boardAction          int
@@ -130,6 +162,9 @@ type Model struct {
 	taskTags             string
 	taskStatus           string
 	taskPriority         int
+	taskCreateErr        error
+	quickCapture         board.QuickCaptureConfig
+	quickCaptureScope    board.QuickCaptureScope
 	taskField            int
 	taskEditMode         taskEditMode
 	taskEditInput        string
@@ -161,6 +196,11 @@ type Model struct {
 	wikiFilterTagMode    string
 	wikiFilterInput      string
 	wikiFilterMode       wikiFilterMode
+	wikiHistoryOpen      bool
+	wikiHistorySlug      string
+	wikiHistory          []wiki.Revision
+	wikiHistoryIndex     int
+	wikiHistoryErr       error
 	adrColumns           []adrColumnModel
 	adrStatusColumns     []adr.Column
 	adrActive            int
@@ -171,6 +211,7 @@ type Model struct {
 	adrTags              string
 	adrStatus            string
 	adrField             int
+	revision             string
 }
 
 // NewModel creates a TUI model backed by a repository.
@@ -189,6 +230,14 @@ func (m Model) SetEditor(editor string) Model {
 	return m
 }
 
+// SetRevision marks the model as showing storage at a git revision: the header names the
+// revision, and edits, which the read-only storage rejects, reload the board instead of
+// stopping with an error.
+func (m Model) SetRevision(revision string) Model {
+	m.revision = revision
+	return m
+}
+
 // Init loads tasks from the repository.
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(loadStateCmd(m.repo), loadBoardsCmd(m.boardRepo))
@@ -231,9 +280,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.repo != nil && msg.boardID != "" && msg.boardID != m.repo.BoardID() {
 			return m, nil
 		}
-		m.columns = buildColumns(msg.columns, msg.tasks)
+		m.activeSprint = msg.activeSprint
+		m.hasActiveSprint = msg.hasActiveSprint
+		if !m.hasActiveSprint {
+			m.sprintFilter = false
+		}
+		tasks := msg.tasks
+		if m.sprintFilter {
+			tasks = board.SprintTasks(tasks, m.activeSprint.ID)
+		}
+		m.views = msg.views
+		m.quickCapture = msg.quickCapture
+		m.quickCaptureScope = board.NewQuickCaptureScope(msg.columns, msg.tasks)
+		columns, tasks := m.viewColumnsAndTasks(msg.boardID, msg.columns, tasks)
+		tasks = filterTasksByQuery(tasks, m.query)
+		m.columns = buildColumns(columns, tasks)
 		m.boardDesc = msg.desc
 		m.boardContext = msg.context
+		m.estimateUnit = msg.estimateUnit
+		m.applyLaneConfig(msg.boardID, msg.lanes)
+		m.arrangeLanes()
 		m.loading = false
 		m.loadingMessage = ""
 		if m.active >= len(m.columns) {
@@ -245,6 +311,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if strings.TrimSpace(m.selectedTaskID) != "" {
 			m.restoreSelection()
 		}
+		m.finishTaskJump()
 		if m.pendingBoardDescEdit {
 			m.pendingBoardDescEdit = false
 			if m.repo == nil {
@@ -340,12 +407,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.wikiNav = msg.nav
 		m.wikiPages = msg.pages
 		m.applyWikiFilters()
+		m.finishWikiJump()
 		m.loading = false
 		m.loadingMessage = ""
 		if len(m.wikiItems) == 0 {
 			m.wikiStatus = "No wiki pages found."
 		}
 		return m, nil
+	case wikiHistoryMsg:
+		if msg.slug != m.wikiHistorySlug {
+			return m, nil
+		}
+		m.wikiHistory = msg.revisions
+		m.wikiHistoryErr = msg.err
+		m.wikiHistoryIndex = 0
+		return m, nil
 	case wikiExportMsg:
 		m = m.cancelInFlight()
 		m.loading = false
@@ -371,6 +447,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.selectedADRID > 0 {
 			m.restoreADRSelection()
 		}
+		m.finishADRJump()
 		return m, nil
 	case adrStatusUpdatedMsg:
 		m = m.cancelInFlight()
@@ -383,9 +460,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loadingMessage = "Opening editor..."
 		m.screen = screenADR
 		return m, openADREditorCmd(m.adrRoot(), msg.record.FilePath, m.editor)
+	case searchResultsMsg:
+		m = m.cancelInFlight()
+		m.searchRan = msg.query
+		m.searchHits = msg.hits
+		m.searchIndex = 0
+		return m, nil
 	case errMsg:
 		m = m.cancelInFlight()
+		loading := m.loading
 		m.pendingRefresh = false
+		if errors.Is(msg.err, shared.ErrReadOnly) && !loading {
+			// An edit of a git revision: reload to drop anything applied locally.
+			return m.startRefresh()
+		}
 		// Ignore context.Canceled errors - these are expected when cancelling
 		// in-flight operations (e.g., when refreshing with Ctrl+R/F5)
 		if msg.err != nil && msg.err != context.Canceled {
@@ -450,9 +538,16 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleADRCreateKey(msg)
 	case screenADRDetail:
 		return m.handleADRDetailKey(msg)
+	case screenViewPicker:
+		return m.handleViewPickerKey(msg)
+	case screenSearch:
+		return m.handleSearchKey(msg)
 	default:
 	}
 
+	if m.queryEditing {
+		return m.handleQueryKey(msg)
+	}
 	if m.boardFocus == focusBoards && m.sidebarWidth() > 0 {
 		switch normalizedKey(msg) {
 		case "ctrl+c", "q":
@@ -528,10 +623,33 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	if msg.String() == "M" {
+	switch msg.String() {
+	case "M":
 		return m.withInFlight(func(ctx context.Context) tea.Cmd {
 			return m.moveSelectedTaskBackCmdContext(ctx)
 		})
+	case "J", "shift+down":
+		m.captureSelection()
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return m.rankSelectedTaskCmdContext(ctx, 1)
+		})
+	case "K", "shift+up":
+		m.captureSelection()
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return m.rankSelectedTaskCmdContext(ctx, -1)
+		})
+	case "V":
+		return m.startViewPicker(), nil
+	case "S":
+		return m.startSearch(), nil
+	case "L":
+		return m.toggleLanes(), nil
+	case "C":
+		return m.toggleLaneCollapse(), nil
+	case "[":
+		return m.moveLaneCursor(-1), nil
+	case "]":
+		return m.moveLaneCursor(1), nil
 	}
 	switch normalizedKey(msg) {
 	case "ctrl+c", "q":
@@ -597,6 +715,19 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.taskAction = 0
 		}
 		return m, nil
+	case "s":
+		if !m.hasActiveSprint {
+			m.err = fmt.Errorf("tui: no active sprint on this board")
+			return m, nil
+		}
+		m.sprintFilter = !m.sprintFilter
+		m.captureSelection()
+		m.loading = true
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return loadStateCmdContext(ctx, m.repo)
+		})
+	case "/":
+		return m.startQuery(), nil
 	case "z":
 		m.screen = screenArchive
 		m.archiveIndex = 0
@@ -613,6 +744,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.taskStatus = status
 		m.taskPriority = board.DefaultPriority
 		m.taskField = 0
+		m.taskCreateErr = nil
 		m.screen = screenTaskCreate
 		return m, nil
 	default:
@@ -621,11 +753,17 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 type stateMsg struct {
-	boardID string
-	columns []board.Column
-	tasks   []board.Task
-	desc    string
-	context board.BoardContext
+	boardID         string
+	columns         []board.Column
+	tasks           []board.Task
+	desc            string
+	context         board.BoardContext
+	estimateUnit    string
+	activeSprint    board.Sprint
+	hasActiveSprint bool
+	lanes           board.LaneConfig
+	views           []board.View
+	quickCapture    board.QuickCaptureConfig
 }
 
 type boardStateMsg struct {
@@ -687,12 +825,22 @@ func loadStateCmdContext(ctx context.Context, repo *board.Repository) tea.Cmd {
 		if err != nil {
 			return errMsg{err: err}
 		}
+		activeSprint, hasActiveSprint, err := repo.ActiveSprintContext(ctx)
+		if err != nil {
+			return errMsg{err: err}
+		}
 		return stateMsg{
-			boardID: repo.BoardID(),
-			columns: config.Columns,
-			tasks:   tasks,
-			desc:    description,
-			context: config.Context,
+			boardID:         repo.BoardID(),
+			columns:         config.Columns,
+			tasks:           tasks,
+			desc:            description,
+			context:         config.Context,
+			estimateUnit:    config.EstimateUnit,
+			activeSprint:    activeSprint,
+			hasActiveSprint: hasActiveSprint,
+			lanes:           config.Lanes,
+			views:           config.Views,
+			quickCapture:    config.QuickCapture,
 		}
 	}
 }
@@ -892,17 +1040,29 @@ func resolveEditor(editor string) string {
 	return "nano"
 }
 
-func taskCreateCmdContext(ctx context.Context, repo *board.Repository, title, status string, tags []string, priority int) tea.Cmd {
+// taskCreateCmdContext creates a task from the fields of the create screen. A status set
+// by a quick-capture token is checked against the board columns first.
+func taskCreateCmdContext(ctx context.Context, repo *board.Repository, draft board.Task) tea.Cmd {
 	return func() tea.Msg {
-		task, err := board.NewTask(title)
+		task, err := board.NewTask(draft.Title)
 		if err != nil {
 			return errMsg{err: err}
 		}
-		if status != "" {
-			task.Status = status
+		if draft.Status != "" {
+			config, err := repo.LoadConfigContext(ctx)
+			if err != nil {
+				return errMsg{err: err}
+			}
+			if err := config.ValidateStatus(draft.Status); err != nil {
+				return errMsg{err: err}
+			}
+			task.Status = draft.Status
 		}
-		task.Tags = tags
-		task.Priority = priority
+		task.Tags = draft.Tags
+		task.Priority = draft.Priority
+		task.Due = draft.Due
+		task.DependsOn = draft.DependsOn
+		task.Sprint = draft.Sprint
 		if _, err := repo.CreateTaskContext(ctx, task); err != nil {
 			return errMsg{err: err}
 		}
@@ -910,6 +1070,15 @@ func taskCreateCmdContext(ctx context.Context, repo *board.Repository, title, st
 	}
 }
 
+func rankTaskCmdContext(ctx context.Context, repo *board.Repository, id string, placement board.RankPlacement) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := repo.RankTaskContext(ctx, id, placement); err != nil {
+			return errMsg{err: err}
+		}
+		return loadStateCmdContext(ctx, repo)()
+	}
+}
+
 func updateStatusCmdContext(ctx context.Context, repo *board.Repository, id, status string) tea.Cmd {
 	return func() tea.Msg {
 		if err := repo.UpdateTaskStatusContext(ctx, id, status); err != nil {
@@ -967,6 +1136,9 @@ func (m Model) moveSelection(delta int) Model {
 	if len(column.Tasks) == 0 {
 		return m
 	}
+	if m.lanesEnabled {
+		return m.moveLaneSelection(delta)
+	}
 	column.Selected += delta
 	clampSelection(column)
 	return m
@@ -1012,6 +1184,30 @@ func (m Model) moveSelectedTaskBackCmdContext(ctx context.Context) tea.Cmd {
 	return updateStatusCmdContext(ctx, m.repo, task.ID, prevStatus)
 }
 
+// rankSelectedTaskCmdContext moves the selected card one slot up (delta -1) or down (delta 1)
+// within its column by re-ranking it against the neighbouring card.
+func (m Model) rankSelectedTaskCmdContext(ctx context.Context, delta int) tea.Cmd {
+	task, ok := m.currentTask()
+	if !ok {
+		return nil
+	}
+	column := m.columns[m.active]
+	neighbour := column.Selected + delta
+	if neighbour < 0 || neighbour >= len(column.Tasks) {
+		return nil
+	}
+	// The column order tells the repository where unranked cards are shown.
+	order := make([]string, 0, len(column.Tasks))
+	for _, card := range column.Tasks {
+		order = append(order, card.ID)
+	}
+	placement := board.RankPlacement{Before: column.Tasks[neighbour].ID, Order: order}
+	if delta > 0 {
+		placement = board.RankPlacement{After: column.Tasks[neighbour].ID, Order: order}
+	}
+	return rankTaskCmdContext(ctx, m.repo, task.ID, placement)
+}
+
 func (m Model) handleBoardActionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch normalizedKey(msg) {
 	case "ctrl+c", "q":
@@ -1309,6 +1505,9 @@ func clampIndex(value, length int) int {
 
 func sortTasksByReadiness(tasks []board.Task, index map[string]board.Task) {
 	sort.SliceStable(tasks, func(a, b int) bool {
+		if cmp, ok := board.CompareRank(tasks[a], tasks[b]); ok && cmp != 0 {
+			return cmp < 0 // manual rank wins, ranked cards first
+		}
 		readyA, _ := board.IsReady(tasks[a], index)
 		readyB, _ := board.IsReady(tasks[b], index)
 		if readyA != readyB {
@@ -1683,14 +1882,31 @@ func (m Model) handleTaskCreateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if title == "" {
 			return m, nil
 		}
-		tags := board.ParseTags(m.taskTags)
-		status := m.taskStatus
-		priority := m.taskPriority
+		capture, err := board.ParseQuickCapture(title, m.quickCapture, m.quickCaptureScope)
+		if err != nil {
+			m.taskCreateErr = err
+			return m, nil
+		}
+		if strings.TrimSpace(capture.Title) == "" {
+			m.taskCreateErr = fmt.Errorf("tui: title is empty after removing quick-capture tokens: %w", board.ErrInvalidTitle)
+			return m, nil
+		}
+		draft := board.Task{
+			Status:   m.taskStatus,
+			Tags:     board.ParseTags(m.taskTags),
+			Priority: m.taskPriority,
+		}
+		capture.Apply(&draft)
+		if m.sprintFilter {
+			// Keep new tasks visible while the board is filtered to the active sprint.
+			draft.Sprint = m.activeSprint.ID
+		}
+		m.taskCreateErr = nil
 		m.screen = screenBoard
 		m.taskTitle = ""
 		m.taskTags = ""
 		return m.withInFlight(func(ctx context.Context) tea.Cmd {
-			return taskCreateCmdContext(ctx, m.repo, title, status, tags, priority)
+			return taskCreateCmdContext(ctx, m.repo, draft)
 		})
 	case tea.KeyTab:
 		m.taskField = (m.taskField + 1) % 3
@@ -1751,8 +1967,10 @@ func (m Model) applyStatusUpdate(id, status string) Model {
 	}
 	m.columns[toCol].Tasks = append(m.columns[toCol].Tasks, task)
 	sortTasksByReadiness(m.columns[toCol].Tasks, buildTaskIndex(m.columns))
+	m.sortColumnByLane(toCol)
 	m.columns[toCol].Selected = taskIndex(m.columns[toCol].Tasks, task.ID)
 	m.active = toCol
+	m.syncLaneCursor()
 	return m
 }
 
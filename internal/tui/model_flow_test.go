@@ -1,10 +1,13 @@
 package tui
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"mochi-sticky/internal/adr"
 	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/search"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -74,3 +77,243 @@ func TestApplyStatusUpdateMissingTaskNoChange(t *testing.T) {
 		t.Fatalf("expected unchanged tasks")
 	}
 }
+
+func TestStateMsgAppliesSprintFilter(t *testing.T) {
+	cols := []board.Column{{Key: "todo", Title: "Todo"}}
+	msg := stateMsg{
+		columns: cols,
+		tasks: []board.Task{
+			{ID: "T-1", Status: "todo", Sprint: "sprint-1"},
+			{ID: "T-2", Status: "todo"},
+		},
+		activeSprint:    board.Sprint{ID: "sprint-1", Name: "Sprint 1", State: board.SprintStateActive},
+		hasActiveSprint: true,
+	}
+	m := Model{sprintFilter: true}
+	updated, _ := m.Update(msg)
+	got := updated.(Model)
+	if len(got.columns[0].Tasks) != 1 || got.columns[0].Tasks[0].ID != "T-1" {
+		t.Fatalf("expected only sprint tasks, got %+v", got.columns[0].Tasks)
+	}
+
+	msg.hasActiveSprint = false
+	updated, _ = got.Update(msg)
+	got = updated.(Model)
+	if got.sprintFilter {
+		t.Fatalf("expected sprint filter to reset without an active sprint")
+	}
+	if len(got.columns[0].Tasks) != 2 {
+		t.Fatalf("expected all tasks, got %d", len(got.columns[0].Tasks))
+	}
+}
+
+func TestSprintFilterKeyRequiresActiveSprint(t *testing.T) {
+	m := Model{}
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	got := updated.(Model)
+	if got.sprintFilter || got.err == nil {
+		t.Fatalf("expected error without active sprint, got filter=%v err=%v", got.sprintFilter, got.err)
+	}
+}
+
+func TestStateMsgArrangesConfiguredLanes(t *testing.T) {
+	cols := []board.Column{{Key: "todo", Title: "Todo"}}
+	msg := stateMsg{
+		boardID: "main",
+		columns: cols,
+		tasks: []board.Task{
+			{ID: "T-1", Status: "todo", Priority: 1, Tags: []string{"ui"}},
+			{ID: "T-2", Status: "todo", Priority: 2, Tags: []string{"api"}},
+			{ID: "T-3", Status: "todo", Priority: 3, Tags: []string{"ui"}},
+		},
+		lanes: board.LaneConfig{GroupBy: "tag", Order: []string{"api"}, Collapsed: []string{"ui"}},
+	}
+	updated, _ := Model{}.Update(msg)
+	got := updated.(Model)
+	if !got.lanesEnabled || len(got.laneKeys) != 2 || got.laneKeys[0] != "api" {
+		t.Fatalf("expected configured lanes, got enabled=%v keys=%v", got.lanesEnabled, got.laneKeys)
+	}
+	ids := []string{got.columns[0].Tasks[0].ID, got.columns[0].Tasks[1].ID, got.columns[0].Tasks[2].ID}
+	if ids[0] != "T-2" || ids[1] != "T-1" || ids[2] != "T-3" {
+		t.Fatalf("expected tasks ordered by lane, got %v", ids)
+	}
+
+	got.columns[0].Selected = 0
+	got = got.moveSelection(1)
+	if got.columns[0].Selected != 0 {
+		t.Fatalf("expected selection to skip collapsed lane, got %d", got.columns[0].Selected)
+	}
+
+	got.laneCursor = 1
+	got = got.toggleLaneCollapse()
+	if got.columns[0].Selected != 1 {
+		t.Fatalf("expected expanded lane to take the selection, got %d", got.columns[0].Selected)
+	}
+	got = got.moveSelection(1)
+	if got.columns[0].Selected != 2 || got.laneCursor != 1 {
+		t.Fatalf("expected selection within expanded lane, got selected=%d cursor=%d", got.columns[0].Selected, got.laneCursor)
+	}
+}
+
+func TestLanesKeyTogglesTagLanes(t *testing.T) {
+	m := Model{columns: []columnModel{{Key: "todo", Tasks: []board.Task{
+		{ID: "T-1", Status: "todo", Tags: []string{"b"}},
+		{ID: "T-2", Status: "todo"},
+		{ID: "T-3", Status: "todo", Tags: []string{"a"}},
+	}}}}
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	got := updated.(Model)
+	if !got.lanesEnabled || got.laneGroup.Kind != board.LaneByTag {
+		t.Fatalf("expected tag lanes enabled, got %+v", got.laneGroup)
+	}
+	if got.columns[0].Tasks[0].ID != "T-3" || got.columns[0].Tasks[2].ID != "T-2" {
+		t.Fatalf("expected untagged lane last, got %+v", got.columns[0].Tasks)
+	}
+	if view := got.renderLanes(30, buildTaskIndex(got.columns), 0); !strings.Contains(view, "(untagged)") {
+		t.Fatalf("expected untagged lane header, got %q", view)
+	}
+}
+
+func TestQueryBarReportsErrorsAndFiltersState(t *testing.T) {
+	m := Model{}
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	got := updated.(Model)
+	if !got.queryEditing {
+		t.Fatalf("expected filter bar to open")
+	}
+	for _, r := range "priority<x" {
+		updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		got = updated.(Model)
+	}
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	if !got.queryEditing || !errors.Is(got.queryErr, board.ErrInvalidQuery) {
+		t.Fatalf("expected parse error to keep the bar open, got editing=%v err=%v", got.queryEditing, got.queryErr)
+	}
+	if lines := strings.Join(got.queryLines(), "\n"); !strings.Contains(lines, "^") {
+		t.Fatalf("expected caret under the bad token, got %q", lines)
+	}
+
+	query, err := board.ParseQuery("tag:api")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got.queryEditing = false
+	got.query = query
+	msg := stateMsg{
+		columns: []board.Column{{Key: "todo", Title: "Todo"}},
+		tasks: []board.Task{
+			{ID: "T-1", Status: "todo", Tags: []string{"api"}},
+			{ID: "T-2", Status: "todo"},
+		},
+	}
+	updated, _ = got.Update(msg)
+	got = updated.(Model)
+	if len(got.columns[0].Tasks) != 1 || got.columns[0].Tasks[0].ID != "T-1" {
+		t.Fatalf("expected query to filter tasks, got %+v", got.columns[0].Tasks)
+	}
+}
+
+func TestViewPickerAppliesSavedView(t *testing.T) {
+	msg := stateMsg{
+		boardID: "main",
+		columns: []board.Column{{Key: "todo", Title: "Todo"}, {Key: "doing", Title: "Doing"}, {Key: "done", Title: "Done"}},
+		tasks: []board.Task{
+			{ID: "T-1", Status: "todo", Priority: 1, Assignee: "ana"},
+			{ID: "T-2", Status: "doing", Priority: 3},
+			{ID: "T-3", Status: "done", Priority: 1},
+		},
+		views: []board.View{{Name: "p1", Query: "priority:1", Columns: []string{"todo", "doing"}, GroupBy: "assignee"}},
+	}
+	updated, _ := Model{}.Update(msg)
+	got := updated.(Model)
+
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	got = updated.(Model)
+	if got.screen != screenViewPicker {
+		t.Fatalf("expected view picker, got screen %v", got.screen)
+	}
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	got = updated.(Model)
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	updated, _ = got.Update(msg)
+	got = updated.(Model)
+
+	if got.view.Name != "p1" || !got.lanesEnabled || got.laneGroup.Kind != board.LaneByAssignee {
+		t.Fatalf("expected view p1 with assignee lanes, got view=%q lanes=%v group=%+v", got.view.Name, got.lanesEnabled, got.laneGroup)
+	}
+	if len(got.columns) != 2 || len(got.columns[0].Tasks) != 1 || got.columns[0].Tasks[0].ID != "T-1" || len(got.columns[1].Tasks) != 0 {
+		t.Fatalf("expected view to hide done and filter to P1, got %+v", got.columns)
+	}
+}
+
+func TestSearchScreenJumpsToTaskHit(t *testing.T) {
+	msg := stateMsg{
+		columns: []board.Column{{Key: "todo", Title: "Todo"}, {Key: "doing", Title: "Doing"}},
+		tasks: []board.Task{
+			{ID: "T-1", Status: "todo"},
+			{ID: "T-2", Status: "doing", Tags: []string{"api"}},
+		},
+	}
+	query, err := board.ParseQuery("tag:ui")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	model := Model{query: query}
+	updated, _ := model.Update(msg)
+	got := updated.(Model)
+
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	got = updated.(Model)
+	if got.screen != screenSearch {
+		t.Fatalf("expected search screen, got %v", got.screen)
+	}
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("api")})
+	got = updated.(Model)
+	updated, _ = got.Update(searchResultsMsg{query: "api", hits: []search.Hit{
+		{Type: search.TypeWiki, ID: "guides/api", Title: "API"},
+		{Type: search.TypeTask, ID: "T-2", Title: "Wire API"},
+	}})
+	got = updated.(Model)
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	got = updated.(Model)
+	updated, _ = got.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	if got.screen != screenBoard || got.query.Root != nil {
+		t.Fatalf("expected the jump to clear the filter, got screen=%v query=%q", got.screen, got.query.Raw)
+	}
+	updated, _ = got.Update(msg)
+	got = updated.(Model)
+
+	task, ok := got.currentTask()
+	if got.screen != screenTaskDetail || !ok || task.ID != "T-2" {
+		t.Fatalf("expected task detail for T-2, got screen=%v task=%+v", got.screen, task)
+	}
+}
+
+func TestTaskCreateRejectsInvalidQuickCaptureTokens(t *testing.T) {
+	m := Model{screen: screenTaskCreate, taskTitle: "Fix login ^2026-13-01", taskPriority: board.DefaultPriority, width: 120, height: 40}
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got := updated.(Model)
+	if cmd != nil || got.screen != screenTaskCreate || !errors.Is(got.taskCreateErr, board.ErrInvalidDue) {
+		t.Fatalf("expected invalid due date to keep the form open, got screen=%v err=%v", got.screen, got.taskCreateErr)
+	}
+	if view := got.viewTaskCreate(); !strings.Contains(view, "must be YYYY-MM-DD") {
+		t.Fatalf("expected error on the create screen, got %q", view)
+	}
+
+	got.taskTitle = "#api !1"
+	updated, cmd = got.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	if cmd != nil || !errors.Is(got.taskCreateErr, board.ErrInvalidTitle) {
+		t.Fatalf("expected token-only title to be rejected, got err=%v", got.taskCreateErr)
+	}
+
+	got.taskTitle = "Fix login #api !1"
+	updated, cmd = got.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	if cmd == nil || got.screen != screenBoard || got.taskCreateErr != nil {
+		t.Fatalf("expected create command, got screen=%v err=%v", got.screen, got.taskCreateErr)
+	}
+}
@@ -3,9 +3,13 @@ package tui
 import (
 	"context"
 	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/wiki"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -95,6 +99,83 @@ func TestBuildColumnsSortsByPriority(t *testing.T) {
 	}
 }
 
+func TestBuildColumnsSortsRankedFirst(t *testing.T) {
+	columns := []board.Column{
+		{Key: "todo", Title: "Todo"},
+	}
+	tasks := []board.Task{
+		{ID: "T-1", Title: "High", Status: "todo", Priority: 1},
+		{ID: "T-2", Title: "Ranked low", Status: "todo", Priority: 3, Rank: "r"},
+		{ID: "T-3", Title: "Ranked top", Status: "todo", Priority: 3, Rank: "i"},
+	}
+	result := buildColumns(columns, tasks)
+	got := []string{result[0].Tasks[0].ID, result[0].Tasks[1].ID, result[0].Tasks[2].ID}
+	if got[0] != "T-3" || got[1] != "T-2" || got[2] != "T-1" {
+		t.Fatalf("expected ranked tasks first in rank order, got %v", got)
+	}
+}
+
+func TestRankSelectedTaskAtEdgeIsNoop(t *testing.T) {
+	m := Model{columns: []columnModel{{Key: "todo", Tasks: []board.Task{{ID: "T-1"}, {ID: "T-2"}}}}}
+	if cmd := m.rankSelectedTaskCmdContext(context.Background(), -1); cmd != nil {
+		t.Fatalf("expected no command when moving the first card up")
+	}
+	m.columns[0].Selected = 1
+	if cmd := m.rankSelectedTaskCmdContext(context.Background(), 1); cmd != nil {
+		t.Fatalf("expected no command when moving the last card down")
+	}
+}
+
+func TestRankSelectedTaskMovesUnrankedCards(t *testing.T) {
+	cases := []struct {
+		name     string
+		selected int
+		delta    int
+		want     []string
+	}{
+		{name: "down", selected: 0, delta: 1, want: []string{"Bravo", "Alpha", "Charlie"}},
+		{name: "up", selected: 2, delta: -1, want: []string{"Alpha", "Charlie", "Bravo"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			baseDir := t.TempDir()
+			repo, err := board.NewRepositoryWithStorage(baseDir, filepath.Join(baseDir, "storage"))
+			if err != nil {
+				t.Fatalf("new repo: %v", err)
+			}
+			if err := repo.InitStore(); err != nil {
+				t.Fatalf("init store: %v", err)
+			}
+			for _, title := range []string{"Charlie", "Alpha", "Bravo"} {
+				if _, err := repo.CreateTask(board.Task{Title: title, Status: "todo"}); err != nil {
+					t.Fatalf("create task: %v", err)
+				}
+			}
+			columns := []board.Column{{Key: "todo", Title: "Todo"}}
+			tasks, _ := repo.GetAllTasks()
+			m := Model{repo: repo, columns: buildColumns(columns, tasks)}
+			m.columns[0].Selected = tc.selected
+
+			// Act
+			msg := m.rankSelectedTaskCmdContext(context.Background(), tc.delta)()
+
+			// Assert
+			if failed, ok := msg.(errMsg); ok {
+				t.Fatalf("rank card: %v", failed.err)
+			}
+			tasks, _ = repo.GetAllTasks()
+			got := make([]string, 0, len(tasks))
+			for _, task := range buildColumns(columns, tasks)[0].Tasks {
+				got = append(got, task.Title)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("expected order %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestApplyStatusUpdateUnknownStatusNoChange(t *testing.T) {
 	cols := []board.Column{
 		{Key: "todo", Title: "Todo"},
@@ -186,3 +267,31 @@ func TestRefreshCancelsInFlightOperations(t *testing.T) {
 		t.Fatalf("expected loading to be true during refresh")
 	}
 }
+
+// TestWikiHistoryPaneShowsSelectedCommit verifies that H opens the history pane for the
+// selected page and that the loaded commits and their diff are rendered.
+func TestWikiHistoryPaneShowsSelectedCommit(t *testing.T) {
+	m := Model{
+		screen:    screenWiki,
+		wikiItems: []wikiNavItem{{Kind: wikiItemPage, Title: "Setup", Slug: "setup"}},
+		wikiPages: map[string]wiki.Page{"setup": {Title: "Setup"}},
+	}
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	resultModel := result.(Model)
+	if !resultModel.wikiHistoryOpen || resultModel.wikiHistorySlug != "setup" || cmd == nil {
+		t.Fatalf("expected the history of setup to load, got open=%v slug=%q", resultModel.wikiHistoryOpen, resultModel.wikiHistorySlug)
+	}
+
+	revisions := []wiki.Revision{
+		{Commit: git.Commit{Hash: "bbbbbbbbb", Author: "Linus", Subject: "document install"}, Content: "intro\ninstall\n"},
+		{Commit: git.Commit{Hash: "aaaaaaaaa", Author: "Ada", Subject: "add setup"}, Content: "intro\n"},
+	}
+	result, _ = resultModel.Update(wikiHistoryMsg{slug: "setup", revisions: revisions})
+	content := result.(Model).renderWikiPageContent()
+	for _, want := range []string{"document install", "add setup", "+install"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in the history pane, got:\n%s", want, content)
+		}
+	}
+}
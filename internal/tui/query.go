@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mochi-sticky/internal/board"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startQuery opens the Kanban filter bar, prefilled with the active query.
+func (m Model) startQuery() Model {
+	m.queryEditing = true
+	m.queryInput = m.query.Raw
+	m.queryErr = nil
+	return m
+}
+
+// handleQueryKey edits the filter bar. The query is re-parsed on every keystroke so errors show
+// up immediately; enter applies it (an empty query clears the filter) and esc cancels.
+func (m Model) handleQueryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.queryEditing = false
+		m.queryErr = nil
+		return m, nil
+	case tea.KeyEnter:
+		query, err := board.ParseQuery(m.queryInput)
+		if err != nil {
+			m.queryErr = err
+			return m, nil
+		}
+		m.query = query
+		m.queryEditing = false
+		m.queryErr = nil
+		m.captureSelection()
+		m.loading = true
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return loadStateCmdContext(ctx, m.repo)
+		})
+	case tea.KeyBackspace, tea.KeyDelete:
+		if len(m.queryInput) > 0 {
+			runes := []rune(m.queryInput)
+			m.queryInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlU:
+		m.queryInput = ""
+	case tea.KeySpace:
+		m.queryInput += " "
+	case tea.KeyRunes:
+		m.queryInput += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	_, m.queryErr = board.ParseQuery(m.queryInput)
+	return m, nil
+}
+
+func filterTasksByQuery(tasks []board.Task, query board.Query) []board.Task {
+	if query.Root == nil {
+		return tasks
+	}
+	filtered := make([]board.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if query.Match(task) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// queryLines renders the filter bar for the board info box.
+func (m Model) queryLines() []string {
+	const label = "Filter: "
+	if m.queryEditing {
+		lines := []string{selectedTask.Render(label + m.queryInput + "_")}
+		var queryErr *board.QueryError
+		if errors.As(m.queryErr, &queryErr) {
+			pointer := strings.SplitN(queryErr.Pointer(), "\n", 2)
+			if len(pointer) == 2 {
+				lines = append(lines, errorStyle.Render(strings.Repeat(" ", len(label))+pointer[1]))
+			}
+			lines = append(lines, errorStyle.Render(queryErr.Msg))
+		}
+		return lines
+	}
+	if strings.TrimSpace(m.query.Raw) == "" {
+		return nil
+	}
+	count := 0
+	for _, column := range m.columns {
+		count += len(column.Tasks)
+	}
+	return []string{taskStyle.Render(fmt.Sprintf("%s%s (%d matching, / to edit)", label, m.query.Raw, count))}
+}
@@ -13,11 +13,17 @@ func Run(repo *board.Repository) error {
 
 // RunWithEditor starts the Bubble Tea program using the provided editor command.
 func RunWithEditor(repo *board.Repository, editor string) error {
+	return RunAtRevision(repo, editor, "")
+}
+
+// RunAtRevision starts the Bubble Tea program on storage read at a git revision (see
+// cli.CheckRevision); an empty revision is the working tree.
+func RunAtRevision(repo *board.Repository, editor, revision string) error {
 	boardRepo, err := board.NewBoardRepositoryWithStorage(repo.BaseDir(), repo.StorageRoot())
 	if err != nil {
 		return err
 	}
-	model := NewModel(repo, boardRepo, repo.BaseDir()).SetEditor(editor)
+	model := NewModel(repo, boardRepo, repo.BaseDir()).SetEditor(editor).SetRevision(revision)
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	_, err = program.Run()
 	return err
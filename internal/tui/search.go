@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mochi-sticky/internal/adr"
+	"mochi-sticky/internal/board"
+	"mochi-sticky/internal/search"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type searchResultsMsg struct {
+	query string
+	hits  []search.Hit
+}
+
+// startSearch opens the global search screen, keeping the previous query and results.
+func (m Model) startSearch() Model {
+	m.screen = screenSearch
+	m.searchErr = nil
+	return m
+}
+
+func (m Model) searchSources() search.Sources {
+	return search.Sources{Board: m.repo, WikiRoot: m.wikiRoot(), ADRRoot: m.adrRoot()}
+}
+
+func searchCmdContext(ctx context.Context, sources search.Sources, query string) tea.Cmd {
+	return func() tea.Msg {
+		hits, err := search.SearchContext(ctx, sources, search.Options{Query: query})
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return searchResultsMsg{query: query, hits: hits}
+	}
+}
+
+// handleSearchKey edits the search input. Enter runs the query, or jumps to the selected hit
+// once results for the current input are shown; up/down move through the hits.
+func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.screen = screenBoard
+		return m, nil
+	case tea.KeyUp, tea.KeyCtrlP:
+		m.searchIndex = clampIndex(m.searchIndex-1, len(m.searchHits))
+		return m, nil
+	case tea.KeyDown, tea.KeyCtrlN:
+		m.searchIndex = clampIndex(m.searchIndex+1, len(m.searchHits))
+		return m, nil
+	case tea.KeyEnter:
+		query := strings.TrimSpace(m.searchInput)
+		if query == "" {
+			return m, nil
+		}
+		if query == m.searchRan && m.searchIndex < len(m.searchHits) {
+			return m.jumpToHit(m.searchHits[m.searchIndex])
+		}
+		m.searchErr = nil
+		sources := m.searchSources()
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return searchCmdContext(ctx, sources, query)
+		})
+	case tea.KeyBackspace, tea.KeyDelete:
+		if len(m.searchInput) > 0 {
+			runes := []rune(m.searchInput)
+			m.searchInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlU:
+		m.searchInput = ""
+	case tea.KeySpace:
+		m.searchInput += " "
+	case tea.KeyRunes:
+		m.searchInput += string(msg.Runes)
+	default:
+	}
+	return m, nil
+}
+
+// jumpToHit opens the screen that shows hit: the task detail, the wiki page or the ADR detail.
+// Filters that could hide the hit are cleared; the selection lands once the screen reloads.
+func (m Model) jumpToHit(hit search.Hit) (tea.Model, tea.Cmd) {
+	m.searchJump = hit
+	switch hit.Type {
+	case search.TypeTask:
+		m.query = board.Query{}
+		m.sprintFilter = false
+		m.view = board.View{}
+		m.viewQuery = board.Query{}
+		m.viewGroup = board.LaneGroup{}
+		m.selectedTaskID = hit.ID
+		m.screen = screenBoard
+		m.boardFocus = focusKanban
+		m.loading = true
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return loadStateCmdContext(ctx, m.repo)
+		})
+	case search.TypeWiki:
+		m.wikiQuery = ""
+		m.wikiFilterTitle = ""
+		m.wikiFilterSection = ""
+		m.wikiFilterTags = nil
+		m.screen = screenWiki
+		m.loading = true
+		m.loadingMessage = "Loading wiki..."
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return loadWikiCmdContext(ctx, m.wikiRoot())
+		})
+	case search.TypeADR:
+		id, err := strconv.Atoi(strings.TrimPrefix(hit.ID, "ADR-"))
+		if err != nil {
+			m.searchJump = search.Hit{}
+			m.searchErr = fmt.Errorf("tui: invalid ADR id %q", hit.ID)
+			return m, nil
+		}
+		m.selectedADRID = id
+		m.screen = screenADR
+		m.loading = true
+		m.loadingMessage = "Loading ADRs..."
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return loadADRCmdContext(ctx, m.adrRoot())
+		})
+	default:
+		m.searchJump = search.Hit{}
+		return m, nil
+	}
+}
+
+// finishTaskJump opens the detail screen when a search jump selected its task.
+func (m *Model) finishTaskJump() {
+	if m.searchJump.Type != search.TypeTask {
+		return
+	}
+	if task, ok := m.currentTask(); ok && task.ID == m.searchJump.ID {
+		m.screen = screenTaskDetail
+		m.detailField = fieldTitle
+	}
+	m.searchJump = search.Hit{}
+}
+
+// finishWikiJump selects the page a search jump targeted.
+func (m *Model) finishWikiJump() {
+	if m.searchJump.Type != search.TypeWiki {
+		return
+	}
+	for i, item := range m.wikiItems {
+		if item.Kind == wikiItemPage && item.Slug == m.searchJump.ID {
+			m.wikiIndex = i
+			break
+		}
+	}
+	m.searchJump = search.Hit{}
+}
+
+// finishADRJump opens the detail screen when a search jump selected its ADR.
+func (m *Model) finishADRJump() {
+	if m.searchJump.Type != search.TypeADR {
+		return
+	}
+	if record, ok := m.currentADR(); ok && "ADR-"+adr.FormatID(record.ID) == m.searchJump.ID {
+		m.screen = screenADRDetail
+	}
+	m.searchJump = search.Hit{}
+}
+
+func (m Model) viewSearch() string {
+	lines := []string{headerStyle.Render("Search"), selectedTask.Render("Query: " + m.searchInput + "_")}
+	if m.searchErr != nil {
+		lines = append(lines, errorStyle.Render(m.searchErr.Error()))
+	}
+	switch {
+	case m.searchRan == "":
+		lines = append(lines, "", taskStyle.Render("Search task bodies, wiki pages and ADRs. Quote words to match a phrase."))
+	case len(m.searchHits) == 0:
+		lines = append(lines, "", taskStyle.Render(fmt.Sprintf("No matches for %q.", m.searchRan)))
+	default:
+		lines = append(lines, "", taskStyle.Render(fmt.Sprintf("%d matches for %q", len(m.searchHits), m.searchRan)))
+		for i, hit := range m.searchHits {
+			label := fmt.Sprintf("%-4s %s  %s", hit.Type, hit.ID, hit.Title)
+			if hit.Status != "" {
+				label += " [" + hit.Status + "]"
+			}
+			snippet := fmt.Sprintf("     %d: %s", hit.Line, hit.Snippet)
+			if i == m.searchIndex {
+				lines = append(lines, selectedTask.Render(label), selectedTask.Render(snippet))
+				continue
+			}
+			lines = append(lines, taskStyle.Render(label), taskStyle.Render(snippet))
+		}
+	}
+	body := strings.Join(lines, "\n")
+	help := "type a query • enter search/open hit • ↑/↓ move • ctrl+u clear • esc back"
+	return m.frame("Search", body, help)
+}
@@ -33,6 +33,9 @@ var (
 	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#F87171")).Bold(true)
 	barStyle     = lipgloss.NewStyle().Background(bg).Foreground(textBright).Bold(true).Padding(0, 1)
 	footerStyle  = lipgloss.NewStyle().Background(bg).Foreground(textMuted).Padding(0, 1)
+
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#4ADE80")).Background(panelBg)
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F87171")).Background(panelBg)
 )
 
 // View renders the TUI.
@@ -86,6 +89,10 @@ func (m Model) View() string {
 		return m.viewADRCreate()
 	case screenADRDetail:
 		return m.viewADRDetail()
+	case screenViewPicker:
+		return m.viewViewPicker()
+	case screenSearch:
+		return m.viewSearch()
 	default:
 	}
 	if len(m.columns) == 0 {
@@ -96,6 +103,15 @@ func (m Model) View() string {
 
 func (m Model) renderBoardScreen(helpOverride string) string {
 	header := fmt.Sprintf("mochi-sticky • Board: %s", m.activeBoardName())
+	if m.hasActiveSprint {
+		header += fmt.Sprintf(" • Sprint: %s", m.activeSprint.Name)
+		if m.sprintFilter {
+			header += " (filtered)"
+		}
+	}
+	if m.view.Name != "" {
+		header += fmt.Sprintf(" • View: %s", m.view.Name)
+	}
 	help := helpOverride
 	if strings.TrimSpace(help) == "" {
 		help = m.boardHelpText()
@@ -197,12 +213,16 @@ func (m Model) renderBoardScreen(helpOverride string) string {
 		columnHeight = kanbanHeight - 2
 	}
 
-	rendered := make([]string, 0, len(m.columns))
-	for i, column := range m.columns {
-		rendered = append(rendered, m.renderColumn(column, i == m.active, columnWidth, i == len(m.columns)-1, taskIndex, columnHeight))
+	var board string
+	if m.lanesEnabled {
+		board = m.renderLanes(columnWidth, taskIndex, columnHeight)
+	} else {
+		rendered := make([]string, 0, len(m.columns))
+		for i, column := range m.columns {
+			rendered = append(rendered, m.renderColumn(column, i == m.active, columnWidth, i == len(m.columns)-1, taskIndex, columnHeight))
+		}
+		board = lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
 	}
-
-	board := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
 	kanbanStyleSized := kanbanStyle
 	if availableWidth > 0 {
 		kanbanStyleSized = kanbanStyleSized.Width(availableWidth)
@@ -265,17 +285,16 @@ func (m Model) renderColumn(column columnModel, active bool, width int, isLast b
 	if column.Key != "" && !strings.EqualFold(title, column.Key) {
 		title = fmt.Sprintf("%s (%s)", title, column.Key)
 	}
+	if board.HasEstimates(column.Tasks) {
+		title = fmt.Sprintf("%s • %s %s", title, board.FormatEstimate(board.SumEstimates(column.Tasks)), board.EstimateUnitLabel(m.estimateUnit))
+	}
 
 	lines := []string{headerStyle.Render(title)}
 	if len(column.Tasks) == 0 {
 		lines = append(lines, taskStyle.Render("No tasks"))
 	} else {
 		for i, task := range column.Tasks {
-			ready, unmet := board.IsReady(task, index)
-			line := fmt.Sprintf("P%d %s %s", effectivePriority(task.Priority), task.ID, task.Title)
-			if !ready {
-				line = fmt.Sprintf("%s ⏳ blocked by %s", line, strings.Join(unmet, ","))
-			}
+			line := taskLine(task, index)
 			if active && i == column.Selected {
 				lines = append(lines, selectedTask.Render(line))
 				continue
@@ -315,6 +334,14 @@ func (m Model) renderColumn(column columnModel, active bool, width int, isLast b
 	return style.Render(strings.Join(lines, "\n"))
 }
 
+func taskLine(task board.Task, index map[string]board.Task) string {
+	line := fmt.Sprintf("P%d %s %s", effectivePriority(task.Priority), task.ID, task.Title)
+	if ready, unmet := board.IsReady(task, index); !ready {
+		line = fmt.Sprintf("%s ⏳ blocked by %s", line, strings.Join(unmet, ","))
+	}
+	return line
+}
+
 func (m Model) renderContextBlock() string {
 	lines := m.contextLines()
 	if len(lines) == 0 {
@@ -328,6 +355,7 @@ func (m Model) renderBoardInfoBox(width int) string {
 	if len(lines) == 0 {
 		lines = []string{taskStyle.Render("(empty)")}
 	}
+	lines = append(lines, m.queryLines()...)
 	body := fmt.Sprintf("%s\n%s", headerStyle.Render("Board Info"), strings.Join(lines, "\n"))
 	style := infoBoxStyle
 	if width > 0 {
@@ -577,6 +605,12 @@ func (m Model) viewTaskCreate() string {
 		linePriority,
 		lineTags,
 	}
+	if syntax := m.quickCapture.Syntax(); syntax != "" {
+		lines = append(lines, "", taskStyle.Render("Title tokens: "+syntax+` (\ keeps a word literal)`))
+	}
+	if m.taskCreateErr != nil {
+		lines = append(lines, errorStyle.Render(m.taskCreateErr.Error()))
+	}
 	body := strings.Join(lines, "\n")
 	help := "tab switch field • 1-3 set priority • enter save • esc cancel"
 	return m.frame("New Task", body, help)
@@ -598,6 +632,12 @@ func (m Model) viewTaskDetail() string {
 		m.fieldLine("Priority", fmt.Sprintf("%d", effectivePriority(task.Priority)), fieldPriority),
 		m.fieldLine("Tags", strings.Join(task.Tags, ", "), fieldTags),
 	}
+	if task.Estimate > 0 {
+		lines = append(lines, taskStyle.Render(fmt.Sprintf("Estimate: %s %s", board.FormatEstimate(task.Estimate), board.EstimateUnitLabel(m.estimateUnit))))
+	}
+	if strings.TrimSpace(task.Sprint) != "" {
+		lines = append(lines, taskStyle.Render(fmt.Sprintf("Sprint: %s", task.Sprint)))
+	}
 	if !task.Created.IsZero() {
 		lines = append(lines, taskStyle.Render(fmt.Sprintf("Created: %s", task.Created.Format("2006-01-02"))))
 	}
@@ -872,6 +912,9 @@ func (m Model) renderWikiPageContent() string {
 	if !ok || item.Kind != wikiItemPage {
 		return taskStyle.Render("Select a page to read its content.")
 	}
+	if m.wikiHistoryOpen {
+		return m.renderWikiHistoryContent(item.Slug)
+	}
 	page, ok := m.wikiPages[item.Slug]
 	if !ok {
 		return taskStyle.Render("Unable to load wiki page.")
@@ -887,6 +930,43 @@ func (m Model) renderWikiPageContent() string {
 	return taskStyle.Render(content)
 }
 
+// renderWikiHistoryContent lists the commits that changed the page, newest first, followed by
+// the diff of the selected one.
+func (m Model) renderWikiHistoryContent(slug string) string {
+	lines := []string{headerStyle.Render("History")}
+	switch {
+	case m.wikiHistoryErr != nil:
+		return strings.Join(append(lines, taskStyle.Render(m.wikiHistoryErr.Error())), "\n")
+	case m.wikiHistorySlug != slug || m.wikiHistory == nil:
+		return strings.Join(append(lines, taskStyle.Render("Loading history...")), "\n")
+	case len(m.wikiHistory) == 0:
+		return strings.Join(append(lines, taskStyle.Render("No committed history.")), "\n")
+	}
+	for i, revision := range m.wikiHistory {
+		line := fmt.Sprintf("%s  %s  %s  %s", revision.Commit.Date.Format("2006-01-02"), revision.Commit.ShortHash(), revision.Commit.Author, revision.Commit.Subject)
+		if i == m.wikiHistoryIndex {
+			lines = append(lines, selectedTask.Render(line))
+			continue
+		}
+		lines = append(lines, taskStyle.Render(line))
+	}
+	lines = append(lines, "")
+	diff := wiki.RevisionDiff(slug, m.wikiHistory, m.wikiHistoryIndex, false)
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++"):
+			lines = append(lines, diffAddedStyle.Render(line))
+		case strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---"):
+			lines = append(lines, diffRemovedStyle.Render(line))
+		case strings.HasPrefix(line, "@@"):
+			lines = append(lines, headerStyle.Render(line))
+		default:
+			lines = append(lines, taskStyle.Render(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m Model) wikiNavWidth(gap int) int {
 	if m.width <= 0 {
 		return 30
@@ -909,7 +989,10 @@ func (m Model) wikiNavWidth(gap int) int {
 }
 
 func (m Model) wikiHelpText() string {
-	base := "j/k move • enter pager • e edit • x actions • f filters • E/P export all • ctrl+r/F5 refresh • b/esc back • q quit"
+	base := "j/k move • enter pager • e edit • x actions • f filters • H history • E/P export all • ctrl+r/F5 refresh • b/esc back • q quit"
+	if m.wikiHistoryOpen {
+		base = "j/k move • [/] newer/older commit • H close history • enter pager • e edit • b/esc back • q quit"
+	}
 	if summary := m.wikiFilterSummaryShort(); summary != "" {
 		return base + " • " + summary
 	}
@@ -1124,6 +1207,9 @@ func (m Model) fieldLine(label, value string, field detailField) string {
 }
 
 func (m Model) frame(title, body, footer string) string {
+	if m.revision != "" {
+		title += fmt.Sprintf(" • at %s (read-only)", m.revision)
+	}
 	head := m.renderBar(title, barStyle)
 	foot := ""
 	if strings.TrimSpace(footer) != "" {
@@ -1167,10 +1253,16 @@ func footerGapFor(footer string) int {
 }
 
 func (m Model) boardHelpText() string {
+	if m.queryEditing {
+		return "type a query (status:doing tag:api -tag:wontfix priority<=2 \"text\") • enter apply (empty clears) • ctrl+u clear • esc cancel"
+	}
 	if m.boardFocus == focusBoards && m.sidebarWidth() > 0 {
 		return "j/k boards • enter use • a add board • e edit board • i board detail • x board actions • w wiki • d adrs • tab/b kanban • ctrl+r/F5 refresh • q quit"
 	}
-	return "h/l columns • j/k tasks • a add task • x task actions • i task info • m/M move • z archive • w wiki • d adrs • tab/b boards • ctrl+r/F5 refresh • q quit"
+	if m.lanesEnabled {
+		return "h/l columns • j/k tasks • [/] lanes • C collapse lane • L hide lanes • a add task • x task actions • i task info • m/M move • / filter • S search • V views • s sprint filter • z archive • w wiki • d adrs • tab/b boards • ctrl+r/F5 refresh • q quit"
+	}
+	return "h/l columns • j/k tasks • a add task • x task actions • i task info • m/M move • / filter • S search • V views • s sprint filter • L lanes • z archive • w wiki • d adrs • tab/b boards • ctrl+r/F5 refresh • q quit"
 }
 
 func (m Model) renderModal(title, body, help string) string {
@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"context"
+	"strings"
+
+	"mochi-sticky/internal/board"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startViewPicker opens the saved view picker with the active view selected. Entry 0 clears
+// the view.
+func (m Model) startViewPicker() Model {
+	m.screen = screenViewPicker
+	m.viewPickIndex = 0
+	for i, view := range m.views {
+		if strings.EqualFold(view.Name, m.view.Name) {
+			m.viewPickIndex = i + 1
+		}
+	}
+	return m
+}
+
+func (m Model) handleViewPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch normalizedKey(msg) {
+	case "esc":
+		m.screen = screenBoard
+		return m, nil
+	case "j":
+		m.viewPickIndex = clampIndex(m.viewPickIndex+1, len(m.views)+1)
+		return m, nil
+	case "k":
+		m.viewPickIndex = clampIndex(m.viewPickIndex-1, len(m.views)+1)
+		return m, nil
+	case "enter":
+		m.screen = screenBoard
+		if m.viewPickIndex == 0 {
+			m.view = board.View{}
+			m.viewQuery = board.Query{}
+			m.viewGroup = board.LaneGroup{}
+		} else if m.viewPickIndex <= len(m.views) {
+			next, err := m.applyView(m.views[m.viewPickIndex-1])
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m = next
+		}
+		m.captureSelection()
+		m.loading = true
+		return m.withInFlight(func(ctx context.Context) tea.Cmd {
+			return loadStateCmdContext(ctx, m.repo)
+		})
+	default:
+		return m, nil
+	}
+}
+
+// applyView activates view for the current board: its query filters the Kanban, its columns
+// hide the others and its grouping turns on swimlanes. The view's sort is ignored so columns
+// keep their readiness and rank order.
+func (m Model) applyView(view board.View) (Model, error) {
+	query, err := view.ParsedQuery()
+	if err != nil {
+		return m, err
+	}
+	group, err := view.LaneGroup()
+	if err != nil {
+		return m, err
+	}
+	m.view = view
+	m.viewQuery = query
+	m.viewGroup = group
+	m.viewBoardID = m.laneBoardID
+	if !group.IsZero() {
+		m.laneGroup = group
+		m.lanesEnabled = true
+	}
+	return m, nil
+}
+
+// viewColumnsAndTasks narrows the board's columns and tasks to the active view. A view belongs
+// to a single board, so switching boards drops it.
+func (m *Model) viewColumnsAndTasks(boardID string, columns []board.Column, tasks []board.Task) ([]board.Column, []board.Task) {
+	if m.view.Name == "" {
+		return columns, tasks
+	}
+	if m.viewBoardID != boardID {
+		m.view = board.View{}
+		m.viewQuery = board.Query{}
+		m.viewGroup = board.LaneGroup{}
+		return columns, tasks
+	}
+	filtered := make([]board.Task, 0, len(tasks))
+	for _, task := range filterTasksByQuery(tasks, m.viewQuery) {
+		if m.view.ShowsStatus(task.Status) {
+			filtered = append(filtered, task)
+		}
+	}
+	return m.view.VisibleColumns(columns), filtered
+}
+
+func (m Model) viewViewPicker() string {
+	lines := []string{headerStyle.Render("Pick View")}
+	labels := []string{"(all tasks)"}
+	for _, view := range m.views {
+		label := view.Name
+		if strings.TrimSpace(view.Query) != "" {
+			label += " — " + view.Query
+		}
+		labels = append(labels, label)
+	}
+	for i, label := range labels {
+		if i == m.viewPickIndex {
+			lines = append(lines, selectedTask.Render(label))
+			continue
+		}
+		lines = append(lines, taskStyle.Render(label))
+	}
+	if len(m.views) == 0 {
+		lines = append(lines, "", taskStyle.Render("No saved views. Create one with 'mochi-sticky view save'."))
+	}
+	body := strings.Join(lines, "\n")
+	help := "j/k move • enter apply • esc back"
+	return m.frame("Pick View", body, help)
+}
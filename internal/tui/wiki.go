@@ -40,6 +40,13 @@ type wikiExportMsg struct {
 	status string
 }
 
+// wikiHistoryMsg carries the git history of a wiki page for the history pane.
+type wikiHistoryMsg struct {
+	slug      string
+	revisions []wiki.Revision
+	err       error
+}
+
 type wikiFilterMode int
 
 const (
@@ -111,6 +118,13 @@ func loadWikiCmdContext(ctx context.Context, root string) tea.Cmd {
 	}
 }
 
+func loadWikiHistoryCmd(root, slug string) tea.Cmd {
+	return func() tea.Msg {
+		revisions, err := wiki.History(context.Background(), root, slug)
+		return wikiHistoryMsg{slug: slug, revisions: revisions, err: err}
+	}
+}
+
 func exportWikiCmdContext(ctx context.Context, baseDir, root, format string, selection wiki.ExportSelection, filters wiki.FilterOptions, includeLinked bool, linkTypes []string) tea.Cmd {
 	return func() tea.Msg {
 		select {
@@ -292,6 +306,10 @@ func (m Model) handleWikiKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.startWikiExport("md", wiki.ExportSelection{})
 	case "P":
 		return m.startWikiExport("pdf", wiki.ExportSelection{})
+	case "H":
+		m.wikiHistoryOpen = !m.wikiHistoryOpen
+		m.wikiHistorySlug = ""
+		return m.syncWikiHistory()
 	}
 
 	switch normalizedKey(msg) {
@@ -309,10 +327,20 @@ func (m Model) handleWikiKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "j":
 		m.wikiIndex++
 		m.wikiIndex = clampIndex(m.wikiIndex, len(m.wikiItems))
-		return m, nil
+		return m.syncWikiHistory()
 	case "k":
 		m.wikiIndex--
 		m.wikiIndex = clampIndex(m.wikiIndex, len(m.wikiItems))
+		return m.syncWikiHistory()
+	case "]":
+		if m.wikiHistoryOpen {
+			m.wikiHistoryIndex = clampIndex(m.wikiHistoryIndex+1, len(m.wikiHistory))
+		}
+		return m, nil
+	case "[":
+		if m.wikiHistoryOpen {
+			m.wikiHistoryIndex = clampIndex(m.wikiHistoryIndex-1, len(m.wikiHistory))
+		}
 		return m, nil
 	case "/", "f":
 		m.screen = screenWikiFilterMenu
@@ -345,6 +373,29 @@ func (m Model) handleWikiKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// syncWikiHistory loads the history of the selected page when the history pane is open and
+// shows another page.
+func (m Model) syncWikiHistory() (tea.Model, tea.Cmd) {
+	if !m.wikiHistoryOpen {
+		return m, nil
+	}
+	item, ok := m.currentWikiSelection()
+	if !ok || item.Kind != wikiItemPage {
+		m.wikiHistorySlug = ""
+		m.wikiHistory = nil
+		m.wikiHistoryErr = nil
+		return m, nil
+	}
+	if item.Slug == m.wikiHistorySlug {
+		return m, nil
+	}
+	m.wikiHistorySlug = item.Slug
+	m.wikiHistory = nil
+	m.wikiHistoryErr = nil
+	m.wikiHistoryIndex = 0
+	return m, loadWikiHistoryCmd(m.wikiRoot(), item.Slug)
+}
+
 func (m Model) startWikiExport(format string, selection wiki.ExportSelection) (tea.Model, tea.Cmd) {
 	return m.startWikiExportWithLinks(format, selection, false)
 }
@@ -0,0 +1,163 @@
+package wiki
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffLine is a line of a line-based diff: ' ' when kept, '-' when removed and '+' when added.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// UnifiedDiff returns the changes from oldText to newText as a unified diff with three lines
+// of context, labelled oldLabel and newLabel. It returns an empty string when the texts
+// match line for line.
+func UnifiedDiff(oldLabel, newLabel, oldText, newText string) string {
+	lines := diffLines(splitLines(oldText), splitLines(newText))
+	changed := false
+	for _, line := range lines {
+		if line.op != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldLabel, newLabel)
+	for start := 0; start < len(lines); {
+		// Find the next change and extend the hunk while the changes are at most
+		// 2*diffContext lines apart.
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for i := first; i < len(lines) && i-last <= 2*diffContext; i++ {
+			if lines[i].op != ' ' {
+				last = i
+			}
+		}
+		from := max(first-diffContext, start)
+		to := min(last+diffContext+1, len(lines))
+
+		oldStart, newStart := 1, 1
+		for _, line := range lines[:from] {
+			if line.op != '+' {
+				oldStart++
+			}
+			if line.op != '-' {
+				newStart++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, line := range lines[from:to] {
+			if line.op != '+' {
+				oldCount++
+			}
+			if line.op != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+		for _, line := range lines[from:to] {
+			b.WriteByte(line.op)
+			b.WriteString(line.text)
+			b.WriteByte('\n')
+		}
+		start = to
+	}
+	return b.String()
+}
+
+// hunkRange formats a hunk range the way diff -u does: an empty range starts at the line
+// before it and a count of one is omitted.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, count)
+	}
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes a shortest edit script from a to b with Myers' algorithm.
+func diffLines(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	trace := make([][]int, 0)
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackDiff(a, b, trace, offset)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrackDiff(a, b []string, trace [][]int, offset int) []diffLine {
+	lines := make([]diffLine, 0, len(a)+len(b))
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			lines = append(lines, diffLine{op: ' ', text: a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				lines = append(lines, diffLine{op: '+', text: b[y]})
+			} else {
+				x--
+				lines = append(lines, diffLine{op: '-', text: a[x]})
+			}
+		}
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
@@ -0,0 +1,34 @@
+package wiki
+
+import "testing"
+
+func TestUnifiedDiffHunks(t *testing.T) {
+	// Arrange
+	oldText := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\ntwelve\n"
+	newText := "one\nTWO\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\ntwelve\nthirteen\n"
+
+	// Act
+	diff := UnifiedDiff("a/page.md", "b/page.md", oldText, newText)
+
+	// Assert
+	expected := "--- a/page.md\n+++ b/page.md\n" +
+		"@@ -1,5 +1,5 @@\n one\n-two\n+TWO\n three\n four\n five\n" +
+		"@@ -10,3 +10,4 @@\n ten\n eleven\n twelve\n+thirteen\n"
+	if diff != expected {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+}
+
+func TestUnifiedDiffNewAndUnchangedFiles(t *testing.T) {
+	// Act
+	created := UnifiedDiff("/dev/null", "b/page.md", "", "# Title\n")
+	unchanged := UnifiedDiff("a/page.md", "b/page.md", "same\n", "same\n")
+
+	// Assert
+	if created != "--- /dev/null\n+++ b/page.md\n@@ -0,0 +1 @@\n+# Title\n" {
+		t.Fatalf("unexpected diff for a new file:\n%s", created)
+	}
+	if unchanged != "" {
+		t.Fatalf("expected no diff, got %q", unchanged)
+	}
+}
@@ -19,3 +19,6 @@ var ErrPageNotFound = errors.New("page not found")
 
 // ErrSectionNotFound indicates a requested section does not exist.
 var ErrSectionNotFound = errors.New("section not found")
+
+// ErrInvalidSearchQuery indicates a wiki search query could not be parsed.
+var ErrInvalidSearchQuery = errors.New("invalid search query")
@@ -0,0 +1,208 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/git"
+	"mochi-sticky/internal/shared"
+)
+
+// Revision is a wiki page as of a commit that changed it.
+type Revision struct {
+	Commit git.Commit `json:"commit"`
+	// Path is the page file in that commit, relative to the git working tree root.
+	Path    string `json:"path"`
+	Deleted bool   `json:"deleted,omitempty"`
+	// Content is the Markdown file, frontmatter included, after the commit.
+	Content string `json:"-"`
+}
+
+// Change is a commit that changed wiki pages.
+type Change struct {
+	Commit git.Commit `json:"commit"`
+	Slugs  []string   `json:"slugs"`
+}
+
+// DiffOptions selects the versions compared by Diff. From defaults to HEAD and an empty To
+// means the page in the working tree.
+type DiffOptions struct {
+	From              string
+	To                string
+	IgnoreFrontmatter bool
+}
+
+// History returns the commits that changed the page at slug, newest first, following
+// renames. An uncommitted page has no history; a page that exists in neither the working tree
+// nor its history fails with ErrPageNotFound. It fails with git.ErrNotRepository when the wiki is not in a git working tree.
+func History(ctx context.Context, root, slug string) ([]Revision, error) {
+	path, err := historyPagePath(root, slug)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.Open(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	fileRevisions, err := repo.FileHistory(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileRevisions) == 0 {
+		if _, err := shared.Files().Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("wiki: %s: %w", slug, ErrPageNotFound)
+		}
+	}
+	revisions := make([]Revision, 0, len(fileRevisions))
+	for i := len(fileRevisions) - 1; i >= 0; i-- {
+		revision := fileRevisions[i]
+		revisions = append(revisions, Revision{
+			Commit:  revision.Commit,
+			Path:    revision.Path,
+			Deleted: revision.Data == nil,
+			Content: string(revision.Data),
+		})
+	}
+	return revisions, nil
+}
+
+// RevisionDiff returns the unified diff of the commit at revisions[i] against the revision
+// before it (revisions as returned by History), or against an empty page for the oldest.
+func RevisionDiff(slug string, revisions []Revision, i int, ignoreFrontmatter bool) string {
+	if i < 0 || i >= len(revisions) {
+		return ""
+	}
+	oldText, oldLabel := "", "/dev/null"
+	if i+1 < len(revisions) {
+		previous := revisions[i+1]
+		oldText, oldLabel = previous.Content, diffLabel("a", slug, previous.Commit.ShortHash())
+	}
+	newText, newLabel := revisions[i].Content, diffLabel("b", slug, revisions[i].Commit.ShortHash())
+	if revisions[i].Deleted {
+		newLabel = "/dev/null"
+	}
+	if ignoreFrontmatter {
+		oldText, newText = pageBody(oldText), pageBody(newText)
+	}
+	return UnifiedDiff(oldLabel, newLabel, oldText, newText)
+}
+
+// Diff returns the unified diff of the page at slug between two revisions, or between a
+// revision and the working tree; it is empty when nothing changed. A page missing from a
+// revision compares as empty; a page missing from both fails with ErrPageNotFound. It fails with git.ErrNotRepository when the wiki is not in a
+// git working tree and with git.ErrUnknownRevision for revisions git does not know.
+func Diff(ctx context.Context, root, slug string, opts DiffOptions) (string, error) {
+	path, err := historyPagePath(root, slug)
+	if err != nil {
+		return "", err
+	}
+	repo, err := git.Open(ctx, root)
+	if err != nil {
+		return "", err
+	}
+	from := strings.TrimSpace(opts.From)
+	if from == "" {
+		from = "HEAD"
+	}
+	oldText, oldFound, err := pageAt(ctx, repo, from, path)
+	if err != nil {
+		return "", err
+	}
+	to := strings.TrimSpace(opts.To)
+	var newText string
+	newFound := true
+	if to == "" {
+		data, err := shared.Files().ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			newFound = false
+		} else if err != nil {
+			return "", fmt.Errorf("wiki: failed to read page %s: %w", path, err)
+		}
+		newText = string(data)
+		to = "working tree"
+	} else if newText, newFound, err = pageAt(ctx, repo, to, path); err != nil {
+		return "", err
+	}
+	if !oldFound && !newFound {
+		return "", fmt.Errorf("wiki: %s: %w", slug, ErrPageNotFound)
+	}
+	if opts.IgnoreFrontmatter {
+		oldText, newText = pageBody(oldText), pageBody(newText)
+	}
+	return UnifiedDiff(diffLabel("a", slug, from), diffLabel("b", slug, to), oldText, newText), nil
+}
+
+// RecentChanges returns the latest commits that changed pages below root, newest first, with
+// the slugs of the pages each changed. A positive limit caps the number of commits. It fails
+// with git.ErrNotRepository when the wiki is not in a git working tree.
+func RecentChanges(ctx context.Context, root string, limit int) ([]Change, error) {
+	repo, err := git.Open(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	changeSets, err := repo.Changes(ctx, root, limit)
+	if err != nil {
+		return nil, err
+	}
+	prefix, _ := repo.Relative(root)
+	prefix = strings.TrimSuffix(prefix, "/") + "/"
+	if prefix == "./" {
+		prefix = ""
+	}
+	changes := make([]Change, 0, len(changeSets))
+	for _, changeSet := range changeSets {
+		change := Change{Commit: changeSet.Commit, Slugs: make([]string, 0, len(changeSet.Paths))}
+		for _, path := range changeSet.Paths {
+			rel, ok := strings.CutPrefix(path, prefix)
+			if !ok || !strings.HasSuffix(rel, ".md") {
+				continue
+			}
+			change.Slugs = append(change.Slugs, strings.TrimSuffix(rel, ".md"))
+		}
+		sort.Strings(change.Slugs)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func historyPagePath(root, slug string) (string, error) {
+	clean, err := NormalizeSlug(slug)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(clean)+".md"), nil
+}
+
+// pageAt reads the page at path as of rev, reporting false when the revision lacks it.
+func pageAt(ctx context.Context, repo *git.Repo, rev, path string) (string, bool, error) {
+	data, err := repo.FileAt(ctx, rev, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	return string(data), err == nil, err
+}
+
+// pageBody drops the frontmatter of a page; content without frontmatter is returned as is.
+func pageBody(content string) string {
+	if content == "" {
+		return ""
+	}
+	_, body, err := splitFrontmatter([]byte(content))
+	if err != nil {
+		return content
+	}
+	body = strings.Trim(body, "\n")
+	if body == "" {
+		return ""
+	}
+	return body + "\n"
+}
+
+func diffLabel(prefix, slug, rev string) string {
+	return prefix + "/" + slug + ".md\t" + rev
+}
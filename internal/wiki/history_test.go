@@ -0,0 +1,80 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHistoryAndDiffFromGit(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	root := filepath.Join(dir, "wiki")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	runWikiGit(t, dir, "init", "-q")
+	pagePath := filepath.Join(root, "guide.md")
+	for _, version := range []struct{ content, message string }{
+		{"---\ntitle: Guide\n---\n\nFirst draft.\n", "Add guide"},
+		{"---\ntitle: Guide\nstatus: published\n---\n\nFirst draft.\n", "Publish guide"},
+		{"---\ntitle: Guide\nstatus: published\n---\n\nSecond draft.\n", "Revise guide"},
+	} {
+		if err := os.WriteFile(pagePath, []byte(version.content), 0o644); err != nil {
+			t.Fatalf("write page: %v", err)
+		}
+		runWikiGit(t, dir, "add", ".")
+		runWikiGit(t, dir, "commit", "-q", "-m", version.message)
+	}
+	if err := os.WriteFile(pagePath, []byte("---\ntitle: Guide\nstatus: published\n---\n\nThird draft.\n"), 0o644); err != nil {
+		t.Fatalf("write page: %v", err)
+	}
+	ctx := context.Background()
+
+	// Act
+	revisions, historyErr := History(ctx, root, "guide")
+	bodyOnly, bodyErr := Diff(ctx, root, "guide", DiffOptions{From: "HEAD~2", To: "HEAD~1", IgnoreFrontmatter: true})
+	workingTree, workingErr := Diff(ctx, root, "guide", DiffOptions{})
+	_, missingErr := Diff(ctx, root, "missing", DiffOptions{})
+
+	// Assert
+	if historyErr != nil || bodyErr != nil || workingErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v", historyErr, bodyErr, workingErr)
+	}
+	if len(revisions) != 3 || revisions[0].Commit.Subject != "Revise guide" || revisions[2].Commit.Subject != "Add guide" {
+		t.Fatalf("expected revisions newest first, got %+v", revisions)
+	}
+	if diff := RevisionDiff("guide", revisions, 0, false); !strings.Contains(diff, "-First draft.\n+Second draft.\n") {
+		t.Fatalf("unexpected revision diff:\n%s", diff)
+	}
+	if diff := RevisionDiff("guide", revisions, 2, false); !strings.HasPrefix(diff, "--- /dev/null\n") {
+		t.Fatalf("expected the first revision to diff against an empty page:\n%s", diff)
+	}
+	if bodyOnly != "" {
+		t.Fatalf("expected a frontmatter-only change to be ignored, got:\n%s", bodyOnly)
+	}
+	if !strings.Contains(workingTree, "+++ b/guide.md\tworking tree\n") || !strings.Contains(workingTree, "+Third draft.") {
+		t.Fatalf("unexpected working tree diff:\n%s", workingTree)
+	}
+	if !errors.Is(missingErr, ErrPageNotFound) {
+		t.Fatalf("expected ErrPageNotFound, got %v", missingErr)
+	}
+}
+
+func runWikiGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test Author", "GIT_AUTHOR_EMAIL=author@example.com",
+		"GIT_COMMITTER_NAME=Test Author", "GIT_COMMITTER_EMAIL=author@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
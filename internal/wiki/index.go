@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"mochi-sticky/internal/shared"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -88,7 +90,7 @@ func LoadIndexContext(ctx context.Context, path string) (Index, error) {
 		return Index{}, ctx.Err()
 	default:
 	}
-	data, err := os.ReadFile(path)
+	data, err := shared.Files().ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return Index{}, ErrIndexNotFound
@@ -123,7 +125,7 @@ func SaveIndexContext(ctx context.Context, path string, index Index) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+	if err := shared.Files().MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return fmt.Errorf("wiki: failed to create index dir %s: %w", filepath.Dir(path), err)
 	}
 	select {
@@ -131,7 +133,7 @@ func SaveIndexContext(ctx context.Context, path string, index Index) error {
 		return ctx.Err()
 	default:
 	}
-	if err := os.WriteFile(path, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(path, data, 0o644); err != nil {
 		return fmt.Errorf("wiki: failed to write index %s: %w", path, err)
 	}
 	return nil
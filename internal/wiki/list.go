@@ -63,7 +63,7 @@ func listPagesContext(ctx context.Context, root string, includeTemplates bool) (
 		return nil, ctx.Err()
 	default:
 	}
-	if _, err := os.Stat(root); err != nil {
+	if _, err := shared.Files().Stat(root); err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
@@ -71,7 +71,7 @@ func listPagesContext(ctx context.Context, root string, includeTemplates bool) (
 	}
 
 	var pages []Page
-	err := filepath.WalkDir(root, func(entryPath string, d fs.DirEntry, err error) error {
+	err := shared.WalkDir(shared.Files(), root, func(entryPath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -4,10 +4,11 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
+	"mochi-sticky/internal/shared"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -88,7 +89,7 @@ func RenderPage(page Page) ([]byte, error) {
 
 // LoadPage reads a markdown file from disk and parses it into a Page.
 func LoadPage(path string) (Page, error) {
-	data, err := os.ReadFile(path)
+	data, err := shared.Files().ReadFile(path)
 	if err != nil {
 		return Page{}, fmt.Errorf("wiki: failed to read page %s: %w", path, err)
 	}
@@ -106,10 +107,10 @@ func SavePage(path string, page Page) error {
 	if err != nil {
 		return err
 	}
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+	if err := shared.Files().MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return fmt.Errorf("wiki: failed to create page dir %s: %w", filepath.Dir(path), err)
 	}
-	if err := os.WriteFile(path, data, 0o644); err != nil {
+	if err := shared.Files().WriteFile(path, data, 0o644); err != nil {
 		return fmt.Errorf("wiki: failed to write page %s: %w", path, err)
 	}
 	return nil
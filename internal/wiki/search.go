@@ -2,161 +2,251 @@ package wiki
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"mochi-sticky/internal/shared"
 )
 
-// SearchResult captures a single search hit.
+// SearchResult captures a single search hit: the page, its BM25 score and the best matching
+// line. Highlight repeats the snippet with matched words wrapped in **.
 type SearchResult struct {
-	Slug    string `json:"slug"`
-	Line    int    `json:"line"`
-	Snippet string `json:"snippet"`
+	Slug      string  `json:"slug"`
+	Title     string  `json:"title,omitempty"`
+	Line      int     `json:"line"`
+	Snippet   string  `json:"snippet"`
+	Highlight string  `json:"highlight"`
+	Score     float64 `json:"score"`
 }
 
-// SearchOptions controls wiki search behavior.
+// SearchOptions controls wiki search behavior. IndexPath names the persistent index (see
+// SearchIndexPath); when empty the index is built in memory for this search only. Rebuild
+// discards the stored index first. Matching is always case-insensitive; CaseInsensitive is
+// kept for compatibility.
 type SearchOptions struct {
 	Query            string
 	Status           string
 	IncludeTemplates bool
 	CaseInsensitive  bool
 	TemplatesRoot    string
+	IndexPath        string
+	Rebuild          bool
+	Limit            int
 }
 
-// SearchPages scans wiki pages for the query and returns matching lines.
+// SearchPages searches wiki pages for the query and returns ranked results.
 func SearchPages(root string, opts SearchOptions) ([]SearchResult, error) {
 	return SearchPagesContext(context.Background(), root, opts)
 }
 
-// SearchPagesContext scans wiki pages for the query and returns matching lines, honoring ctx cancellation.
+// SearchPagesContext refreshes the search index for changed pages, evaluates the query and
+// returns one result per matching page ordered by score, honoring ctx cancellation.
 func SearchPagesContext(ctx context.Context, root string, opts SearchOptions) ([]SearchResult, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
 	if strings.TrimSpace(opts.Query) == "" {
-		return nil, fmt.Errorf("wiki: search query is required")
+		return nil, fmt.Errorf("wiki: search query is required: %w", ErrInvalidSearchQuery)
+	}
+	query, err := parseSearchQuery(opts.Query)
+	if err != nil {
+		return nil, err
 	}
-	if _, err := os.Stat(root); err != nil {
+	if _, err := shared.Files().Stat(root); err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("wiki: failed to stat wiki root %s: %w", root, err)
 	}
 
-	query := opts.Query
-	if opts.CaseInsensitive {
-		query = strings.ToLower(query)
+	idx := newSearchIndex()
+	if !opts.Rebuild {
+		idx = loadSearchIndex(opts.IndexPath)
+	}
+	idx.dirty = idx.dirty || opts.Rebuild
+	if err := idx.refresh(ctx, searchRootWiki, root); err != nil {
+		return nil, fmt.Errorf("wiki: failed to index pages: %w", err)
+	}
+	templatesRoot := ""
+	if opts.IncludeTemplates &&
+		strings.TrimSpace(opts.TemplatesRoot) != "" &&
+		!shared.IsSubpath(root, opts.TemplatesRoot) {
+		if info, err := shared.Files().Stat(opts.TemplatesRoot); err == nil {
+			if info.IsDir() {
+				templatesRoot = opts.TemplatesRoot
+				if err := idx.refresh(ctx, searchRootTemplate, templatesRoot); err != nil {
+					return nil, fmt.Errorf("wiki: failed to index templates: %w", err)
+				}
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("wiki: failed to stat templates root %s: %w", opts.TemplatesRoot, err)
+		}
+	}
+	if idx.dirty && strings.TrimSpace(opts.IndexPath) != "" {
+		// A read-only snapshot (such as a git revision) is searched without caching the index.
+		if err := idx.save(opts.IndexPath); err != nil && !errors.Is(err, shared.ErrReadOnly) {
+			return nil, err
+		}
 	}
-	statusFilter := strings.ToLower(strings.TrimSpace(opts.Status))
 
+	statusFilter := strings.ToLower(strings.TrimSpace(opts.Status))
+	terms := query.terms()
 	results := make([]SearchResult, 0)
-	searchRoot := func(searchBase string, includeTemplates bool) error {
-		return filepath.WalkDir(searchBase, func(path string, d fs.DirEntry, err error) error {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-			}
-			if err != nil {
-				return err
+	for key, score := range idx.evaluate(query) {
+		page := idx.Pages[key]
+		if page.Template && !opts.IncludeTemplates {
+			continue
+		}
+		if page.Root == searchRootTemplate && templatesRoot == "" {
+			continue
+		}
+		if statusFilter != "" && strings.ToLower(strings.TrimSpace(page.Status)) != statusFilter {
+			continue
+		}
+		base := root
+		if page.Root == searchRootTemplate {
+			base = templatesRoot
+		}
+		result := SearchResult{Slug: page.Slug, Title: page.Title, Score: score}
+		if result.Slug == "" {
+			result.Slug = page.Path
+		}
+		if err := fillSnippet(&result, filepath.Join(base, filepath.FromSlash(page.Path)), terms); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Slug < results[j].Slug
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results, nil
+}
+
+// fillSnippet picks the body line matching the most query terms (the frontmatter title line
+// when only metadata matched) and highlights the matched words.
+func fillSnippet(result *SearchResult, path string, terms []*searchNode) error {
+	data, err := shared.Files().ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("wiki: failed to read page %s: %w", path, err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("wiki: failed to read page %s: %w", path, err)
+	}
+
+	bodyStart, titleLine := 0, 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			trimmed := strings.TrimSpace(lines[i])
+			if trimmed == "---" {
+				bodyStart = i + 1
+				break
 			}
-			if d.IsDir() {
-				if !includeTemplates && filepath.Base(path) == "templates" {
-					return filepath.SkipDir
-				}
-				return nil
+			if strings.HasPrefix(trimmed, "title:") {
+				titleLine = i
 			}
-			if filepath.Ext(path) != ".md" {
-				return nil
+		}
+	}
+	best, bestCount := -1, 0
+	for i := bodyStart; i < len(lines); i++ {
+		count := 0
+		spans := tokenSpans(lines[i])
+		for _, term := range terms {
+			if term.field == "" && len(matchSpans(spans, term)) > 0 {
+				count++
 			}
+		}
+		if count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+	if best < 0 {
+		best = titleLine
+	}
+	if best >= len(lines) {
+		return nil
+	}
+	result.Line = best + 1
+	result.Snippet = strings.TrimSpace(lines[best])
+	result.Highlight = highlightTerms(result.Snippet, terms)
+	return nil
+}
 
-			file, err := os.Open(path)
-			if err != nil {
-				return err
+// matchSpans returns the indexes of the spans matched by term: every word for a single-word
+// term, every run of consecutive words for a phrase.
+func matchSpans(spans []tokenSpan, term *searchNode) []int {
+	last := len(term.words) - 1
+	var matched []int
+	for start := 0; start+last < len(spans); start++ {
+		ok := true
+		for i, word := range term.words {
+			candidate := spans[start+i].word
+			if term.prefix && i == last {
+				ok = strings.HasPrefix(candidate, word)
+			} else {
+				ok = candidate == word
 			}
-			defer func() {
-				_ = file.Close()
-			}()
-
-			scanner := bufio.NewScanner(file)
-			scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
-			lineNum := 0
-			inFrontmatter := false
-			pageStatus := ""
-			allowed := true
-			for scanner.Scan() {
-				select {
-				case <-ctx.Done():
-					return ctx.Err()
-				default:
-				}
-				lineNum++
-				line := scanner.Text()
-				if strings.TrimSpace(line) == "---" {
-					if !inFrontmatter {
-						inFrontmatter = true
-						continue
-					}
-					if inFrontmatter {
-						if statusFilter != "" {
-							if strings.ToLower(strings.TrimSpace(pageStatus)) != statusFilter {
-								allowed = false
-							}
-						}
-						inFrontmatter = false
-						continue
-					}
-				}
-				if inFrontmatter && strings.HasPrefix(strings.TrimSpace(line), "status:") {
-					parts := strings.SplitN(line, ":", 2)
-					if len(parts) == 2 {
-						pageStatus = strings.TrimSpace(parts[1])
-						continue
-					}
-				}
-				if !allowed {
-					continue
-				}
-				haystack := line
-				if opts.CaseInsensitive {
-					haystack = strings.ToLower(line)
-				}
-				if strings.Contains(haystack, query) {
-					slug := SlugFromPath(searchBase, path)
-					if slug == "" {
-						slug = path
-					}
-					results = append(results, SearchResult{
-						Slug:    slug,
-						Line:    lineNum,
-						Snippet: line,
-					})
-				}
+			if !ok {
+				break
 			}
-			if err := scanner.Err(); err != nil {
-				return err
+		}
+		if ok {
+			for i := 0; i <= last; i++ {
+				matched = append(matched, start+i)
 			}
-			return nil
-		})
+		}
 	}
+	return matched
+}
 
-	if err := searchRoot(root, opts.IncludeTemplates); err != nil {
-		return nil, fmt.Errorf("wiki: failed to search pages: %w", err)
+func highlightTerms(line string, terms []*searchNode) string {
+	spans := tokenSpans(line)
+	marked := make([]bool, len(spans))
+	for _, term := range terms {
+		if term.field != "" {
+			continue
+		}
+		for _, i := range matchSpans(spans, term) {
+			marked[i] = true
+		}
 	}
-	if opts.IncludeTemplates &&
-		strings.TrimSpace(opts.TemplatesRoot) != "" &&
-		!shared.IsSubpath(root, opts.TemplatesRoot) {
-		if info, err := os.Stat(opts.TemplatesRoot); err == nil {
-			if info.IsDir() {
-				if err := searchRoot(opts.TemplatesRoot, true); err != nil {
-					return nil, fmt.Errorf("wiki: failed to search templates: %w", err)
-				}
-			}
-		} else if !os.IsNotExist(err) {
-			return nil, fmt.Errorf("wiki: failed to stat templates root %s: %w", opts.TemplatesRoot, err)
+	var b strings.Builder
+	offset := 0
+	for i := 0; i < len(spans); i++ {
+		if !marked[i] {
+			continue
 		}
+		end := i
+		for end+1 < len(spans) && marked[end+1] && strings.TrimSpace(line[spans[end].end:spans[end+1].start]) == "" {
+			end++
+		}
+		b.WriteString(line[offset:spans[i].start])
+		b.WriteString("**")
+		b.WriteString(line[spans[i].start:spans[end].end])
+		b.WriteString("**")
+		offset = spans[end].end
+		i = end
 	}
-	return results, nil
+	b.WriteString(line[offset:])
+	return b.String()
 }
@@ -0,0 +1,404 @@
+package wiki
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mochi-sticky/internal/shared"
+)
+
+const (
+	searchIndexVersion = 1
+	searchRootWiki     = "wiki"
+	searchRootTemplate = "templates"
+
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// SearchIndexPath returns the location of the persistent wiki search index within the
+// storage root.
+func SearchIndexPath(storageRoot string) string {
+	return filepath.Join(storageRoot, ".cache", "wiki-search.json")
+}
+
+// searchIndex is the on-disk inverted index: for every term, the pages containing it and the
+// token positions per field. Pages keep the mtime, size and hash they were indexed at so a
+// refresh only re-reads files that changed.
+type searchIndex struct {
+	Version int                                    `json:"version"`
+	Pages   map[string]*indexedPage                `json:"pages"`
+	Terms   map[string]map[string]map[string][]int `json:"terms"`
+	dirty   bool
+}
+
+type indexedPage struct {
+	Root     string   `json:"root"`
+	Path     string   `json:"path"`
+	Slug     string   `json:"slug"`
+	Title    string   `json:"title,omitempty"`
+	Section  string   `json:"section,omitempty"`
+	Status   string   `json:"status,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Template bool     `json:"template,omitempty"`
+	ModTime  int64    `json:"mtime"`
+	Size     int64    `json:"size"`
+	Hash     string   `json:"hash"`
+	Length   int      `json:"length"`
+	Words    []string `json:"words"`
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		Version: searchIndexVersion,
+		Pages:   map[string]*indexedPage{},
+		Terms:   map[string]map[string]map[string][]int{},
+	}
+}
+
+// loadSearchIndex reads the index at path. A missing, unreadable or outdated index is
+// replaced by an empty one that the next refresh rebuilds.
+func loadSearchIndex(path string) *searchIndex {
+	if strings.TrimSpace(path) == "" {
+		return newSearchIndex()
+	}
+	data, err := shared.Files().ReadFile(path)
+	if err != nil {
+		return newSearchIndex()
+	}
+	var idx searchIndex
+	if err := json.Unmarshal(data, &idx); err != nil || idx.Version != searchIndexVersion || idx.Pages == nil || idx.Terms == nil {
+		return newSearchIndex()
+	}
+	return &idx
+}
+
+// save writes the index atomically and keeps the cache directory out of version control.
+func (idx *searchIndex) save(path string) error {
+	dir := filepath.Dir(path)
+	if err := shared.Files().MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("wiki: failed to create search index dir %s: %w", dir, err)
+	}
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := shared.Files().Stat(ignore); errors.Is(err, fs.ErrNotExist) {
+		if err := shared.Files().WriteFile(ignore, []byte("*\n"), 0o644); err != nil {
+			return fmt.Errorf("wiki: failed to write %s: %w", ignore, err)
+		}
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("wiki: failed to encode search index: %w", err)
+	}
+	if err := shared.Files().WriteFileAtomic(path, data, 0o644); err != nil {
+		return fmt.Errorf("wiki: failed to write search index: %w", err)
+	}
+	idx.dirty = false
+	return nil
+}
+
+// refresh brings the pages under base up to date: unchanged files (same mtime and size, or
+// same hash) are kept, changed files are re-indexed and deleted files are dropped.
+func (idx *searchIndex) refresh(ctx context.Context, rootName, base string) error {
+	seen := map[string]bool{}
+	err := shared.WalkDir(shared.Files(), base, func(path string, d fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		key := rootName + ":" + rel
+		seen[key] = true
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		existing := idx.Pages[key]
+		if existing != nil && existing.ModTime == info.ModTime().UnixNano() && existing.Size == info.Size() {
+			return nil
+		}
+		data, err := shared.Files().ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+		idx.dirty = true
+		if existing != nil && existing.Hash == hash {
+			existing.ModTime = info.ModTime().UnixNano()
+			existing.Size = info.Size()
+			return nil
+		}
+		idx.remove(key)
+		page := &indexedPage{
+			Root:     rootName,
+			Path:     rel,
+			Slug:     SlugFromPath(base, path),
+			Template: rootName == searchRootTemplate || isTemplatePath(rel),
+			ModTime:  info.ModTime().UnixNano(),
+			Size:     info.Size(),
+			Hash:     hash,
+		}
+		idx.add(key, page, data)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for key, page := range idx.Pages {
+		if page.Root == rootName && !seen[key] {
+			idx.remove(key)
+			idx.dirty = true
+		}
+	}
+	return nil
+}
+
+func isTemplatePath(rel string) bool {
+	for _, part := range strings.Split(rel, "/") {
+		if part == "templates" {
+			return true
+		}
+	}
+	return false
+}
+
+// add tokenizes a page into its title, tag, section and body fields and records the postings.
+func (idx *searchIndex) add(key string, page *indexedPage, data []byte) {
+	body := string(data)
+	if parsed, err := ParsePage(data); err == nil {
+		page.Title = parsed.Title
+		page.Section = parsed.Section
+		page.Status = parsed.Status
+		page.Tags = parsed.Tags
+		body = parsed.Content
+	}
+	fields := map[string][]string{
+		fieldTitle:   tokenizeSearchText(page.Title),
+		fieldSection: tokenizeSearchText(page.Section),
+		fieldBody:    tokenizeSearchText(body),
+	}
+	// Tags are separated by a gap so a phrase cannot span two tags.
+	position := 0
+	tagWords := map[int]string{}
+	for _, tag := range page.Tags {
+		for _, word := range tokenizeSearchText(tag) {
+			tagWords[position] = word
+			position++
+		}
+		position++
+	}
+	words := map[string]bool{}
+	record := func(field, word string, pos int) {
+		postings := idx.Terms[word]
+		if postings == nil {
+			postings = map[string]map[string][]int{}
+			idx.Terms[word] = postings
+		}
+		hits := postings[key]
+		if hits == nil {
+			hits = map[string][]int{}
+			postings[key] = hits
+		}
+		hits[field] = append(hits[field], pos)
+		words[word] = true
+	}
+	for field, tokens := range fields {
+		for pos, word := range tokens {
+			record(field, word, pos)
+		}
+		page.Length += len(tokens)
+	}
+	for pos := 0; pos < position; pos++ {
+		if word, ok := tagWords[pos]; ok {
+			record(fieldTag, word, pos)
+			page.Length++
+		}
+	}
+	page.Words = make([]string, 0, len(words))
+	for word := range words {
+		page.Words = append(page.Words, word)
+	}
+	sort.Strings(page.Words)
+	idx.Pages[key] = page
+}
+
+func (idx *searchIndex) remove(key string) {
+	page := idx.Pages[key]
+	if page == nil {
+		return
+	}
+	for _, word := range page.Words {
+		postings := idx.Terms[word]
+		delete(postings, key)
+		if len(postings) == 0 {
+			delete(idx.Terms, word)
+		}
+	}
+	delete(idx.Pages, key)
+}
+
+// evaluate returns the BM25 score of every page matching node.
+func (idx *searchIndex) evaluate(node *searchNode) map[string]float64 {
+	switch node.op {
+	case "term":
+		return idx.scoreTerm(node)
+	case "or":
+		out := map[string]float64{}
+		for _, child := range node.children {
+			for key, score := range idx.evaluate(child) {
+				out[key] += score
+			}
+		}
+		return out
+	case "and":
+		var out map[string]float64
+		var excluded []map[string]float64
+		for _, child := range node.children {
+			if child.op == "not" {
+				excluded = append(excluded, idx.evaluate(child.children[0]))
+				continue
+			}
+			scores := idx.evaluate(child)
+			if out == nil {
+				out = scores
+				continue
+			}
+			for key, score := range out {
+				if extra, ok := scores[key]; ok {
+					out[key] = score + extra
+				} else {
+					delete(out, key)
+				}
+			}
+		}
+		for _, scores := range excluded {
+			for key := range scores {
+				delete(out, key)
+			}
+		}
+		return out
+	default:
+		return map[string]float64{}
+	}
+}
+
+// scoreTerm scores a word, prefix or phrase with BM25 over the weighted field frequencies.
+func (idx *searchIndex) scoreTerm(node *searchNode) map[string]float64 {
+	freqs := idx.termFrequencies(node)
+	if len(freqs) == 0 {
+		return map[string]float64{}
+	}
+	total := 0
+	for _, page := range idx.Pages {
+		total += page.Length
+	}
+	avgLength := float64(total) / float64(max(1, len(idx.Pages)))
+	df := float64(len(freqs))
+	n := float64(len(idx.Pages))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+	scores := make(map[string]float64, len(freqs))
+	for key, tf := range freqs {
+		length := float64(idx.Pages[key].Length)
+		norm := bm25K1 * (1 - bm25B + bm25B*length/math.Max(avgLength, 1))
+		scores[key] = idf * tf * (bm25K1 + 1) / (tf + norm)
+	}
+	return scores
+}
+
+// termFrequencies returns the field-weighted frequency of node in every page containing it.
+func (idx *searchIndex) termFrequencies(node *searchNode) map[string]float64 {
+	allowed := func(field string) bool {
+		return node.field == "" || node.field == field
+	}
+	freqs := map[string]float64{}
+	if len(node.words) == 1 {
+		for _, word := range idx.expand(node.words[0], node.prefix) {
+			for key, hits := range idx.Terms[word] {
+				for field, positions := range hits {
+					if allowed(field) {
+						freqs[key] += searchFields[field] * float64(len(positions))
+					}
+				}
+			}
+		}
+		return freqs
+	}
+
+	last := len(node.words) - 1
+	expanded := make([][]string, len(node.words))
+	for i, word := range node.words {
+		expanded[i] = idx.expand(word, node.prefix && i == last)
+	}
+	for key := range idx.Terms[node.words[0]] {
+		for field, weight := range searchFields {
+			if !allowed(field) {
+				continue
+			}
+			count := 0
+			for _, start := range idx.positions(expanded[0], key, field) {
+				matched := true
+				for i := 1; i <= last && matched; i++ {
+					matched = containsInt(idx.positions(expanded[i], key, field), start+i)
+				}
+				if matched {
+					count++
+				}
+			}
+			if count > 0 {
+				freqs[key] += weight * float64(count)
+			}
+		}
+	}
+	return freqs
+}
+
+// expand returns the indexed words matching word, or every word it prefixes.
+func (idx *searchIndex) expand(word string, prefix bool) []string {
+	if !prefix {
+		return []string{word}
+	}
+	var words []string
+	for candidate := range idx.Terms {
+		if strings.HasPrefix(candidate, word) {
+			words = append(words, candidate)
+		}
+	}
+	return words
+}
+
+func (idx *searchIndex) positions(words []string, key, field string) []int {
+	var out []int
+	for _, candidate := range words {
+		out = append(out, idx.Terms[candidate][key][field]...)
+	}
+	return out
+}
+
+func containsInt(values []int, value int) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,317 @@
+package wiki
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	fieldTitle   = "title"
+	fieldTag     = "tag"
+	fieldSection = "section"
+	fieldBody    = "body"
+)
+
+// searchFields lists the indexed fields with their BM25 weights.
+var searchFields = map[string]float64{
+	fieldTitle:   3,
+	fieldTag:     2,
+	fieldSection: 1.5,
+	fieldBody:    1,
+}
+
+// searchNode is a parsed wiki search query: a term, or an AND/OR/NOT over child nodes.
+type searchNode struct {
+	op       string // "term", "and", "or" or "not"
+	children []*searchNode
+	field    string   // term only; empty searches every field
+	words    []string // term only; more than one word is a phrase
+	prefix   bool     // term only; the last word matches as a prefix
+}
+
+// parseSearchQuery parses the wiki search syntax: words and "quoted phrases" are ANDed,
+// OR and NOT (or a leading -) combine them, parentheses group, title:/tag:/section: scope a
+// term to one field and a trailing * matches a prefix.
+func parseSearchQuery(input string) (*searchNode, error) {
+	tokens, err := lexSearchQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("wiki: search query is required: %w", ErrInvalidSearchQuery)
+	}
+	p := &searchParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("wiki: unexpected %q in search query: %w", p.tokens[p.pos].text, ErrInvalidSearchQuery)
+	}
+	if !node.positive() {
+		return nil, fmt.Errorf("wiki: search query needs a term that is not negated: %w", ErrInvalidSearchQuery)
+	}
+	return node, nil
+}
+
+// positive reports whether the node selects documents on its own. NOT only narrows a
+// positive sibling, so "a -b" is valid but "-b" and "a OR -b" are not.
+func (n *searchNode) positive() bool {
+	switch n.op {
+	case "term":
+		return true
+	case "not":
+		return false
+	case "and":
+		any := false
+		for _, child := range n.children {
+			if child.op == "not" {
+				if !child.children[0].positive() {
+					return false
+				}
+				continue
+			}
+			if !child.positive() {
+				return false
+			}
+			any = true
+		}
+		return any
+	default:
+		for _, child := range n.children {
+			if !child.positive() {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// terms returns the positive term nodes, used for snippets and highlighting.
+func (n *searchNode) terms() []*searchNode {
+	switch n.op {
+	case "term":
+		return []*searchNode{n}
+	case "not":
+		return nil
+	default:
+		var out []*searchNode
+		for _, child := range n.children {
+			out = append(out, child.terms()...)
+		}
+		return out
+	}
+}
+
+type searchToken struct {
+	text   string
+	quoted bool
+	field  string
+	prefix bool
+}
+
+func lexSearchQuery(input string) ([]searchToken, error) {
+	var tokens []searchToken
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '(' || ch == ')':
+			tokens = append(tokens, searchToken{text: string(ch)})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			word := string(runes[start:i])
+			if word == "-" && i < len(runes) && runes[i] == '(' {
+				tokens = append(tokens, searchToken{text: "NOT"})
+				continue
+			}
+			token := searchToken{text: word}
+			if i < len(runes) && runes[i] == '"' {
+				if word != "" && word != "-" && !strings.HasSuffix(word, ":") {
+					return nil, fmt.Errorf("wiki: unexpected quote after %q in search query: %w", word, ErrInvalidSearchQuery)
+				}
+				end := i + 1
+				for end < len(runes) && runes[end] != '"' {
+					end++
+				}
+				if end >= len(runes) {
+					return nil, fmt.Errorf("wiki: unterminated quote in search query: %w", ErrInvalidSearchQuery)
+				}
+				if word == "-" {
+					tokens = append(tokens, searchToken{text: "NOT"})
+					word = ""
+				}
+				token = searchToken{text: string(runes[i+1 : end]), quoted: true, field: strings.TrimSuffix(word, ":")}
+				i = end + 1
+				if i < len(runes) && runes[i] == '*' {
+					token.prefix = true
+					i++
+				}
+				if strings.HasSuffix(token.text, "*") {
+					token.prefix = true
+					token.text = strings.TrimSuffix(token.text, "*")
+				}
+			} else if strings.HasPrefix(word, "-") && len(word) > 1 {
+				tokens = append(tokens, searchToken{text: "NOT"})
+				token.text = word[1:]
+			}
+			if !token.quoted {
+				if field, value, ok := strings.Cut(token.text, ":"); ok && value != "" {
+					token.field = field
+					token.text = value
+				}
+				if strings.HasSuffix(token.text, "*") && len(token.text) > 1 {
+					token.prefix = true
+					token.text = strings.TrimSuffix(token.text, "*")
+				}
+			}
+			if token.field != "" {
+				field := strings.ToLower(token.field)
+				if field == "tags" {
+					field = fieldTag
+				}
+				if _, ok := searchFields[field]; ok && field != fieldBody {
+					token.field = field
+				} else {
+					// Not a field scope (e.g. "http://host" or "key:value"): search the text as written.
+					token.text = token.field + ":" + token.text
+					token.field = ""
+				}
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+type searchParser struct {
+	tokens []searchToken
+	pos    int
+}
+
+func (p *searchParser) peekKeyword(keyword string) bool {
+	if p.pos >= len(p.tokens) {
+		return false
+	}
+	token := p.tokens[p.pos]
+	return !token.quoted && token.field == "" && !token.prefix && token.text == keyword
+}
+
+func (p *searchParser) parseOr() (*searchNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	node := left
+	for p.peekKeyword("OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if node.op != "or" {
+			node = &searchNode{op: "or", children: []*searchNode{node}}
+		}
+		node.children = append(node.children, right)
+	}
+	return node, nil
+}
+
+func (p *searchParser) parseAnd() (*searchNode, error) {
+	var children []*searchNode
+	for p.pos < len(p.tokens) && !p.peekKeyword("OR") && !p.peekKeyword(")") {
+		if p.peekKeyword("AND") {
+			p.pos++
+			continue
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	switch len(children) {
+	case 0:
+		if p.pos < len(p.tokens) {
+			return nil, fmt.Errorf("wiki: expected a term before %q in search query: %w", p.tokens[p.pos].text, ErrInvalidSearchQuery)
+		}
+		return nil, fmt.Errorf("wiki: expected a term at the end of the search query: %w", ErrInvalidSearchQuery)
+	case 1:
+		return children[0], nil
+	default:
+		return &searchNode{op: "and", children: children}, nil
+	}
+}
+
+func (p *searchParser) parseUnary() (*searchNode, error) {
+	if p.peekKeyword("NOT") {
+		p.pos++
+		if p.pos >= len(p.tokens) {
+			return nil, fmt.Errorf("wiki: expected a term after NOT in search query: %w", ErrInvalidSearchQuery)
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &searchNode{op: "not", children: []*searchNode{child}}, nil
+	}
+	if p.peekKeyword("(") {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekKeyword(")") {
+			return nil, fmt.Errorf("wiki: missing ) in search query: %w", ErrInvalidSearchQuery)
+		}
+		p.pos++
+		return node, nil
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	words := tokenizeSearchText(token.text)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("wiki: search term %q has no letters or digits: %w", token.text, ErrInvalidSearchQuery)
+	}
+	return &searchNode{op: "term", field: token.field, words: words, prefix: token.prefix}, nil
+}
+
+// tokenizeSearchText lowercases text and splits it into runs of letters and digits.
+func tokenizeSearchText(text string) []string {
+	var words []string
+	for _, span := range tokenSpans(text) {
+		words = append(words, span.word)
+	}
+	return words
+}
+
+type tokenSpan struct {
+	word       string
+	start, end int // byte offsets in the original text
+}
+
+func tokenSpans(text string) []tokenSpan {
+	var spans []tokenSpan
+	start := -1
+	for i, ch := range text {
+		isWord := unicode.IsLetter(ch) || unicode.IsDigit(ch)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			spans = append(spans, tokenSpan{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, tokenSpan{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return spans
+}
@@ -1,8 +1,10 @@
 package wiki
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -38,3 +40,152 @@ Another line
 		t.Fatalf("unexpected result: %+v", results[0])
 	}
 }
+
+func writeSearchPage(t *testing.T, root string, page Page) string {
+	t.Helper()
+
+	path := filepath.Join(root, filepath.FromSlash(page.Slug)+".md")
+	if err := SavePage(path, page); err != nil {
+		t.Fatalf("save page: %v", err)
+	}
+	return path
+}
+
+func setupSearchWiki(t *testing.T) string {
+	t.Helper()
+
+	root := filepath.Join(t.TempDir(), "wiki")
+	writeSearchPage(t, root, Page{
+		Title:   "Caching Strategy",
+		Slug:    "guides/caching",
+		Section: "Guides",
+		Tags:    []string{"performance"},
+		Status:  "published",
+		Content: "# Caching\n\nWe use a write-through cache.\nCache invalidation happens on deploy.\n",
+	})
+	writeSearchPage(t, root, Page{
+		Title:   "Deployment",
+		Slug:    "ops/deploy",
+		Section: "Operations",
+		Tags:    []string{"ops"},
+		Status:  "draft",
+		Content: "Deploys run nightly.\nThe cache is warmed after each deploy.\n",
+	})
+	writeSearchPage(t, root, Page{
+		Title:   "Glossary",
+		Slug:    "glossary",
+		Status:  "published",
+		Content: "Invalidation: removing stale entries.\n",
+	})
+	return root
+}
+
+func searchSlugs(t *testing.T, root string, opts SearchOptions) []string {
+	t.Helper()
+
+	results, err := SearchPages(root, opts)
+	if err != nil {
+		t.Fatalf("search %q: %v", opts.Query, err)
+	}
+	slugs := make([]string, 0, len(results))
+	for _, result := range results {
+		slugs = append(slugs, result.Slug)
+	}
+	return slugs
+}
+
+func TestSearchPagesQuerySyntax(t *testing.T) {
+	// Arrange
+	root := setupSearchWiki(t)
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{query: "cache", want: []string{"guides/caching", "ops/deploy"}},
+		{query: `"cache invalidation"`, want: []string{"guides/caching"}},
+		{query: "cache deploy", want: []string{"guides/caching", "ops/deploy"}},
+		{query: "cache -nightly", want: []string{"guides/caching"}},
+		{query: "cache AND NOT nightly", want: []string{"guides/caching"}},
+		{query: "nightly OR stale", want: []string{"glossary", "ops/deploy"}},
+		{query: "(nightly OR stale) invalidation", want: []string{"glossary"}},
+		{query: "title:deployment", want: []string{"ops/deploy"}},
+		{query: "tag:perf*", want: []string{"guides/caching"}},
+		{query: "section:operations", want: []string{"ops/deploy"}},
+		{query: "invalid*", want: []string{"glossary", "guides/caching"}},
+		{query: "missingword", want: []string{}},
+	}
+
+	for _, tc := range cases {
+		// Act
+		got := searchSlugs(t, root, SearchOptions{Query: tc.query})
+
+		// Assert
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Fatalf("query %q: expected %v, got %v", tc.query, tc.want, got)
+		}
+	}
+}
+
+func TestSearchPagesRanksAndHighlights(t *testing.T) {
+	// Arrange
+	root := setupSearchWiki(t)
+
+	// Act
+	results, err := SearchPages(root, SearchOptions{Query: "caching", Status: "published"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(results) != 1 || results[0].Slug != "guides/caching" || results[0].Score <= 0 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if results[0].Title != "Caching Strategy" || results[0].Highlight != "# **Caching**" || results[0].Line != 10 {
+		t.Fatalf("unexpected snippet: %+v", results[0])
+	}
+	drafts := searchSlugs(t, root, SearchOptions{Query: "cache", Status: "draft"})
+	if strings.Join(drafts, ",") != "ops/deploy" {
+		t.Fatalf("expected status filter to keep drafts only, got %v", drafts)
+	}
+}
+
+func TestSearchPagesRejectsInvalidQueries(t *testing.T) {
+	root := setupSearchWiki(t)
+	for _, query := range []string{"-cache", "cache OR -deploy", `"open`, "(cache", "cache OR", "***"} {
+		if _, err := SearchPages(root, SearchOptions{Query: query}); !errors.Is(err, ErrInvalidSearchQuery) {
+			t.Fatalf("query %q: expected ErrInvalidSearchQuery, got %v", query, err)
+		}
+	}
+}
+
+func TestSearchPagesUpdatesPersistentIndex(t *testing.T) {
+	// Arrange
+	root := setupSearchWiki(t)
+	indexPath := SearchIndexPath(filepath.Dir(root))
+	opts := SearchOptions{Query: "nightly", IndexPath: indexPath}
+	if got := searchSlugs(t, root, opts); strings.Join(got, ",") != "ops/deploy" {
+		t.Fatalf("unexpected initial results: %v", got)
+	}
+	if _, err := os.Stat(indexPath); err != nil {
+		t.Fatalf("expected index on disk: %v", err)
+	}
+
+	// Act
+	writeSearchPage(t, root, Page{Title: "Backups", Slug: "ops/backups", Content: "Backups run nightly too.\n"})
+	if err := os.Remove(filepath.Join(root, "ops", "deploy.md")); err != nil {
+		t.Fatalf("remove page: %v", err)
+	}
+	got := searchSlugs(t, root, opts)
+
+	// Assert
+	if strings.Join(got, ",") != "ops/backups" {
+		t.Fatalf("expected index to pick up the new page and drop the deleted one, got %v", got)
+	}
+	idx := loadSearchIndex(indexPath)
+	if _, ok := idx.Pages["wiki:ops/deploy.md"]; ok {
+		t.Fatalf("expected deleted page to leave the index")
+	}
+	if _, ok := idx.Terms["deploys"]; ok {
+		t.Fatalf("expected postings of the deleted page to be removed")
+	}
+}